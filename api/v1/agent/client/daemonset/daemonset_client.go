@@ -41,10 +41,16 @@ type ClientService interface {
 
 	GetWorkloadendpoint(params *GetWorkloadendpointParams, opts ...ClientOption) (*GetWorkloadendpointOK, error)
 
+	PostIpamCheck(params *PostIpamCheckParams, opts ...ClientOption) (*PostIpamCheckOK, error)
+
+	PostIpamGc(params *PostIpamGcParams, opts ...ClientOption) (*PostIpamGcOK, error)
+
 	PostIpamIP(params *PostIpamIPParams, opts ...ClientOption) (*PostIpamIPOK, error)
 
 	PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*PostIpamIpsOK, error)
 
+	PostIpamStatus(params *PostIpamStatusParams, opts ...ClientOption) (*PostIpamStatusOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -89,9 +95,11 @@ func (a *Client) DeleteIpamIP(params *DeleteIpamIPParams, opts ...ClientOption)
 }
 
 /*
-DeleteIpamIps deletes multiple ip as a batch
+	DeleteIpamIps deletes multiple ip as a batch
+
+	Send a request to daemonset to ask for the ip deleting of multiple
 
-Delete multiple ip for a pod, case for spiderflat compent
+NICs of a pod at once
 */
 func (a *Client) DeleteIpamIps(params *DeleteIpamIpsParams, opts ...ClientOption) (*DeleteIpamIpsOK, error) {
 	// TODO: Validate the params before sending
@@ -208,6 +216,90 @@ func (a *Client) GetWorkloadendpoint(params *GetWorkloadendpointParams, opts ...
 	panic(msg)
 }
 
+/*
+	PostIpamCheck checks ip of spiderpool daemon
+
+	Send a request to daemonset to check whether the ips of a container
+
+are still the ones allocated to the pod
+*/
+func (a *Client) PostIpamCheck(params *PostIpamCheckParams, opts ...ClientOption) (*PostIpamCheckOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIpamCheckParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostIpamCheck",
+		Method:             "POST",
+		PathPattern:        "/ipam/check",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIpamCheckReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostIpamCheckOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostIpamCheck: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	PostIpamGc garbages collect ip of spiderpool daemon
+
+	Send a request to daemonset to release the ips of the containers on
+
+the node which are not in the valid attachments of the runtime
+*/
+func (a *Client) PostIpamGc(params *PostIpamGcParams, opts ...ClientOption) (*PostIpamGcOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIpamGcParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostIpamGc",
+		Method:             "POST",
+		PathPattern:        "/ipam/gc",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIpamGcReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostIpamGcOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostIpamGc: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostIpamIP gets ip from spiderpool daemon
 
@@ -249,9 +341,13 @@ func (a *Client) PostIpamIP(params *PostIpamIPParams, opts ...ClientOption) (*Po
 }
 
 /*
-PostIpamIps assigns multiple ip as a batch
+	PostIpamIps assigns multiple ip as a batch
+
+	Send a request to daemonset to ask for the ip assignments of
 
-Assign multiple ip for a pod, case for spiderflat compent
+multiple NICs of a pod at once. If any NIC fails, no ip is returned,
+and the ips already assigned to the other NICs are reused by the
+retry. The spiderpool plugin still asks for the ips NIC by NIC
 */
 func (a *Client) PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*PostIpamIpsOK, error) {
 	// TODO: Validate the params before sending
@@ -288,6 +384,48 @@ func (a *Client) PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*
 	panic(msg)
 }
 
+/*
+	PostIpamStatus gets status of spiderpool daemon
+
+	Check whether spiderpool daemonset is ready to serve ip assignments,
+
+including whether the ippools to allocate from are usable
+*/
+func (a *Client) PostIpamStatus(params *PostIpamStatusParams, opts ...ClientOption) (*PostIpamStatusOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIpamStatusParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostIpamStatus",
+		Method:             "POST",
+		PathPattern:        "/ipam/status",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIpamStatusReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostIpamStatusOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostIpamStatus: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// NewPostIpamCheckParams creates a new PostIpamCheckParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewPostIpamCheckParams() *PostIpamCheckParams {
+	return &PostIpamCheckParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewPostIpamCheckParamsWithTimeout creates a new PostIpamCheckParams object
+// with the ability to set a timeout on a request.
+func NewPostIpamCheckParamsWithTimeout(timeout time.Duration) *PostIpamCheckParams {
+	return &PostIpamCheckParams{
+		timeout: timeout,
+	}
+}
+
+// NewPostIpamCheckParamsWithContext creates a new PostIpamCheckParams object
+// with the ability to set a context for a request.
+func NewPostIpamCheckParamsWithContext(ctx context.Context) *PostIpamCheckParams {
+	return &PostIpamCheckParams{
+		Context: ctx,
+	}
+}
+
+// NewPostIpamCheckParamsWithHTTPClient creates a new PostIpamCheckParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewPostIpamCheckParamsWithHTTPClient(client *http.Client) *PostIpamCheckParams {
+	return &PostIpamCheckParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+PostIpamCheckParams contains all the parameters to send to the API endpoint
+
+	for the post ipam check operation.
+
+	Typically these are written to a http.Request.
+*/
+type PostIpamCheckParams struct {
+
+	// IpamCheckArgs.
+	IpamCheckArgs *models.IpamCheckArgs
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the post ipam check params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamCheckParams) WithDefaults() *PostIpamCheckParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the post ipam check params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamCheckParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the post ipam check params
+func (o *PostIpamCheckParams) WithTimeout(timeout time.Duration) *PostIpamCheckParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the post ipam check params
+func (o *PostIpamCheckParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the post ipam check params
+func (o *PostIpamCheckParams) WithContext(ctx context.Context) *PostIpamCheckParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the post ipam check params
+func (o *PostIpamCheckParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the post ipam check params
+func (o *PostIpamCheckParams) WithHTTPClient(client *http.Client) *PostIpamCheckParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the post ipam check params
+func (o *PostIpamCheckParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithIpamCheckArgs adds the ipamCheckArgs to the post ipam check params
+func (o *PostIpamCheckParams) WithIpamCheckArgs(ipamCheckArgs *models.IpamCheckArgs) *PostIpamCheckParams {
+	o.SetIpamCheckArgs(ipamCheckArgs)
+	return o
+}
+
+// SetIpamCheckArgs adds the ipamCheckArgs to the post ipam check params
+func (o *PostIpamCheckParams) SetIpamCheckArgs(ipamCheckArgs *models.IpamCheckArgs) {
+	o.IpamCheckArgs = ipamCheckArgs
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *PostIpamCheckParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.IpamCheckArgs != nil {
+		if err := r.SetBodyParam(o.IpamCheckArgs); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamCheckReader is a Reader for the PostIpamCheck structure.
+type PostIpamCheckReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *PostIpamCheckReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewPostIpamCheckOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 409:
+		result := NewPostIpamCheckDrift()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 500:
+		result := NewPostIpamCheckFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewPostIpamCheckOK creates a PostIpamCheckOK with default headers values
+func NewPostIpamCheckOK() *PostIpamCheckOK {
+	return &PostIpamCheckOK{}
+}
+
+/*
+PostIpamCheckOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type PostIpamCheckOK struct {
+}
+
+// IsSuccess returns true when this post ipam check o k response has a 2xx status code
+func (o *PostIpamCheckOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this post ipam check o k response has a 3xx status code
+func (o *PostIpamCheckOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam check o k response has a 4xx status code
+func (o *PostIpamCheckOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam check o k response has a 5xx status code
+func (o *PostIpamCheckOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam check o k response a status code equal to that given
+func (o *PostIpamCheckOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the post ipam check o k response
+func (o *PostIpamCheckOK) Code() int {
+	return 200
+}
+
+func (o *PostIpamCheckOK) Error() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckOK ", 200)
+}
+
+func (o *PostIpamCheckOK) String() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckOK ", 200)
+}
+
+func (o *PostIpamCheckOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewPostIpamCheckDrift creates a PostIpamCheckDrift with default headers values
+func NewPostIpamCheckDrift() *PostIpamCheckDrift {
+	return &PostIpamCheckDrift{}
+}
+
+/*
+PostIpamCheckDrift describes a response with status code 409, with default header values.
+
+The ips diverge from the allocation
+*/
+type PostIpamCheckDrift struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam check drift response has a 2xx status code
+func (o *PostIpamCheckDrift) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam check drift response has a 3xx status code
+func (o *PostIpamCheckDrift) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam check drift response has a 4xx status code
+func (o *PostIpamCheckDrift) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this post ipam check drift response has a 5xx status code
+func (o *PostIpamCheckDrift) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam check drift response a status code equal to that given
+func (o *PostIpamCheckDrift) IsCode(code int) bool {
+	return code == 409
+}
+
+// Code gets the status code for the post ipam check drift response
+func (o *PostIpamCheckDrift) Code() int {
+	return 409
+}
+
+func (o *PostIpamCheckDrift) Error() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckDrift  %+v", 409, o.Payload)
+}
+
+func (o *PostIpamCheckDrift) String() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckDrift  %+v", 409, o.Payload)
+}
+
+func (o *PostIpamCheckDrift) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamCheckDrift) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPostIpamCheckFailure creates a PostIpamCheckFailure with default headers values
+func NewPostIpamCheckFailure() *PostIpamCheckFailure {
+	return &PostIpamCheckFailure{}
+}
+
+/*
+PostIpamCheckFailure describes a response with status code 500, with default header values.
+
+Check failure
+*/
+type PostIpamCheckFailure struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam check failure response has a 2xx status code
+func (o *PostIpamCheckFailure) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam check failure response has a 3xx status code
+func (o *PostIpamCheckFailure) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam check failure response has a 4xx status code
+func (o *PostIpamCheckFailure) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam check failure response has a 5xx status code
+func (o *PostIpamCheckFailure) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post ipam check failure response a status code equal to that given
+func (o *PostIpamCheckFailure) IsCode(code int) bool {
+	return code == 500
+}
+
+// Code gets the status code for the post ipam check failure response
+func (o *PostIpamCheckFailure) Code() int {
+	return 500
+}
+
+func (o *PostIpamCheckFailure) Error() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamCheckFailure) String() string {
+	return fmt.Sprintf("[POST /ipam/check][%d] postIpamCheckFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamCheckFailure) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamCheckFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// NewPostIpamGcParams creates a new PostIpamGcParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewPostIpamGcParams() *PostIpamGcParams {
+	return &PostIpamGcParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewPostIpamGcParamsWithTimeout creates a new PostIpamGcParams object
+// with the ability to set a timeout on a request.
+func NewPostIpamGcParamsWithTimeout(timeout time.Duration) *PostIpamGcParams {
+	return &PostIpamGcParams{
+		timeout: timeout,
+	}
+}
+
+// NewPostIpamGcParamsWithContext creates a new PostIpamGcParams object
+// with the ability to set a context for a request.
+func NewPostIpamGcParamsWithContext(ctx context.Context) *PostIpamGcParams {
+	return &PostIpamGcParams{
+		Context: ctx,
+	}
+}
+
+// NewPostIpamGcParamsWithHTTPClient creates a new PostIpamGcParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewPostIpamGcParamsWithHTTPClient(client *http.Client) *PostIpamGcParams {
+	return &PostIpamGcParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+PostIpamGcParams contains all the parameters to send to the API endpoint
+
+	for the post ipam gc operation.
+
+	Typically these are written to a http.Request.
+*/
+type PostIpamGcParams struct {
+
+	// IpamGcArgs.
+	IpamGcArgs *models.IpamGCArgs
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the post ipam gc params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamGcParams) WithDefaults() *PostIpamGcParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the post ipam gc params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamGcParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the post ipam gc params
+func (o *PostIpamGcParams) WithTimeout(timeout time.Duration) *PostIpamGcParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the post ipam gc params
+func (o *PostIpamGcParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the post ipam gc params
+func (o *PostIpamGcParams) WithContext(ctx context.Context) *PostIpamGcParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the post ipam gc params
+func (o *PostIpamGcParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the post ipam gc params
+func (o *PostIpamGcParams) WithHTTPClient(client *http.Client) *PostIpamGcParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the post ipam gc params
+func (o *PostIpamGcParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithIpamGcArgs adds the ipamGcArgs to the post ipam gc params
+func (o *PostIpamGcParams) WithIpamGcArgs(ipamGcArgs *models.IpamGCArgs) *PostIpamGcParams {
+	o.SetIpamGcArgs(ipamGcArgs)
+	return o
+}
+
+// SetIpamGcArgs adds the ipamGcArgs to the post ipam gc params
+func (o *PostIpamGcParams) SetIpamGcArgs(ipamGcArgs *models.IpamGCArgs) {
+	o.IpamGcArgs = ipamGcArgs
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *PostIpamGcParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.IpamGcArgs != nil {
+		if err := r.SetBodyParam(o.IpamGcArgs); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamGcReader is a Reader for the PostIpamGc structure.
+type PostIpamGcReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *PostIpamGcReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewPostIpamGcOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewPostIpamGcFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewPostIpamGcOK creates a PostIpamGcOK with default headers values
+func NewPostIpamGcOK() *PostIpamGcOK {
+	return &PostIpamGcOK{}
+}
+
+/*
+PostIpamGcOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type PostIpamGcOK struct {
+}
+
+// IsSuccess returns true when this post ipam gc o k response has a 2xx status code
+func (o *PostIpamGcOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this post ipam gc o k response has a 3xx status code
+func (o *PostIpamGcOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam gc o k response has a 4xx status code
+func (o *PostIpamGcOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam gc o k response has a 5xx status code
+func (o *PostIpamGcOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam gc o k response a status code equal to that given
+func (o *PostIpamGcOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the post ipam gc o k response
+func (o *PostIpamGcOK) Code() int {
+	return 200
+}
+
+func (o *PostIpamGcOK) Error() string {
+	return fmt.Sprintf("[POST /ipam/gc][%d] postIpamGcOK ", 200)
+}
+
+func (o *PostIpamGcOK) String() string {
+	return fmt.Sprintf("[POST /ipam/gc][%d] postIpamGcOK ", 200)
+}
+
+func (o *PostIpamGcOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewPostIpamGcFailure creates a PostIpamGcFailure with default headers values
+func NewPostIpamGcFailure() *PostIpamGcFailure {
+	return &PostIpamGcFailure{}
+}
+
+/*
+PostIpamGcFailure describes a response with status code 500, with default header values.
+
+Garbage collection failure
+*/
+type PostIpamGcFailure struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam gc failure response has a 2xx status code
+func (o *PostIpamGcFailure) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam gc failure response has a 3xx status code
+func (o *PostIpamGcFailure) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam gc failure response has a 4xx status code
+func (o *PostIpamGcFailure) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam gc failure response has a 5xx status code
+func (o *PostIpamGcFailure) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post ipam gc failure response a status code equal to that given
+func (o *PostIpamGcFailure) IsCode(code int) bool {
+	return code == 500
+}
+
+// Code gets the status code for the post ipam gc failure response
+func (o *PostIpamGcFailure) Code() int {
+	return 500
+}
+
+func (o *PostIpamGcFailure) Error() string {
+	return fmt.Sprintf("[POST /ipam/gc][%d] postIpamGcFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamGcFailure) String() string {
+	return fmt.Sprintf("[POST /ipam/gc][%d] postIpamGcFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamGcFailure) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamGcFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 )
 
 // NewPostIpamIpsParams creates a new PostIpamIpsParams object,
@@ -63,6 +65,10 @@ PostIpamIpsParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type PostIpamIpsParams struct {
+
+	// IpamBatchAddArgs.
+	IpamBatchAddArgs *models.IpamBatchAddArgs
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -116,6 +122,17 @@ func (o *PostIpamIpsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIpamBatchAddArgs adds the ipamBatchAddArgs to the post ipam ips params
+func (o *PostIpamIpsParams) WithIpamBatchAddArgs(ipamBatchAddArgs *models.IpamBatchAddArgs) *PostIpamIpsParams {
+	o.SetIpamBatchAddArgs(ipamBatchAddArgs)
+	return o
+}
+
+// SetIpamBatchAddArgs adds the ipamBatchAddArgs to the post ipam ips params
+func (o *PostIpamIpsParams) SetIpamBatchAddArgs(ipamBatchAddArgs *models.IpamBatchAddArgs) {
+	o.IpamBatchAddArgs = ipamBatchAddArgs
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PostIpamIpsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -123,6 +140,11 @@ func (o *PostIpamIpsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.R
 		return err
 	}
 	var res []error
+	if o.IpamBatchAddArgs != nil {
+		if err := r.SetBodyParam(o.IpamBatchAddArgs); err != nil {
+			return err
+		}
+	}
 
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
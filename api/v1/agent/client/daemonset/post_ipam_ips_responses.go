@@ -54,6 +54,7 @@ PostIpamIpsOK describes a response with status code 200, with default header val
 Success
 */
 type PostIpamIpsOK struct {
+	Payload *models.IpamBatchAddResponse
 }
 
 // IsSuccess returns true when this post ipam ips o k response has a 2xx status code
@@ -87,15 +88,26 @@ func (o *PostIpamIpsOK) Code() int {
 }
 
 func (o *PostIpamIpsOK) Error() string {
-	return fmt.Sprintf("[POST /ipam/ips][%d] postIpamIpsOK ", 200)
+	return fmt.Sprintf("[POST /ipam/ips][%d] postIpamIpsOK  %+v", 200, o.Payload)
 }
 
 func (o *PostIpamIpsOK) String() string {
-	return fmt.Sprintf("[POST /ipam/ips][%d] postIpamIpsOK ", 200)
+	return fmt.Sprintf("[POST /ipam/ips][%d] postIpamIpsOK  %+v", 200, o.Payload)
+}
+
+func (o *PostIpamIpsOK) GetPayload() *models.IpamBatchAddResponse {
+	return o.Payload
 }
 
 func (o *PostIpamIpsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(models.IpamBatchAddResponse)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,153 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	cr "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// NewPostIpamStatusParams creates a new PostIpamStatusParams object,
+// with the default timeout for this client.
+//
+// Default values are not hydrated, since defaults are normally applied by the API server side.
+//
+// To enforce default values in parameter, use SetDefaults or WithDefaults.
+func NewPostIpamStatusParams() *PostIpamStatusParams {
+	return &PostIpamStatusParams{
+		timeout: cr.DefaultTimeout,
+	}
+}
+
+// NewPostIpamStatusParamsWithTimeout creates a new PostIpamStatusParams object
+// with the ability to set a timeout on a request.
+func NewPostIpamStatusParamsWithTimeout(timeout time.Duration) *PostIpamStatusParams {
+	return &PostIpamStatusParams{
+		timeout: timeout,
+	}
+}
+
+// NewPostIpamStatusParamsWithContext creates a new PostIpamStatusParams object
+// with the ability to set a context for a request.
+func NewPostIpamStatusParamsWithContext(ctx context.Context) *PostIpamStatusParams {
+	return &PostIpamStatusParams{
+		Context: ctx,
+	}
+}
+
+// NewPostIpamStatusParamsWithHTTPClient creates a new PostIpamStatusParams object
+// with the ability to set a custom HTTPClient for a request.
+func NewPostIpamStatusParamsWithHTTPClient(client *http.Client) *PostIpamStatusParams {
+	return &PostIpamStatusParams{
+		HTTPClient: client,
+	}
+}
+
+/*
+PostIpamStatusParams contains all the parameters to send to the API endpoint
+
+	for the post ipam status operation.
+
+	Typically these are written to a http.Request.
+*/
+type PostIpamStatusParams struct {
+
+	// IpamStatusArgs.
+	IpamStatusArgs *models.IpamStatusArgs
+
+	timeout    time.Duration
+	Context    context.Context
+	HTTPClient *http.Client
+}
+
+// WithDefaults hydrates default values in the post ipam status params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamStatusParams) WithDefaults() *PostIpamStatusParams {
+	o.SetDefaults()
+	return o
+}
+
+// SetDefaults hydrates default values in the post ipam status params (not the query body).
+//
+// All values with no default are reset to their zero value.
+func (o *PostIpamStatusParams) SetDefaults() {
+	// no default values defined for this parameter
+}
+
+// WithTimeout adds the timeout to the post ipam status params
+func (o *PostIpamStatusParams) WithTimeout(timeout time.Duration) *PostIpamStatusParams {
+	o.SetTimeout(timeout)
+	return o
+}
+
+// SetTimeout adds the timeout to the post ipam status params
+func (o *PostIpamStatusParams) SetTimeout(timeout time.Duration) {
+	o.timeout = timeout
+}
+
+// WithContext adds the context to the post ipam status params
+func (o *PostIpamStatusParams) WithContext(ctx context.Context) *PostIpamStatusParams {
+	o.SetContext(ctx)
+	return o
+}
+
+// SetContext adds the context to the post ipam status params
+func (o *PostIpamStatusParams) SetContext(ctx context.Context) {
+	o.Context = ctx
+}
+
+// WithHTTPClient adds the HTTPClient to the post ipam status params
+func (o *PostIpamStatusParams) WithHTTPClient(client *http.Client) *PostIpamStatusParams {
+	o.SetHTTPClient(client)
+	return o
+}
+
+// SetHTTPClient adds the HTTPClient to the post ipam status params
+func (o *PostIpamStatusParams) SetHTTPClient(client *http.Client) {
+	o.HTTPClient = client
+}
+
+// WithIpamStatusArgs adds the ipamStatusArgs to the post ipam status params
+func (o *PostIpamStatusParams) WithIpamStatusArgs(ipamStatusArgs *models.IpamStatusArgs) *PostIpamStatusParams {
+	o.SetIpamStatusArgs(ipamStatusArgs)
+	return o
+}
+
+// SetIpamStatusArgs adds the ipamStatusArgs to the post ipam status params
+func (o *PostIpamStatusParams) SetIpamStatusArgs(ipamStatusArgs *models.IpamStatusArgs) {
+	o.IpamStatusArgs = ipamStatusArgs
+}
+
+// WriteToRequest writes these params to a swagger request
+func (o *PostIpamStatusParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+
+	if err := r.SetTimeout(o.timeout); err != nil {
+		return err
+	}
+	var res []error
+	if o.IpamStatusArgs != nil {
+		if err := r.SetBodyParam(o.IpamStatusArgs); err != nil {
+			return err
+		}
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamStatusReader is a Reader for the PostIpamStatus structure.
+type PostIpamStatusReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *PostIpamStatusReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewPostIpamStatusOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewPostIpamStatusFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 503:
+		result := NewPostIpamStatusUnavailable()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewPostIpamStatusOK creates a PostIpamStatusOK with default headers values
+func NewPostIpamStatusOK() *PostIpamStatusOK {
+	return &PostIpamStatusOK{}
+}
+
+/*
+PostIpamStatusOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type PostIpamStatusOK struct {
+}
+
+// IsSuccess returns true when this post ipam status o k response has a 2xx status code
+func (o *PostIpamStatusOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this post ipam status o k response has a 3xx status code
+func (o *PostIpamStatusOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam status o k response has a 4xx status code
+func (o *PostIpamStatusOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam status o k response has a 5xx status code
+func (o *PostIpamStatusOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam status o k response a status code equal to that given
+func (o *PostIpamStatusOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the post ipam status o k response
+func (o *PostIpamStatusOK) Code() int {
+	return 200
+}
+
+func (o *PostIpamStatusOK) Error() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusOK ", 200)
+}
+
+func (o *PostIpamStatusOK) String() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusOK ", 200)
+}
+
+func (o *PostIpamStatusOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewPostIpamStatusFailure creates a PostIpamStatusFailure with default headers values
+func NewPostIpamStatusFailure() *PostIpamStatusFailure {
+	return &PostIpamStatusFailure{}
+}
+
+/*
+PostIpamStatusFailure describes a response with status code 500, with default header values.
+
+Status failure
+*/
+type PostIpamStatusFailure struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam status failure response has a 2xx status code
+func (o *PostIpamStatusFailure) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam status failure response has a 3xx status code
+func (o *PostIpamStatusFailure) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam status failure response has a 4xx status code
+func (o *PostIpamStatusFailure) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam status failure response has a 5xx status code
+func (o *PostIpamStatusFailure) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post ipam status failure response a status code equal to that given
+func (o *PostIpamStatusFailure) IsCode(code int) bool {
+	return code == 500
+}
+
+// Code gets the status code for the post ipam status failure response
+func (o *PostIpamStatusFailure) Code() int {
+	return 500
+}
+
+func (o *PostIpamStatusFailure) Error() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamStatusFailure) String() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamStatusFailure) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamStatusFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPostIpamStatusUnavailable creates a PostIpamStatusUnavailable with default headers values
+func NewPostIpamStatusUnavailable() *PostIpamStatusUnavailable {
+	return &PostIpamStatusUnavailable{}
+}
+
+/*
+PostIpamStatusUnavailable describes a response with status code 503, with default header values.
+
+Not ready to serve ip assignments
+*/
+type PostIpamStatusUnavailable struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam status unavailable response has a 2xx status code
+func (o *PostIpamStatusUnavailable) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam status unavailable response has a 3xx status code
+func (o *PostIpamStatusUnavailable) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam status unavailable response has a 4xx status code
+func (o *PostIpamStatusUnavailable) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam status unavailable response has a 5xx status code
+func (o *PostIpamStatusUnavailable) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post ipam status unavailable response a status code equal to that given
+func (o *PostIpamStatusUnavailable) IsCode(code int) bool {
+	return code == 503
+}
+
+// Code gets the status code for the post ipam status unavailable response
+func (o *PostIpamStatusUnavailable) Code() int {
+	return 503
+}
+
+func (o *PostIpamStatusUnavailable) Error() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusUnavailable  %+v", 503, o.Payload)
+}
+
+func (o *PostIpamStatusUnavailable) String() string {
+	return fmt.Sprintf("[POST /ipam/status][%d] postIpamStatusUnavailable  %+v", 503, o.Payload)
+}
+
+func (o *PostIpamStatusUnavailable) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamStatusUnavailable) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
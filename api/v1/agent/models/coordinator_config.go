@@ -25,6 +25,12 @@ type CoordinatorConfig struct {
 	// detect gateway
 	DetectGateway bool `json:"detectGateway,omitempty"`
 
+	// detect gateway loss threshold
+	DetectGatewayLossThreshold int64 `json:"detectGatewayLossThreshold,omitempty"`
+
+	// detect gateway mode
+	DetectGatewayMode string `json:"detectGatewayMode,omitempty"`
+
 	// detect IP conflict
 	DetectIPConflict bool `json:"detectIPConflict,omitempty"`
 
@@ -64,6 +70,9 @@ type CoordinatorConfig struct {
 
 	// tx queue len
 	TxQueueLen int64 `json:"txQueueLen,omitempty"`
+
+	// veth m t u
+	VethMTU int64 `json:"vethMTU,omitempty"`
 }
 
 // Validate validates this coordinator config
@@ -20,6 +20,9 @@ import (
 // swagger:model GetCoordinatorArgs
 type GetCoordinatorArgs struct {
 
+	// coordinator name
+	CoordinatorName string `json:"coordinatorName,omitempty"`
+
 	// pod name
 	PodName string `json:"podName,omitempty"`
 
@@ -0,0 +1,91 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamAttachment An attachment of a container which is still in use
+//
+// swagger:model IpamAttachment
+type IpamAttachment struct {
+
+	// container ID
+	// Required: true
+	ContainerID *string `json:"containerID"`
+
+	// if name
+	// Required: true
+	IfName *string `json:"ifName"`
+}
+
+// Validate validates this ipam attachment
+func (m *IpamAttachment) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContainerID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIfName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamAttachment) validateContainerID(formats strfmt.Registry) error {
+
+	if err := validate.Required("containerID", "body", m.ContainerID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamAttachment) validateIfName(formats strfmt.Registry) error {
+
+	if err := validate.Required("ifName", "body", m.IfName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this ipam attachment based on context it is used
+func (m *IpamAttachment) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamAttachment) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamAttachment) UnmarshalBinary(b []byte) error {
+	var res IpamAttachment
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,214 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamBatchAddArgs IPAM batch request args
+//
+// swagger:model IpamBatchAddArgs
+type IpamBatchAddArgs struct {
+
+	// container ID
+	// Required: true
+	ContainerID *string `json:"containerID"`
+
+	// net namespace
+	// Required: true
+	NetNamespace *string `json:"netNamespace"`
+
+	// nics
+	// Required: true
+	// Min Items: 1
+	Nics []*IpamBatchNicArgs `json:"nics"`
+
+	// pod name
+	// Required: true
+	PodName *string `json:"podName"`
+
+	// pod namespace
+	// Required: true
+	PodNamespace *string `json:"podNamespace"`
+
+	// pod UID
+	// Required: true
+	PodUID *string `json:"podUID"`
+}
+
+// Validate validates this ipam batch add args
+func (m *IpamBatchAddArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContainerID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNetNamespace(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateNics(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodNamespace(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodUID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validateContainerID(formats strfmt.Registry) error {
+
+	if err := validate.Required("containerID", "body", m.ContainerID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validateNetNamespace(formats strfmt.Registry) error {
+
+	if err := validate.Required("netNamespace", "body", m.NetNamespace); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validateNics(formats strfmt.Registry) error {
+
+	if err := validate.Required("nics", "body", m.Nics); err != nil {
+		return err
+	}
+
+	iNicsSize := int64(len(m.Nics))
+
+	if err := validate.MinItems("nics", "body", iNicsSize, 1); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Nics); i++ {
+		if swag.IsZero(m.Nics[i]) { // not required
+			continue
+		}
+
+		if m.Nics[i] != nil {
+			if err := m.Nics[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nics" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("nics" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validatePodName(formats strfmt.Registry) error {
+
+	if err := validate.Required("podName", "body", m.PodName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validatePodNamespace(formats strfmt.Registry) error {
+
+	if err := validate.Required("podNamespace", "body", m.PodNamespace); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchAddArgs) validatePodUID(formats strfmt.Registry) error {
+
+	if err := validate.Required("podUID", "body", m.PodUID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this ipam batch add args based on the context it is used
+func (m *IpamBatchAddArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateNics(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamBatchAddArgs) contextValidateNics(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Nics); i++ {
+
+		if m.Nics[i] != nil {
+			if err := m.Nics[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("nics" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("nics" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamBatchAddArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamBatchAddArgs) UnmarshalBinary(b []byte) error {
+	var res IpamBatchAddArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
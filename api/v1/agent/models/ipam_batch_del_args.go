@@ -0,0 +1,152 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamBatchDelArgs IPAM batch release IP information
+//
+// swagger:model IpamBatchDelArgs
+type IpamBatchDelArgs struct {
+
+	// container ID
+	// Required: true
+	ContainerID *string `json:"containerID"`
+
+	// if names
+	// Required: true
+	// Min Items: 1
+	IfNames []string `json:"ifNames"`
+
+	// net namespace
+	NetNamespace string `json:"netNamespace,omitempty"`
+
+	// pod name
+	// Required: true
+	PodName *string `json:"podName"`
+
+	// pod namespace
+	// Required: true
+	PodNamespace *string `json:"podNamespace"`
+
+	// pod UID
+	// Required: true
+	PodUID *string `json:"podUID"`
+}
+
+// Validate validates this ipam batch del args
+func (m *IpamBatchDelArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateContainerID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIfNames(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodNamespace(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validatePodUID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamBatchDelArgs) validateContainerID(formats strfmt.Registry) error {
+
+	if err := validate.Required("containerID", "body", m.ContainerID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchDelArgs) validateIfNames(formats strfmt.Registry) error {
+
+	if err := validate.Required("ifNames", "body", m.IfNames); err != nil {
+		return err
+	}
+
+	iIfNamesSize := int64(len(m.IfNames))
+
+	if err := validate.MinItems("ifNames", "body", iIfNamesSize, 1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchDelArgs) validatePodName(formats strfmt.Registry) error {
+
+	if err := validate.Required("podName", "body", m.PodName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchDelArgs) validatePodNamespace(formats strfmt.Registry) error {
+
+	if err := validate.Required("podNamespace", "body", m.PodNamespace); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamBatchDelArgs) validatePodUID(formats strfmt.Registry) error {
+
+	if err := validate.Required("podUID", "body", m.PodUID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this ipam batch del args based on context it is used
+func (m *IpamBatchDelArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamBatchDelArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamBatchDelArgs) UnmarshalBinary(b []byte) error {
+	var res IpamBatchDelArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,83 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamBatchNicArgs IPAM request args of a NIC in the batch
+//
+// swagger:model IpamBatchNicArgs
+type IpamBatchNicArgs struct {
+
+	// clean gateway
+	CleanGateway bool `json:"cleanGateway,omitempty"`
+
+	// default IPv4 IP pool
+	DefaultIPV4IPPool []string `json:"defaultIPv4IPPool"`
+
+	// default IPv6 IP pool
+	DefaultIPV6IPPool []string `json:"defaultIPv6IPPool"`
+
+	// if name
+	// Required: true
+	IfName *string `json:"ifName"`
+}
+
+// Validate validates this ipam batch nic args
+func (m *IpamBatchNicArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIfName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamBatchNicArgs) validateIfName(formats strfmt.Registry) error {
+
+	if err := validate.Required("ifName", "body", m.IfName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this ipam batch nic args based on context it is used
+func (m *IpamBatchNicArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamBatchNicArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamBatchNicArgs) UnmarshalBinary(b []byte) error {
+	var res IpamBatchNicArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,122 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamGCArgs IPAM garbage collection args
+//
+// swagger:model IpamGCArgs
+type IpamGCArgs struct {
+
+	// valid attachments
+	// Required: true
+	ValidAttachments []*IpamAttachment `json:"validAttachments"`
+}
+
+// Validate validates this ipam g c args
+func (m *IpamGCArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateValidAttachments(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamGCArgs) validateValidAttachments(formats strfmt.Registry) error {
+
+	if err := validate.Required("validAttachments", "body", m.ValidAttachments); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.ValidAttachments); i++ {
+		if swag.IsZero(m.ValidAttachments[i]) { // not required
+			continue
+		}
+
+		if m.ValidAttachments[i] != nil {
+			if err := m.ValidAttachments[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("validAttachments" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("validAttachments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this ipam g c args based on the context it is used
+func (m *IpamGCArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateValidAttachments(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamGCArgs) contextValidateValidAttachments(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.ValidAttachments); i++ {
+
+		if m.ValidAttachments[i] != nil {
+			if err := m.ValidAttachments[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("validAttachments" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("validAttachments" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamGCArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamGCArgs) UnmarshalBinary(b []byte) error {
+	var res IpamGCArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
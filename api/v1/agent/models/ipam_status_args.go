@@ -0,0 +1,56 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// IpamStatusArgs IPAM status args
+//
+// swagger:model IpamStatusArgs
+type IpamStatusArgs struct {
+
+	// default IPv4 IP pool
+	DefaultIPV4IPPool []string `json:"defaultIPv4IPPool"`
+
+	// default IPv6 IP pool
+	DefaultIPV6IPPool []string `json:"defaultIPv6IPPool"`
+}
+
+// Validate validates this ipam status args
+func (m *IpamStatusArgs) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this ipam status args based on context it is used
+func (m *IpamStatusArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamStatusArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamStatusArgs) UnmarshalBinary(b []byte) error {
+	var res IpamStatusArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -83,6 +83,16 @@ func configureAPI(api *restapi.SpiderpoolAgentAPIAPI) http.Handler {
 			return middleware.NotImplemented("operation daemonset.GetWorkloadendpoint has not yet been implemented")
 		})
 	}
+	if api.DaemonsetPostIpamCheckHandler == nil {
+		api.DaemonsetPostIpamCheckHandler = daemonset.PostIpamCheckHandlerFunc(func(params daemonset.PostIpamCheckParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamCheck has not yet been implemented")
+		})
+	}
+	if api.DaemonsetPostIpamGcHandler == nil {
+		api.DaemonsetPostIpamGcHandler = daemonset.PostIpamGcHandlerFunc(func(params daemonset.PostIpamGcParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamGc has not yet been implemented")
+		})
+	}
 	if api.DaemonsetPostIpamIPHandler == nil {
 		api.DaemonsetPostIpamIPHandler = daemonset.PostIpamIPHandlerFunc(func(params daemonset.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIP has not yet been implemented")
@@ -93,6 +103,11 @@ func configureAPI(api *restapi.SpiderpoolAgentAPIAPI) http.Handler {
 			return middleware.NotImplemented("operation daemonset.PostIpamIps has not yet been implemented")
 		})
 	}
+	if api.DaemonsetPostIpamStatusHandler == nil {
+		api.DaemonsetPostIpamStatusHandler = daemonset.PostIpamStatusHandlerFunc(func(params daemonset.PostIpamStatusParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamStatus has not yet been implemented")
+		})
+	}
 
 	api.PreServerShutdown = func() {}
 
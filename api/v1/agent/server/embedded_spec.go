@@ -69,6 +69,75 @@ func init() {
         }
       }
     },
+    "/ipam/check": {
+      "post": {
+        "description": "Send a request to daemonset to check whether the ips of a container\nare still the ones allocated to the pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Check ip of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-check-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamCheckArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "409": {
+            "description": "The ips diverge from the allocation",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Drift"
+          },
+          "500": {
+            "description": "Check failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/gc": {
+      "post": {
+        "description": "Send a request to daemonset to release the ips of the containers on\nthe node which are not in the valid attachments of the runtime\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Garbage collect ip of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-gc-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamGCArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Garbage collection failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/healthy": {
       "get": {
         "description": "Check spiderpool daemonset health to make sure whether it's ready\nfor CNI plugin usage\n",
@@ -151,14 +220,27 @@ func init() {
     },
     "/ipam/ips": {
       "post": {
-        "description": "Assign multiple ip for a pod, case for spiderflat compent\n",
+        "description": "Send a request to daemonset to ask for the ip assignments of\nmultiple NICs of a pod at once. If any NIC fails, no ip is returned,\nand the ips already assigned to the other NICs are reused by the\nretry. The spiderpool plugin still asks for the ips NIC by NIC\n",
         "tags": [
           "daemonset"
         ],
         "summary": "Assign multiple ip as a batch",
+        "parameters": [
+          {
+            "name": "ipam-batch-add-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamBatchAddArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamBatchAddResponse"
+            }
           },
           "500": {
             "description": "Allocation failure",
@@ -170,11 +252,21 @@ func init() {
         }
       },
       "delete": {
-        "description": "Delete multiple ip for a pod, case for spiderflat compent\n",
+        "description": "Send a request to daemonset to ask for the ip deleting of multiple\nNICs of a pod at once\n",
         "tags": [
           "daemonset"
         ],
         "summary": "Delete multiple ip as a batch",
+        "parameters": [
+          {
+            "name": "ipam-batch-del-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamBatchDelArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success"
@@ -189,6 +281,44 @@ func init() {
         }
       }
     },
+    "/ipam/status": {
+      "post": {
+        "description": "Check whether spiderpool daemonset is ready to serve ip assignments,\nincluding whether the ippools to allocate from are usable\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Get status of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-status-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamStatusArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Status failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          },
+          "503": {
+            "description": "Not ready to serve ip assignments",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Unavailable"
+          }
+        }
+      }
+    },
     "/runtime/liveness": {
       "get": {
         "description": "Check pod liveness probe",
@@ -272,6 +402,12 @@ func init() {
         "detectGateway": {
           "type": "boolean"
         },
+        "detectGatewayLossThreshold": {
+          "type": "integer"
+        },
+        "detectGatewayMode": {
+          "type": "string"
+        },
         "detectIPConflict": {
           "type": "boolean"
         },
@@ -319,6 +455,9 @@ func init() {
         },
         "txQueueLen": {
           "type": "integer"
+        },
+        "vethMTU": {
+          "type": "integer"
         }
       }
     },
@@ -357,6 +496,9 @@ func init() {
       "description": "Get Coordinator Args",
       "type": "object",
       "properties": {
+        "coordinatorName": {
+          "type": "string"
+        },
         "podName": {
           "type": "string"
         },
@@ -470,6 +612,202 @@ func init() {
         }
       }
     },
+    "IpamAttachment": {
+      "description": "An attachment of a container which is still in use",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchAddArgs": {
+      "description": "IPAM batch request args",
+      "type": "object",
+      "required": [
+        "containerID",
+        "netNamespace",
+        "podNamespace",
+        "podName",
+        "podUID",
+        "nics"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "nics": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/IpamBatchNicArgs"
+          }
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchAddResponse": {
+      "description": "IPAM assignment IPs information of the NICs in the batch",
+      "type": "object",
+      "required": [
+        "results"
+      ],
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamBatchNicResult"
+          }
+        }
+      }
+    },
+    "IpamBatchDelArgs": {
+      "description": "IPAM batch release IP information",
+      "type": "object",
+      "required": [
+        "containerID",
+        "podNamespace",
+        "podName",
+        "podUID",
+        "ifNames"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifNames": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchNicArgs": {
+      "description": "IPAM request args of a NIC in the batch",
+      "type": "object",
+      "required": [
+        "ifName"
+      ],
+      "properties": {
+        "cleanGateway": {
+          "type": "boolean"
+        },
+        "defaultIPv4IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "defaultIPv6IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ifName": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchNicResult": {
+      "description": "IPAM assignment IPs information of a NIC in the batch",
+      "type": "object",
+      "required": [
+        "ifName",
+        "ips"
+      ],
+      "properties": {
+        "dns": {
+          "type": "object",
+          "$ref": "#/definitions/DNS"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpConfig"
+          }
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Route"
+          }
+        }
+      }
+    },
+    "IpamCheckArgs": {
+      "description": "IPAM check args",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName",
+        "podNamespace",
+        "podName",
+        "podUID"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "description": "the ips of the container in CIDR notation",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
     "IpamDelArgs": {
       "description": "IPAM release IP information",
       "type": "object",
@@ -501,6 +839,39 @@ func init() {
         }
       }
     },
+    "IpamGCArgs": {
+      "description": "IPAM garbage collection args",
+      "type": "object",
+      "required": [
+        "validAttachments"
+      ],
+      "properties": {
+        "validAttachments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamAttachment"
+          }
+        }
+      }
+    },
+    "IpamStatusArgs": {
+      "description": "IPAM status args",
+      "type": "object",
+      "properties": {
+        "defaultIPv4IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "defaultIPv6IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "Route": {
       "description": "IPAM CNI types Route",
       "type": "object",
@@ -560,13 +931,82 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/CoordinatorConfig"
-            }
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/CoordinatorConfig"
+            }
+          },
+          "500": {
+            "description": "Config not initialized successfully or others",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/check": {
+      "post": {
+        "description": "Send a request to daemonset to check whether the ips of a container\nare still the ones allocated to the pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Check ip of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-check-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamCheckArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "409": {
+            "description": "The ips diverge from the allocation",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Drift"
+          },
+          "500": {
+            "description": "Check failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/gc": {
+      "post": {
+        "description": "Send a request to daemonset to release the ips of the containers on\nthe node which are not in the valid attachments of the runtime\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Garbage collect ip of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-gc-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamGCArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
           },
           "500": {
-            "description": "Config not initialized successfully or others",
+            "description": "Garbage collection failure",
             "schema": {
               "$ref": "#/definitions/Error"
             },
@@ -657,14 +1097,27 @@ func init() {
     },
     "/ipam/ips": {
       "post": {
-        "description": "Assign multiple ip for a pod, case for spiderflat compent\n",
+        "description": "Send a request to daemonset to ask for the ip assignments of\nmultiple NICs of a pod at once. If any NIC fails, no ip is returned,\nand the ips already assigned to the other NICs are reused by the\nretry. The spiderpool plugin still asks for the ips NIC by NIC\n",
         "tags": [
           "daemonset"
         ],
         "summary": "Assign multiple ip as a batch",
+        "parameters": [
+          {
+            "name": "ipam-batch-add-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamBatchAddArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamBatchAddResponse"
+            }
           },
           "500": {
             "description": "Allocation failure",
@@ -676,11 +1129,21 @@ func init() {
         }
       },
       "delete": {
-        "description": "Delete multiple ip for a pod, case for spiderflat compent\n",
+        "description": "Send a request to daemonset to ask for the ip deleting of multiple\nNICs of a pod at once\n",
         "tags": [
           "daemonset"
         ],
         "summary": "Delete multiple ip as a batch",
+        "parameters": [
+          {
+            "name": "ipam-batch-del-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamBatchDelArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success"
@@ -695,6 +1158,44 @@ func init() {
         }
       }
     },
+    "/ipam/status": {
+      "post": {
+        "description": "Check whether spiderpool daemonset is ready to serve ip assignments,\nincluding whether the ippools to allocate from are usable\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Get status of spiderpool daemon",
+        "parameters": [
+          {
+            "name": "ipam-status-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IpamStatusArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Status failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          },
+          "503": {
+            "description": "Not ready to serve ip assignments",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Unavailable"
+          }
+        }
+      }
+    },
     "/runtime/liveness": {
       "get": {
         "description": "Check pod liveness probe",
@@ -778,6 +1279,12 @@ func init() {
         "detectGateway": {
           "type": "boolean"
         },
+        "detectGatewayLossThreshold": {
+          "type": "integer"
+        },
+        "detectGatewayMode": {
+          "type": "string"
+        },
         "detectIPConflict": {
           "type": "boolean"
         },
@@ -825,6 +1332,9 @@ func init() {
         },
         "txQueueLen": {
           "type": "integer"
+        },
+        "vethMTU": {
+          "type": "integer"
         }
       }
     },
@@ -863,6 +1373,9 @@ func init() {
       "description": "Get Coordinator Args",
       "type": "object",
       "properties": {
+        "coordinatorName": {
+          "type": "string"
+        },
         "podName": {
           "type": "string"
         },
@@ -976,6 +1489,202 @@ func init() {
         }
       }
     },
+    "IpamAttachment": {
+      "description": "An attachment of a container which is still in use",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchAddArgs": {
+      "description": "IPAM batch request args",
+      "type": "object",
+      "required": [
+        "containerID",
+        "netNamespace",
+        "podNamespace",
+        "podName",
+        "podUID",
+        "nics"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "nics": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/IpamBatchNicArgs"
+          }
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchAddResponse": {
+      "description": "IPAM assignment IPs information of the NICs in the batch",
+      "type": "object",
+      "required": [
+        "results"
+      ],
+      "properties": {
+        "results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamBatchNicResult"
+          }
+        }
+      }
+    },
+    "IpamBatchDelArgs": {
+      "description": "IPAM batch release IP information",
+      "type": "object",
+      "required": [
+        "containerID",
+        "podNamespace",
+        "podName",
+        "podUID",
+        "ifNames"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifNames": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "string"
+          }
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchNicArgs": {
+      "description": "IPAM request args of a NIC in the batch",
+      "type": "object",
+      "required": [
+        "ifName"
+      ],
+      "properties": {
+        "cleanGateway": {
+          "type": "boolean"
+        },
+        "defaultIPv4IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "defaultIPv6IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "ifName": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamBatchNicResult": {
+      "description": "IPAM assignment IPs information of a NIC in the batch",
+      "type": "object",
+      "required": [
+        "ifName",
+        "ips"
+      ],
+      "properties": {
+        "dns": {
+          "type": "object",
+          "$ref": "#/definitions/DNS"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpConfig"
+          }
+        },
+        "routes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Route"
+          }
+        }
+      }
+    },
+    "IpamCheckArgs": {
+      "description": "IPAM check args",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName",
+        "podNamespace",
+        "podName",
+        "podUID"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "description": "the ips of the container in CIDR notation",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "netNamespace": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        }
+      }
+    },
     "IpamDelArgs": {
       "description": "IPAM release IP information",
       "type": "object",
@@ -1007,6 +1716,39 @@ func init() {
         }
       }
     },
+    "IpamGCArgs": {
+      "description": "IPAM garbage collection args",
+      "type": "object",
+      "required": [
+        "validAttachments"
+      ],
+      "properties": {
+        "validAttachments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamAttachment"
+          }
+        }
+      }
+    },
+    "IpamStatusArgs": {
+      "description": "IPAM status args",
+      "type": "object",
+      "properties": {
+        "defaultIPv4IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "defaultIPv6IPPool": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "Route": {
       "description": "IPAM CNI types Route",
       "type": "object",
@@ -37,7 +37,8 @@ func NewDeleteIpamIps(ctx *middleware.Context, handler DeleteIpamIpsHandler) *De
 
 # Delete multiple ip as a batch
 
-Delete multiple ip for a pod, case for spiderflat compent
+Send a request to daemonset to ask for the ip deleting of multiple
+NICs of a pod at once
 */
 type DeleteIpamIps struct {
 	Context *middleware.Context
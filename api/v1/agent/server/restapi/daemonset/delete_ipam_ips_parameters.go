@@ -9,10 +9,15 @@ package daemonset
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/validate"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 )
 
 // NewDeleteIpamIpsParams creates a new DeleteIpamIpsParams object
@@ -31,6 +36,12 @@ type DeleteIpamIpsParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  Required: true
+	  In: body
+	*/
+	IpamBatchDelArgs *models.IpamBatchDelArgs
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -42,6 +53,33 @@ func (o *DeleteIpamIpsParams) BindRequest(r *http.Request, route *middleware.Mat
 
 	o.HTTPRequest = r
 
+	if runtime.HasBody(r) {
+		defer r.Body.Close()
+		var body models.IpamBatchDelArgs
+		if err := route.Consumer.Consume(r.Body, &body); err != nil {
+			if err == io.EOF {
+				res = append(res, errors.Required("ipamBatchDelArgs", "body", ""))
+			} else {
+				res = append(res, errors.NewParseError("ipamBatchDelArgs", "body", "", err))
+			}
+		} else {
+			// validate body object
+			if err := body.Validate(route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			ctx := validate.WithOperationRequest(r.Context())
+			if err := body.ContextValidate(ctx, route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			if len(res) == 0 {
+				o.IpamBatchDelArgs = &body
+			}
+		}
+	} else {
+		res = append(res, errors.Required("ipamBatchDelArgs", "body", ""))
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PostIpamCheckHandlerFunc turns a function with the right signature into a post ipam check handler
+type PostIpamCheckHandlerFunc func(PostIpamCheckParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PostIpamCheckHandlerFunc) Handle(params PostIpamCheckParams) middleware.Responder {
+	return fn(params)
+}
+
+// PostIpamCheckHandler interface for that can handle valid post ipam check params
+type PostIpamCheckHandler interface {
+	Handle(PostIpamCheckParams) middleware.Responder
+}
+
+// NewPostIpamCheck creates a new http.Handler for the post ipam check operation
+func NewPostIpamCheck(ctx *middleware.Context, handler PostIpamCheckHandler) *PostIpamCheck {
+	return &PostIpamCheck{Context: ctx, Handler: handler}
+}
+
+/*
+	PostIpamCheck swagger:route POST /ipam/check daemonset postIpamCheck
+
+# Check ip of spiderpool daemon
+
+Send a request to daemonset to check whether the ips of a container
+are still the ones allocated to the pod
+*/
+type PostIpamCheck struct {
+	Context *middleware.Context
+	Handler PostIpamCheckHandler
+}
+
+func (o *PostIpamCheck) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewPostIpamCheckParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
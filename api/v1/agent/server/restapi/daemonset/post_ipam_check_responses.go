@@ -0,0 +1,128 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamCheckOKCode is the HTTP code returned for type PostIpamCheckOK
+const PostIpamCheckOKCode int = 200
+
+/*
+PostIpamCheckOK Success
+
+swagger:response postIpamCheckOK
+*/
+type PostIpamCheckOK struct {
+}
+
+// NewPostIpamCheckOK creates PostIpamCheckOK with default headers values
+func NewPostIpamCheckOK() *PostIpamCheckOK {
+
+	return &PostIpamCheckOK{}
+}
+
+// WriteResponse to the client
+func (o *PostIpamCheckOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(200)
+}
+
+// PostIpamCheckDriftCode is the HTTP code returned for type PostIpamCheckDrift
+const PostIpamCheckDriftCode int = 409
+
+/*
+PostIpamCheckDrift The ips diverge from the allocation
+
+swagger:response postIpamCheckDrift
+*/
+type PostIpamCheckDrift struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamCheckDrift creates PostIpamCheckDrift with default headers values
+func NewPostIpamCheckDrift() *PostIpamCheckDrift {
+
+	return &PostIpamCheckDrift{}
+}
+
+// WithPayload adds the payload to the post ipam check drift response
+func (o *PostIpamCheckDrift) WithPayload(payload models.Error) *PostIpamCheckDrift {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam check drift response
+func (o *PostIpamCheckDrift) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamCheckDrift) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PostIpamCheckFailureCode is the HTTP code returned for type PostIpamCheckFailure
+const PostIpamCheckFailureCode int = 500
+
+/*
+PostIpamCheckFailure Check failure
+
+swagger:response postIpamCheckFailure
+*/
+type PostIpamCheckFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamCheckFailure creates PostIpamCheckFailure with default headers values
+func NewPostIpamCheckFailure() *PostIpamCheckFailure {
+
+	return &PostIpamCheckFailure{}
+}
+
+// WithPayload adds the payload to the post ipam check failure response
+func (o *PostIpamCheckFailure) WithPayload(payload models.Error) *PostIpamCheckFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam check failure response
+func (o *PostIpamCheckFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamCheckFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PostIpamGcHandlerFunc turns a function with the right signature into a post ipam gc handler
+type PostIpamGcHandlerFunc func(PostIpamGcParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PostIpamGcHandlerFunc) Handle(params PostIpamGcParams) middleware.Responder {
+	return fn(params)
+}
+
+// PostIpamGcHandler interface for that can handle valid post ipam gc params
+type PostIpamGcHandler interface {
+	Handle(PostIpamGcParams) middleware.Responder
+}
+
+// NewPostIpamGc creates a new http.Handler for the post ipam gc operation
+func NewPostIpamGc(ctx *middleware.Context, handler PostIpamGcHandler) *PostIpamGc {
+	return &PostIpamGc{Context: ctx, Handler: handler}
+}
+
+/*
+	PostIpamGc swagger:route POST /ipam/gc daemonset postIpamGc
+
+# Garbage collect ip of spiderpool daemon
+
+Send a request to daemonset to release the ips of the containers on
+the node which are not in the valid attachments of the runtime
+*/
+type PostIpamGc struct {
+	Context *middleware.Context
+	Handler PostIpamGcHandler
+}
+
+func (o *PostIpamGc) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewPostIpamGcParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
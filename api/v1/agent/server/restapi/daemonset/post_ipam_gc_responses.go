@@ -0,0 +1,85 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamGcOKCode is the HTTP code returned for type PostIpamGcOK
+const PostIpamGcOKCode int = 200
+
+/*
+PostIpamGcOK Success
+
+swagger:response postIpamGcOK
+*/
+type PostIpamGcOK struct {
+}
+
+// NewPostIpamGcOK creates PostIpamGcOK with default headers values
+func NewPostIpamGcOK() *PostIpamGcOK {
+
+	return &PostIpamGcOK{}
+}
+
+// WriteResponse to the client
+func (o *PostIpamGcOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(200)
+}
+
+// PostIpamGcFailureCode is the HTTP code returned for type PostIpamGcFailure
+const PostIpamGcFailureCode int = 500
+
+/*
+PostIpamGcFailure Garbage collection failure
+
+swagger:response postIpamGcFailure
+*/
+type PostIpamGcFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamGcFailure creates PostIpamGcFailure with default headers values
+func NewPostIpamGcFailure() *PostIpamGcFailure {
+
+	return &PostIpamGcFailure{}
+}
+
+// WithPayload adds the payload to the post ipam gc failure response
+func (o *PostIpamGcFailure) WithPayload(payload models.Error) *PostIpamGcFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam gc failure response
+func (o *PostIpamGcFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamGcFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -37,7 +37,10 @@ func NewPostIpamIps(ctx *middleware.Context, handler PostIpamIpsHandler) *PostIp
 
 # Assign multiple ip as a batch
 
-Assign multiple ip for a pod, case for spiderflat compent
+Send a request to daemonset to ask for the ip assignments of
+multiple NICs of a pod at once. If any NIC fails, no ip is returned,
+and the ips already assigned to the other NICs are reused by the
+retry. The spiderpool plugin still asks for the ips NIC by NIC
 */
 type PostIpamIps struct {
 	Context *middleware.Context
@@ -25,6 +25,11 @@ PostIpamIpsOK Success
 swagger:response postIpamIpsOK
 */
 type PostIpamIpsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.IpamBatchAddResponse `json:"body,omitempty"`
 }
 
 // NewPostIpamIpsOK creates PostIpamIpsOK with default headers values
@@ -33,12 +38,27 @@ func NewPostIpamIpsOK() *PostIpamIpsOK {
 	return &PostIpamIpsOK{}
 }
 
+// WithPayload adds the payload to the post ipam ips o k response
+func (o *PostIpamIpsOK) WithPayload(payload *models.IpamBatchAddResponse) *PostIpamIpsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam ips o k response
+func (o *PostIpamIpsOK) SetPayload(payload *models.IpamBatchAddResponse) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *PostIpamIpsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
 // PostIpamIpsFailureCode is the HTTP code returned for type PostIpamIpsFailure
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PostIpamStatusHandlerFunc turns a function with the right signature into a post ipam status handler
+type PostIpamStatusHandlerFunc func(PostIpamStatusParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PostIpamStatusHandlerFunc) Handle(params PostIpamStatusParams) middleware.Responder {
+	return fn(params)
+}
+
+// PostIpamStatusHandler interface for that can handle valid post ipam status params
+type PostIpamStatusHandler interface {
+	Handle(PostIpamStatusParams) middleware.Responder
+}
+
+// NewPostIpamStatus creates a new http.Handler for the post ipam status operation
+func NewPostIpamStatus(ctx *middleware.Context, handler PostIpamStatusHandler) *PostIpamStatus {
+	return &PostIpamStatus{Context: ctx, Handler: handler}
+}
+
+/*
+	PostIpamStatus swagger:route POST /ipam/status daemonset postIpamStatus
+
+# Get status of spiderpool daemon
+
+Check whether spiderpool daemonset is ready to serve ip assignments,
+including whether the ippools to allocate from are usable
+*/
+type PostIpamStatus struct {
+	Context *middleware.Context
+	Handler PostIpamStatusHandler
+}
+
+func (o *PostIpamStatus) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewPostIpamStatusParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
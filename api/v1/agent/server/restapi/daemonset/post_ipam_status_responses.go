@@ -0,0 +1,128 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PostIpamStatusOKCode is the HTTP code returned for type PostIpamStatusOK
+const PostIpamStatusOKCode int = 200
+
+/*
+PostIpamStatusOK Success
+
+swagger:response postIpamStatusOK
+*/
+type PostIpamStatusOK struct {
+}
+
+// NewPostIpamStatusOK creates PostIpamStatusOK with default headers values
+func NewPostIpamStatusOK() *PostIpamStatusOK {
+
+	return &PostIpamStatusOK{}
+}
+
+// WriteResponse to the client
+func (o *PostIpamStatusOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(200)
+}
+
+// PostIpamStatusFailureCode is the HTTP code returned for type PostIpamStatusFailure
+const PostIpamStatusFailureCode int = 500
+
+/*
+PostIpamStatusFailure Status failure
+
+swagger:response postIpamStatusFailure
+*/
+type PostIpamStatusFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamStatusFailure creates PostIpamStatusFailure with default headers values
+func NewPostIpamStatusFailure() *PostIpamStatusFailure {
+
+	return &PostIpamStatusFailure{}
+}
+
+// WithPayload adds the payload to the post ipam status failure response
+func (o *PostIpamStatusFailure) WithPayload(payload models.Error) *PostIpamStatusFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam status failure response
+func (o *PostIpamStatusFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamStatusFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PostIpamStatusUnavailableCode is the HTTP code returned for type PostIpamStatusUnavailable
+const PostIpamStatusUnavailableCode int = 503
+
+/*
+PostIpamStatusUnavailable Not ready to serve ip assignments
+
+swagger:response postIpamStatusUnavailable
+*/
+type PostIpamStatusUnavailable struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamStatusUnavailable creates PostIpamStatusUnavailable with default headers values
+func NewPostIpamStatusUnavailable() *PostIpamStatusUnavailable {
+
+	return &PostIpamStatusUnavailable{}
+}
+
+// WithPayload adds the payload to the post ipam status unavailable response
+func (o *PostIpamStatusUnavailable) WithPayload(payload models.Error) *PostIpamStatusUnavailable {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam status unavailable response
+func (o *PostIpamStatusUnavailable) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamStatusUnavailable) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(503)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -73,12 +73,21 @@ func NewSpiderpoolAgentAPIAPI(spec *loads.Document) *SpiderpoolAgentAPIAPI {
 		DaemonsetGetWorkloadendpointHandler: daemonset.GetWorkloadendpointHandlerFunc(func(params daemonset.GetWorkloadendpointParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.GetWorkloadendpoint has not yet been implemented")
 		}),
+		DaemonsetPostIpamCheckHandler: daemonset.PostIpamCheckHandlerFunc(func(params daemonset.PostIpamCheckParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamCheck has not yet been implemented")
+		}),
+		DaemonsetPostIpamGcHandler: daemonset.PostIpamGcHandlerFunc(func(params daemonset.PostIpamGcParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamGc has not yet been implemented")
+		}),
 		DaemonsetPostIpamIPHandler: daemonset.PostIpamIPHandlerFunc(func(params daemonset.PostIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIP has not yet been implemented")
 		}),
 		DaemonsetPostIpamIpsHandler: daemonset.PostIpamIpsHandlerFunc(func(params daemonset.PostIpamIpsParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIps has not yet been implemented")
 		}),
+		DaemonsetPostIpamStatusHandler: daemonset.PostIpamStatusHandlerFunc(func(params daemonset.PostIpamStatusParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PostIpamStatus has not yet been implemented")
+		}),
 	}
 }
 
@@ -131,10 +140,16 @@ type SpiderpoolAgentAPIAPI struct {
 	RuntimeGetRuntimeStartupHandler runtimeops.GetRuntimeStartupHandler
 	// DaemonsetGetWorkloadendpointHandler sets the operation handler for the get workloadendpoint operation
 	DaemonsetGetWorkloadendpointHandler daemonset.GetWorkloadendpointHandler
+	// DaemonsetPostIpamCheckHandler sets the operation handler for the post ipam check operation
+	DaemonsetPostIpamCheckHandler daemonset.PostIpamCheckHandler
+	// DaemonsetPostIpamGcHandler sets the operation handler for the post ipam gc operation
+	DaemonsetPostIpamGcHandler daemonset.PostIpamGcHandler
 	// DaemonsetPostIpamIPHandler sets the operation handler for the post ipam IP operation
 	DaemonsetPostIpamIPHandler daemonset.PostIpamIPHandler
 	// DaemonsetPostIpamIpsHandler sets the operation handler for the post ipam ips operation
 	DaemonsetPostIpamIpsHandler daemonset.PostIpamIpsHandler
+	// DaemonsetPostIpamStatusHandler sets the operation handler for the post ipam status operation
+	DaemonsetPostIpamStatusHandler daemonset.PostIpamStatusHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -236,12 +251,21 @@ func (o *SpiderpoolAgentAPIAPI) Validate() error {
 	if o.DaemonsetGetWorkloadendpointHandler == nil {
 		unregistered = append(unregistered, "daemonset.GetWorkloadendpointHandler")
 	}
+	if o.DaemonsetPostIpamCheckHandler == nil {
+		unregistered = append(unregistered, "daemonset.PostIpamCheckHandler")
+	}
+	if o.DaemonsetPostIpamGcHandler == nil {
+		unregistered = append(unregistered, "daemonset.PostIpamGcHandler")
+	}
 	if o.DaemonsetPostIpamIPHandler == nil {
 		unregistered = append(unregistered, "daemonset.PostIpamIPHandler")
 	}
 	if o.DaemonsetPostIpamIpsHandler == nil {
 		unregistered = append(unregistered, "daemonset.PostIpamIpsHandler")
 	}
+	if o.DaemonsetPostIpamStatusHandler == nil {
+		unregistered = append(unregistered, "daemonset.PostIpamStatusHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -365,11 +389,23 @@ func (o *SpiderpoolAgentAPIAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/ipam/check"] = daemonset.NewPostIpamCheck(o.context, o.DaemonsetPostIpamCheckHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/ipam/gc"] = daemonset.NewPostIpamGc(o.context, o.DaemonsetPostIpamGcHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/ipam/ip"] = daemonset.NewPostIpamIP(o.context, o.DaemonsetPostIpamIPHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/ipam/ips"] = daemonset.NewPostIpamIps(o.context, o.DaemonsetPostIpamIpsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/ipam/status"] = daemonset.NewPostIpamStatus(o.context, o.DaemonsetPostIpamStatusHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -33,6 +33,8 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	DeleteIpamIP(params *DeleteIpamIPParams, opts ...ClientOption) (*DeleteIpamIPOK, error)
+
 	GetIpamStatus(params *GetIpamStatusParams, opts ...ClientOption) (*GetIpamStatusOK, error)
 
 	PostIpamGcIps(params *PostIpamGcIpsParams, opts ...ClientOption) (*PostIpamGcIpsOK, error)
@@ -42,6 +44,46 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+DeleteIpamIP releases ip
+
+Release ip and clean up its allocation records for spiderpool controller cli debug usage
+*/
+func (a *Client) DeleteIpamIP(params *DeleteIpamIPParams, opts ...ClientOption) (*DeleteIpamIPOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteIpamIPParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "DeleteIpamIP",
+		Method:             "DELETE",
+		PathPattern:        "/ipam/ip",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteIpamIPReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteIpamIPOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for DeleteIpamIP: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetIpamStatus gets status
 
@@ -0,0 +1,166 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+// DeleteIpamIPReader is a Reader for the DeleteIpamIP structure.
+type DeleteIpamIPReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *DeleteIpamIPReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewDeleteIpamIPOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case 500:
+		result := NewDeleteIpamIPFailure()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewDeleteIpamIPOK creates a DeleteIpamIPOK with default headers values
+func NewDeleteIpamIPOK() *DeleteIpamIPOK {
+	return &DeleteIpamIPOK{}
+}
+
+/*
+DeleteIpamIPOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type DeleteIpamIPOK struct {
+}
+
+// IsSuccess returns true when this delete ipam Ip o k response has a 2xx status code
+func (o *DeleteIpamIPOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this delete ipam Ip o k response has a 3xx status code
+func (o *DeleteIpamIPOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this delete ipam Ip o k response has a 4xx status code
+func (o *DeleteIpamIPOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this delete ipam Ip o k response has a 5xx status code
+func (o *DeleteIpamIPOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this delete ipam Ip o k response a status code equal to that given
+func (o *DeleteIpamIPOK) IsCode(code int) bool {
+	return code == 200
+}
+
+// Code gets the status code for the delete ipam Ip o k response
+func (o *DeleteIpamIPOK) Code() int {
+	return 200
+}
+
+func (o *DeleteIpamIPOK) Error() string {
+	return fmt.Sprintf("[DELETE /ipam/ip][%d] deleteIpamIpOK ", 200)
+}
+
+func (o *DeleteIpamIPOK) String() string {
+	return fmt.Sprintf("[DELETE /ipam/ip][%d] deleteIpamIpOK ", 200)
+}
+
+func (o *DeleteIpamIPOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
+// NewDeleteIpamIPFailure creates a DeleteIpamIPFailure with default headers values
+func NewDeleteIpamIPFailure() *DeleteIpamIPFailure {
+	return &DeleteIpamIPFailure{}
+}
+
+/*
+DeleteIpamIPFailure describes a response with status code 500, with default header values.
+
+Release ip failure
+*/
+type DeleteIpamIPFailure struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this delete ipam Ip failure response has a 2xx status code
+func (o *DeleteIpamIPFailure) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this delete ipam Ip failure response has a 3xx status code
+func (o *DeleteIpamIPFailure) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this delete ipam Ip failure response has a 4xx status code
+func (o *DeleteIpamIPFailure) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this delete ipam Ip failure response has a 5xx status code
+func (o *DeleteIpamIPFailure) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this delete ipam Ip failure response a status code equal to that given
+func (o *DeleteIpamIPFailure) IsCode(code int) bool {
+	return code == 500
+}
+
+// Code gets the status code for the delete ipam Ip failure response
+func (o *DeleteIpamIPFailure) Code() int {
+	return 500
+}
+
+func (o *DeleteIpamIPFailure) Error() string {
+	return fmt.Sprintf("[DELETE /ipam/ip][%d] deleteIpamIpFailure  %+v", 500, o.Payload)
+}
+
+func (o *DeleteIpamIPFailure) String() string {
+	return fmt.Sprintf("[DELETE /ipam/ip][%d] deleteIpamIpFailure  %+v", 500, o.Payload)
+}
+
+func (o *DeleteIpamIPFailure) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *DeleteIpamIPFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -63,6 +63,13 @@ GetIpamStatusParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type GetIpamStatusParams struct {
+
+	/* IP.
+
+	   Only show the status of this ip
+	*/
+	IP *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -116,6 +123,17 @@ func (o *GetIpamStatusParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIP adds the ip to the get ipam status params
+func (o *GetIpamStatusParams) WithIP(ip *string) *GetIpamStatusParams {
+	o.SetIP(ip)
+	return o
+}
+
+// SetIP adds the ip to the get ipam status params
+func (o *GetIpamStatusParams) SetIP(ip *string) {
+	o.IP = ip
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetIpamStatusParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -124,6 +142,23 @@ func (o *GetIpamStatusParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 	}
 	var res []error
 
+	if o.IP != nil {
+
+		// query param ip
+		var qrIP string
+
+		if o.IP != nil {
+			qrIP = *o.IP
+		}
+		qIP := qrIP
+		if qIP != "" {
+
+			if err := r.SetQueryParam("ip", qIP); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
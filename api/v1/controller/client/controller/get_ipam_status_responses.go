@@ -10,9 +10,12 @@ package controller
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // GetIpamStatusReader is a Reader for the GetIpamStatus structure.
@@ -30,7 +33,7 @@ func (o *GetIpamStatusReader) ReadResponse(response runtime.ClientResponse, cons
 		}
 		return result, nil
 	case 500:
-		result := NewGetIpamStatusInternalServerError()
+		result := NewGetIpamStatusFailure()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -51,6 +54,7 @@ GetIpamStatusOK describes a response with status code 200, with default header v
 Success
 */
 type GetIpamStatusOK struct {
+	Payload *models.IpamStatus
 }
 
 // IsSuccess returns true when this get ipam status o k response has a 2xx status code
@@ -84,70 +88,91 @@ func (o *GetIpamStatusOK) Code() int {
 }
 
 func (o *GetIpamStatusOK) Error() string {
-	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusOK ", 200)
+	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusOK  %+v", 200, o.Payload)
 }
 
 func (o *GetIpamStatusOK) String() string {
-	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusOK ", 200)
+	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusOK  %+v", 200, o.Payload)
+}
+
+func (o *GetIpamStatusOK) GetPayload() *models.IpamStatus {
+	return o.Payload
 }
 
 func (o *GetIpamStatusOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(models.IpamStatus)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
 
-// NewGetIpamStatusInternalServerError creates a GetIpamStatusInternalServerError with default headers values
-func NewGetIpamStatusInternalServerError() *GetIpamStatusInternalServerError {
-	return &GetIpamStatusInternalServerError{}
+// NewGetIpamStatusFailure creates a GetIpamStatusFailure with default headers values
+func NewGetIpamStatusFailure() *GetIpamStatusFailure {
+	return &GetIpamStatusFailure{}
 }
 
 /*
-GetIpamStatusInternalServerError describes a response with status code 500, with default header values.
+GetIpamStatusFailure describes a response with status code 500, with default header values.
 
 Get ipam status failure
 */
-type GetIpamStatusInternalServerError struct {
+type GetIpamStatusFailure struct {
+	Payload models.Error
 }
 
-// IsSuccess returns true when this get ipam status internal server error response has a 2xx status code
-func (o *GetIpamStatusInternalServerError) IsSuccess() bool {
+// IsSuccess returns true when this get ipam status failure response has a 2xx status code
+func (o *GetIpamStatusFailure) IsSuccess() bool {
 	return false
 }
 
-// IsRedirect returns true when this get ipam status internal server error response has a 3xx status code
-func (o *GetIpamStatusInternalServerError) IsRedirect() bool {
+// IsRedirect returns true when this get ipam status failure response has a 3xx status code
+func (o *GetIpamStatusFailure) IsRedirect() bool {
 	return false
 }
 
-// IsClientError returns true when this get ipam status internal server error response has a 4xx status code
-func (o *GetIpamStatusInternalServerError) IsClientError() bool {
+// IsClientError returns true when this get ipam status failure response has a 4xx status code
+func (o *GetIpamStatusFailure) IsClientError() bool {
 	return false
 }
 
-// IsServerError returns true when this get ipam status internal server error response has a 5xx status code
-func (o *GetIpamStatusInternalServerError) IsServerError() bool {
+// IsServerError returns true when this get ipam status failure response has a 5xx status code
+func (o *GetIpamStatusFailure) IsServerError() bool {
 	return true
 }
 
-// IsCode returns true when this get ipam status internal server error response a status code equal to that given
-func (o *GetIpamStatusInternalServerError) IsCode(code int) bool {
+// IsCode returns true when this get ipam status failure response a status code equal to that given
+func (o *GetIpamStatusFailure) IsCode(code int) bool {
 	return code == 500
 }
 
-// Code gets the status code for the get ipam status internal server error response
-func (o *GetIpamStatusInternalServerError) Code() int {
+// Code gets the status code for the get ipam status failure response
+func (o *GetIpamStatusFailure) Code() int {
 	return 500
 }
 
-func (o *GetIpamStatusInternalServerError) Error() string {
-	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusInternalServerError ", 500)
+func (o *GetIpamStatusFailure) Error() string {
+	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusFailure  %+v", 500, o.Payload)
+}
+
+func (o *GetIpamStatusFailure) String() string {
+	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusFailure  %+v", 500, o.Payload)
 }
 
-func (o *GetIpamStatusInternalServerError) String() string {
-	return fmt.Sprintf("[GET /ipam/status][%d] getIpamStatusInternalServerError ", 500)
+func (o *GetIpamStatusFailure) GetPayload() models.Error {
+	return o.Payload
 }
 
-func (o *GetIpamStatusInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *GetIpamStatusFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
 
 	return nil
 }
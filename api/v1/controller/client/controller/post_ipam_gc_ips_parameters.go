@@ -17,6 +17,8 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // NewPostIpamGcIpsParams creates a new PostIpamGcIpsParams object,
@@ -63,6 +65,10 @@ PostIpamGcIpsParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type PostIpamGcIpsParams struct {
+
+	// GcArgs.
+	GcArgs *models.IpamGcArgs
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -116,6 +122,17 @@ func (o *PostIpamGcIpsParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithGcArgs adds the gcArgs to the post ipam gc ips params
+func (o *PostIpamGcIpsParams) WithGcArgs(gcArgs *models.IpamGcArgs) *PostIpamGcIpsParams {
+	o.SetGcArgs(gcArgs)
+	return o
+}
+
+// SetGcArgs adds the gcArgs to the post ipam gc ips params
+func (o *PostIpamGcIpsParams) SetGcArgs(gcArgs *models.IpamGcArgs) {
+	o.GcArgs = gcArgs
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PostIpamGcIpsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -123,6 +140,11 @@ func (o *PostIpamGcIpsParams) WriteToRequest(r runtime.ClientRequest, reg strfmt
 		return err
 	}
 	var res []error
+	if o.GcArgs != nil {
+		if err := r.SetBodyParam(o.GcArgs); err != nil {
+			return err
+		}
+	}
 
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
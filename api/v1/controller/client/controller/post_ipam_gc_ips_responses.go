@@ -10,9 +10,12 @@ package controller
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // PostIpamGcIpsReader is a Reader for the PostIpamGcIps structure.
@@ -30,7 +33,7 @@ func (o *PostIpamGcIpsReader) ReadResponse(response runtime.ClientResponse, cons
 		}
 		return result, nil
 	case 500:
-		result := NewPostIpamGcIpsInternalServerError()
+		result := NewPostIpamGcIpsFailure()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -51,6 +54,7 @@ PostIpamGcIpsOK describes a response with status code 200, with default header v
 Success
 */
 type PostIpamGcIpsOK struct {
+	Payload *models.IpamGcResult
 }
 
 // IsSuccess returns true when this post ipam gc ips o k response has a 2xx status code
@@ -84,70 +88,91 @@ func (o *PostIpamGcIpsOK) Code() int {
 }
 
 func (o *PostIpamGcIpsOK) Error() string {
-	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsOK ", 200)
+	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsOK  %+v", 200, o.Payload)
 }
 
 func (o *PostIpamGcIpsOK) String() string {
-	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsOK ", 200)
+	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsOK  %+v", 200, o.Payload)
+}
+
+func (o *PostIpamGcIpsOK) GetPayload() *models.IpamGcResult {
+	return o.Payload
 }
 
 func (o *PostIpamGcIpsOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(models.IpamGcResult)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
 
-// NewPostIpamGcIpsInternalServerError creates a PostIpamGcIpsInternalServerError with default headers values
-func NewPostIpamGcIpsInternalServerError() *PostIpamGcIpsInternalServerError {
-	return &PostIpamGcIpsInternalServerError{}
+// NewPostIpamGcIpsFailure creates a PostIpamGcIpsFailure with default headers values
+func NewPostIpamGcIpsFailure() *PostIpamGcIpsFailure {
+	return &PostIpamGcIpsFailure{}
 }
 
 /*
-PostIpamGcIpsInternalServerError describes a response with status code 500, with default header values.
+PostIpamGcIpsFailure describes a response with status code 500, with default header values.
 
 Global gc failure
 */
-type PostIpamGcIpsInternalServerError struct {
+type PostIpamGcIpsFailure struct {
+	Payload models.Error
 }
 
-// IsSuccess returns true when this post ipam gc ips internal server error response has a 2xx status code
-func (o *PostIpamGcIpsInternalServerError) IsSuccess() bool {
+// IsSuccess returns true when this post ipam gc ips failure response has a 2xx status code
+func (o *PostIpamGcIpsFailure) IsSuccess() bool {
 	return false
 }
 
-// IsRedirect returns true when this post ipam gc ips internal server error response has a 3xx status code
-func (o *PostIpamGcIpsInternalServerError) IsRedirect() bool {
+// IsRedirect returns true when this post ipam gc ips failure response has a 3xx status code
+func (o *PostIpamGcIpsFailure) IsRedirect() bool {
 	return false
 }
 
-// IsClientError returns true when this post ipam gc ips internal server error response has a 4xx status code
-func (o *PostIpamGcIpsInternalServerError) IsClientError() bool {
+// IsClientError returns true when this post ipam gc ips failure response has a 4xx status code
+func (o *PostIpamGcIpsFailure) IsClientError() bool {
 	return false
 }
 
-// IsServerError returns true when this post ipam gc ips internal server error response has a 5xx status code
-func (o *PostIpamGcIpsInternalServerError) IsServerError() bool {
+// IsServerError returns true when this post ipam gc ips failure response has a 5xx status code
+func (o *PostIpamGcIpsFailure) IsServerError() bool {
 	return true
 }
 
-// IsCode returns true when this post ipam gc ips internal server error response a status code equal to that given
-func (o *PostIpamGcIpsInternalServerError) IsCode(code int) bool {
+// IsCode returns true when this post ipam gc ips failure response a status code equal to that given
+func (o *PostIpamGcIpsFailure) IsCode(code int) bool {
 	return code == 500
 }
 
-// Code gets the status code for the post ipam gc ips internal server error response
-func (o *PostIpamGcIpsInternalServerError) Code() int {
+// Code gets the status code for the post ipam gc ips failure response
+func (o *PostIpamGcIpsFailure) Code() int {
 	return 500
 }
 
-func (o *PostIpamGcIpsInternalServerError) Error() string {
-	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsInternalServerError ", 500)
+func (o *PostIpamGcIpsFailure) Error() string {
+	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsFailure  %+v", 500, o.Payload)
+}
+
+func (o *PostIpamGcIpsFailure) String() string {
+	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsFailure  %+v", 500, o.Payload)
 }
 
-func (o *PostIpamGcIpsInternalServerError) String() string {
-	return fmt.Sprintf("[POST /ipam/gc_ips][%d] postIpamGcIpsInternalServerError ", 500)
+func (o *PostIpamGcIpsFailure) GetPayload() models.Error {
+	return o.Payload
 }
 
-func (o *PostIpamGcIpsInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *PostIpamGcIpsFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
 
 	return nil
 }
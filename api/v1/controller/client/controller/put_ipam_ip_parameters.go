@@ -17,6 +17,8 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // NewPutIpamIPParams creates a new PutIpamIPParams object,
@@ -63,6 +65,10 @@ PutIpamIPParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type PutIpamIPParams struct {
+
+	// IPSetArgs.
+	IPSetArgs *models.IPSetArgs
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -116,6 +122,17 @@ func (o *PutIpamIPParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIPSetArgs adds the iPSetArgs to the put ipam IP params
+func (o *PutIpamIPParams) WithIPSetArgs(iPSetArgs *models.IPSetArgs) *PutIpamIPParams {
+	o.SetIPSetArgs(iPSetArgs)
+	return o
+}
+
+// SetIPSetArgs adds the ipSetArgs to the put ipam IP params
+func (o *PutIpamIPParams) SetIPSetArgs(iPSetArgs *models.IPSetArgs) {
+	o.IPSetArgs = iPSetArgs
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PutIpamIPParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -123,6 +140,11 @@ func (o *PutIpamIPParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Reg
 		return err
 	}
 	var res []error
+	if o.IPSetArgs != nil {
+		if err := r.SetBodyParam(o.IPSetArgs); err != nil {
+			return err
+		}
+	}
 
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
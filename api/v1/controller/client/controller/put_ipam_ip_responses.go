@@ -10,9 +10,12 @@ package controller
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // PutIpamIPReader is a Reader for the PutIpamIP structure.
@@ -30,7 +33,7 @@ func (o *PutIpamIPReader) ReadResponse(response runtime.ClientResponse, consumer
 		}
 		return result, nil
 	case 500:
-		result := NewPutIpamIPInternalServerError()
+		result := NewPutIpamIPFailure()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
@@ -96,58 +99,68 @@ func (o *PutIpamIPOK) readResponse(response runtime.ClientResponse, consumer run
 	return nil
 }
 
-// NewPutIpamIPInternalServerError creates a PutIpamIPInternalServerError with default headers values
-func NewPutIpamIPInternalServerError() *PutIpamIPInternalServerError {
-	return &PutIpamIPInternalServerError{}
+// NewPutIpamIPFailure creates a PutIpamIPFailure with default headers values
+func NewPutIpamIPFailure() *PutIpamIPFailure {
+	return &PutIpamIPFailure{}
 }
 
 /*
-PutIpamIPInternalServerError describes a response with status code 500, with default header values.
+PutIpamIPFailure describes a response with status code 500, with default header values.
 
 Force set ip failure
 */
-type PutIpamIPInternalServerError struct {
+type PutIpamIPFailure struct {
+	Payload models.Error
 }
 
-// IsSuccess returns true when this put ipam Ip internal server error response has a 2xx status code
-func (o *PutIpamIPInternalServerError) IsSuccess() bool {
+// IsSuccess returns true when this put ipam Ip failure response has a 2xx status code
+func (o *PutIpamIPFailure) IsSuccess() bool {
 	return false
 }
 
-// IsRedirect returns true when this put ipam Ip internal server error response has a 3xx status code
-func (o *PutIpamIPInternalServerError) IsRedirect() bool {
+// IsRedirect returns true when this put ipam Ip failure response has a 3xx status code
+func (o *PutIpamIPFailure) IsRedirect() bool {
 	return false
 }
 
-// IsClientError returns true when this put ipam Ip internal server error response has a 4xx status code
-func (o *PutIpamIPInternalServerError) IsClientError() bool {
+// IsClientError returns true when this put ipam Ip failure response has a 4xx status code
+func (o *PutIpamIPFailure) IsClientError() bool {
 	return false
 }
 
-// IsServerError returns true when this put ipam Ip internal server error response has a 5xx status code
-func (o *PutIpamIPInternalServerError) IsServerError() bool {
+// IsServerError returns true when this put ipam Ip failure response has a 5xx status code
+func (o *PutIpamIPFailure) IsServerError() bool {
 	return true
 }
 
-// IsCode returns true when this put ipam Ip internal server error response a status code equal to that given
-func (o *PutIpamIPInternalServerError) IsCode(code int) bool {
+// IsCode returns true when this put ipam Ip failure response a status code equal to that given
+func (o *PutIpamIPFailure) IsCode(code int) bool {
 	return code == 500
 }
 
-// Code gets the status code for the put ipam Ip internal server error response
-func (o *PutIpamIPInternalServerError) Code() int {
+// Code gets the status code for the put ipam Ip failure response
+func (o *PutIpamIPFailure) Code() int {
 	return 500
 }
 
-func (o *PutIpamIPInternalServerError) Error() string {
-	return fmt.Sprintf("[PUT /ipam/ip][%d] putIpamIpInternalServerError ", 500)
+func (o *PutIpamIPFailure) Error() string {
+	return fmt.Sprintf("[PUT /ipam/ip][%d] putIpamIpFailure  %+v", 500, o.Payload)
+}
+
+func (o *PutIpamIPFailure) String() string {
+	return fmt.Sprintf("[PUT /ipam/ip][%d] putIpamIpFailure  %+v", 500, o.Payload)
 }
 
-func (o *PutIpamIPInternalServerError) String() string {
-	return fmt.Sprintf("[PUT /ipam/ip][%d] putIpamIpInternalServerError ", 500)
+func (o *PutIpamIPFailure) GetPayload() models.Error {
+	return o.Payload
 }
 
-func (o *PutIpamIPInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+func (o *PutIpamIPFailure) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
 
 	return nil
 }
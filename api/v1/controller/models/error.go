@@ -0,0 +1,30 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+)
+
+// Error API error
+//
+// swagger:model Error
+type Error string
+
+// Validate validates this error
+func (m Error) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this error based on context it is used
+func (m Error) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
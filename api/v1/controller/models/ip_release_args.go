@@ -0,0 +1,77 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IPReleaseArgs Args to release an ip
+//
+// swagger:model IPReleaseArgs
+type IPReleaseArgs struct {
+
+	// force
+	Force bool `json:"force,omitempty"`
+
+	// ip
+	// Required: true
+	IP *string `json:"ip"`
+}
+
+// Validate validates this IP release args
+func (m *IPReleaseArgs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIP(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IPReleaseArgs) validateIP(formats strfmt.Registry) error {
+
+	if err := validate.Required("ip", "body", m.IP); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this IP release args based on context it is used
+func (m *IPReleaseArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IPReleaseArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IPReleaseArgs) UnmarshalBinary(b []byte) error {
+	var res IPReleaseArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,159 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IPStatus Allocation details of an ip recorded in IPPool and SpiderEndpoint
+//
+// swagger:model IPStatus
+type IPStatus struct {
+
+	// endpoint
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// endpoint UID
+	EndpointUID string `json:"endpointUID,omitempty"`
+
+	// ip
+	// Required: true
+	IP *string `json:"ip"`
+
+	// ip pool
+	// Required: true
+	IPPool *string `json:"ipPool"`
+
+	// nic
+	Nic string `json:"nic,omitempty"`
+
+	// node
+	Node string `json:"node,omitempty"`
+
+	// owner controller name
+	OwnerControllerName string `json:"ownerControllerName,omitempty"`
+
+	// owner controller type
+	OwnerControllerType string `json:"ownerControllerType,omitempty"`
+
+	// pod
+	Pod string `json:"pod,omitempty"`
+
+	// pod UID
+	PodUID string `json:"podUID,omitempty"`
+
+	// version
+	// Required: true
+	// Enum: [4 6]
+	Version *int64 `json:"version"`
+}
+
+// Validate validates this IP status
+func (m *IPStatus) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIP(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIPPool(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IPStatus) validateIP(formats strfmt.Registry) error {
+
+	if err := validate.Required("ip", "body", m.IP); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IPStatus) validateIPPool(formats strfmt.Registry) error {
+
+	if err := validate.Required("ipPool", "body", m.IPPool); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var ipStatusTypeVersionPropEnum []interface{}
+
+func init() {
+	var res []int64
+	if err := json.Unmarshal([]byte(`[4,6]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		ipStatusTypeVersionPropEnum = append(ipStatusTypeVersionPropEnum, v)
+	}
+}
+
+// prop value enum
+func (m *IPStatus) validateVersionEnum(path, location string, value int64) error {
+	if err := validate.EnumCase(path, location, value, ipStatusTypeVersionPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *IPStatus) validateVersion(formats strfmt.Registry) error {
+
+	if err := validate.Required("version", "body", m.Version); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateVersionEnum("version", "body", *m.Version); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this IP status based on context it is used
+func (m *IPStatus) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IPStatus) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IPStatus) UnmarshalBinary(b []byte) error {
+	var res IPStatus
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// IpamGcArgs Args to scope the gc of ips, the global gc is triggered if no scope is specified
+//
+// swagger:model IpamGcArgs
+type IpamGcArgs struct {
+
+	// Only list the ips to be released without releasing them
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Only gc this ip
+	IP string `json:"ip,omitempty"`
+
+	// Only gc the ips of this IPPool
+	IPPool string `json:"ipPool,omitempty"`
+
+	// Only gc the ips allocated to the pods of this namespace
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Validate validates this ipam gc args
+func (m *IpamGcArgs) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this ipam gc args based on context it is used
+func (m *IpamGcArgs) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamGcArgs) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamGcArgs) UnmarshalBinary(b []byte) error {
+	var res IpamGcArgs
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
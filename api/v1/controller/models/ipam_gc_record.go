@@ -0,0 +1,100 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IpamGcRecord An ip allocation released by gc
+//
+// swagger:model IpamGcRecord
+type IpamGcRecord struct {
+
+	// ip
+	// Required: true
+	IP *string `json:"ip"`
+
+	// ip pool
+	// Required: true
+	IPPool *string `json:"ipPool"`
+
+	// pod
+	Pod string `json:"pod,omitempty"`
+
+	// pod UID
+	PodUID string `json:"podUID,omitempty"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+}
+
+// Validate validates this ipam gc record
+func (m *IpamGcRecord) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateIP(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIPPool(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IpamGcRecord) validateIP(formats strfmt.Registry) error {
+
+	if err := validate.Required("ip", "body", m.IP); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IpamGcRecord) validateIPPool(formats strfmt.Registry) error {
+
+	if err := validate.Required("ipPool", "body", m.IPPool); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this ipam gc record based on context it is used
+func (m *IpamGcRecord) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IpamGcRecord) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IpamGcRecord) UnmarshalBinary(b []byte) error {
+	var res IpamGcRecord
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -42,6 +42,11 @@ func configureAPI(api *restapi.SpiderpoolControllerAPIAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	if api.ControllerDeleteIpamIPHandler == nil {
+		api.ControllerDeleteIpamIPHandler = controller.DeleteIpamIPHandlerFunc(func(params controller.DeleteIpamIPParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.DeleteIpamIP has not yet been implemented")
+		})
+	}
 	if api.ControllerGetIpamStatusHandler == nil {
 		api.ControllerGetIpamStatusHandler = controller.GetIpamStatusHandlerFunc(func(params controller.GetIpamStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.GetIpamStatus has not yet been implemented")
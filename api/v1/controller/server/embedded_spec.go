@@ -42,12 +42,28 @@ func init() {
           "controller"
         ],
         "summary": "Trigger gc",
+        "parameters": [
+          {
+            "name": "gc-args",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/IpamGcArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamGcResult"
+            }
           },
           "500": {
-            "description": "Global gc failure"
+            "description": "Global gc failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -59,12 +75,55 @@ func init() {
           "controller"
         ],
         "summary": "Force set ip",
+        "parameters": [
+          {
+            "name": "ip-set-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IPSetArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success"
           },
           "500": {
-            "description": "Force set ip failure"
+            "description": "Force set ip failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "description": "Release ip and clean up its allocation records for spiderpool controller cli debug usage\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Release ip",
+        "parameters": [
+          {
+            "name": "ip-release-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IPReleaseArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Release ip failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -76,12 +135,27 @@ func init() {
           "controller"
         ],
         "summary": "Get status",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only show the status of this ip",
+            "name": "ip",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamStatus"
+            }
           },
           "500": {
-            "description": "Get ipam status failure"
+            "description": "Get ipam status failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -138,6 +212,183 @@ func init() {
       }
     }
   },
+  "definitions": {
+    "Error": {
+      "description": "API error",
+      "type": "string"
+    },
+    "IPReleaseArgs": {
+      "description": "Args to release an ip",
+      "type": "object",
+      "required": [
+        "ip"
+      ],
+      "properties": {
+        "force": {
+          "type": "boolean"
+        },
+        "ip": {
+          "type": "string"
+        }
+      }
+    },
+    "IPSetArgs": {
+      "description": "Args to force set an ip to be taken by a pod",
+      "type": "object",
+      "required": [
+        "ip",
+        "podNamespace",
+        "podName",
+        "containerID",
+        "node",
+        "ifName"
+      ],
+      "properties": {
+        "containerID": {
+          "description": "the container ID of the Pod, recorded in its SpiderEndpoint",
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ip": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        }
+      }
+    },
+    "IPStatus": {
+      "description": "Allocation details of an ip recorded in IPPool and SpiderEndpoint",
+      "type": "object",
+      "required": [
+        "ip",
+        "ipPool",
+        "version"
+      ],
+      "properties": {
+        "endpoint": {
+          "type": "string"
+        },
+        "endpointUID": {
+          "type": "string"
+        },
+        "ip": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "ownerControllerName": {
+          "type": "string"
+        },
+        "ownerControllerType": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        },
+        "version": {
+          "type": "integer",
+          "enum": [
+            4,
+            6
+          ]
+        }
+      }
+    },
+    "IpamGcArgs": {
+      "description": "Args to scope the gc of ips, the global gc is triggered if no scope is specified",
+      "type": "object",
+      "properties": {
+        "dryRun": {
+          "description": "Only list the ips to be released without releasing them",
+          "type": "boolean"
+        },
+        "ip": {
+          "description": "Only gc this ip",
+          "type": "string"
+        },
+        "ipPool": {
+          "description": "Only gc the ips of this IPPool",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Only gc the ips allocated to the pods of this namespace",
+          "type": "string"
+        }
+      }
+    },
+    "IpamGcRecord": {
+      "description": "An ip allocation released by gc",
+      "type": "object",
+      "required": [
+        "ip",
+        "ipPool"
+      ],
+      "properties": {
+        "ip": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamGcResult": {
+      "description": "Result of the gc of ips",
+      "type": "object",
+      "properties": {
+        "ips": {
+          "description": "The ips released, or to be released in dry-run mode",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamGcRecord"
+          }
+        }
+      }
+    },
+    "IpamStatus": {
+      "description": "IPAM status of the allocated ips",
+      "type": "object",
+      "required": [
+        "ips"
+      ],
+      "properties": {
+        "ips": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IPStatus"
+          }
+        }
+      }
+    }
+  },
   "x-schemes": [
     "http"
   ]
@@ -164,12 +415,28 @@ func init() {
           "controller"
         ],
         "summary": "Trigger gc",
+        "parameters": [
+          {
+            "name": "gc-args",
+            "in": "body",
+            "schema": {
+              "$ref": "#/definitions/IpamGcArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamGcResult"
+            }
           },
           "500": {
-            "description": "Global gc failure"
+            "description": "Global gc failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -181,12 +448,55 @@ func init() {
           "controller"
         ],
         "summary": "Force set ip",
+        "parameters": [
+          {
+            "name": "ip-set-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IPSetArgs"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success"
           },
           "500": {
-            "description": "Force set ip failure"
+            "description": "Force set ip failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "description": "Release ip and clean up its allocation records for spiderpool controller cli debug usage\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Release ip",
+        "parameters": [
+          {
+            "name": "ip-release-args",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/IPReleaseArgs"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Release ip failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -198,12 +508,27 @@ func init() {
           "controller"
         ],
         "summary": "Get status",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only show the status of this ip",
+            "name": "ip",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpamStatus"
+            }
           },
           "500": {
-            "description": "Get ipam status failure"
+            "description": "Get ipam status failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
           }
         }
       }
@@ -260,6 +585,183 @@ func init() {
       }
     }
   },
+  "definitions": {
+    "Error": {
+      "description": "API error",
+      "type": "string"
+    },
+    "IPReleaseArgs": {
+      "description": "Args to release an ip",
+      "type": "object",
+      "required": [
+        "ip"
+      ],
+      "properties": {
+        "force": {
+          "type": "boolean"
+        },
+        "ip": {
+          "type": "string"
+        }
+      }
+    },
+    "IPSetArgs": {
+      "description": "Args to force set an ip to be taken by a pod",
+      "type": "object",
+      "required": [
+        "ip",
+        "podNamespace",
+        "podName",
+        "containerID",
+        "node",
+        "ifName"
+      ],
+      "properties": {
+        "containerID": {
+          "description": "the container ID of the Pod, recorded in its SpiderEndpoint",
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ip": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        }
+      }
+    },
+    "IPStatus": {
+      "description": "Allocation details of an ip recorded in IPPool and SpiderEndpoint",
+      "type": "object",
+      "required": [
+        "ip",
+        "ipPool",
+        "version"
+      ],
+      "properties": {
+        "endpoint": {
+          "type": "string"
+        },
+        "endpointUID": {
+          "type": "string"
+        },
+        "ip": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "ownerControllerName": {
+          "type": "string"
+        },
+        "ownerControllerType": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        },
+        "version": {
+          "type": "integer",
+          "enum": [
+            4,
+            6
+          ]
+        }
+      }
+    },
+    "IpamGcArgs": {
+      "description": "Args to scope the gc of ips, the global gc is triggered if no scope is specified",
+      "type": "object",
+      "properties": {
+        "dryRun": {
+          "description": "Only list the ips to be released without releasing them",
+          "type": "boolean"
+        },
+        "ip": {
+          "description": "Only gc this ip",
+          "type": "string"
+        },
+        "ipPool": {
+          "description": "Only gc the ips of this IPPool",
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Only gc the ips allocated to the pods of this namespace",
+          "type": "string"
+        }
+      }
+    },
+    "IpamGcRecord": {
+      "description": "An ip allocation released by gc",
+      "type": "object",
+      "required": [
+        "ip",
+        "ipPool"
+      ],
+      "properties": {
+        "ip": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "podUID": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
+    "IpamGcResult": {
+      "description": "Result of the gc of ips",
+      "type": "object",
+      "properties": {
+        "ips": {
+          "description": "The ips released, or to be released in dry-run mode",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IpamGcRecord"
+          }
+        }
+      }
+    },
+    "IpamStatus": {
+      "description": "IPAM status of the allocated ips",
+      "type": "object",
+      "required": [
+        "ips"
+      ],
+      "properties": {
+        "ips": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IPStatus"
+          }
+        }
+      }
+    }
+  },
   "x-schemes": [
     "http"
   ]
@@ -12,6 +12,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // GetIpamStatusOKCode is the HTTP code returned for type GetIpamStatusOK
@@ -23,6 +25,11 @@ GetIpamStatusOK Success
 swagger:response getIpamStatusOK
 */
 type GetIpamStatusOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.IpamStatus `json:"body,omitempty"`
 }
 
 // NewGetIpamStatusOK creates GetIpamStatusOK with default headers values
@@ -31,35 +38,68 @@ func NewGetIpamStatusOK() *GetIpamStatusOK {
 	return &GetIpamStatusOK{}
 }
 
+// WithPayload adds the payload to the get ipam status o k response
+func (o *GetIpamStatusOK) WithPayload(payload *models.IpamStatus) *GetIpamStatusOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get ipam status o k response
+func (o *GetIpamStatusOK) SetPayload(payload *models.IpamStatus) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *GetIpamStatusOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
-// GetIpamStatusInternalServerErrorCode is the HTTP code returned for type GetIpamStatusInternalServerError
-const GetIpamStatusInternalServerErrorCode int = 500
+// GetIpamStatusFailureCode is the HTTP code returned for type GetIpamStatusFailure
+const GetIpamStatusFailureCode int = 500
 
 /*
-GetIpamStatusInternalServerError Get ipam status failure
+GetIpamStatusFailure Get ipam status failure
 
-swagger:response getIpamStatusInternalServerError
+swagger:response getIpamStatusFailure
 */
-type GetIpamStatusInternalServerError struct {
+type GetIpamStatusFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
 }
 
-// NewGetIpamStatusInternalServerError creates GetIpamStatusInternalServerError with default headers values
-func NewGetIpamStatusInternalServerError() *GetIpamStatusInternalServerError {
+// NewGetIpamStatusFailure creates GetIpamStatusFailure with default headers values
+func NewGetIpamStatusFailure() *GetIpamStatusFailure {
 
-	return &GetIpamStatusInternalServerError{}
+	return &GetIpamStatusFailure{}
 }
 
-// WriteResponse to the client
-func (o *GetIpamStatusInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+// WithPayload adds the payload to the get ipam status failure response
+func (o *GetIpamStatusFailure) WithPayload(payload models.Error) *GetIpamStatusFailure {
+	o.Payload = payload
+	return o
+}
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+// SetPayload sets the payload to the get ipam status failure response
+func (o *GetIpamStatusFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetIpamStatusFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
 	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
 }
@@ -16,7 +16,11 @@ import (
 
 // GetIpamStatusURL generates an URL for the get ipam status operation
 type GetIpamStatusURL struct {
+	IP *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -46,6 +50,18 @@ func (o *GetIpamStatusURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var ipQ string
+	if o.IP != nil {
+		ipQ = *o.IP
+	}
+	if ipQ != "" {
+		qs.Set("ip", ipQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
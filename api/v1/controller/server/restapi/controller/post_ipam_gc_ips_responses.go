@@ -12,6 +12,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // PostIpamGcIpsOKCode is the HTTP code returned for type PostIpamGcIpsOK
@@ -23,6 +25,11 @@ PostIpamGcIpsOK Success
 swagger:response postIpamGcIpsOK
 */
 type PostIpamGcIpsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.IpamGcResult `json:"body,omitempty"`
 }
 
 // NewPostIpamGcIpsOK creates PostIpamGcIpsOK with default headers values
@@ -31,35 +38,68 @@ func NewPostIpamGcIpsOK() *PostIpamGcIpsOK {
 	return &PostIpamGcIpsOK{}
 }
 
+// WithPayload adds the payload to the post ipam gc ips o k response
+func (o *PostIpamGcIpsOK) WithPayload(payload *models.IpamGcResult) *PostIpamGcIpsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam gc ips o k response
+func (o *PostIpamGcIpsOK) SetPayload(payload *models.IpamGcResult) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *PostIpamGcIpsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
-// PostIpamGcIpsInternalServerErrorCode is the HTTP code returned for type PostIpamGcIpsInternalServerError
-const PostIpamGcIpsInternalServerErrorCode int = 500
+// PostIpamGcIpsFailureCode is the HTTP code returned for type PostIpamGcIpsFailure
+const PostIpamGcIpsFailureCode int = 500
 
 /*
-PostIpamGcIpsInternalServerError Global gc failure
+PostIpamGcIpsFailure Global gc failure
 
-swagger:response postIpamGcIpsInternalServerError
+swagger:response postIpamGcIpsFailure
 */
-type PostIpamGcIpsInternalServerError struct {
+type PostIpamGcIpsFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
 }
 
-// NewPostIpamGcIpsInternalServerError creates PostIpamGcIpsInternalServerError with default headers values
-func NewPostIpamGcIpsInternalServerError() *PostIpamGcIpsInternalServerError {
+// NewPostIpamGcIpsFailure creates PostIpamGcIpsFailure with default headers values
+func NewPostIpamGcIpsFailure() *PostIpamGcIpsFailure {
 
-	return &PostIpamGcIpsInternalServerError{}
+	return &PostIpamGcIpsFailure{}
 }
 
-// WriteResponse to the client
-func (o *PostIpamGcIpsInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+// WithPayload adds the payload to the post ipam gc ips failure response
+func (o *PostIpamGcIpsFailure) WithPayload(payload models.Error) *PostIpamGcIpsFailure {
+	o.Payload = payload
+	return o
+}
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+// SetPayload sets the payload to the post ipam gc ips failure response
+func (o *PostIpamGcIpsFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamGcIpsFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
 	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
 }
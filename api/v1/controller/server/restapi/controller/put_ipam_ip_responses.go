@@ -12,6 +12,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // PutIpamIPOKCode is the HTTP code returned for type PutIpamIPOK
@@ -39,27 +41,45 @@ func (o *PutIpamIPOK) WriteResponse(rw http.ResponseWriter, producer runtime.Pro
 	rw.WriteHeader(200)
 }
 
-// PutIpamIPInternalServerErrorCode is the HTTP code returned for type PutIpamIPInternalServerError
-const PutIpamIPInternalServerErrorCode int = 500
+// PutIpamIPFailureCode is the HTTP code returned for type PutIpamIPFailure
+const PutIpamIPFailureCode int = 500
 
 /*
-PutIpamIPInternalServerError Force set ip failure
+PutIpamIPFailure Force set ip failure
 
-swagger:response putIpamIpInternalServerError
+swagger:response putIpamIpFailure
 */
-type PutIpamIPInternalServerError struct {
+type PutIpamIPFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
 }
 
-// NewPutIpamIPInternalServerError creates PutIpamIPInternalServerError with default headers values
-func NewPutIpamIPInternalServerError() *PutIpamIPInternalServerError {
+// NewPutIpamIPFailure creates PutIpamIPFailure with default headers values
+func NewPutIpamIPFailure() *PutIpamIPFailure {
 
-	return &PutIpamIPInternalServerError{}
+	return &PutIpamIPFailure{}
 }
 
-// WriteResponse to the client
-func (o *PutIpamIPInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+// WithPayload adds the payload to the put ipam Ip failure response
+func (o *PutIpamIPFailure) WithPayload(payload models.Error) *PutIpamIPFailure {
+	o.Payload = payload
+	return o
+}
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+// SetPayload sets the payload to the put ipam Ip failure response
+func (o *PutIpamIPFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutIpamIPFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
 	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
 }
@@ -48,6 +48,9 @@ func NewSpiderpoolControllerAPIAPI(spec *loads.Document) *SpiderpoolControllerAP
 
 		JSONProducer: runtime.JSONProducer(),
 
+		ControllerDeleteIpamIPHandler: controller.DeleteIpamIPHandlerFunc(func(params controller.DeleteIpamIPParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.DeleteIpamIP has not yet been implemented")
+		}),
 		ControllerGetIpamStatusHandler: controller.GetIpamStatusHandlerFunc(func(params controller.GetIpamStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.GetIpamStatus has not yet been implemented")
 		}),
@@ -102,6 +105,8 @@ type SpiderpoolControllerAPIAPI struct {
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// ControllerDeleteIpamIPHandler sets the operation handler for the delete ipam IP operation
+	ControllerDeleteIpamIPHandler controller.DeleteIpamIPHandler
 	// ControllerGetIpamStatusHandler sets the operation handler for the get ipam status operation
 	ControllerGetIpamStatusHandler controller.GetIpamStatusHandler
 	// RuntimeGetRuntimeLivenessHandler sets the operation handler for the get runtime liveness operation
@@ -191,6 +196,9 @@ func (o *SpiderpoolControllerAPIAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.ControllerDeleteIpamIPHandler == nil {
+		unregistered = append(unregistered, "controller.DeleteIpamIPHandler")
+	}
 	if o.ControllerGetIpamStatusHandler == nil {
 		unregistered = append(unregistered, "controller.GetIpamStatusHandler")
 	}
@@ -297,6 +305,10 @@ func (o *SpiderpoolControllerAPIAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/ipam/ip"] = controller.NewDeleteIpamIP(o.context, o.ControllerDeleteIpamIPHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
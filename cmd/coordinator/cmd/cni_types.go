@@ -20,11 +20,13 @@ import (
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/networking/gwconnection"
 )
 
 var (
 	defaultLogPath          = "/var/log/spidernet/coordinator.log"
 	defaultUnderlayVethName = "veth0"
+	defaultVethMTU          = 1500
 	defaultMarkBit          = 0 // ox1
 	// by default, k8s pod's first NIC is eth0
 	defaultOverlayVethName  = "eth0"
@@ -57,7 +59,9 @@ type Config struct {
 	HostRuleTable      *int64         `json:"hostRuleTable,omitempty"`
 	RPFilter           int32          `json:"hostRPFilter,omitempty" `
 	TxQueueLen         *int64         `json:"txQueueLen,omitempty"`
+	VethMTU            *int64         `json:"vethMTU,omitempty"`
 	IPConflict         *bool          `json:"detectIPConflict,omitempty"`
+	CoordinatorName    string         `json:"coordinatorName,omitempty"`
 	DetectOptions      *DetectOptions `json:"detectOptions,omitempty"`
 	LogOptions         *LogOptions    `json:"logOptions,omitempty"`
 }
@@ -67,6 +71,12 @@ type DetectOptions struct {
 	Retry    int    `json:"retries,omitempty"`
 	Interval string `json:"interval,omitempty"`
 	TimeOut  string `json:"timeout,omitempty"`
+	// GatewayMode is how to detect the gateway, icmp or l2. The l2 mode
+	// resolves the gateway with ARP for IPv4 and NDP for IPv6.
+	GatewayMode string `json:"gatewayMode,omitempty"`
+	// LossThreshold is the percentage of the lost probes tolerated when
+	// detecting the gateway.
+	LossThreshold *int `json:"lossThreshold,omitempty"`
 }
 
 type LogOptions struct {
@@ -87,6 +97,19 @@ const (
 // SupportCNIVersions indicate the CNI version that spiderpool support.
 var SupportCNIVersions = []string{CniVersion030, CniVersion031, CniVersion040, CniVersion100}
 
+// parseCoordinatorName returns the SpiderCoordinator referenced by the
+// configuration from stdin, which the configuration is merged from.
+func parseCoordinatorName(stdin []byte) (string, error) {
+	conf := struct {
+		CoordinatorName string `json:"coordinatorName,omitempty"`
+	}{}
+	if err := json.Unmarshal(stdin, &conf); err != nil {
+		return "", fmt.Errorf("failed to parse config: %v", err)
+	}
+
+	return conf.CoordinatorName, nil
+}
+
 // ParseConfig parses the supplied configuration (and prevResult) from stdin.
 func ParseConfig(stdin []byte, coordinatorConfig *models.CoordinatorConfig) (*Config, error) {
 	var err error
@@ -144,6 +167,16 @@ func ParseConfig(stdin []byte, coordinatorConfig *models.CoordinatorConfig) (*Co
 		conf.IPConflict = pointer.Bool(true)
 	}
 
+	if conf.DetectOptions == nil {
+		conf.DetectOptions = &DetectOptions{}
+	}
+	if conf.DetectOptions.GatewayMode == "" {
+		conf.DetectOptions.GatewayMode = coordinatorConfig.DetectGatewayMode
+	}
+	if conf.DetectOptions.LossThreshold == nil && coordinatorConfig.DetectGatewayLossThreshold > 0 {
+		conf.DetectOptions.LossThreshold = pointer.Int(int(coordinatorConfig.DetectGatewayLossThreshold))
+	}
+
 	conf.DetectOptions, err = ValidateDelectOptions(conf.DetectOptions)
 	if err != nil {
 		return nil, err
@@ -157,6 +190,10 @@ func ParseConfig(stdin []byte, coordinatorConfig *models.CoordinatorConfig) (*Co
 		conf.TxQueueLen = pointer.Int64(coordinatorConfig.TxQueueLen)
 	}
 
+	if conf.VethMTU == nil {
+		conf.VethMTU = pointer.Int64(coordinatorConfig.VethMTU)
+	}
+
 	if conf.HostRuleTable == nil {
 		conf.HostRuleTable = pointer.Int64(500)
 	}
@@ -259,11 +296,7 @@ func validateRPFilterConfig(rpfilter int32) error {
 
 func ValidateDelectOptions(config *DetectOptions) (*DetectOptions, error) {
 	if config == nil {
-		return &DetectOptions{
-			Interval: "1s",
-			TimeOut:  "1s",
-			Retry:    3,
-		}, nil
+		config = &DetectOptions{}
 	}
 
 	if config.Retry == 0 {
@@ -288,5 +321,21 @@ func ValidateDelectOptions(config *DetectOptions) (*DetectOptions, error) {
 		return nil, fmt.Errorf("invalid detectOptions.timeout %s: %v, input like: 1s or 1m", config.TimeOut, err)
 	}
 
+	if config.GatewayMode == "" {
+		config.GatewayMode = gwconnection.ModeICMP
+	}
+
+	if config.GatewayMode != gwconnection.ModeICMP && config.GatewayMode != gwconnection.ModeL2 {
+		return nil, fmt.Errorf("invalid detectOptions.gatewayMode %s, it must be %s or %s", config.GatewayMode, gwconnection.ModeICMP, gwconnection.ModeL2)
+	}
+
+	if config.LossThreshold == nil {
+		config.LossThreshold = pointer.Int(0)
+	}
+
+	if *config.LossThreshold < 0 || *config.LossThreshold > 99 {
+		return nil, fmt.Errorf("invalid detectOptions.lossThreshold %d, it must be in range [0, 99]", *config.LossThreshold)
+	}
+
 	return config, nil
 }
@@ -4,7 +4,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net/netip"
+	"sync"
 	"time"
 
 	"github.com/containernetworking/cni/pkg/skel"
@@ -35,6 +38,11 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		return fmt.Errorf("failed to load CNI ENV args: %w", err)
 	}
 
+	coordinatorName, err := parseCoordinatorName(args.StdinData)
+	if err != nil {
+		return err
+	}
+
 	client, err := openapi.NewAgentOpenAPIUnixClient(constant.DefaultIPAMUnixSocketPath)
 	if err != nil {
 		return err
@@ -42,8 +50,9 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 
 	resp, err := client.Daemonset.GetCoordinatorConfig(daemonset.NewGetCoordinatorConfigParams().WithGetCoordinatorConfig(
 		&models.GetCoordinatorArgs{
-			PodName:      string(k8sArgs.K8S_POD_NAME),
-			PodNamespace: string(k8sArgs.K8S_POD_NAMESPACE),
+			PodName:         string(k8sArgs.K8S_POD_NAME),
+			PodNamespace:    string(k8sArgs.K8S_POD_NAMESPACE),
+			CoordinatorName: coordinatorName,
 		},
 	))
 	if err != nil {
@@ -99,6 +108,7 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		currentInterface: args.IfName,
 		tuneMode:         conf.Mode,
 		podNics:          coordinatorConfig.PodNICs,
+		vethMTU:          int(*conf.VethMTU),
 	}
 	c.HijackCIDR = append(c.HijackCIDR, conf.ServiceCIDR...)
 	c.HijackCIDR = append(c.HijackCIDR, conf.HijackCIDR...)
@@ -155,9 +165,13 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 	logger.Sugar().Infof("Get coordinator config: %+v", c)
 
 	errg := errgroup.Group{}
+	// the failures of all the gateways are reported, so that it is clear
+	// which family's gateway is unreachable
+	var gwErrs []error
+	var gwErrsLock sync.Mutex
 	//  we do detect gateway connection firstly
 	if conf.DetectGateway != nil && *conf.DetectGateway {
-		logger.Debug("Try to detect gateway")
+		logger.Debug("Try to detect gateway", zap.String("mode", conf.DetectOptions.GatewayMode), zap.Int("lossThreshold", *conf.DetectOptions.LossThreshold))
 
 		var gws []string
 		err = c.netns.Do(func(netNS ns.NetNS) error {
@@ -169,11 +183,18 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 
 			logger.Debug("Get GetDefaultGatewayByName", zap.Strings("Gws", gws))
 			for _, gw := range gws {
-				p, err := gwconnection.NewPinger(conf.DetectOptions.Retry, conf.DetectOptions.Interval, conf.DetectOptions.TimeOut, gw, logger)
+				d, err := newGatewayDetector(conf.DetectOptions, c.currentInterface, prevResult.IPs, gw, logger)
 				if err != nil {
-					return fmt.Errorf("failed to run NewPinger: %v", err)
+					return fmt.Errorf("failed to create the detector of gateway %s: %v", gw, err)
 				}
-				errg.Go(c.hostNs, c.netns, p.DetectGateway)
+				errg.Go(c.hostNs, c.netns, func() error {
+					if err := d.DetectGateway(); err != nil {
+						gwErrsLock.Lock()
+						gwErrs = append(gwErrs, err)
+						gwErrsLock.Unlock()
+					}
+					return nil
+				})
 			}
 			return nil
 		})
@@ -199,6 +220,11 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		logger.Error("failed to detect gateway and ip checking", zap.Error(err))
 		return err
 	}
+	if len(gwErrs) != 0 {
+		err = errors.Join(gwErrs...)
+		logger.Error("failed to detect gateway", zap.Error(err))
+		return err
+	}
 
 	// overwrite mac address
 	if len(conf.MacPrefix) != 0 {
@@ -334,3 +360,27 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 	logger.Sugar().Infof("coordinator end, time cost: %v", time.Since(startTime))
 	return types.PrintResult(conf.PrevResult, conf.CNIVersion)
 }
+
+// newGatewayDetector creates the detector of gw according to the gateway
+// mode of the detect options. The l2 mode sends the ARP requests with the
+// IPv4 address of the pod.
+func newGatewayDetector(opts *DetectOptions, iface string, ips []*current.IPConfig, gw string, logger *zap.Logger) (gwconnection.Detector, error) {
+	if opts.GatewayMode != gwconnection.ModeL2 {
+		return gwconnection.NewPinger(opts.Retry, opts.Interval, opts.TimeOut, gw, *opts.LossThreshold, logger)
+	}
+
+	gwAddr, err := netip.ParseAddr(gw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway %s: %v", gw, err)
+	}
+
+	var src netip.Addr
+	for _, ip := range ips {
+		if ip.Address.IP.To4() != nil {
+			src, _ = netip.AddrFromSlice(ip.Address.IP.To4())
+			break
+		}
+	}
+
+	return gwconnection.NewNeighbor(opts.Retry, opts.Interval, opts.TimeOut, iface, src, gwAddr.Unmap(), *opts.LossThreshold, logger)
+}
@@ -0,0 +1,410 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"go.uber.org/zap"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utiliptables "k8s.io/kubernetes/pkg/util/iptables"
+	"k8s.io/utils/exec"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	plugincmd "github.com/spidernet-io/spiderpool/cmd/spiderpool/cmd"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
+	"github.com/spidernet-io/spiderpool/pkg/openapi"
+)
+
+// CmdCheck verifies that the veth pair, the host rule table, the hijack
+// routes, the static neighborhood tables and the iptables mark rules set
+// up by CmdAdd still exist, in the pod netns and on the host. The error
+// returned lists every missing piece.
+func CmdCheck(args *skel.CmdArgs) (err error) {
+	k8sArgs := plugincmd.K8sArgs{}
+	if err = types.LoadArgs(args.Args, &k8sArgs); nil != err {
+		return fmt.Errorf("failed to load CNI ENV args: %w", err)
+	}
+
+	coordinatorName, err := parseCoordinatorName(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	client, err := openapi.NewAgentOpenAPIUnixClient(constant.DefaultIPAMUnixSocketPath)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Daemonset.GetCoordinatorConfig(daemonset.NewGetCoordinatorConfigParams().WithGetCoordinatorConfig(
+		&models.GetCoordinatorArgs{
+			PodName:         string(k8sArgs.K8S_POD_NAME),
+			PodNamespace:    string(k8sArgs.K8S_POD_NAMESPACE),
+			CoordinatorName: coordinatorName,
+		},
+	))
+	if err != nil {
+		return fmt.Errorf("failed to GetCoordinatorConfig: %v", err)
+	}
+	coordinatorConfig := resp.Payload
+
+	conf, err := ParseConfig(args.StdinData, coordinatorConfig)
+	if err != nil {
+		return err
+	}
+
+	if conf.Mode == ModeDisable {
+		return nil
+	}
+
+	logger, err := logutils.SetupFileLogging(conf.LogOptions.LogLevel,
+		conf.LogOptions.LogFilePath, conf.LogOptions.LogFileMaxSize,
+		conf.LogOptions.LogFileMaxAge, conf.LogOptions.LogFileMaxCount)
+	if err != nil {
+		return fmt.Errorf("failed to init logger: %v ", err)
+	}
+
+	logger = logger.Named(BinNamePlugin).With(
+		zap.String("Action", "CHECK"),
+		zap.String("ContainerID", args.ContainerID),
+		zap.String("Netns", args.Netns),
+		zap.String("IfName", args.IfName),
+		zap.String("PodName", string(k8sArgs.K8S_POD_NAME)),
+		zap.String("PodNamespace", string(k8sArgs.K8S_POD_NAMESPACE)),
+	)
+	logger.Info(fmt.Sprintf("start to implement CHECK command in %v mode", conf.Mode))
+
+	if conf.PrevResult == nil {
+		return fmt.Errorf("required prevResult missing")
+	}
+	prevResult, err := current.GetResult(conf.PrevResult)
+	if err != nil {
+		logger.Error("failed to convert prevResult", zap.Error(err))
+		return err
+	}
+
+	ipFamily, err := networking.GetIPFamilyByResult(prevResult)
+	if err != nil {
+		logger.Error("failed to GetIPFamilyByResult", zap.Error(err))
+		return err
+	}
+
+	c := &coordinator{
+		HijackCIDR:       conf.OverlayPodCIDR,
+		hostRuleTable:    int(*conf.HostRuleTable),
+		ipFamily:         ipFamily,
+		currentInterface: args.IfName,
+		tuneMode:         conf.Mode,
+		podNics:          coordinatorConfig.PodNICs,
+	}
+	c.HijackCIDR = append(c.HijackCIDR, conf.ServiceCIDR...)
+	c.HijackCIDR = append(c.HijackCIDR, conf.HijackCIDR...)
+
+	c.netns, err = ns.GetNS(args.Netns)
+	if err != nil {
+		logger.Error(err.Error())
+		return fmt.Errorf("failed to GetNS %q: %v", args.Netns, err)
+	}
+	defer c.netns.Close()
+
+	if err = c.coordinatorModeAndFirstInvoke(logger, conf.PodDefaultCniNic); err != nil {
+		logger.Error(err.Error())
+		return err
+	}
+
+	switch c.tuneMode {
+	case ModeUnderlay:
+		c.podVethName = defaultUnderlayVethName
+		c.hostVethName = getHostVethName(args.ContainerID)
+	case ModeOverlay:
+		c.podVethName = defaultOverlayVethName
+		c.hostVethName, err = networking.GetHostVethName(c.netns, defaultOverlayVethName)
+		if err != nil {
+			logger.Error("failed to GetHostVethName", zap.Error(err))
+			return fmt.Errorf("veth pair of pod interface %s is missing on host: %v", defaultOverlayVethName, err)
+		}
+	default:
+		return fmt.Errorf("unknown tuneMode: %s", conf.Mode)
+	}
+
+	// without the veth pair, nothing else can be checked
+	c.hostVethHwAddress, c.podVethHwAddress, err = networking.GetHwAddressByName(c.netns, c.podVethName, c.hostVethName)
+	if err != nil {
+		logger.Error("failed to GetHwAddressByName", zap.Error(err))
+		return fmt.Errorf("veth pair %s(pod)/%s(host) is missing: %v", c.podVethName, c.hostVethName, err)
+	}
+
+	c.currentAddress, err = networking.IPAddressByName(c.netns, args.IfName, ipFamily)
+	if err != nil {
+		logger.Error(err.Error())
+		return fmt.Errorf("failed to IPAddressByName for pod %s : %v", args.IfName, err)
+	}
+
+	var allPodIp []netlink.Addr
+	err = c.netns.Do(func(netNS ns.NetNS) error {
+		allPodIp, err = networking.GetAllIPAddress(ipFamily, []string{`^lo$`})
+		return err
+	})
+	if err != nil {
+		logger.Error("failed to GetAllIPAddress in pod", zap.Error(err))
+		return fmt.Errorf("failed to GetAllIPAddress in pod: %v", err)
+	}
+
+	c.hostIPRouteForPod, err = GetAllHostIPRouteForPod(c, ipFamily, allPodIp)
+	if err != nil {
+		logger.Error("failed to get IPAddressOnNode", zap.Error(err))
+		return fmt.Errorf("failed to get IPAddressOnNode: %v", err)
+	}
+
+	c.currentRuleTable = c.mustGetRuleNumber(c.podNics)
+	if c.currentRuleTable < 0 {
+		return fmt.Errorf("coordinator must be working with spiderpool: no spiderendpoint records found")
+	}
+
+	var missing []error
+	for _, check := range []func() ([]error, error){
+		c.checkHostRoutes,
+		c.checkHostNeighborhood,
+		c.checkPodRoutes,
+		c.checkPodNeighborhood,
+		c.checkReplyPacketViaVeth,
+	} {
+		m, err := check()
+		if err != nil {
+			logger.Error("failed to check", zap.Error(err))
+			return err
+		}
+		missing = append(missing, m...)
+	}
+
+	if len(missing) != 0 {
+		err = utilerrors.NewAggregate(missing)
+		logger.Error("coordinator check failed", zap.Error(err))
+		return fmt.Errorf("coordinator check failed: %w", err)
+	}
+
+	logger.Info("cmdCheck end")
+	return nil
+}
+
+// checkHostRoutes checks the `ip rule from all lookup <hostRuleTable>` and
+// the routes to the pod IP addresses in the host rule table on the host.
+func (c *coordinator) checkHostRoutes() ([]error, error) {
+	var missing []error
+
+	for _, family := range ipFamilies(c.ipFamily) {
+		rule := netlink.NewRule()
+		rule.Table = c.hostRuleTable
+		rule.Priority = defaultHostRulePriority
+		exist, err := networking.RuleExist(rule, family)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rules on host: %v", err)
+		}
+		if !exist {
+			missing = append(missing, fmt.Errorf("host rule 'from all lookup %d pref %d' is missing", c.hostRuleTable, defaultHostRulePriority))
+		}
+	}
+
+	for idx := range c.currentAddress {
+		ipNet := networking.ConvertMaxMaskIPNet(c.currentAddress[idx].IP)
+		exist, err := networking.RouteExist(c.hostRuleTable, c.ipFamily, c.hostVethName, ipNet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list routes on host: %v", err)
+		}
+		if !exist {
+			missing = append(missing, fmt.Errorf("host route '%s dev %s table %d' is missing", ipNet, c.hostVethName, c.hostRuleTable))
+		}
+	}
+
+	return missing, nil
+}
+
+// checkHostNeighborhood checks the static neighborhood tables of the pod IP
+// addresses on the host veth.
+func (c *coordinator) checkHostNeighborhood() ([]error, error) {
+	hostVethLink, err := netlink.LinkByName(c.hostVethName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find host veth link %s: %v", c.hostVethName, err)
+	}
+
+	var missing []error
+	for _, ipAddr := range c.currentAddress {
+		exist, err := networking.StaticNeighborTableExist(hostVethLink.Attrs().Index, ipAddr.IP, c.podVethHwAddress)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			missing = append(missing, fmt.Errorf("host neighbor '%s lladdr %s dev %s' is missing", ipAddr.IP, c.podVethHwAddress, c.hostVethName))
+		}
+	}
+
+	return missing, nil
+}
+
+// checkPodRoutes checks the routes to the host IP addresses and the hijack
+// routes in the rule table of the current interface in the pod netns.
+func (c *coordinator) checkPodRoutes() ([]error, error) {
+	v4Gw, v6Gw, err := networking.GetGatewayIP(c.currentAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []error
+	err = c.netns.Do(func(_ ns.NetNS) error {
+		for _, hostAddress := range c.hostIPRouteForPod {
+			ipNet := networking.ConvertMaxMaskIPNet(hostAddress)
+			exist, err := networking.RouteExist(c.currentRuleTable, c.ipFamily, c.podVethName, ipNet)
+			if err != nil {
+				return fmt.Errorf("failed to list routes in pod: %v", err)
+			}
+			if !exist {
+				missing = append(missing, fmt.Errorf("pod route '%s dev %s table %d' is missing", ipNet, c.podVethName, c.currentRuleTable))
+			}
+		}
+
+		for _, hijack := range c.HijackCIDR {
+			nip, ipNet, err := net.ParseCIDR(hijack)
+			if err != nil {
+				return err
+			}
+			// the same as setupHijackRoutes, no route is added without gateway
+			if (nip.To4() != nil && v4Gw == nil) || (nip.To4() == nil && v6Gw == nil) {
+				continue
+			}
+
+			exist, err := networking.RouteExist(c.currentRuleTable, c.ipFamily, c.podVethName, ipNet)
+			if err != nil {
+				return fmt.Errorf("failed to list routes in pod: %v", err)
+			}
+			if !exist {
+				missing = append(missing, fmt.Errorf("pod hijack route '%s dev %s table %d' is missing", ipNet, c.podVethName, c.currentRuleTable))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// checkPodNeighborhood checks the static neighborhood tables of the host IP
+// addresses on the pod veth, which are set up by the first invoke only.
+func (c *coordinator) checkPodNeighborhood() ([]error, error) {
+	if !c.firstInvoke {
+		return nil, nil
+	}
+
+	var missing []error
+	err := c.netns.Do(func(_ ns.NetNS) error {
+		podVethLink, err := netlink.LinkByName(c.podVethName)
+		if err != nil {
+			return fmt.Errorf("failed to find pod veth link %s: %v", c.podVethName, err)
+		}
+
+		for _, ipAddr := range c.hostIPRouteForPod {
+			exist, err := networking.StaticNeighborTableExist(podVethLink.Attrs().Index, ipAddr, c.hostVethHwAddress)
+			if err != nil {
+				return err
+			}
+			if !exist {
+				missing = append(missing, fmt.Errorf("pod neighbor '%s lladdr %s dev %s' is missing", ipAddr, c.hostVethHwAddress, c.podVethName))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// checkReplyPacketViaVeth checks the iptables mark rules, the mark rule
+// and the default route set up by makeReplyPacketViaVeth in the pod netns.
+// NOTE: underlay mode only.
+func (c *coordinator) checkReplyPacketViaVeth() ([]error, error) {
+	if c.tuneMode != ModeUnderlay || !c.firstInvoke {
+		return nil, nil
+	}
+
+	markInt := getMarkInt(defaultMarkBit)
+	iptablesInterfaces, families := getIPtablesInterfaces(c.ipFamily)
+
+	var missing []error
+	err := c.netns.Do(func(_ ns.NetNS) error {
+		for _, ipt := range iptablesInterfaces {
+			for _, rule := range replyPacketRules() {
+				exist, err := iptablesRuleExist(ipt.Protocol(), rule)
+				if err != nil {
+					return err
+				}
+				if !exist {
+					missing = append(missing, fmt.Errorf("pod %s rule '%s' in chain %s of table %s is missing", iptablesCmd(ipt.Protocol()), rule.name, rule.chain, utiliptables.TableMangle))
+				}
+			}
+		}
+
+		for _, family := range families {
+			rule := netlink.NewRule()
+			rule.Table = c.hostRuleTable
+			rule.Mark = markInt
+			exist, err := networking.RuleExist(rule, family)
+			if err != nil {
+				return fmt.Errorf("failed to list rules in pod: %v", err)
+			}
+			if !exist {
+				missing = append(missing, fmt.Errorf("pod rule 'fwmark %#x lookup %d' is missing", markInt, c.hostRuleTable))
+			}
+
+			exist, err = networking.RouteExist(c.hostRuleTable, family, c.podVethName, nil)
+			if err != nil {
+				return fmt.Errorf("failed to list routes in pod: %v", err)
+			}
+			if !exist {
+				missing = append(missing, fmt.Errorf("pod default route 'dev %s table %d' is missing", c.podVethName, c.hostRuleTable))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// iptablesRuleExist checks the rule in the mangle table.
+// Equivalent to: `iptables -w -t mangle -C <chain> <args>`
+func iptablesRuleExist(protocol utiliptables.Protocol, rule iptablesRule) (bool, error) {
+	args := append([]string{"-w", "-t", string(utiliptables.TableMangle), "-C", string(rule.chain)}, rule.args...)
+	out, err := exec.New().Command(iptablesCmd(protocol), args...).CombinedOutput()
+	if err == nil {
+		return true, nil
+	}
+
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitStatus() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to check iptables rule %s: %v: %s", rule.name, err, out)
+}
+
+func iptablesCmd(protocol utiliptables.Protocol) string {
+	if protocol == utiliptables.ProtocolIPv6 {
+		return "ip6tables"
+	}
+	return "iptables"
+}
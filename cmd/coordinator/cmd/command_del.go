@@ -28,6 +28,11 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 		return fmt.Errorf("failed to load CNI ENV args: %w", err)
 	}
 
+	coordinatorName, err := parseCoordinatorName(args.StdinData)
+	if err != nil {
+		return err
+	}
+
 	client, err := openapi.NewAgentOpenAPIUnixClient(constant.DefaultIPAMUnixSocketPath)
 	if err != nil {
 		return err
@@ -35,8 +40,9 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 
 	resp, err := client.Daemonset.GetCoordinatorConfig(daemonset.NewGetCoordinatorConfigParams().WithGetCoordinatorConfig(
 		&models.GetCoordinatorArgs{
-			PodName:      string(k8sArgs.K8S_POD_NAME),
-			PodNamespace: string(k8sArgs.K8S_POD_NAMESPACE),
+			PodName:         string(k8sArgs.K8S_POD_NAME),
+			PodNamespace:    string(k8sArgs.K8S_POD_NAMESPACE),
+			CoordinatorName: coordinatorName,
 		},
 	))
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 type coordinator struct {
 	firstInvoke                                 bool
 	ipFamily, currentRuleTable, hostRuleTable   int
+	vethMTU                                     int
 	tuneMode                                    Mode
 	hostVethName, podVethName, currentInterface string
 	HijackCIDR, podNics                         []string
@@ -156,7 +157,21 @@ func (c *coordinator) setupVeth(logger *zap.Logger, containerID string) error {
 	var containerInterface net.Interface
 	hostVethName := getHostVethName(containerID)
 	err = c.netns.Do(func(hostNS ns.NetNS) error {
-		_, containerInterface, err = ip.SetupVethWithName(c.podVethName, hostVethName, 1500, podVethMAC.String(), hostNS)
+		// the veth follows the MTU of the pod's first NIC unless it is specified,
+		// otherwise the large packets between the pod and the host are dropped.
+		mtu := c.vethMTU
+		if mtu <= 0 {
+			currentLink, err := netlink.LinkByName(c.currentInterface)
+			if err != nil {
+				return fmt.Errorf("failed to get the MTU of %s: %v", c.currentInterface, err)
+			}
+			mtu = currentLink.Attrs().MTU
+		}
+		if mtu <= 0 {
+			mtu = defaultVethMTU
+		}
+
+		_, containerInterface, err = ip.SetupVethWithName(c.podVethName, hostVethName, mtu, podVethMAC.String(), hostNS)
 		if err != nil {
 			return err
 		}
@@ -171,6 +186,9 @@ func (c *coordinator) setupVeth(logger *zap.Logger, containerID string) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
 	hostVethLink, err := netlink.LinkByName(hostVethName)
 	if err != nil {
@@ -354,18 +372,11 @@ func (c *coordinator) setupHostRoutes(logger *zap.Logger) error {
 		return err
 	}
 
-	var ipFamilies []int
-	if c.ipFamily == netlink.FAMILY_ALL {
-		ipFamilies = append(ipFamilies, netlink.FAMILY_V4, netlink.FAMILY_V6)
-	} else {
-		ipFamilies = append(ipFamilies, c.ipFamily)
-	}
-
 	// make sure `ip rule from all lookup 500 pref 32765` exist
 	rule := netlink.NewRule()
 	rule.Table = c.hostRuleTable
 	rule.Priority = defaultHostRulePriority
-	for _, ipfamily := range ipFamilies {
+	for _, ipfamily := range ipFamilies(c.ipFamily) {
 		rule.Family = ipfamily
 		if err = netlink.RuleAdd(rule); err != nil && !os.IsExist(err) {
 			logger.Error("failed to Add ToRuleTable for host", zap.String("rule", rule.String()), zap.Error(err))
@@ -504,23 +515,8 @@ func (c *coordinator) makeReplyPacketViaVeth(logger *zap.Logger) error {
 		return fmt.Errorf("failed to get gateway ips: %v", err)
 	}
 
-	var iptablesInterface []utiliptables.Interface
-	var ipFamily []int
-	execer := exec.New()
 	markInt := getMarkInt(defaultMarkBit)
-	switch c.ipFamily {
-	case netlink.FAMILY_V4:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv4))
-		ipFamily = append(ipFamily, netlink.FAMILY_V4)
-	case netlink.FAMILY_V6:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv6))
-		ipFamily = append(ipFamily, netlink.FAMILY_V6)
-	case netlink.FAMILY_ALL:
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv4))
-		iptablesInterface = append(iptablesInterface, utiliptables.New(execer, utiliptables.ProtocolIPv6))
-		ipFamily = append(ipFamily, netlink.FAMILY_V4)
-		ipFamily = append(ipFamily, netlink.FAMILY_V6)
-	}
+	iptablesInterface, ipFamily := getIPtablesInterfaces(c.ipFamily)
 
 	return c.netns.Do(func(_ ns.NetNS) error {
 		if err := c.ensureIPtablesRule(iptablesInterface); err != nil {
@@ -547,6 +543,30 @@ func (c *coordinator) makeReplyPacketViaVeth(logger *zap.Logger) error {
 	})
 }
 
+// getIPtablesInterfaces returns the iptables interfaces and the ip families
+// for the given ip family.
+func getIPtablesInterfaces(ipFamily int) ([]utiliptables.Interface, []int) {
+	var iptablesInterfaces []utiliptables.Interface
+	execer := exec.New()
+	families := ipFamilies(ipFamily)
+	for _, family := range families {
+		if family == netlink.FAMILY_V4 {
+			iptablesInterfaces = append(iptablesInterfaces, utiliptables.New(execer, utiliptables.ProtocolIPv4))
+		} else {
+			iptablesInterfaces = append(iptablesInterfaces, utiliptables.New(execer, utiliptables.ProtocolIPv6))
+		}
+	}
+	return iptablesInterfaces, families
+}
+
+// ipFamilies splits netlink.FAMILY_ALL into IPv4 and IPv6.
+func ipFamilies(ipFamily int) []int {
+	if ipFamily == netlink.FAMILY_ALL {
+		return []int{netlink.FAMILY_V4, netlink.FAMILY_V6}
+	}
+	return []int{ipFamily}
+}
+
 // getHostVethName select the first 11 characters of the containerID for the host veth.
 func getHostVethName(containerID string) string {
 	return fmt.Sprintf("veth%s", containerID[:min(len(containerID))])
@@ -567,39 +587,59 @@ func getMarkString(mark int) string {
 	return fmt.Sprintf("%#08x", mark)
 }
 
-func (c *coordinator) ensureIPtablesRule(iptablesInterfaces []utiliptables.Interface) error {
+// iptablesRule is an iptables rule installed by coordinator in the mangle table.
+type iptablesRule struct {
+	name  string
+	chain utiliptables.Chain
+	args  []string
+}
+
+// replyPacketRules returns the iptables rules which make sure that the
+// reply packets of the connections from veth0 are forwarded by veth0.
+func replyPacketRules() []iptablesRule {
 	markStr := getMarkString(getMarkInt(0))
+	return []iptablesRule{
+		{
+			name:  "set-xmark",
+			chain: utiliptables.ChainPrerouting,
+			args: []string{
+				"-i", defaultUnderlayVethName,
+				"-m", "conntrack",
+				"--ctstate", "NEW",
+				"-j", "MARK",
+				"--set-xmark", markStr,
+			},
+		},
+		{
+			name:  "save-mark",
+			chain: utiliptables.ChainPrerouting,
+			args: []string{
+				"-m", "mark",
+				"--mark", markStr,
+				"-j", "CONNMARK",
+				"--save-mark",
+			},
+		},
+		{
+			name:  "restore-mark",
+			chain: utiliptables.ChainOutput,
+			args: []string{
+				"-j", "CONNMARK",
+				"--restore-mark",
+			},
+		},
+	}
+}
+
+func (c *coordinator) ensureIPtablesRule(iptablesInterfaces []utiliptables.Interface) error {
 	for _, ipt := range iptablesInterfaces {
 		if ipt == nil {
 			continue
 		}
-		_, err := ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainPrerouting, []string{
-			"-i", defaultUnderlayVethName,
-			"-m", "conntrack",
-			"--ctstate", "NEW",
-			"-j", "MARK",
-			"--set-xmark", markStr,
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to set-xmark: %v", err)
-		}
-
-		_, err = ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainPrerouting, []string{
-			"-m", "mark",
-			"--mark", markStr,
-			"-j", "CONNMARK",
-			"--save-mark",
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to save-mark: %v", err)
-		}
-
-		_, err = ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, utiliptables.ChainOutput, []string{
-			"-j", "CONNMARK",
-			"--restore-mark",
-		}...)
-		if err != nil {
-			return fmt.Errorf("iptables ensureRule err: failed to restore-mark: %v", err)
+		for _, rule := range replyPacketRules() {
+			if _, err := ipt.EnsureRule(utiliptables.Append, utiliptables.TableMangle, rule.chain, rule.args...); err != nil {
+				return fmt.Errorf("iptables ensureRule err: failed to %s: %v", rule.name, err)
+			}
 		}
 	}
 	return nil
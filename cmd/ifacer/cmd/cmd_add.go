@@ -10,6 +10,7 @@ import (
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/spidernet-io/spiderpool/pkg/networking/ifacerstate"
 	"github.com/spidernet-io/spiderpool/pkg/networking/networking"
 	"github.com/vishvananda/netlink"
 )
@@ -24,78 +25,103 @@ func CmdAdd(args *skel.CmdArgs) error {
 		CNIVersion: current.ImplementedSpecVersion,
 	}
 
-	switch len(conf.Interfaces) {
-	case 0:
-		return types.PrintResult(result, conf.CNIVersion)
-	case 1:
-		if conf.VlanID == 0 {
-			return types.PrintResult(result, conf.CNIVersion)
+	if (len(conf.Interfaces) == 1 && conf.VlanID != 0) || (len(conf.Interfaces) > 1 && conf.Bond != nil) {
+		attachment, err := newAttachment(args)
+		if err != nil {
+			return err
 		}
 
-		if err := checkInterfaceWithSameVlan(conf.VlanID, getVlanIfaceName(conf.Interfaces[0], conf.VlanID)); err != nil {
+		// Record the attachment of the interfaces, so that they can be torn
+		// down by CNI DEL once no attachment uses them.
+		err = ifacerstate.Update(ifacerstate.StatePath(conf.IPAMUnixSocketPath), func(state *ifacerstate.State) error {
+			return setupInterfaces(conf, state, attachment)
+		})
+		if err != nil {
 			return err
 		}
+	}
 
-		if err = createVlanDevice(conf); err != nil {
-			return fmt.Errorf("failed to createVlanDevice: %v", err)
-		}
+	return types.PrintResult(result, conf.CNIVersion)
+}
 
-		return types.PrintResult(result, conf.CNIVersion)
-	default:
-		if conf.Bond == nil {
-			return types.PrintResult(result, conf.CNIVersion)
+func setupInterfaces(conf *Ifacer, state *ifacerstate.State, attachment ifacerstate.Attachment) error {
+	if len(conf.Interfaces) == 1 {
+		vlanName := getVlanIfaceName(conf.Interfaces[0], conf.VlanID)
+		if err := checkInterfaceWithSameVlan(conf.VlanID, vlanName); err != nil {
+			return err
 		}
 
-		bond, err := createBondDevice(conf)
+		exist, err := linkExists(vlanName)
 		if err != nil {
-			return fmt.Errorf("failed to createBondDevice: %v", err)
+			return err
 		}
-
-		if conf.VlanID == 0 {
-			return types.PrintResult(result, conf.CNIVersion)
+		if err = createVlanDevice(conf); err != nil {
+			return fmt.Errorf("failed to createVlanDevice: %v", err)
 		}
+		state.Attach(vlanName, conf.Interfaces[0], !exist, attachment)
 
-		vlanName := getVlanIfaceName(conf.Bond.Name, conf.VlanID)
-		if err := checkInterfaceWithSameVlan(conf.VlanID, vlanName); err != nil {
-			return err
-		}
+		return nil
+	}
 
-		vlanLink, err := netlink.LinkByName(vlanName)
-		if err == nil {
-			if vlanLink.Attrs().Flags != net.FlagUp {
-				if err = netlink.LinkSetUp(vlanLink); err != nil {
-					return fmt.Errorf("failed to set %s up: %v", vlanLink.Attrs().Name, err)
-				}
-			}
-			return nil
-		}
+	exist, err := linkExists(conf.Bond.Name)
+	if err != nil {
+		return err
+	}
+	bond, err := createBondDevice(conf)
+	if err != nil {
+		return fmt.Errorf("failed to createBondDevice: %v", err)
+	}
+	state.Attach(conf.Bond.Name, "", !exist, attachment)
 
-		if _, ok := err.(netlink.LinkNotFoundError); !ok {
-			return fmt.Errorf("failed to LinkByName %s: %v", vlanName, err)
-		}
+	if conf.VlanID == 0 {
+		return nil
+	}
+
+	vlanName := getVlanIfaceName(conf.Bond.Name, conf.VlanID)
+	if err := checkInterfaceWithSameVlan(conf.VlanID, vlanName); err != nil {
+		return err
+	}
 
-		// create vlan interface
-		if err = networking.LinkAdd(&netlink.Vlan{
-			LinkAttrs: netlink.LinkAttrs{
-				Name:        vlanName,
-				ParentIndex: bond.Index,
-			},
-			VlanId: conf.VlanID,
-		}); err != nil {
-			return fmt.Errorf("failed to create vlan interface %s: %w", vlanName, err)
+	vlanLink, err := netlink.LinkByName(vlanName)
+	if err == nil {
+		if vlanLink.Attrs().Flags != net.FlagUp {
+			if err = netlink.LinkSetUp(vlanLink); err != nil {
+				return fmt.Errorf("failed to set %s up: %v", vlanLink.Attrs().Name, err)
+			}
 		}
+		state.Attach(vlanName, conf.Bond.Name, false, attachment)
+		return nil
+	}
+
+	if _, ok := err.(netlink.LinkNotFoundError); !ok {
+		return fmt.Errorf("failed to LinkByName %s: %v", vlanName, err)
+	}
 
-		return types.PrintResult(result, conf.CNIVersion)
+	// create vlan interface
+	if err = networking.LinkAdd(&netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        vlanName,
+			ParentIndex: bond.Index,
+		},
+		VlanId: conf.VlanID,
+	}); err != nil {
+		return fmt.Errorf("failed to create vlan interface %s: %w", vlanName, err)
 	}
+	state.Attach(vlanName, conf.Bond.Name, true, attachment)
+
+	return nil
 }
 
 func createBondDevice(conf *Ifacer) (*netlink.Bond, error) {
 	var err error
 	var bondLink netlink.Link
 	bondLink, err = netlink.LinkByName(conf.Bond.Name)
-	if err == nil && bondLink.Attrs().Flags != net.FlagUp {
-		if err = netlink.LinkSetUp(bondLink); err != nil {
-			return nil, fmt.Errorf("failed to set %s up: %v", bondLink.Attrs().Name, err)
+	if err == nil {
+		// the bond is shared by the attachments of the same configuration
+		if bondLink.Attrs().Flags&net.FlagUp == 0 {
+			if err = netlink.LinkSetUp(bondLink); err != nil {
+				return nil, fmt.Errorf("failed to set %s up: %v", bondLink.Attrs().Name, err)
+			}
 		}
 
 		if bondLink.Type() != "bond" {
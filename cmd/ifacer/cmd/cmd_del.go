@@ -3,8 +3,22 @@
 
 package cmd
 
-import "github.com/containernetworking/cni/pkg/skel"
+import (
+	"github.com/containernetworking/cni/pkg/skel"
 
+	"github.com/spidernet-io/spiderpool/pkg/networking/ifacerstate"
+)
+
+// CmdDel detaches the NIC of the container from the bond and VLAN
+// interfaces, and deletes the ones created by ifacer once no attachment
+// uses them.
 func CmdDel(args *skel.CmdArgs) error {
-	return nil
+	conf, err := ParseConfig(args.StdinData)
+	if err != nil {
+		return err
+	}
+
+	return ifacerstate.Update(ifacerstate.StatePath(conf.IPAMUnixSocketPath), func(state *ifacerstate.State) error {
+		return ifacerstate.DeleteLinks(state.Detach(args.ContainerID, args.IfName))
+	})
 }
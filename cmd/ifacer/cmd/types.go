@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/containernetworking/cni/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/vishvananda/netlink"
 	"net"
 	"strconv"
@@ -20,6 +21,17 @@ type Ifacer struct {
 	Interfaces []string `json:"interfaces,omitempty"`
 	VlanID     int      `json:"vlanID,omitempty"`
 	Bond       *Bond    `json:"bond,omitempty"`
+	// IPAMUnixSocketPath is the path of the spiderpool-agent UNIX socket,
+	// the state file of ifacer lives in its directory.
+	IPAMUnixSocketPath string `json:"ipam_unix_socket_path,omitempty"`
+}
+
+// K8sArgs is the valid CNI_ARGS used for Kubernetes.
+type K8sArgs struct {
+	types.CommonArgs
+	K8S_POD_NAME      types.UnmarshallableString //revive:disable-line
+	K8S_POD_NAMESPACE types.UnmarshallableString //revive:disable-line
+	K8S_POD_UID       types.UnmarshallableString //revive:disable-line
 }
 
 type Bond struct {
@@ -47,6 +59,10 @@ func ParseConfig(stdin []byte) (*Ifacer, error) {
 		conf.Bond.Name = DefaultBondName
 	}
 
+	if conf.IPAMUnixSocketPath == "" {
+		conf.IPAMUnixSocketPath = constant.DefaultIPAMUnixSocketPath
+	}
+
 	return &conf, nil
 }
 
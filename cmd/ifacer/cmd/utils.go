@@ -5,8 +5,13 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/vishvananda/netlink"
 	"net"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/vishvananda/netlink"
+
+	"github.com/spidernet-io/spiderpool/pkg/networking/ifacerstate"
 )
 
 // BondOptions  for the bonding driver are supplied as parameters to the
@@ -261,6 +266,33 @@ func GetAllIntBondOptions(bondOptions *BondOptions, bondOptionFuncs []BondOption
 	return bondOptionFuncs
 }
 
+func newAttachment(args *skel.CmdArgs) (ifacerstate.Attachment, error) {
+	k8sArgs := K8sArgs{}
+	if err := types.LoadArgs(args.Args, &k8sArgs); err != nil {
+		return ifacerstate.Attachment{}, fmt.Errorf("failed to load CNI ENV args: %w", err)
+	}
+
+	return ifacerstate.Attachment{
+		ContainerID:  args.ContainerID,
+		IfName:       args.IfName,
+		PodNamespace: string(k8sArgs.K8S_POD_NAMESPACE),
+		PodName:      string(k8sArgs.K8S_POD_NAME),
+		PodUID:       string(k8sArgs.K8S_POD_UID),
+	}, nil
+}
+
+func linkExists(name string) (bool, error) {
+	_, err := netlink.LinkByName(name)
+	if err == nil {
+		return true, nil
+	}
+	if _, ok := err.(netlink.LinkNotFoundError); ok {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("failed to LinkByName %s: %v", name, err)
+}
+
 func getVlanIfaceName(master string, vlanId int) string {
 	return fmt.Sprintf("%s.%d", master, vlanId)
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 var agentContext = new(AgentContext)
@@ -55,14 +56,20 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_ENABLED_DEBUG_METRIC", "false", false, nil, &agentContext.Cfg.EnableDebugLevelMetric, nil},
 	{"SPIDERPOOL_POD_NAMESPACE", "", true, &agentContext.Cfg.AgentPodNamespace, nil, nil},
 	{"SPIDERPOOL_POD_NAME", "", true, &agentContext.Cfg.AgentPodName, nil, nil},
+	{"SPIDERPOOL_NODE_NAME", "", false, &agentContext.Cfg.AgentNodeName, nil, nil},
 	{"SPIDERPOOL_HEALTH_PORT", "5710", true, &agentContext.Cfg.HttpPort, nil, nil},
 	{"SPIDERPOOL_METRIC_HTTP_PORT", "5711", true, &agentContext.Cfg.MetricHttpPort, nil, nil},
 	{"SPIDERPOOL_GOPS_LISTEN_PORT", "5712", false, &agentContext.Cfg.GopsListenPort, nil, nil},
 	{"SPIDERPOOL_PYROSCOPE_PUSH_SERVER_ADDRESS", "", false, &agentContext.Cfg.PyroscopeAddress, nil, nil},
 
 	{"SPIDERPOOL_IPPOOL_MAX_ALLOCATED_IPS", "5000", true, nil, nil, &agentContext.Cfg.IPPoolMaxAllocatedIPs},
+	{"SPIDERPOOL_IPPOOL_COMPACT_ALLOCATED_IPS", "false", false, nil, &agentContext.Cfg.EnableCompactAllocatedIPs, nil},
+	{"SPIDERPOOL_IPPOOL_BLOCK_SIZE", "0", false, nil, nil, &agentContext.Cfg.IPPoolBlockSize},
+	{"SPIDERPOOL_IPPOOL_SPREADING", "false", false, nil, &agentContext.Cfg.EnableIPPoolSpreading, nil},
 	{"SPIDERPOOL_WAIT_SUBNET_POOL_TIME_IN_SECOND", "2", false, nil, nil, &agentContext.Cfg.WaitSubnetPoolTime},
 	{"SPIDERPOOL_WAIT_SUBNET_POOL_MAX_RETRIES", "25", false, nil, nil, &agentContext.Cfg.WaitSubnetPoolMaxRetries},
+	{"SPIDERPOOL_IFACER_RECONCILE_INTERVAL_IN_SECOND", "300", false, nil, nil, &agentContext.Cfg.IfacerReconcileInterval},
+	{"SPIDERPOOL_DEFAULT_COORDINATOR_NAME", "default", false, &agentContext.Cfg.DefaultCoordinatorName, nil, nil},
 
 	{"MULTUS_CLUSTER_NETWORK", "", false, &agentContext.Cfg.MultusClusterNetwork, nil, nil},
 }
@@ -82,26 +89,33 @@ type Config struct {
 	EnableDebugLevelMetric bool
 	AgentPodNamespace      string
 	AgentPodName           string
+	AgentNodeName          string
 
 	HttpPort         string
 	MetricHttpPort   string
 	GopsListenPort   string
 	PyroscopeAddress string
 
-	IPPoolMaxAllocatedIPs    int
-	WaitSubnetPoolTime       int
-	WaitSubnetPoolMaxRetries int
+	IPPoolMaxAllocatedIPs     int
+	EnableCompactAllocatedIPs bool
+	IPPoolBlockSize           int
+	EnableIPPoolSpreading     bool
+	WaitSubnetPoolTime        int
+	WaitSubnetPoolMaxRetries  int
+	IfacerReconcileInterval   int
+	DefaultCoordinatorName    string
 
 	MultusClusterNetwork string
 
 	// configmap
-	IpamUnixSocketPath                string `yaml:"ipamUnixSocketPath"`
-	EnableIPv4                        bool   `yaml:"enableIPv4"`
-	EnableIPv6                        bool   `yaml:"enableIPv6"`
-	EnableStatefulSet                 bool   `yaml:"enableStatefulSet"`
-	EnableKubevirtStaticIP            bool   `yaml:"enableKubevirtStaticIP"`
-	EnableSpiderSubnet                bool   `yaml:"enableSpiderSubnet"`
-	ClusterSubnetDefaultFlexibleIPNum int    `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	IpamUnixSocketPath                string                        `yaml:"ipamUnixSocketPath"`
+	EnableIPv4                        bool                          `yaml:"enableIPv4"`
+	EnableIPv6                        bool                          `yaml:"enableIPv6"`
+	EnableStatefulSet                 bool                          `yaml:"enableStatefulSet"`
+	EnableKubevirtStaticIP            bool                          `yaml:"enableKubevirtStaticIP"`
+	EnableSpiderSubnet                bool                          `yaml:"enableSpiderSubnet"`
+	ClusterSubnetDefaultFlexibleIPNum int                           `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	CustomWorkloads                   []workloadkind.CustomWorkload `yaml:"customWorkloads"`
 }
 
 type AgentContext struct {
@@ -124,6 +138,7 @@ type AgentContext struct {
 	StsManager        statefulsetmanager.StatefulSetManager
 	SubnetManager     subnetmanager.SubnetManager
 	KubevirtManager   kubevirtmanager.KubevirtManager
+	WorkloadKinds     workloadkind.Registry
 
 	// handler
 	HttpServer        *server.Server
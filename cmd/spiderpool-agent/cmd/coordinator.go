@@ -4,18 +4,22 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-openapi/runtime/middleware"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	kubevirtv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server/restapi/daemonset"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 )
 
@@ -31,20 +35,6 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 	epClient := agentContext.EndpointManager
 	kubevirtMgr := agentContext.KubevirtManager
 
-	var coordList spiderpoolv2beta1.SpiderCoordinatorList
-	if err := crdClient.List(ctx, &coordList); err != nil {
-		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(err.Error()))
-	}
-
-	if len(coordList.Items) == 0 {
-		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error("coordinator config not found"))
-	}
-	coord := coordList.Items[0]
-
-	if coord.Status.Phase != coordinatormanager.Synced {
-		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(fmt.Sprintf("spidercoordinator: %s no ready", coord.Name)))
-	}
-
 	var err error
 	var spNics []string
 	var se *spiderpoolv2beta1.SpiderEndpoint
@@ -55,6 +45,20 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(fmt.Sprintf("failed to get pod %s/%s", params.GetCoordinatorConfig.PodNamespace, params.GetCoordinatorConfig.PodName)))
 	}
 
+	coordName := agentContext.Cfg.DefaultCoordinatorName
+	if params.GetCoordinatorConfig.CoordinatorName != "" {
+		coordName = params.GetCoordinatorConfig.CoordinatorName
+	}
+
+	coord, err := getPodCoordinator(ctx, crdClient, pod, coordName)
+	if err != nil {
+		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	if coord.Status.Phase != coordinatormanager.Synced {
+		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(fmt.Sprintf("spidercoordinator: %s no ready", coord.Name)))
+	}
+
 	isVMPod := false
 	// kubevirt vm pod corresponding SpiderEndpoint uses kubevirt VM/VMI name
 	endpointName := params.GetCoordinatorConfig.PodName
@@ -65,8 +69,9 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 	}
 
 	// get spiderendpoint
-	se, err = epClient.GetEndpointByName(ctx, params.GetCoordinatorConfig.PodNamespace, endpointName, constant.UseCache)
-	if err != nil && !apierrors.IsNotFound(err) {
+	// the sticky IP addresses are held by the spiderendpoint of the replica slot claimed by the pod
+	se, err = epClient.GetPodEndpoint(ctx, params.GetCoordinatorConfig.PodNamespace, endpointName, string(pod.UID), constant.UseCache)
+	if err != nil {
 		return daemonset.NewGetCoordinatorConfigFailure().WithPayload(models.Error(fmt.Sprintf("failed to get spiderendpoint %s/%s", params.GetCoordinatorConfig.PodNamespace, params.GetCoordinatorConfig.PodName)))
 	}
 
@@ -132,6 +137,16 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 		PodNICs:            spNics,
 	}
 
+	if coord.Spec.DetectGatewayMode != nil {
+		config.DetectGatewayMode = *coord.Spec.DetectGatewayMode
+	}
+	if coord.Spec.DetectGatewayLossThreshold != nil {
+		config.DetectGatewayLossThreshold = int64(*coord.Spec.DetectGatewayLossThreshold)
+	}
+	if coord.Spec.VethMTU != nil {
+		config.VethMTU = int64(*coord.Spec.VethMTU)
+	}
+
 	if config.OverlayPodCIDR == nil {
 		config.OverlayPodCIDR = []string{}
 	}
@@ -141,3 +156,34 @@ func (g *_unixGetCoordinatorConfig) Handle(params daemonset.GetCoordinatorConfig
 
 	return daemonset.NewGetCoordinatorConfigOK().WithPayload(config)
 }
+
+// getPodCoordinator gets the SpiderCoordinator referenced by the Pod
+// annotation, which takes precedence over the one referenced by the CNI
+// configuration. The annotation is only validated if the Pod webhook is
+// enabled, so the Pod falls back to the SpiderCoordinator referenced by the
+// CNI configuration with a warning Event if the annotated one does not exist.
+func getPodCoordinator(ctx context.Context, crdClient client.Client, pod *corev1.Pod, coordName string) (*spiderpoolv2beta1.SpiderCoordinator, error) {
+	var coord spiderpoolv2beta1.SpiderCoordinator
+	if name, ok := pod.Annotations[constant.AnnoSpiderCoordinator]; ok && name != "" && name != coordName {
+		err := crdClient.Get(ctx, apitypes.NamespacedName{Name: name}, &coord)
+		if err == nil {
+			return &coord, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		logger.Sugar().Warnf("spidercoordinator %s referenced by pod %s/%s not found, fall back to spidercoordinator %s", name, pod.Namespace, pod.Name, coordName)
+		event.EventRecorder.Eventf(pod, corev1.EventTypeWarning, constant.EventReasonCoordinatorNotFound,
+			"SpiderCoordinator %s referenced by annotation %s not found, fall back to SpiderCoordinator %s", name, constant.AnnoSpiderCoordinator, coordName)
+	}
+
+	if err := crdClient.Get(ctx, apitypes.NamespacedName{Name: coordName}, &coord); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("spidercoordinator %s not found", coordName)
+		}
+		return nil, err
+	}
+
+	return &coord, nil
+}
@@ -18,10 +18,13 @@ import (
 	"github.com/google/gops/agent"
 	"github.com/grafana/pyroscope-go"
 	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
@@ -34,6 +37,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 // DaemonMain runs agentContext handlers.
@@ -127,6 +131,13 @@ func DaemonMain() {
 	}
 	agentContext.CRDManager = mgr
 
+	logger.Info("Begin to initialize K8s event recorder")
+	clientSet, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	event.InitEventRecorder(clientSet, mgr.GetScheme(), constant.SpiderpoolAgent)
+
 	// init managers...
 	initAgentServiceManagers(agentContext.InnerCtx)
 
@@ -139,7 +150,10 @@ func DaemonMain() {
 		EnableKubevirtStaticIP: agentContext.Cfg.EnableKubevirtStaticIP,
 		OperationRetries:       agentContext.Cfg.WaitSubnetPoolMaxRetries,
 		OperationGapDuration:   time.Duration(agentContext.Cfg.WaitSubnetPoolTime) * time.Second,
+		EnableIPBlock:          agentContext.Cfg.IPPoolBlockSize > 0,
+		EnableIPPoolSpreading:  agentContext.Cfg.EnableIPPoolSpreading,
 		AgentNamespace:         agentContext.Cfg.AgentPodNamespace,
+		AgentPodName:           agentContext.Cfg.AgentPodName,
 	}
 	if len(agentContext.Cfg.MultusClusterNetwork) != 0 {
 		ipamConfig.MultusClusterNetwork = pointer.String(agentContext.Cfg.MultusClusterNetwork)
@@ -151,9 +165,8 @@ func DaemonMain() {
 		agentContext.NodeManager,
 		agentContext.NSManager,
 		agentContext.PodManager,
-		agentContext.StsManager,
 		agentContext.SubnetManager,
-		agentContext.KubevirtManager,
+		agentContext.WorkloadKinds,
 	)
 	if nil != err {
 		logger.Fatal(err.Error())
@@ -178,6 +191,9 @@ func DaemonMain() {
 		logger.Fatal("failed to wait for syncing controller-runtime cache")
 	}
 
+	logger.Info("Starting the reconcile of the ifacer interfaces")
+	startIfacerReconcile(agentContext.InnerCtx)
+
 	logger.Info("Begin to initialize spiderpool-agent OpenAPI HTTP server")
 	srv, err := newAgentOpenAPIHttpServer()
 	if nil != err {
@@ -322,39 +338,56 @@ func initAgentServiceManagers(ctx context.Context) {
 	}
 	agentContext.NSManager = nsManager
 
-	logger.Debug("Begin to initialize Pod manager")
-	podManager, err := podmanager.NewPodManager(
+	logger.Debug("Begin to initialize StatefulSet manager")
+	statefulSetManager, err := statefulsetmanager.NewStatefulSetManager(
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
-	agentContext.PodManager = podManager
+	agentContext.StsManager = statefulSetManager
 
-	logger.Debug("Begin to initialize StatefulSet manager")
-	statefulSetManager, err := statefulsetmanager.NewStatefulSetManager(
+	logger.Debug("Begin to initialize Kubevirt manager")
+	kubevirtManager := kubevirtmanager.NewKubevirtManager(
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
 	)
+	agentContext.KubevirtManager = kubevirtManager
+
+	logger.Debug("Begin to initialize workload kind registry")
+	workloadKinds, err := workloadkind.NewRegistry(
+		agentContext.CRDManager.GetClient(),
+		agentContext.CRDManager.GetAPIReader(),
+		statefulSetManager,
+		kubevirtManager,
+		workloadkind.RegistryConfig{
+			EnableStatefulSet:      agentContext.Cfg.EnableStatefulSet,
+			EnableKubevirtStaticIP: agentContext.Cfg.EnableKubevirtStaticIP,
+			CustomWorkloads:        agentContext.Cfg.CustomWorkloads,
+		},
+	)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
-	agentContext.StsManager = statefulSetManager
+	agentContext.WorkloadKinds = workloadKinds
 
-	logger.Debug("Begin to initialize Kubevirt manager")
-	kubevirtManager := kubevirtmanager.NewKubevirtManager(
+	logger.Debug("Begin to initialize Pod manager")
+	podManager, err := podmanager.NewPodManager(
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
+		workloadKinds,
 	)
-	agentContext.KubevirtManager = kubevirtManager
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	agentContext.PodManager = podManager
 
 	logger.Debug("Begin to initialize Endpoint manager")
 	endpointManager, err := workloadendpointmanager.NewWorkloadEndpointManager(
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
-		agentContext.Cfg.EnableStatefulSet,
-		agentContext.Cfg.EnableKubevirtStaticIP,
+		workloadKinds,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -374,8 +407,11 @@ func initAgentServiceManagers(ctx context.Context) {
 	logger.Debug("Begin to initialize IPPool manager")
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
-			MaxAllocatedIPs:        &agentContext.Cfg.IPPoolMaxAllocatedIPs,
-			EnableKubevirtStaticIP: agentContext.Cfg.EnableKubevirtStaticIP,
+			MaxAllocatedIPs:           &agentContext.Cfg.IPPoolMaxAllocatedIPs,
+			EnableCompactAllocatedIPs: agentContext.Cfg.EnableCompactAllocatedIPs,
+			EnableKubevirtStaticIP:    agentContext.Cfg.EnableKubevirtStaticIP,
+			IPBlockSize:               agentContext.Cfg.IPPoolBlockSize,
+			NodeName:                  agentContext.Cfg.AgentNodeName,
 		},
 		agentContext.CRDManager.GetClient(),
 		agentContext.CRDManager.GetAPIReader(),
@@ -0,0 +1,80 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/networking/ifacerstate"
+)
+
+// startIfacerReconcile periodically cleans up the VLAN/Bond interfaces
+// created by the ifacer plugin, whose attachments are left behind because
+// CNI DEL is never called, for example, the node crashes or the Pods are
+// force deleted.
+func startIfacerReconcile(ctx context.Context) {
+	if agentContext.Cfg.IfacerReconcileInterval <= 0 {
+		logger.Info("The reconcile of the ifacer interfaces is disabled")
+		return
+	}
+
+	interval := time.Duration(agentContext.Cfg.IfacerReconcileInterval) * time.Second
+	go wait.UntilWithContext(ctx, reconcileIfacerInterfaces, interval)
+}
+
+func reconcileIfacerInterfaces(ctx context.Context) {
+	statePath := ifacerstate.StatePath(agentContext.Cfg.IpamUnixSocketPath)
+	state, err := ifacerstate.Read(statePath)
+	if err != nil {
+		logger.Sugar().Errorf("Failed to read the ifacer state: %v", err)
+		return
+	}
+
+	// Check the Pods without holding the lock of the state file, so that
+	// the ifacer plugin is not blocked. The attachments of the Pods no
+	// longer exist never come back.
+	orphans := map[ifacerstate.Attachment]struct{}{}
+	for _, iface := range state.Interfaces {
+		for _, a := range iface.Attachments {
+			if _, ok := orphans[a]; ok || a.PodName == "" {
+				continue
+			}
+
+			pod, err := agentContext.PodManager.GetPodByName(ctx, a.PodNamespace, a.PodName, constant.IgnoreCache)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					logger.Sugar().Warnf("Failed to get Pod %s/%s of the ifacer attachment: %v", a.PodNamespace, a.PodName, err)
+					continue
+				}
+			} else if a.PodUID == "" || string(pod.UID) == a.PodUID {
+				continue
+			}
+			orphans[a] = struct{}{}
+		}
+	}
+	if len(orphans) == 0 {
+		return
+	}
+
+	err = ifacerstate.Update(statePath, func(state *ifacerstate.State) error {
+		unused := state.Prune(func(a ifacerstate.Attachment) bool {
+			_, ok := orphans[a]
+			return !ok
+		})
+		if len(unused) != 0 {
+			logger.Sugar().Infof("Delete the ifacer interfaces no longer used: %v", unused)
+		}
+		return ifacerstate.DeleteLinks(unused)
+	})
+	if err != nil {
+		logger.Sugar().Errorf("Failed to clean up the orphan ifacer attachments %v: %v", orphans, err)
+		return
+	}
+	logger.Sugar().Infof("Succeed to clean up %d orphan ifacer attachments", len(orphans))
+}
@@ -20,10 +20,13 @@ import (
 
 // Singleton.
 var (
-	unixPostAgentIpamIp    = &_unixPostAgentIpamIp{}
-	unixDeleteAgentIpamIp  = &_unixDeleteAgentIpamIp{}
-	unixPostAgentIpamIps   = &_unixPostAgentIpamIps{}
-	unixDeleteAgentIpamIps = &_unixDeleteAgentIpamIps{}
+	unixPostAgentIpamIp     = &_unixPostAgentIpamIp{}
+	unixDeleteAgentIpamIp   = &_unixDeleteAgentIpamIp{}
+	unixPostAgentIpamIps    = &_unixPostAgentIpamIps{}
+	unixDeleteAgentIpamIps  = &_unixDeleteAgentIpamIps{}
+	unixPostAgentIpamCheck  = &_unixPostAgentIpamCheck{}
+	unixPostAgentIpamStatus = &_unixPostAgentIpamStatus{}
+	unixPostAgentIpamGC     = &_unixPostAgentIpamGC{}
 )
 
 type _unixPostAgentIpamIp struct{}
@@ -115,16 +118,168 @@ type _unixPostAgentIpamIps struct{}
 
 // Handle handles POST requests for /ipam/ips.
 func (g *_unixPostAgentIpamIps) Handle(params daemonset.PostIpamIpsParams) middleware.Responder {
-	return daemonset.NewPostIpamIpsOK()
+	if err := params.IpamBatchAddArgs.Validate(strfmt.Default); err != nil {
+		return daemonset.NewPostIpamIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	ifNames := make([]string, 0, len(params.IpamBatchAddArgs.Nics))
+	for _, nic := range params.IpamBatchAddArgs.Nics {
+		ifNames = append(ifNames, *nic.IfName)
+	}
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("CNICommand", "ADD"),
+		zap.String("ContainerID", *params.IpamBatchAddArgs.ContainerID),
+		zap.Strings("IfNames", ifNames),
+		zap.String("NetNamespace", *params.IpamBatchAddArgs.NetNamespace),
+		zap.String("PodNamespace", *params.IpamBatchAddArgs.PodNamespace),
+		zap.String("PodName", *params.IpamBatchAddArgs.PodName),
+		zap.String("PodUID", *params.IpamBatchAddArgs.PodUID),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	// The total count of IP allocations.
+	metric.IpamAllocationTotalCounts.Add(ctx, 1)
+
+	timeRecorder := metric.NewTimeRecorder()
+	defer func() {
+		// Time taken for once IP allocation.
+		allocationDuration := timeRecorder.SinceInSeconds()
+		metric.IPAMDurationConstruct.RecordIPAMAllocationDuration(ctx, allocationDuration)
+		logger.Sugar().Infof("IPAM allocation duration: %v", allocationDuration)
+	}()
+
+	resp, err := agentContext.IPAM.AllocateBatch(ctx, params.IpamBatchAddArgs)
+	if err != nil {
+		// The count of failures in IP allocations.
+		metric.IpamAllocationFailureCounts.Add(ctx, 1)
+		gatherIPAMAllocationErrMetric(ctx, err)
+		logger.Error(err.Error())
+
+		return daemonset.NewPostIpamIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	return daemonset.NewPostIpamIpsOK().WithPayload(resp)
 }
 
 type _unixDeleteAgentIpamIps struct{}
 
 // Handle handles DELETE requests for /ipam/ips.
 func (g *_unixDeleteAgentIpamIps) Handle(params daemonset.DeleteIpamIpsParams) middleware.Responder {
+	if err := params.IpamBatchDelArgs.Validate(strfmt.Default); err != nil {
+		return daemonset.NewDeleteIpamIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("CNICommand", "DEL"),
+		zap.String("ContainerID", *params.IpamBatchDelArgs.ContainerID),
+		zap.Strings("IfNames", params.IpamBatchDelArgs.IfNames),
+		zap.String("NetNamespace", params.IpamBatchDelArgs.NetNamespace),
+		zap.String("PodNamespace", *params.IpamBatchDelArgs.PodNamespace),
+		zap.String("PodName", *params.IpamBatchDelArgs.PodName),
+		zap.String("PodUID", *params.IpamBatchDelArgs.PodUID),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	// The total count of IP releasing.
+	metric.IpamReleaseTotalCounts.Add(ctx, 1)
+
+	timeRecorder := metric.NewTimeRecorder()
+	defer func() {
+		// Time taken for once IP releasing.
+		releaseDuration := timeRecorder.SinceInSeconds()
+		metric.IPAMDurationConstruct.RecordIPAMReleaseDuration(ctx, releaseDuration)
+		logger.Sugar().Infof("IPAM releasing duration: %v", releaseDuration)
+	}()
+
+	if err := agentContext.IPAM.ReleaseBatch(ctx, params.IpamBatchDelArgs); err != nil {
+		// The count of failures in IP releasing.
+		metric.IpamReleaseFailureCounts.Add(ctx, 1)
+		gatherIPAMReleasingErrMetric(ctx, err)
+		logger.Error(err.Error())
+
+		return daemonset.NewDeleteIpamIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
 	return daemonset.NewDeleteIpamIpsOK()
 }
 
+type _unixPostAgentIpamCheck struct{}
+
+// Handle handles POST requests for /ipam/check.
+func (g *_unixPostAgentIpamCheck) Handle(params daemonset.PostIpamCheckParams) middleware.Responder {
+	if err := params.IpamCheckArgs.Validate(strfmt.Default); err != nil {
+		return daemonset.NewPostIpamCheckFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("CNICommand", "CHECK"),
+		zap.String("ContainerID", *params.IpamCheckArgs.ContainerID),
+		zap.String("IfName", *params.IpamCheckArgs.IfName),
+		zap.String("NetNamespace", params.IpamCheckArgs.NetNamespace),
+		zap.String("PodNamespace", *params.IpamCheckArgs.PodNamespace),
+		zap.String("PodName", *params.IpamCheckArgs.PodName),
+		zap.String("PodUID", *params.IpamCheckArgs.PodUID),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	if err := agentContext.IPAM.Check(ctx, params.IpamCheckArgs); err != nil {
+		logger.Error(err.Error())
+		if errors.Is(err, constant.ErrIPAllocationDrift) {
+			return daemonset.NewPostIpamCheckDrift().WithPayload(models.Error(err.Error()))
+		}
+
+		return daemonset.NewPostIpamCheckFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	return daemonset.NewPostIpamCheckOK()
+}
+
+type _unixPostAgentIpamStatus struct{}
+
+// Handle handles POST requests for /ipam/status.
+func (g *_unixPostAgentIpamStatus) Handle(params daemonset.PostIpamStatusParams) middleware.Responder {
+	if err := params.IpamStatusArgs.Validate(strfmt.Default); err != nil {
+		return daemonset.NewPostIpamStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("CNICommand", "STATUS"),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	if err := agentContext.IPAM.Status(ctx, params.IpamStatusArgs); err != nil {
+		logger.Error(err.Error())
+		if errors.Is(err, constant.ErrNoAvailablePool) {
+			return daemonset.NewPostIpamStatusUnavailable().WithPayload(models.Error(err.Error()))
+		}
+
+		return daemonset.NewPostIpamStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	return daemonset.NewPostIpamStatusOK()
+}
+
+type _unixPostAgentIpamGC struct{}
+
+// Handle handles POST requests for /ipam/gc.
+func (g *_unixPostAgentIpamGC) Handle(params daemonset.PostIpamGcParams) middleware.Responder {
+	if err := params.IpamGcArgs.Validate(strfmt.Default); err != nil {
+		return daemonset.NewPostIpamGcFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("CNICommand", "GC"),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	if err := agentContext.IPAM.GC(ctx, params.IpamGcArgs); err != nil {
+		logger.Error(err.Error())
+		return daemonset.NewPostIpamGcFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	return daemonset.NewPostIpamGcOK()
+}
+
 func gatherIPAMAllocationErrMetric(ctx context.Context, err error) {
 	internal := true
 	if errors.Is(err, constant.ErrWrongInput) {
@@ -33,6 +33,9 @@ func newAgentOpenAPIUnixServer() (*agentOpenAPIServer.Server, error) {
 	api.DaemonsetDeleteIpamIPHandler = unixDeleteAgentIpamIp
 	api.DaemonsetPostIpamIpsHandler = unixPostAgentIpamIps
 	api.DaemonsetDeleteIpamIpsHandler = unixDeleteAgentIpamIps
+	api.DaemonsetPostIpamCheckHandler = unixPostAgentIpamCheck
+	api.DaemonsetPostIpamStatusHandler = unixPostAgentIpamStatus
+	api.DaemonsetPostIpamGcHandler = unixPostAgentIpamGC
 	api.DaemonsetGetCoordinatorConfigHandler = unixGetCoordinatorConfig
 
 	// new agent OpenAPI server with api
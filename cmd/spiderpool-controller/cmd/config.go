@@ -31,6 +31,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 var controllerContext = new(ControllerContext)
@@ -82,6 +83,7 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_LEADER_RETRY_GAP", "1", true, nil, nil, &controllerContext.Cfg.LeaseRetryGap},
 
 	{"SPIDERPOOL_IPPOOL_MAX_ALLOCATED_IPS", "5000", false, nil, nil, &controllerContext.Cfg.IPPoolMaxAllocatedIPs},
+	{"SPIDERPOOL_IPPOOL_COMPACT_ALLOCATED_IPS", "false", false, nil, &controllerContext.Cfg.EnableCompactAllocatedIPs, nil},
 
 	{"SPIDERPOOL_SUBNET_INFORMER_RESYNC_PERIOD", "300", false, nil, nil, &controllerContext.Cfg.SubnetInformerResyncPeriod},
 	{"SPIDERPOOL_SUBNET_INFORMER_WORKERS", "5", true, nil, nil, &controllerContext.Cfg.SubnetInformerWorkers},
@@ -135,7 +137,8 @@ type Config struct {
 	LeaseRetryPeriod       int
 	LeaseRetryGap          int
 
-	IPPoolMaxAllocatedIPs int
+	IPPoolMaxAllocatedIPs     int
+	EnableCompactAllocatedIPs bool
 
 	SubnetInformerResyncPeriod       int
 	SubnetInformerWorkers            int
@@ -154,12 +157,13 @@ type Config struct {
 	MultusConfigInformerResyncPeriod int
 
 	// configmap
-	EnableIPv4                        bool `yaml:"enableIPv4"`
-	EnableIPv6                        bool `yaml:"enableIPv6"`
-	EnableStatefulSet                 bool `yaml:"enableStatefulSet"`
-	EnableKubevirtStaticIP            bool `yaml:"enableKubevirtStaticIP"`
-	EnableSpiderSubnet                bool `yaml:"enableSpiderSubnet"`
-	ClusterSubnetDefaultFlexibleIPNum int  `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	EnableIPv4                        bool                          `yaml:"enableIPv4"`
+	EnableIPv6                        bool                          `yaml:"enableIPv6"`
+	EnableStatefulSet                 bool                          `yaml:"enableStatefulSet"`
+	EnableKubevirtStaticIP            bool                          `yaml:"enableKubevirtStaticIP"`
+	EnableSpiderSubnet                bool                          `yaml:"enableSpiderSubnet"`
+	ClusterSubnetDefaultFlexibleIPNum int                           `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	CustomWorkloads                   []workloadkind.CustomWorkload `yaml:"customWorkloads"`
 }
 
 type ControllerContext struct {
@@ -186,6 +190,7 @@ type ControllerContext struct {
 	GCManager         gcmanager.GCManager
 	StsManager        statefulsetmanager.StatefulSetManager
 	KubevirtManager   kubevirtmanager.KubevirtManager
+	WorkloadKinds     workloadkind.Registry
 	Leader            election.SpiderLeaseElector
 
 	// handler
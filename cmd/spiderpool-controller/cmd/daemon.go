@@ -38,6 +38,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 // DaemonMain runs controllerContext handlers.
@@ -242,39 +243,56 @@ func initControllerServiceManagers(ctx context.Context) {
 	}
 	controllerContext.NSManager = nsManager
 
-	logger.Debug("Begin to initialize Pod manager")
-	podManager, err := podmanager.NewPodManager(
+	logger.Info("Begin to initialize StatefulSet manager")
+	statefulSetManager, err := statefulsetmanager.NewStatefulSetManager(
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.CRDManager.GetAPIReader(),
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
-	controllerContext.PodManager = podManager
+	controllerContext.StsManager = statefulSetManager
 
-	logger.Info("Begin to initialize StatefulSet manager")
-	statefulSetManager, err := statefulsetmanager.NewStatefulSetManager(
+	logger.Debug("Begin to initialize Kubevirt manager")
+	kubevirtManager := kubevirtmanager.NewKubevirtManager(
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.CRDManager.GetAPIReader(),
 	)
+	controllerContext.KubevirtManager = kubevirtManager
+
+	logger.Debug("Begin to initialize workload kind registry")
+	workloadKinds, err := workloadkind.NewRegistry(
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetAPIReader(),
+		statefulSetManager,
+		kubevirtManager,
+		workloadkind.RegistryConfig{
+			EnableStatefulSet:      controllerContext.Cfg.EnableStatefulSet,
+			EnableKubevirtStaticIP: controllerContext.Cfg.EnableKubevirtStaticIP,
+			CustomWorkloads:        controllerContext.Cfg.CustomWorkloads,
+		},
+	)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
-	controllerContext.StsManager = statefulSetManager
+	controllerContext.WorkloadKinds = workloadKinds
 
-	logger.Debug("Begin to initialize Kubevirt manager")
-	kubevirtManager := kubevirtmanager.NewKubevirtManager(
+	logger.Debug("Begin to initialize Pod manager")
+	podManager, err := podmanager.NewPodManager(
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.CRDManager.GetAPIReader(),
+		workloadKinds,
 	)
-	controllerContext.KubevirtManager = kubevirtManager
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	controllerContext.PodManager = podManager
 
 	logger.Debug("Begin to initialize Endpoint manager")
 	endpointManager, err := workloadendpointmanager.NewWorkloadEndpointManager(
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.CRDManager.GetAPIReader(),
-		controllerContext.Cfg.EnableStatefulSet,
-		controllerContext.Cfg.EnableKubevirtStaticIP,
+		workloadKinds,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -302,8 +320,9 @@ func initControllerServiceManagers(ctx context.Context) {
 	logger.Debug("Begin to initialize IPPool manager")
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
-			MaxAllocatedIPs:        &controllerContext.Cfg.IPPoolMaxAllocatedIPs,
-			EnableKubevirtStaticIP: controllerContext.Cfg.EnableKubevirtStaticIP,
+			MaxAllocatedIPs:           &controllerContext.Cfg.IPPoolMaxAllocatedIPs,
+			EnableCompactAllocatedIPs: controllerContext.Cfg.EnableCompactAllocatedIPs,
+			EnableKubevirtStaticIP:    controllerContext.Cfg.EnableKubevirtStaticIP,
 		},
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.CRDManager.GetAPIReader(),
@@ -330,6 +349,11 @@ func initControllerServiceManagers(ctx context.Context) {
 		logger.Fatal(err.Error())
 	}
 
+	logger.Debug("Begin to set up Pod webhook")
+	if err := (&podmanager.PodWebhook{}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+		logger.Fatal(err.Error())
+	}
+
 	if controllerContext.Cfg.EnableSpiderSubnet {
 		logger.Debug("Begin to initialize Subnet manager")
 		subnetManager, err := subnetmanager.NewSubnetManager(
@@ -360,17 +384,15 @@ func initControllerServiceManagers(ctx context.Context) {
 
 	if controllerContext.Cfg.EnableMultusConfig {
 		logger.Debug("Begin to set up MultusConfig webhook")
-		if err := (&multuscniconfig.MultusConfigWebhook{}).SetupWebhookWithManager(controllerContext.CRDManager); nil != err {
+		if err := (&multuscniconfig.MultusConfigWebhook{
+			APIReader: controllerContext.CRDManager.GetAPIReader(),
+		}).SetupWebhookWithManager(controllerContext.CRDManager); nil != err {
 			logger.Fatal(err.Error())
 		}
 	}
 }
 
 func initGCManager(ctx context.Context) {
-	// EnableStatefulSet was determined by Configmap.
-	gcIPConfig.EnableStatefulSet = controllerContext.Cfg.EnableStatefulSet
-	// EnableKubevirtStaticIP was determined by Configmap.
-	gcIPConfig.EnableKubevirtStaticIP = controllerContext.Cfg.EnableKubevirtStaticIP
 	gcIPConfig.LeaderRetryElectGap = time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second
 	gcManager, err := gcmanager.NewGCManager(
 		controllerContext.ClientSet,
@@ -378,8 +400,7 @@ func initGCManager(ctx context.Context) {
 		controllerContext.EndpointManager,
 		controllerContext.IPPoolManager,
 		controllerContext.PodManager,
-		controllerContext.StsManager,
-		controllerContext.KubevirtManager,
+		controllerContext.WorkloadKinds,
 		controllerContext.Leader,
 	)
 	if nil != err {
@@ -484,12 +505,29 @@ func setupInformers() {
 		},
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.DynamicClient,
+		controllerContext.CRDManager.GetCache(),
 	)
 	err = ipPoolController.SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader)
 	if nil != err {
 		logger.Fatal(err.Error())
 	}
 
+	logger.Info("Begin to set up ReservedIP informer")
+	reservedIPController := reservedipmanager.NewReservedIPController(
+		reservedipmanager.ReservedIPControllerConfig{
+			ControllerWorkers:   1,
+			MaxWorkqueueLength:  controllerContext.Cfg.IPPoolInformerMaxWorkQueueLength,
+			LeaderRetryElectGap: time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
+			ResyncPeriod:        time.Duration(controllerContext.Cfg.IPPoolInformerResyncPeriod) * time.Second,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetCache(),
+	)
+	err = reservedIPController.SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+
 	if controllerContext.Cfg.EnableSpiderSubnet {
 		logger.Info("Begin to set up Subnet informer")
 		if err := (&subnetmanager.SubnetController{
@@ -509,6 +547,7 @@ func setupInformers() {
 			controllerContext.CRDManager.GetClient(),
 			controllerContext.CRDManager.GetAPIReader(),
 			controllerContext.SubnetManager,
+			controllerContext.WorkloadKinds,
 			applicationcontroller.SubnetAppControllerConfig{
 				EnableIPv4:                    controllerContext.Cfg.EnableIPv4,
 				EnableIPv6:                    controllerContext.Cfg.EnableIPv6,
@@ -522,7 +561,7 @@ func setupInformers() {
 			logger.Fatal(err.Error())
 		}
 
-		err = subnetAppController.SetupInformer(controllerContext.InnerCtx, controllerContext.ClientSet, controllerContext.Leader)
+		err = subnetAppController.SetupInformer(controllerContext.InnerCtx, controllerContext.ClientSet, controllerContext.DynamicClient, controllerContext.Leader)
 		if nil != err {
 			logger.Fatal(err.Error())
 		}
@@ -538,7 +577,8 @@ func setupInformers() {
 				LeaderRetryElectGap:           time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
 				ResyncPeriod:                  time.Duration(controllerContext.Cfg.MultusConfigInformerResyncPeriod) * time.Second,
 			},
-			controllerContext.CRDManager.GetClient())
+			controllerContext.CRDManager.GetClient(),
+			controllerContext.CRDManager.GetCache())
 		err = multusConfigController.SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader)
 		if nil != err {
 			logger.Fatal(err.Error())
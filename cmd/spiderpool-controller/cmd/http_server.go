@@ -33,6 +33,12 @@ func newControllerOpenAPIServer() (*controllerOpenAPIServer.Server, error) {
 	api.RuntimeGetRuntimeReadinessHandler = httpGetControllerReadiness
 	api.RuntimeGetRuntimeLivenessHandler = httpGetControllerLiveness
 
+	// IPAM API
+	api.ControllerGetIpamStatusHandler = httpGetIpamStatus
+	api.ControllerPutIpamIPHandler = httpPutIpamIP
+	api.ControllerDeleteIpamIPHandler = httpDeleteIpamIP
+	api.ControllerPostIpamGcIpsHandler = httpPostIpamGCIPs
+
 	// new controller OpenAPI server with api
 	srv := controllerOpenAPIServer.NewServer(api)
 
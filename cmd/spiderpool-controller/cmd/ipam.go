@@ -0,0 +1,433 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+// Singleton
+var (
+	httpGetIpamStatus = &_httpGetIpamStatus{controllerContext}
+	httpPutIpamIP     = &_httpPutIpamIP{controllerContext}
+	httpDeleteIpamIP  = &_httpDeleteIpamIP{controllerContext}
+	httpPostIpamGCIPs = &_httpPostIpamGCIPs{controllerContext}
+)
+
+type _httpGetIpamStatus struct {
+	*ControllerContext
+}
+
+// Handle handles GET requests for /ipam/status.
+func (g *_httpGetIpamStatus) Handle(params controller.GetIpamStatusParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	var filterIP string
+	if params.IP != nil && *params.IP != "" {
+		ip := net.ParseIP(*params.IP)
+		if ip == nil {
+			return controller.NewGetIpamStatusFailure().WithPayload(models.Error(fmt.Sprintf("invalid IP address '%s'", *params.IP)))
+		}
+		filterIP = ip.String()
+	}
+
+	poolList, err := g.IPPoolManager.ListIPPools(ctx, constant.UseCache)
+	if err != nil {
+		return controller.NewGetIpamStatusFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	ipStatuses := []*models.IPStatus{}
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return controller.NewGetIpamStatusFailure().WithPayload(models.Error(fmt.Sprintf("failed to parse the allocated IPs of IPPool %s: %v", pool.Name, err)))
+		}
+
+		for ip, record := range records {
+			if filterIP != "" && ip != filterIP {
+				continue
+			}
+
+			ipStatus, err := g.buildIPStatus(ctx, pool, ip, record)
+			if err != nil {
+				return controller.NewGetIpamStatusFailure().WithPayload(models.Error(err.Error()))
+			}
+			ipStatuses = append(ipStatuses, ipStatus)
+		}
+	}
+
+	sort.Slice(ipStatuses, func(i, j int) bool {
+		if *ipStatuses[i].IPPool != *ipStatuses[j].IPPool {
+			return *ipStatuses[i].IPPool < *ipStatuses[j].IPPool
+		}
+		return spiderpoolip.Cmp(net.ParseIP(*ipStatuses[i].IP), net.ParseIP(*ipStatuses[j].IP)) < 0
+	})
+
+	return controller.NewGetIpamStatusOK().WithPayload(&models.IpamStatus{Ips: ipStatuses})
+}
+
+// buildIPStatus assembles the allocation details of the IP address from the
+// IPPool record and the SpiderEndpoint of the Pod holding it.
+func (g *_httpGetIpamStatus) buildIPStatus(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool, ip string, record spiderpoolv2beta1.PoolIPAllocation) (*models.IPStatus, error) {
+	poolName := pool.Name
+	ipStatus := &models.IPStatus{
+		IP:      &ip,
+		IPPool:  &poolName,
+		Version: pool.Spec.IPVersion,
+		Pod:     record.NamespacedName,
+		PodUID:  record.PodUID,
+	}
+
+	podNS, podName, err := cache.SplitMetaNamespaceKey(record.NamespacedName)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := g.EndpointManager.GetEndpointByName(ctx, podNS, podName, constant.UseCache)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ipStatus, nil
+		}
+		return nil, err
+	}
+
+	ipStatus.Endpoint = fmt.Sprintf("%s/%s", endpoint.Namespace, endpoint.Name)
+	ipStatus.EndpointUID = endpoint.Status.Current.UID
+	ipStatus.Node = endpoint.Status.Current.Node
+	ipStatus.OwnerControllerType = endpoint.Status.OwnerControllerType
+	ipStatus.OwnerControllerName = endpoint.Status.OwnerControllerName
+	for _, d := range endpoint.Status.Current.IPs {
+		if (d.IPv4 != nil && strings.Split(*d.IPv4, "/")[0] == ip) ||
+			(d.IPv6 != nil && strings.Split(*d.IPv6, "/")[0] == ip) {
+			ipStatus.Nic = d.NIC
+			break
+		}
+	}
+
+	return ipStatus, nil
+}
+
+type _httpPutIpamIP struct {
+	*ControllerContext
+}
+
+// Handle handles PUT requests for /ipam/ip.
+func (g *_httpPutIpamIP) Handle(params controller.PutIpamIPParams) middleware.Responder {
+	args := params.IPSetArgs
+	if err := args.Validate(strfmt.Default); err != nil {
+		return controller.NewPutIpamIPFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("Operation", "SetIP"),
+		zap.String("IP", *args.IP),
+		zap.String("PodNamespace", *args.PodNamespace),
+		zap.String("PodName", *args.PodName),
+		zap.String("ContainerID", *args.ContainerID),
+		zap.String("Node", *args.Node),
+		zap.String("IfName", *args.IfName),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	if err := g.setIP(ctx, args); err != nil {
+		logger.Error(err.Error())
+		return controller.NewPutIpamIPFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger.Info("Succeed to set IP")
+	return controller.NewPutIpamIPOK()
+}
+
+func (g *_httpPutIpamIP) setIP(ctx context.Context, args *models.IPSetArgs) error {
+	ip := net.ParseIP(*args.IP)
+	if ip == nil {
+		return fmt.Errorf("%w: invalid IP address '%s'", constant.ErrWrongInput, *args.IP)
+	}
+
+	pod, err := g.PodManager.GetPodByName(ctx, *args.PodNamespace, *args.PodName, constant.IgnoreCache)
+	if err != nil {
+		return fmt.Errorf("failed to get Pod %s/%s: %w", *args.PodNamespace, *args.PodName, err)
+	}
+	if pod.Spec.NodeName != *args.Node {
+		return fmt.Errorf("%w: Pod %s/%s is scheduled to node '%s' rather than '%s'", constant.ErrWrongInput, pod.Namespace, pod.Name, pod.Spec.NodeName, *args.Node)
+	}
+
+	podController, err := g.PodManager.GetPodTopController(ctx, pod)
+	if err != nil {
+		return err
+	}
+	isStickyIPPod, err := g.EndpointManager.IsStickyIPPod(ctx, pod, podController)
+	if err != nil {
+		return err
+	}
+	if isStickyIPPod {
+		return fmt.Errorf("%w: the IP addresses of Pod %s/%s are held by the replica slot of %s %s", constant.ErrWrongInput, pod.Namespace, pod.Name, podController.Kind, podController.Name)
+	}
+
+	pool, err := g.findIPPoolOfIP(ctx, ip)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := g.EndpointManager.GetEndpointByName(ctx, pod.Namespace, pod.Name, constant.IgnoreCache)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		endpoint = nil
+	}
+	if endpoint != nil && endpoint.Status.Current.UID != string(pod.UID) {
+		return fmt.Errorf("SpiderEndpoint %s/%s belongs to the previous Pod (UID %s), wait for it to be cleaned up", endpoint.Namespace, endpoint.Name, endpoint.Status.Current.UID)
+	}
+	// The container ID is recorded in the Endpoint to tell its attachment
+	// from the stale ones, so it must not be changed by the IP address set.
+	if endpoint != nil && endpoint.Status.Current.ContainerID != "" && endpoint.Status.Current.ContainerID != *args.ContainerID {
+		return fmt.Errorf("%w: SpiderEndpoint %s/%s records the container ID '%s' rather than '%s'", constant.ErrWrongInput, endpoint.Namespace, endpoint.Name, endpoint.Status.Current.ContainerID, *args.ContainerID)
+	}
+
+	ipConfig, err := g.IPPoolManager.AssignIP(ctx, pool.Name, ip.String(), *args.IfName, pod)
+	if err != nil {
+		return err
+	}
+	result := &types.AllocationResult{
+		IP:     ipConfig,
+		Routes: convert.ConvertSpecRoutesToOAIRoutes(*args.IfName, pool.Spec.Routes),
+	}
+
+	if endpoint == nil {
+		return g.EndpointManager.PatchIPAllocationResults(ctx, []*types.AllocationResult{result}, nil, pod, *args.ContainerID, podController, false)
+	}
+
+	// The IP address of the same NIC and IP version is replaced, so release
+	// the previous one from its IPPool.
+	for _, d := range endpoint.Status.Current.IPs {
+		if d.NIC != *args.IfName {
+			continue
+		}
+
+		previousIP, previousPool := d.IPv4, d.IPv4Pool
+		if *pool.Spec.IPVersion == constant.IPv6 {
+			previousIP, previousPool = d.IPv6, d.IPv6Pool
+		}
+		if previousIP == nil || previousPool == nil || strings.Split(*previousIP, "/")[0] == ip.String() {
+			break
+		}
+
+		if err := g.IPPoolManager.ReleaseIP(ctx, *previousPool, []types.IPAndUID{{
+			IP:  strings.Split(*previousIP, "/")[0],
+			UID: string(pod.UID),
+		}}); err != nil {
+			return err
+		}
+		logutils.FromContext(ctx).Sugar().Infof("release the previous IP %s from IPPool %s", *previousIP, *previousPool)
+		break
+	}
+
+	return g.EndpointManager.UpdateIPAllocationResult(ctx, endpoint, result)
+}
+
+// findIPPoolOfIP finds the IPPool whose IP ranges contain the IP address.
+func (g *_httpPutIpamIP) findIPPoolOfIP(ctx context.Context, ip net.IP) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	version := constant.IPv4
+	if ip.To4() == nil {
+		version = constant.IPv6
+	}
+
+	poolList, err := g.IPPoolManager.ListIPPools(ctx, constant.UseCache)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != version {
+			continue
+		}
+
+		contains, err := spiderpoolip.ContainsIP(version, pool.Spec.Subnet, ip.String())
+		if err != nil || !contains {
+			continue
+		}
+
+		for _, r := range pool.Spec.IPs {
+			if contains, err := spiderpoolip.IPRangeContainsIP(version, r, ip.String()); err == nil && contains {
+				return pool, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no IPPool contains IP %s", constant.ErrNoAvailablePool, ip)
+}
+
+type _httpDeleteIpamIP struct {
+	*ControllerContext
+}
+
+// Handle handles DELETE requests for /ipam/ip.
+func (g *_httpDeleteIpamIP) Handle(params controller.DeleteIpamIPParams) middleware.Responder {
+	args := params.IPReleaseArgs
+	if err := args.Validate(strfmt.Default); err != nil {
+		return controller.NewDeleteIpamIPFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("Operation", "ReleaseIP"),
+		zap.String("IP", *args.IP),
+		zap.Bool("Force", args.Force),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	if err := g.releaseIP(ctx, *args.IP, args.Force); err != nil {
+		logger.Error(err.Error())
+		return controller.NewDeleteIpamIPFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	logger.Info("Succeed to release IP")
+	return controller.NewDeleteIpamIPOK()
+}
+
+func (g *_httpDeleteIpamIP) releaseIP(ctx context.Context, rawIP string, force bool) error {
+	ip := net.ParseIP(rawIP)
+	if ip == nil {
+		return fmt.Errorf("%w: invalid IP address '%s'", constant.ErrWrongInput, rawIP)
+	}
+
+	poolList, err := g.IPPoolManager.ListIPPools(ctx, constant.IgnoreCache)
+	if err != nil {
+		return err
+	}
+
+	var poolName string
+	var record spiderpoolv2beta1.PoolIPAllocation
+	for _, pool := range poolList.Items {
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return fmt.Errorf("failed to parse the allocated IPs of IPPool %s: %w", pool.Name, err)
+		}
+		if r, ok := records[ip.String()]; ok {
+			poolName = pool.Name
+			record = r
+			break
+		}
+	}
+	if poolName == "" {
+		return fmt.Errorf("%w: IP %s is not allocated by any IPPool", constant.ErrWrongInput, ip)
+	}
+
+	podNS, podName, err := cache.SplitMetaNamespaceKey(record.NamespacedName)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		pod, err := g.PodManager.GetPodByName(ctx, podNS, podName, constant.IgnoreCache)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+		} else if string(pod.UID) == record.PodUID && podmanager.IsPodAlive(pod) {
+			return fmt.Errorf("IP %s is still taken by the alive Pod %s, release it with force if you insist", ip, record.NamespacedName)
+		}
+	}
+
+	if err := g.IPPoolManager.ReleaseIP(ctx, poolName, []types.IPAndUID{{
+		IP:  ip.String(),
+		UID: record.PodUID,
+	}}); err != nil {
+		return err
+	}
+	logutils.FromContext(ctx).Sugar().Infof("release IP %s from IPPool %s", ip, poolName)
+
+	endpoint, err := g.EndpointManager.GetEndpointByName(ctx, podNS, podName, constant.IgnoreCache)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if endpoint.Status.Current.UID != record.PodUID {
+		return nil
+	}
+
+	return g.EndpointManager.ReleaseIPAllocation(ctx, endpoint, ip.String())
+}
+
+type _httpPostIpamGCIPs struct {
+	*ControllerContext
+}
+
+// Handle handles POST requests for /ipam/gc_ips.
+func (g *_httpPostIpamGCIPs) Handle(params controller.PostIpamGcIpsParams) middleware.Responder {
+	args := params.GcArgs
+	if args == nil {
+		args = &models.IpamGcArgs{}
+	}
+
+	// Without any scope, just trigger the global GC as the periodic one.
+	if args.IPPool == "" && args.Namespace == "" && args.IP == "" && !args.DryRun {
+		g.GCManager.TriggerGCAll()
+		return controller.NewPostIpamGcIpsOK().WithPayload(&models.IpamGcResult{Ips: []*models.IpamGcRecord{}})
+	}
+
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("Operation", "GC"),
+		zap.String("IPPool", args.IPPool),
+		zap.String("Namespace", args.Namespace),
+		zap.String("IP", args.IP),
+		zap.Bool("DryRun", args.DryRun),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	gcRecords, err := g.GCManager.TriggerGCWithScope(ctx, gcmanager.GCScope{
+		IPPool:    args.IPPool,
+		Namespace: args.Namespace,
+		IP:        args.IP,
+		DryRun:    args.DryRun,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+		return controller.NewPostIpamGcIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	records := make([]*models.IpamGcRecord, 0, len(gcRecords))
+	for i := range gcRecords {
+		r := gcRecords[i]
+		records = append(records, &models.IpamGcRecord{
+			IP:     &r.IP,
+			IPPool: &r.IPPool,
+			Pod:    r.NamespacedName,
+			PodUID: r.PodUID,
+			Reason: r.Reason,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if *records[i].IPPool != *records[j].IPPool {
+			return *records[i].IPPool < *records[j].IPPool
+		}
+		return spiderpoolip.Cmp(net.ParseIP(*records[i].IP), net.ParseIP(*records[j].IP)) < 0
+	})
+
+	logger.Sugar().Infof("Succeed to GC with scope, %d IP allocations involved", len(records))
+	return controller.NewPostIpamGcIpsOK().WithPayload(&models.IpamGcResult{Ips: records})
+}
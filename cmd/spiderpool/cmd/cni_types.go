@@ -21,17 +21,39 @@ var (
 	ErrAgentHealthCheck = fmt.Errorf("unhealthy spiderpool-agent backend")
 	ErrPostIPAM         = fmt.Errorf("spiderpool IP allocation error")
 	ErrDeleteIPAM       = fmt.Errorf("spiderpool IP release error")
+	ErrCheckIPAM        = fmt.Errorf("spiderpool IP check error")
+	ErrGCIPAM           = fmt.Errorf("spiderpool IP garbage collection error")
 )
 
+// ErrCodeIPAllocationDrift is the CNI error code returned by CHECK when the
+// IP addresses of the container diverge from the IP allocation of the Pod.
+// Codes 100+ are reserved for plugin specific errors by the CNI SPEC.
+const ErrCodeIPAllocationDrift uint = 100
+
+// ErrCodePluginNotAvailable is the CNI error code returned by STATUS when
+// the plugin is not able to serve ADD requests, defined by CNI SPEC 1.1.
+const ErrCodePluginNotAvailable uint = 50
+
 const (
 	CniVersion030 = "0.3.0"
 	CniVersion031 = "0.3.1"
 	CniVersion040 = "0.4.0"
 	CniVersion100 = "1.0.0"
+	CniVersion110 = "1.1.0"
 )
 
 // SupportCNIVersions indicate the CNI version that spiderpool support.
-var SupportCNIVersions = []string{CniVersion030, CniVersion031, CniVersion040, CniVersion100}
+var SupportCNIVersions = []string{CniVersion030, CniVersion031, CniVersion040, CniVersion100, CniVersion110}
+
+// resultVersion returns the version of CNI result for the CNI version of
+// network configuration. The result of CNI 1.1.0 is the same as 1.0.0, but
+// the CNI library in use only implements the latter.
+func resultVersion(cniVersion string) string {
+	if cniVersion == CniVersion110 {
+		return CniVersion100
+	}
+	return cniVersion
+}
 
 const DefaultLogLevelStr = logutils.LogInfoLevelStr
 
@@ -50,6 +72,17 @@ type NetConf struct {
 	Name       string     `json:"name"`
 	CNIVersion string     `json:"cniVersion"`
 	IPAM       IPAMConfig `json:"ipam"`
+
+	RawPrevResult map[string]interface{} `json:"prevResult,omitempty"`
+
+	// ValidAttachments is handed over by the runtime in GC of CNI 1.1.
+	ValidAttachments []Attachment `json:"cni.dev/valid-attachments,omitempty"`
+}
+
+// Attachment is an attachment of a container which is still in use.
+type Attachment struct {
+	ContainerID string `json:"containerID"`
+	IfName      string `json:"ifname"`
 }
 
 // IPAMConfig is a custom IPAM struct.
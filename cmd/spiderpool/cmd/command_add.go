@@ -126,7 +126,7 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 	}
 
 	// Assemble the result of IPAM request response.
-	result, err := assembleResult(conf.CNIVersion, args.IfName, ipamResponse)
+	result, err := assembleResult(resultVersion(conf.CNIVersion), args.IfName, ipamResponse)
 	if err != nil {
 		err := fmt.Errorf("%w: %v", ErrPostIPAM, err)
 		logger.Error(err.Error())
@@ -134,7 +134,7 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 	}
 
 	logger.Sugar().Infof("IPAM allocation result: %+v", *result)
-	return types.PrintResult(result, conf.CNIVersion)
+	return types.PrintResult(result, resultVersion(conf.CNIVersion))
 }
 
 // assembleResult groups the IP allocation resutls of IPAM request response
@@ -144,7 +144,7 @@ func assembleResult(cniVersion, IfName string, ipamResponse *daemonset.PostIpamI
 		CNIVersion: cniVersion,
 	}
 
-	// Mock DNS.
+	// DNS of the NIC.
 	if nil != ipamResponse.Payload.DNS {
 		result.DNS = types.DNS{
 			Nameservers: ipamResponse.Payload.DNS.Nameservers,
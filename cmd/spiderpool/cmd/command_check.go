@@ -0,0 +1,174 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/cni/pkg/version"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/connectivity"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/openapi"
+)
+
+// CmdCheck follows CNI SPEC cmdCheck.
+func CmdCheck(args *skel.CmdArgs) (err error) {
+	var logger *zap.Logger
+
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Spiderpool IPAM CNI panicked during CHECK: %v", e)
+
+			if err != nil {
+				// If it is recovering and an error occurs, then we need to
+				// present both.
+				msg = fmt.Sprintf("%s: error=%v", msg, err.Error())
+			}
+
+			if nil != logger {
+				logger.Sugar().Errorf("%s\n\n%s", msg, debug.Stack())
+			}
+		}
+	}()
+
+	conf, err := LoadNetConf(args.StdinData)
+	if nil != err {
+		return fmt.Errorf("failed to load CNI network configuration: %v", err)
+	}
+
+	logger, err = setupFileLogging(conf)
+	if nil != err {
+		return fmt.Errorf("failed to setup file logging: %v", err)
+	}
+
+	logger = logger.Named(BinNamePlugin).With(
+		zap.String("Action", "CHECK"),
+		zap.String("ContainerID", args.ContainerID),
+		zap.String("Netns", args.Netns),
+		zap.String("IfName", args.IfName),
+	)
+	logger.Debug("Processing CNI CHECK request")
+	logger.Sugar().Debugf("CNI network configuration: %+v", *conf)
+
+	k8sArgs := K8sArgs{}
+	if err = types.LoadArgs(args.Args, &k8sArgs); nil != err {
+		err := fmt.Errorf("failed to load CNI ENV args: %w", err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger = logger.With(
+		zap.String("PodName", string(k8sArgs.K8S_POD_NAME)),
+		zap.String("PodNamespace", string(k8sArgs.K8S_POD_NAMESPACE)),
+		zap.String("PodUID", string(k8sArgs.K8S_POD_UID)),
+	)
+	logger.Sugar().Debugf("CNI ENV args: %+v", k8sArgs)
+
+	ips, err := getPrevResultIPs(conf, args.IfName)
+	if nil != err {
+		err := fmt.Errorf("failed to parse prevResult: %w", err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	spiderpoolAgentAPI, err := openapi.NewAgentOpenAPIUnixClient(conf.IPAM.IPAMUnixSocketPath)
+	if nil != err {
+		err := fmt.Errorf("failed to create spiderpool-agent client: %w", err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Debug("Send health check request to spiderpool-agent backend")
+	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParams())
+	if nil != err {
+		err := fmt.Errorf("%w, failed to check: %v", ErrAgentHealthCheck, err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	params := daemonset.NewPostIpamCheckParams().
+		WithContext(ctx).
+		WithIpamCheckArgs(&models.IpamCheckArgs{
+			ContainerID:  &args.ContainerID,
+			NetNamespace: args.Netns,
+			IfName:       &args.IfName,
+			PodNamespace: (*string)(&k8sArgs.K8S_POD_NAMESPACE),
+			PodName:      (*string)(&k8sArgs.K8S_POD_NAME),
+			PodUID:       (*string)(&k8sArgs.K8S_POD_UID),
+			Ips:          ips,
+		})
+
+	logger.Debug("Send IPAM check request")
+	_, err = spiderpoolAgentAPI.Daemonset.PostIpamCheck(params)
+	if nil != err {
+		var drift *daemonset.PostIpamCheckDrift
+		if errors.As(err, &drift) {
+			logger.Sugar().Errorf("IP allocation drift: %s", drift.Payload)
+			return types.NewError(ErrCodeIPAllocationDrift, "spiderpool IP allocation drift", string(drift.Payload))
+		}
+
+		err := fmt.Errorf("%w: %v", ErrCheckIPAM, err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Info("IPAM check successfully")
+	return nil
+}
+
+// getPrevResultIPs returns the IP addresses of the NIC in the prevResult of
+// the CNI network configuration.
+func getPrevResultIPs(conf *NetConf, ifName string) ([]string, error) {
+	if conf.RawPrevResult == nil {
+		return nil, fmt.Errorf("required prevResult missing")
+	}
+
+	rawPrevResult := make(map[string]interface{}, len(conf.RawPrevResult))
+	for k, v := range conf.RawPrevResult {
+		rawPrevResult[k] = v
+	}
+	if v, ok := rawPrevResult["cniVersion"].(string); ok {
+		rawPrevResult["cniVersion"] = resultVersion(v)
+	}
+
+	resultBytes, err := json.Marshal(rawPrevResult)
+	if err != nil {
+		return nil, err
+	}
+	res, err := version.NewResult(resultVersion(conf.CNIVersion), resultBytes)
+	if err != nil {
+		return nil, err
+	}
+	result, err := current.NewResultFromResult(res)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	for _, ip := range result.IPs {
+		if ip.Interface != nil && *ip.Interface >= 0 && *ip.Interface < len(result.Interfaces) &&
+			result.Interfaces[*ip.Interface].Name != ifName {
+			continue
+		}
+		ips = append(ips, ip.Address.String())
+	}
+
+	return ips, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/connectivity"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/openapi"
+)
+
+// CmdGC follows CNI SPEC 1.1 cmdGC.
+func CmdGC(args *skel.CmdArgs) (err error) {
+	var logger *zap.Logger
+
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Spiderpool IPAM CNI panicked during GC: %v", e)
+
+			if err != nil {
+				// If it is recovering and an error occurs, then we need to
+				// present both.
+				msg = fmt.Sprintf("%s: error=%v", msg, err.Error())
+			}
+
+			if nil != logger {
+				logger.Sugar().Errorf("%s\n\n%s", msg, debug.Stack())
+			}
+		}
+	}()
+
+	conf, err := LoadNetConf(args.StdinData)
+	if nil != err {
+		return fmt.Errorf("failed to load CNI network configuration: %v", err)
+	}
+
+	logger, err = setupFileLogging(conf)
+	if nil != err {
+		return fmt.Errorf("failed to setup file logging: %v", err)
+	}
+
+	logger = logger.Named(BinNamePlugin).With(
+		zap.String("Action", "GC"),
+	)
+	logger.Debug("Processing CNI GC request")
+	logger.Sugar().Debugf("CNI network configuration: %+v", *conf)
+
+	spiderpoolAgentAPI, err := openapi.NewAgentOpenAPIUnixClient(conf.IPAM.IPAMUnixSocketPath)
+	if nil != err {
+		err := fmt.Errorf("failed to create spiderpool-agent client: %w", err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Debug("Send health check request to spiderpool-agent backend")
+	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParams())
+	if nil != err {
+		err := fmt.Errorf("%w, failed to check: %v", ErrAgentHealthCheck, err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	validAttachments := make([]*models.IpamAttachment, 0, len(conf.ValidAttachments))
+	for _, a := range conf.ValidAttachments {
+		a := a
+		validAttachments = append(validAttachments, &models.IpamAttachment{
+			ContainerID: &a.ContainerID,
+			IfName:      &a.IfName,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	params := daemonset.NewPostIpamGcParams().
+		WithContext(ctx).
+		WithIpamGcArgs(&models.IpamGCArgs{
+			ValidAttachments: validAttachments,
+		})
+
+	logger.Sugar().Debugf("Send IPAM GC request with %d valid attachments", len(validAttachments))
+	_, err = spiderpoolAgentAPI.Daemonset.PostIpamGc(params)
+	if nil != err {
+		err := fmt.Errorf("%w: %v", ErrGCIPAM, err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Info("IPAM garbage collection successfully")
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/connectivity"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/openapi"
+)
+
+// CmdStatus follows CNI SPEC 1.1 cmdStatus.
+func CmdStatus(args *skel.CmdArgs) (err error) {
+	var logger *zap.Logger
+
+	// Defer a panic recover, so that in case we panic we can still return
+	// a proper error to the runtime.
+	defer func() {
+		if e := recover(); e != nil {
+			msg := fmt.Sprintf("Spiderpool IPAM CNI panicked during STATUS: %v", e)
+
+			if err != nil {
+				// If it is recovering and an error occurs, then we need to
+				// present both.
+				msg = fmt.Sprintf("%s: error=%v", msg, err.Error())
+			}
+
+			if nil != logger {
+				logger.Sugar().Errorf("%s\n\n%s", msg, debug.Stack())
+			}
+		}
+	}()
+
+	conf, err := LoadNetConf(args.StdinData)
+	if nil != err {
+		return fmt.Errorf("failed to load CNI network configuration: %v", err)
+	}
+
+	logger, err = setupFileLogging(conf)
+	if nil != err {
+		return fmt.Errorf("failed to setup file logging: %v", err)
+	}
+
+	logger = logger.Named(BinNamePlugin).With(
+		zap.String("Action", "STATUS"),
+	)
+	logger.Debug("Processing CNI STATUS request")
+	logger.Sugar().Debugf("CNI network configuration: %+v", *conf)
+
+	spiderpoolAgentAPI, err := openapi.NewAgentOpenAPIUnixClient(conf.IPAM.IPAMUnixSocketPath)
+	if nil != err {
+		err := fmt.Errorf("failed to create spiderpool-agent client: %w", err)
+		logger.Error(err.Error())
+		return err
+	}
+
+	logger.Debug("Send health check request to spiderpool-agent backend")
+	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParams())
+	if nil != err {
+		err := fmt.Errorf("%w, failed to check: %v", ErrAgentHealthCheck, err)
+		logger.Error(err.Error())
+		return types.NewError(ErrCodePluginNotAvailable, ErrAgentHealthCheck.Error(), err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	params := daemonset.NewPostIpamStatusParams().
+		WithContext(ctx).
+		WithIpamStatusArgs(&models.IpamStatusArgs{
+			DefaultIPV4IPPool: conf.IPAM.DefaultIPv4IPPool,
+			DefaultIPV6IPPool: conf.IPAM.DefaultIPv6IPPool,
+		})
+
+	logger.Debug("Send IPAM status request")
+	_, err = spiderpoolAgentAPI.Daemonset.PostIpamStatus(params)
+	if nil != err {
+		logger.Error(err.Error())
+
+		var unavailable *daemonset.PostIpamStatusUnavailable
+		if errors.As(err, &unavailable) {
+			return types.NewError(ErrCodePluginNotAvailable, "no usable IPPool", string(unavailable.Payload))
+		}
+		return types.NewError(ErrCodePluginNotAvailable, "failed to get the status of spiderpool-agent", err.Error())
+	}
+
+	logger.Debug("IPAM is available")
+	return nil
+}
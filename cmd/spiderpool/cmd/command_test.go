@@ -5,6 +5,7 @@ package cmd_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -40,6 +41,9 @@ const CNILogFilePath = "/tmp/spiderpool.log"
 const (
 	healthCheckRoute = "/v1/ipam/healthy"
 	ipamReqRoute     = "/v1/ipam/ip"
+	ipamCheckRoute   = "/v1/ipam/check"
+	ipamStatusRoute  = "/v1/ipam/status"
+	ipamGCRoute      = "/v1/ipam/gc"
 )
 
 const CNIVersion010 = "0.1.0"
@@ -402,6 +406,155 @@ var _ = Describe("spiderpool plugin", Label("unittest", "ipam_plugin_test"), fun
 				return args
 			}),
 		)
+
+		DescribeTable("test cmdCheck",
+			func(checkCode int, cmdArgs func() *skel.CmdArgs, expectIPs []string, expectErr func(err error)) {
+				// GET /v1/ipam/healthy
+				server.RouteToHandler(http.MethodGet, healthCheckRoute, ghttp.CombineHandlers(getHealthHandleFunc(true)))
+
+				// POST /v1/ipam/check
+				var checkArgs models.IpamCheckArgs
+				var ipamCheckHandleFunc http.HandlerFunc
+				switch checkCode {
+				case daemonset.PostIpamCheckOKCode:
+					ipamCheckHandleFunc = ghttp.RespondWith(checkCode, nil)
+				default:
+					ipamCheckHandleFunc = ghttp.RespondWithJSONEncoded(checkCode, models.Error("IP allocation drift"))
+				}
+				server.RouteToHandler(http.MethodPost, ipamCheckRoute, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(json.NewDecoder(r.Body).Decode(&checkArgs)).To(Succeed())
+					},
+					ipamCheckHandleFunc,
+				))
+
+				// start client test
+				err := testutils.CmdCheckWithArgs(cmdArgs(), func() error {
+					return cmd.CmdCheck(cmdArgs())
+				})
+
+				if expectErr != nil {
+					Expect(err).To(HaveOccurred())
+					expectErr(err)
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+				Expect(checkArgs.Ips).To(ConsistOf(expectIPs))
+			},
+			Entry("checks addresses with CHECK successfully", daemonset.PostIpamCheckOKCode, func() *skel.CmdArgs {
+				setPrevResult()
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+				args.StdinData = netConfBytes
+				return args
+			}, []string{"10.6.1.10/24", "fd00:10:6::10/64"}, nil),
+			Entry("returning a CNI error on IP allocation drift with CHECK", daemonset.PostIpamCheckDriftCode, func() *skel.CmdArgs {
+				setPrevResult()
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+				args.StdinData = netConfBytes
+				return args
+			}, nil, func(err error) {
+				var cniErr *types.Error
+				Expect(errors.As(err, &cniErr)).To(BeTrue())
+				Expect(cniErr.Code).To(Equal(cmd.ErrCodeIPAllocationDrift))
+			}),
+			Entry("failed to check addresses with bad spiderpool agent response", daemonset.PostIpamCheckFailureCode, func() *skel.CmdArgs {
+				setPrevResult()
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+				args.StdinData = netConfBytes
+				return args
+			}, nil, func(err error) {
+				Expect(err).To(MatchError(cmd.ErrCheckIPAM))
+			}),
+			Entry("returning an error on missing prevResult with CHECK", daemonset.PostIpamCheckOKCode, func() *skel.CmdArgs {
+				netConf.CNIVersion = cmd.CniVersion040
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+				args.StdinData = netConfBytes
+				return args
+			}, nil, func(err error) {
+				Expect(err.Error()).To(ContainSubstring("prevResult"))
+			}),
+		)
+
+		DescribeTable("test cmdStatus",
+			func(isHealthy bool, statusCode int, expectErrCode uint) {
+				// GET /v1/ipam/healthy
+				server.RouteToHandler(http.MethodGet, healthCheckRoute, ghttp.CombineHandlers(getHealthHandleFunc(isHealthy)))
+
+				// POST /v1/ipam/status
+				var statusArgs models.IpamStatusArgs
+				var ipamStatusHandleFunc http.HandlerFunc
+				if statusCode == daemonset.PostIpamStatusOKCode {
+					ipamStatusHandleFunc = ghttp.RespondWith(statusCode, nil)
+				} else {
+					ipamStatusHandleFunc = ghttp.RespondWithJSONEncoded(statusCode, models.Error("no IPPool available"))
+				}
+				server.RouteToHandler(http.MethodPost, ipamStatusRoute, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(json.NewDecoder(r.Body).Decode(&statusArgs)).To(Succeed())
+					},
+					ipamStatusHandleFunc,
+				))
+
+				netConf.CNIVersion = cmd.CniVersion110
+				netConf.IPAM.DefaultIPv4IPPool = []string{"default-v4-ippool"}
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = cmd.CmdStatus(&skel.CmdArgs{StdinData: netConfBytes})
+				if expectErrCode == 0 {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(statusArgs.DefaultIPV4IPPool).To(Equal(netConf.IPAM.DefaultIPv4IPPool))
+					return
+				}
+
+				var cniErr *types.Error
+				Expect(errors.As(err, &cniErr)).To(BeTrue())
+				Expect(cniErr.Code).To(Equal(expectErrCode))
+			},
+			Entry("is available with STATUS", true, daemonset.PostIpamStatusOKCode, uint(0)),
+			Entry("is not available on bad health check with STATUS", false, daemonset.PostIpamStatusOKCode, cmd.ErrCodePluginNotAvailable),
+			Entry("is not available without usable IPPools with STATUS", true, daemonset.PostIpamStatusUnavailableCode, cmd.ErrCodePluginNotAvailable),
+			Entry("is not available with bad spiderpool agent response", true, daemonset.PostIpamStatusFailureCode, cmd.ErrCodePluginNotAvailable),
+		)
+
+		DescribeTable("test cmdGC",
+			func(gcCode int, expectErr error) {
+				// GET /v1/ipam/healthy
+				server.RouteToHandler(http.MethodGet, healthCheckRoute, ghttp.CombineHandlers(getHealthHandleFunc(true)))
+
+				// POST /v1/ipam/gc
+				var gcArgs models.IpamGCArgs
+				server.RouteToHandler(http.MethodPost, ipamGCRoute, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						Expect(json.NewDecoder(r.Body).Decode(&gcArgs)).To(Succeed())
+					},
+					ghttp.RespondWith(gcCode, nil),
+				))
+
+				netConf.CNIVersion = cmd.CniVersion110
+				netConf.ValidAttachments = []cmd.Attachment{
+					{ContainerID: containerID, IfName: ifName},
+				}
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = cmd.CmdGC(&skel.CmdArgs{StdinData: netConfBytes})
+				if expectErr != nil {
+					Expect(err).To(MatchError(expectErr))
+					return
+				}
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gcArgs.ValidAttachments).To(HaveLen(1))
+				Expect(*gcArgs.ValidAttachments[0].ContainerID).To(Equal(containerID))
+				Expect(*gcArgs.ValidAttachments[0].IfName).To(Equal(ifName))
+			},
+			Entry("garbage collects addresses with GC successfully", daemonset.PostIpamGcOKCode, nil),
+			Entry("failed to garbage collect addresses with bad spiderpool agent response", daemonset.PostIpamGcFailureCode, cmd.ErrGCIPAM),
+		)
 	})
 
 	Describe("test ipam plugin configuration ", func() {
@@ -562,6 +715,24 @@ var _ = Describe("spiderpool plugin", Label("unittest", "ipam_plugin_test"), fun
 
 })
 
+// setPrevResult sets a prevResult with IP addresses of NIC eth0 and another
+// NIC to the CNI network configuration.
+func setPrevResult() {
+	netConf.CNIVersion = cmd.CniVersion040
+	netConf.RawPrevResult = map[string]interface{}{
+		"cniVersion": cmd.CniVersion040,
+		"interfaces": []interface{}{
+			map[string]interface{}{"name": ifName, "sandbox": nsPath},
+			map[string]interface{}{"name": "net1", "sandbox": nsPath},
+		},
+		"ips": []interface{}{
+			map[string]interface{}{"version": "4", "address": "10.6.1.10/24", "interface": 0},
+			map[string]interface{}{"version": "6", "address": "fd00:10:6::10/64"},
+			map[string]interface{}{"version": "4", "address": "10.7.1.10/24", "interface": 1},
+		},
+	}
+}
+
 func getHealthHandleFunc(isHealthy bool) http.HandlerFunc {
 	var healthHandleFunc http.HandlerFunc
 
@@ -0,0 +1,89 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/containernetworking/cni/pkg/skel"
+	"github.com/containernetworking/cni/pkg/types"
+	"github.com/containernetworking/cni/pkg/version"
+)
+
+// The commands added by CNI SPEC 1.1, which the CNI skel in use does not
+// dispatch yet.
+const (
+	cniCommandStatus = "STATUS"
+	cniCommandGC     = "GC"
+)
+
+// PluginMain dispatches the STATUS and GC commands of CNI SPEC 1.1 to
+// cmdStatus and cmdGC, and hands over the others to skel.PluginMain.
+func PluginMain(cmdAdd, cmdCheck, cmdDel, cmdStatus, cmdGC func(_ *skel.CmdArgs) error, versionInfo version.PluginInfo, about string) {
+	var cmdFunc func(_ *skel.CmdArgs) error
+	switch os.Getenv("CNI_COMMAND") {
+	case cniCommandStatus:
+		cmdFunc = cmdStatus
+	case cniCommandGC:
+		cmdFunc = cmdGC
+	default:
+		skel.PluginMain(cmdAdd, cmdCheck, cmdDel, versionInfo, about)
+		return
+	}
+
+	if e := pluginMainCNI11(cmdFunc, versionInfo); e != nil {
+		if err := e.Print(); err != nil {
+			log.Print("Error writing error JSON to stdout: ", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// pluginMainCNI11 reads the CNI network configuration from stdin, checks
+// whether its version allows the command, and calls cmdFunc.
+func pluginMainCNI11(cmdFunc func(_ *skel.CmdArgs) error, versionInfo version.PluginInfo) *types.Error {
+	stdinData, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return types.NewError(types.ErrIOFailure, fmt.Sprintf("error reading from stdin: %v", err), "")
+	}
+
+	configVersion, err := (&version.ConfigDecoder{}).Decode(stdinData)
+	if err != nil {
+		return types.NewError(types.ErrDecodingFailure, err.Error(), "")
+	}
+	if gtet, err := version.GreaterThanOrEqualTo(configVersion, CniVersion110); err != nil {
+		return types.NewError(types.ErrDecodingFailure, err.Error(), "")
+	} else if !gtet {
+		return types.NewError(types.ErrIncompatibleCNIVersion, fmt.Sprintf("config version %s does not allow %s", configVersion, os.Getenv("CNI_COMMAND")), "")
+	}
+
+	supported := false
+	for _, v := range versionInfo.SupportedVersions() {
+		if v == configVersion {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return types.NewError(types.ErrIncompatibleCNIVersion, "incompatible CNI versions", fmt.Sprintf("config is %q, plugin supports %q", configVersion, versionInfo.SupportedVersions()))
+	}
+
+	args := &skel.CmdArgs{
+		Path:      os.Getenv("CNI_PATH"),
+		StdinData: stdinData,
+	}
+	if err := cmdFunc(args); err != nil {
+		var e *types.Error
+		if errors.As(err, &e) {
+			return e
+		}
+		return types.NewError(types.ErrInternal, err.Error(), "")
+	}
+
+	return nil
+}
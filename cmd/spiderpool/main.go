@@ -4,7 +4,6 @@
 package main
 
 import (
-	"github.com/containernetworking/cni/pkg/skel"
 	cniSpecVersion "github.com/containernetworking/cni/pkg/version"
 	"github.com/spidernet-io/spiderpool/cmd/spiderpool/cmd"
 )
@@ -13,11 +12,7 @@ import (
 var version string
 
 func main() {
-	skel.PluginMain(cmd.CmdAdd, cmdCheck, cmd.CmdDel,
+	cmd.PluginMain(cmd.CmdAdd, cmd.CmdCheck, cmd.CmdDel, cmd.CmdStatus, cmd.CmdGC,
 		cniSpecVersion.PluginSupports(cmd.SupportCNIVersions...),
 		"Spiderpool IPAM "+version)
 }
-
-func cmdCheck(args *skel.CmdArgs) error {
-	return nil
-}
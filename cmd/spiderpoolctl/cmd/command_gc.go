@@ -4,7 +4,14 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
 	"github.com/spf13/cobra"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // gcCmd represents the gc command.
@@ -12,11 +19,59 @@ var gcCmd = &cobra.Command{
 	Use:   "gc",
 	Short: "spiderpool gc",
 	Long:  `trigger GC request to spiderpool-controller`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("This is spiderpool ctl gc...")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		ipPool, _ := flags.GetString("ippool")
+		namespace, _ := flags.GetString("namespace")
+		ip, _ := flags.GetString("ip")
+		dryRun, _ := flags.GetBool("dry-run")
+
+		gcArgs := &models.IpamGcArgs{
+			IPPool:    ipPool,
+			Namespace: namespace,
+			IP:        ip,
+			DryRun:    dryRun,
+		}
+		resp, err := newControllerClient().Controller.PostIpamGcIps(controller.NewPostIpamGcIpsParams().WithGcArgs(gcArgs))
+		if err != nil {
+			return fmt.Errorf("failed to trigger GC: %w", err)
+		}
+
+		if ipPool == "" && namespace == "" && ip == "" && !dryRun {
+			fmt.Println("Global GC is triggered")
+			return nil
+		}
+
+		if len(resp.Payload.Ips) == 0 {
+			fmt.Println("No IP needs to be released")
+			return nil
+		}
+
+		if dryRun {
+			fmt.Println("IPs to be released:")
+		} else {
+			fmt.Println("IPs released:")
+		}
+
+		return printGCRecords(resp.Payload.Ips)
 	},
 }
 
+func printGCRecords(records []*models.IpamGcRecord) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IP\tIPPOOL\tPOD\tPOD-UID\tREASON")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", *r.IP, *r.IPPool, r.Pod, r.PodUID, r.Reason)
+	}
+
+	return w.Flush()
+}
+
 func init() {
+	gcCmd.PersistentFlags().String("ippool", "", "[optional] only gc the ips of this ippool")
+	gcCmd.PersistentFlags().String("namespace", "", "[optional] only gc the ips of the pods in this namespace")
+	gcCmd.PersistentFlags().String("ip", "", "[optional] only gc this ip")
+	gcCmd.PersistentFlags().Bool("dry-run", false, "[optional] only list the ips to be released without releasing them")
+
 	rootCmd.AddCommand(gcCmd)
 }
@@ -4,7 +4,14 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
 	"github.com/spf13/cobra"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // ipCmd represents the base command.
@@ -19,8 +26,27 @@ var ipShowCmd = &cobra.Command{
 	Use:   "show",
 	Short: "show ip related data",
 	Long:  `show pod who is taking this ip`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("This is spiderpool ctl ip show...")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ip, err := cmd.Flags().GetString("ip")
+		if err != nil {
+			return err
+		}
+
+		params := controller.NewGetIpamStatusParams()
+		if ip != "" {
+			params.SetIP(&ip)
+		}
+		resp, err := newControllerClient().Controller.GetIpamStatus(params)
+		if err != nil {
+			return fmt.Errorf("failed to get IPAM status: %w", err)
+		}
+
+		if ip != "" && len(resp.Payload.Ips) == 0 {
+			fmt.Printf("IP %s is not allocated by any IPPool\n", ip)
+			return nil
+		}
+
+		return printIPStatuses(resp.Payload.Ips)
 	},
 }
 
@@ -29,8 +55,26 @@ var ipReleaseCmd = &cobra.Command{
 	Use:   "release",
 	Short: "try to release ip",
 	Long:  `try to release ip and other related data`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("This is spiderpool ctl ip release...")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ip, err := cmd.Flags().GetString("ip")
+		if err != nil {
+			return err
+		}
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			return err
+		}
+
+		params := controller.NewDeleteIpamIPParams().WithIPReleaseArgs(&models.IPReleaseArgs{
+			IP:    &ip,
+			Force: force,
+		})
+		if _, err := newControllerClient().Controller.DeleteIpamIP(params); err != nil {
+			return fmt.Errorf("failed to release IP %s: %w", ip, err)
+		}
+
+		fmt.Printf("IP %s is released\n", ip)
+		return nil
 	},
 }
 
@@ -39,11 +83,47 @@ var ipSetCmd = &cobra.Command{
 	Use:   "set",
 	Short: "set ip to be taken by a pod",
 	Long:  `set ip to be taken by a pod , this will update ippool and workloadendpoint resource`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("This is spiderpool ctl ip set...")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		ip, _ := flags.GetString("ip")
+		pod, _ := flags.GetString("pod")
+		namespace, _ := flags.GetString("namespace")
+		containerID, _ := flags.GetString("containerid")
+		node, _ := flags.GetString("node")
+		nic, _ := flags.GetString("interface")
+
+		setArgs := &models.IPSetArgs{
+			IP:           &ip,
+			PodName:      &pod,
+			PodNamespace: &namespace,
+			ContainerID:  &containerID,
+			Node:         &node,
+			IfName:       &nic,
+		}
+		params := controller.NewPutIpamIPParams().WithIPSetArgs(setArgs)
+		if _, err := newControllerClient().Controller.PutIpamIP(params); err != nil {
+			return fmt.Errorf("failed to set IP %s: %w", *setArgs.IP, err)
+		}
+
+		fmt.Printf("IP %s is set to Pod %s/%s\n", *setArgs.IP, *setArgs.PodNamespace, *setArgs.PodName)
+		return nil
 	},
 }
 
+func printIPStatuses(ipStatuses []*models.IPStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IP\tIPPOOL\tPOD\tPOD-UID\tENDPOINT-UID\tNODE\tINTERFACE\tOWNER")
+	for _, s := range ipStatuses {
+		owner := ""
+		if s.OwnerControllerType != "" {
+			owner = fmt.Sprintf("%s/%s", s.OwnerControllerType, s.OwnerControllerName)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", *s.IP, *s.IPPool, s.Pod, s.PodUID, s.EndpointUID, s.Node, s.Nic, owner)
+	}
+
+	return w.Flush()
+}
+
 func init() {
 	// show flags
 	ipShowCmd.PersistentFlags().String("ip", "", "[optional] ip")
@@ -4,16 +4,27 @@
 package cmd
 
 import (
+	"net"
+	"os"
+
+	"github.com/go-openapi/strfmt"
 	"github.com/spf13/cobra"
 
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/utils/cmdgenmd"
 )
 
 const SPIDERPOOL_CTL = "spiderpoolctl"
 
+// defaultControllerPort is the default HTTP port of spiderpool-controller,
+// which could be overwritten by the env SPIDERPOOL_HEALTH_PORT.
+const defaultControllerPort = "5720"
+
 var logger = logutils.Logger.Named(SPIDERPOOL_CTL)
 
+var controllerAddress string
+
 // rootCmd represents the base command.
 var rootCmd = &cobra.Command{
 	Use:   "spiderpoolctl",
@@ -29,7 +40,23 @@ func Execute() {
 	}
 }
 
+// newControllerClient creates a spiderpool-controller OpenAPI client with the
+// address specified by flag, or the local controller address by default.
+func newControllerClient() *controllerOpenAPIClient.SpiderpoolControllerAPI {
+	address := controllerAddress
+	if address == "" {
+		port, ok := os.LookupEnv("SPIDERPOOL_HEALTH_PORT")
+		if !ok || port == "" {
+			port = defaultControllerPort
+		}
+		address = net.JoinHostPort("127.0.0.1", port)
+	}
+
+	return controllerOpenAPIClient.NewHTTPClientWithConfig(strfmt.Default, controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+}
+
 func init() {
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.PersistentFlags().StringVar(&controllerAddress, "address", "", "[optional] address for spiderpool-controller (default to the local spiderpool-controller)")
 	rootCmd.AddCommand(cmdgenmd.GenMarkDownCmd(SPIDERPOOL_CTL, rootCmd, logger))
 }
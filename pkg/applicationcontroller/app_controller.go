@@ -9,21 +9,33 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8types "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
 	batchlisters "k8s.io/client-go/listers/batch/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -36,15 +48,28 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 var logger *zap.Logger
 
+// The third-party applications are watched with the dynamic informers, only if their CRDs are installed.
+var (
+	cloneSetGVR            = kruisev1alpha1.SchemeGroupVersion.WithResource("clonesets")
+	advancedStatefulSetGVR = kruisev1beta1.SchemeGroupVersion.WithResource("statefulsets")
+	rolloutGVR             = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+)
+
+// unservedResourcesRecheckInterval is the interval to check whether the CRDs of the unwatched third-party applications
+// are installed.
+var unservedResourcesRecheckInterval = time.Minute
+
 type SubnetAppController struct {
 	client    client.Client
 	apiReader client.Reader
 
 	subnetMgr     subnetmanager.SubnetManager
+	workloadKinds workloadkind.Registry
 	workQueue     workqueue.RateLimitingInterface
 	appController *applicationinformers.Controller
 
@@ -66,6 +91,22 @@ type SubnetAppController struct {
 	cronJobLister   batchlisters.CronJobLister
 	cronJobInformer cache.SharedIndexInformer
 
+	hpaLister   autoscalinglisters.HorizontalPodAutoscalerLister
+	hpaInformer cache.SharedIndexInformer
+
+	cloneSetLister   cache.GenericLister
+	cloneSetInformer cache.SharedIndexInformer
+
+	advancedStatefulSetLister   cache.GenericLister
+	advancedStatefulSetInformer cache.SharedIndexInformer
+
+	rolloutLister   cache.GenericLister
+	rolloutInformer cache.SharedIndexInformer
+
+	// the listers of the custom workloads declared in the configmap, keyed by their API version and kind
+	customWorkloadListers   map[schema.GroupVersionKind]cache.GenericLister
+	customWorkloadInformers []cache.SharedIndexInformer
+
 	SubnetAppControllerConfig
 }
 
@@ -79,13 +120,18 @@ type SubnetAppControllerConfig struct {
 	LeaderRetryElectGap           time.Duration
 }
 
-func NewSubnetAppController(client client.Client, apiReader client.Reader, subnetMgr subnetmanager.SubnetManager, subnetAppControllerConfig SubnetAppControllerConfig) (*SubnetAppController, error) {
+func NewSubnetAppController(client client.Client, apiReader client.Reader, subnetMgr subnetmanager.SubnetManager, workloadKinds workloadkind.Registry, subnetAppControllerConfig SubnetAppControllerConfig) (*SubnetAppController, error) {
 	logger = logutils.Logger.Named("SpiderSubnet-Application-Controllers")
 
+	if workloadKinds == nil {
+		return nil, fmt.Errorf("workload kind registry %w", constant.ErrMissingRequiredParam)
+	}
+
 	c := &SubnetAppController{
 		client:                    client,
 		apiReader:                 apiReader,
 		subnetMgr:                 subnetMgr,
+		workloadKinds:             workloadKinds,
 		SubnetAppControllerConfig: subnetAppControllerConfig,
 	}
 
@@ -98,7 +144,7 @@ func NewSubnetAppController(client client.Client, apiReader client.Reader, subne
 	return c, nil
 }
 
-func (sac *SubnetAppController) SetupInformer(ctx context.Context, client kubernetes.Interface, leader election.SpiderLeaseElector) error {
+func (sac *SubnetAppController) SetupInformer(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, leader election.SpiderLeaseElector) error {
 	if leader == nil {
 		return fmt.Errorf("failed to start SpiderSubnet App informer, controller leader must be specified")
 	}
@@ -143,7 +189,16 @@ func (sac *SubnetAppController) SetupInformer(ctx context.Context, client kubern
 				continue
 			}
 
+			dynamicFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+			unserved, err := sac.addThirdPartyEventHandlers(client.Discovery(), dynamicFactory)
+			if nil != err {
+				logger.Error(err.Error())
+				continue
+			}
+			go watchUnservedResources(innerCtx, innerCancel, client.Discovery(), unserved)
+
 			factory.Start(innerCtx.Done())
+			dynamicFactory.Start(innerCtx.Done())
 			err = sac.Run(innerCtx.Done())
 			if nil != err {
 				logger.Sugar().Errorf("failed to run SpiderSubnet App controller, error: %v", err)
@@ -198,6 +253,13 @@ func (sac *SubnetAppController) addEventHandlers(factory kubeinformers.SharedInf
 		return err
 	}
 
+	sac.hpaLister = factory.Autoscaling().V2().HorizontalPodAutoscalers().Lister()
+	sac.hpaInformer = factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+	err = sac.appController.AddHPAHandler(sac.hpaInformer, sac.hpaHandler())
+	if nil != err {
+		return err
+	}
+
 	// Once we lost the leader but get leader later, we have to use a new workqueue.
 	// Because the former workqueue was already shut down and wouldn't be re-start forever.
 	sac.workQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Application-Controllers")
@@ -205,6 +267,159 @@ func (sac *SubnetAppController) addEventHandlers(factory kubeinformers.SharedInf
 	return nil
 }
 
+// addThirdPartyEventHandlers registers the informers of the OpenKruise CloneSet, Advanced StatefulSet, the Argo Rollout
+// and the custom workloads declared in the configmap. The ones whose CRD is not installed are skipped, they would be
+// registered once their CRDs are installed, see watchUnservedResources.
+func (sac *SubnetAppController) addThirdPartyEventHandlers(discoveryClient discovery.DiscoveryInterface, dynamicFactory dynamicinformer.DynamicSharedInformerFactory) (unservedResources, error) {
+	var unserved unservedResources
+
+	for _, item := range []struct {
+		gvr        schema.GroupVersionResource
+		lister     *cache.GenericLister
+		informer   *cache.SharedIndexInformer
+		addHandler func(cache.SharedIndexInformer) error
+	}{
+		{cloneSetGVR, &sac.cloneSetLister, &sac.cloneSetInformer, sac.appController.AddCloneSetHandler},
+		{advancedStatefulSetGVR, &sac.advancedStatefulSetLister, &sac.advancedStatefulSetInformer, sac.appController.AddAdvancedStatefulSetHandler},
+		{rolloutGVR, &sac.rolloutLister, &sac.rolloutInformer, sac.appController.AddRolloutHandler},
+	} {
+		*item.lister, *item.informer = nil, nil
+
+		served, err := isResourceServed(discoveryClient, item.gvr)
+		if nil != err {
+			return unservedResources{}, fmt.Errorf("failed to discover resource %s: %w", item.gvr, err)
+		}
+		if !served {
+			logger.Sugar().Debugf("resource %s is not served, skip watching it", item.gvr)
+			unserved.resources = append(unserved.resources, item.gvr)
+			continue
+		}
+
+		informer := dynamicFactory.ForResource(item.gvr)
+		err = item.addHandler(informer.Informer())
+		if nil != err {
+			return unservedResources{}, err
+		}
+		*item.lister, *item.informer = informer.Lister(), informer.Informer()
+	}
+
+	sac.customWorkloadListers = map[schema.GroupVersionKind]cache.GenericLister{}
+	sac.customWorkloadInformers = nil
+	for _, cw := range sac.workloadKinds.CustomWorkloads() {
+		gvk := schema.FromAPIVersionAndKind(cw.APIVersion, cw.Kind)
+		gvr, served, err := resourceOfKind(discoveryClient, gvk)
+		if nil != err {
+			return unservedResources{}, fmt.Errorf("failed to discover the resource of %s: %w", gvk, err)
+		}
+		if !served {
+			logger.Sugar().Debugf("kind %s is not served, skip watching it", gvk)
+			unserved.kinds = append(unserved.kinds, gvk)
+			continue
+		}
+
+		informer := dynamicFactory.ForResource(gvr)
+		err = sac.appController.AddCustomWorkloadHandler(informer.Informer())
+		if nil != err {
+			return unservedResources{}, err
+		}
+		sac.customWorkloadListers[gvk] = informer.Lister()
+		sac.customWorkloadInformers = append(sac.customWorkloadInformers, informer.Informer())
+	}
+
+	return unserved, nil
+}
+
+// unservedResources records the third-party resources and the custom workload kinds skipped by
+// addThirdPartyEventHandlers, because their CRDs were not installed.
+type unservedResources struct {
+	resources []schema.GroupVersionResource
+	kinds     []schema.GroupVersionKind
+}
+
+// anyServed checks whether any of the unserved resources is served by the API server now.
+func (u unservedResources) anyServed(discoveryClient discovery.DiscoveryInterface) (bool, error) {
+	for _, gvr := range u.resources {
+		served, err := isResourceServed(discoveryClient, gvr)
+		if nil != err {
+			return false, fmt.Errorf("failed to discover resource %s: %w", gvr, err)
+		}
+		if served {
+			return true, nil
+		}
+	}
+
+	for _, gvk := range u.kinds {
+		_, served, err := resourceOfKind(discoveryClient, gvk)
+		if nil != err {
+			return false, fmt.Errorf("failed to discover the resource of %s: %w", gvk, err)
+		}
+		if served {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// watchUnservedResources checks the unserved resources periodically, and calls cancel to restart the informers once
+// any of their CRDs is installed, so that it would be watched without waiting for the next leader election.
+func watchUnservedResources(ctx context.Context, cancel context.CancelFunc, discoveryClient discovery.DiscoveryInterface, unserved unservedResources) {
+	if len(unserved.resources) == 0 && len(unserved.kinds) == 0 {
+		return
+	}
+
+	wait.Until(func() {
+		served, err := unserved.anyServed(discoveryClient)
+		if nil != err {
+			logger.Sugar().Warnf("failed to check the unserved third-party resources: %v", err)
+			return
+		}
+		if served {
+			logger.Info("new third-party resources are served, restart SpiderSubnet App informer to watch them")
+			cancel()
+		}
+	}, unservedResourcesRecheckInterval, ctx.Done())
+}
+
+// isResourceServed checks whether the API server serves the given resource.
+func isResourceServed(discoveryClient discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	resourceList, err := discoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if nil != err {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, resource := range resourceList.APIResources {
+		if resource.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// resourceOfKind finds the resource of the given kind served by the API server.
+func resourceOfKind(discoveryClient discovery.DiscoveryInterface, gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool, error) {
+	resourceList, err := discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if nil != err {
+		if apierrors.IsNotFound(err) {
+			return schema.GroupVersionResource{}, false, nil
+		}
+		return schema.GroupVersionResource{}, false, err
+	}
+
+	for _, resource := range resourceList.APIResources {
+		// skip the subresources, such as 'statefulsets/scale'
+		if resource.Kind == gvk.Kind && !strings.Contains(resource.Name, "/") {
+			return gvk.GroupVersion().WithResource(resource.Name), true, nil
+		}
+	}
+
+	return schema.GroupVersionResource{}, false, nil
+}
+
 // controllerAddOrUpdateHandler serves for kubernetes original controller applications(such as: Deployment,ReplicaSet,Job...),
 // to create a new IPPool or scale the IPPool
 func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinformers.AppInformersAddOrUpdateFunc {
@@ -228,7 +443,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = applicationinformers.GetAppReplicas(newObject.Spec.Replicas)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -244,7 +458,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldDeployment := oldObj.(*appsv1.Deployment)
-				oldAppReplicas = applicationinformers.GetAppReplicas(oldDeployment.Spec.Replicas)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldDeployment.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
@@ -268,7 +481,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = applicationinformers.GetAppReplicas(newObject.Spec.Replicas)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -284,7 +496,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldReplicaSet := oldObj.(*appsv1.ReplicaSet)
-				oldAppReplicas = applicationinformers.GetAppReplicas(oldReplicaSet.Spec.Replicas)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldReplicaSet.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
@@ -301,7 +512,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = applicationinformers.GetAppReplicas(newObject.Spec.Replicas)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -317,7 +527,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldStatefulSet := oldObj.(*appsv1.StatefulSet)
-				oldAppReplicas = applicationinformers.GetAppReplicas(oldStatefulSet.Spec.Replicas)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldStatefulSet.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
@@ -341,7 +550,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = applicationinformers.CalculateJobPodNum(newObject.Spec.Parallelism, newObject.Spec.Completions)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -357,7 +565,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldJob := oldObj.(*batchv1.Job)
-				oldAppReplicas = applicationinformers.CalculateJobPodNum(oldJob.Spec.Parallelism, oldJob.Spec.Completions)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldJob.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
@@ -374,7 +581,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = applicationinformers.CalculateJobPodNum(newObject.Spec.JobTemplate.Spec.Parallelism, newObject.Spec.JobTemplate.Spec.Completions)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.JobTemplate.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -390,7 +596,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldCronJob := oldObj.(*batchv1.CronJob)
-				oldAppReplicas = applicationinformers.CalculateJobPodNum(oldCronJob.Spec.JobTemplate.Spec.Parallelism, oldCronJob.Spec.JobTemplate.Spec.Completions)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldCronJob.Spec.JobTemplate.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
@@ -407,7 +612,6 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 				return nil
 			}
 
-			newAppReplicas = int(newObject.Status.DesiredNumberScheduled)
 			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
 			if nil != err {
 				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
@@ -423,17 +627,135 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 
 			if oldObj != nil {
 				oldDaemonSet := oldObj.(*appsv1.DaemonSet)
-				oldAppReplicas = int(oldDaemonSet.Status.DesiredNumberScheduled)
 				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldDaemonSet.Spec.Template.Annotations, log)
 				if nil != err {
 					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
 				}
 			}
 
+		case *kruisev1alpha1.CloneSet:
+			appKind = constant.KindCloneSet
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
+
+			// no need reconcile for HostNetwork application
+			if newObject.Spec.Template.Spec.HostNetwork {
+				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
+				return nil
+			}
+
+			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
+			if nil != err {
+				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
+			}
+
+			// default IPAM mode
+			if applicationinformers.IsDefaultIPPoolMode(newSubnetConfig) {
+				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
+				return nil
+			}
+
+			app = newObject.DeepCopy()
+
+			if oldObj != nil {
+				oldCloneSet := oldObj.(*kruisev1alpha1.CloneSet)
+				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldCloneSet.Spec.Template.Annotations, log)
+				if nil != err {
+					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
+				}
+			}
+
+		case *kruisev1beta1.StatefulSet:
+			appKind = constant.KindStatefulSet
+			log = log.With(zap.String("AdvancedStatefulSet", fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
+
+			// no need reconcile for HostNetwork application
+			if newObject.Spec.Template.Spec.HostNetwork {
+				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
+				return nil
+			}
+
+			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
+			if nil != err {
+				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
+			}
+
+			// default IPAM mode
+			if applicationinformers.IsDefaultIPPoolMode(newSubnetConfig) {
+				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
+				return nil
+			}
+
+			app = newObject.DeepCopy()
+
+			if oldObj != nil {
+				oldStatefulSet := oldObj.(*kruisev1beta1.StatefulSet)
+				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldStatefulSet.Spec.Template.Annotations, log)
+				if nil != err {
+					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
+				}
+			}
+
+		case *unstructured.Unstructured:
+			appKind = newObject.GetKind()
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
+
+			podTemplate, err := sac.unstructuredPodTemplate(newObject)
+			if nil != err {
+				return err
+			}
+
+			// no need reconcile for HostNetwork application
+			if podTemplate.Spec.HostNetwork {
+				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
+				return nil
+			}
+
+			newSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(podTemplate.Annotations, log)
+			if nil != err {
+				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
+			}
+
+			// default IPAM mode
+			if applicationinformers.IsDefaultIPPoolMode(newSubnetConfig) {
+				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
+				return nil
+			}
+
+			app = newObject.DeepCopy()
+
+			if oldObj != nil {
+				oldPodTemplate, err := sac.unstructuredPodTemplate(oldObj.(*unstructured.Unstructured))
+				if nil != err {
+					return err
+				}
+				oldSubnetConfig, err = applicationinformers.GetSubnetAnnoConfig(oldPodTemplate.Annotations, log)
+				if nil != err {
+					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
+				}
+			}
+
 		default:
 			return fmt.Errorf("unrecognized application: %+v", newObj)
 		}
 
+		// size the auto-created IPPools by the sizing policy
+		appNamespacedName := types.AppNamespacedName{
+			APIVersion: appAPIVersion(newObj),
+			Kind:       appKind,
+			Namespace:  app.GetNamespace(),
+			Name:       app.GetName(),
+		}
+		appWorkloadKind := sac.workloadKinds.Lookup(appNamespacedName.APIVersion, appKind)
+		if appWorkloadKind == nil {
+			return fmt.Errorf("unrecognized application: %s/%s", appNamespacedName.APIVersion, appKind)
+		}
+		newAppReplicas, _ = appWorkloadKind.DesiredReplicas(newObj)
+		newAppReplicas = sac.autoPoolReplicas(log, newSubnetConfig, appNamespacedName, newObj, newAppReplicas)
+		if oldObj != nil {
+			oldAppReplicas, _ = appWorkloadKind.DesiredReplicas(oldObj)
+			oldAppReplicas = sac.autoPoolReplicas(log, oldSubnetConfig, appNamespacedName, oldObj, oldAppReplicas)
+		}
+
 		ctx = logutils.IntoContext(ctx, log)
 		// check the difference between the two object and choose to reconcile or not
 		if hasSubnetConfigChanged(ctx, oldSubnetConfig, newSubnetConfig, oldAppReplicas, newAppReplicas) {
@@ -445,13 +767,44 @@ func (sac *SubnetAppController) controllerAddOrUpdateHandler() applicationinform
 	}
 }
 
+// unstructuredPodTemplate returns the pod template of the Argo Rollout or the custom workload declared in the configmap.
+func (sac *SubnetAppController) unstructuredPodTemplate(app *unstructured.Unstructured) (*corev1.PodTemplateSpec, error) {
+	if applicationinformers.IsArgoRollout(app.GetAPIVersion(), app.GetKind()) {
+		return applicationinformers.GetRolloutPodTemplate(app)
+	}
+
+	if cw := sac.workloadKinds.LookupCustom(app.GetAPIVersion(), app.GetKind()); cw != nil {
+		return cw.PodTemplate(app)
+	}
+
+	return nil, fmt.Errorf("%w: unrecognized application: %s/%s", constant.ErrWrongInput, app.GetAPIVersion(), app.GetKind())
+}
+
 // appWorkQueueKey involves application object meta namespaceKey and application kind
 type appWorkQueueKey struct {
 	MetaNamespaceKey string
+	AppAPIVersion    string
 	AppKind          string
 	AppUID           k8types.UID
 }
 
+// appAPIVersion returns the API version of the application, because the objects got from the typed informers
+// have no TypeMeta.
+func appAPIVersion(app interface{}) string {
+	switch object := app.(type) {
+	case *batchv1.Job, *batchv1.CronJob:
+		return batchv1.SchemeGroupVersion.String()
+	case *kruisev1alpha1.CloneSet:
+		return kruisev1alpha1.SchemeGroupVersion.String()
+	case *kruisev1beta1.StatefulSet:
+		return kruisev1beta1.SchemeGroupVersion.String()
+	case *unstructured.Unstructured:
+		return object.GetAPIVersion()
+	default:
+		return appsv1.SchemeGroupVersion.String()
+	}
+}
+
 // enqueueApp will insert application custom appWorkQueueKey to the workQueue
 func (sac *SubnetAppController) enqueueApp(ctx context.Context, obj interface{}, appKind string, appUID k8types.UID) {
 	log := logutils.FromContext(ctx)
@@ -465,6 +818,7 @@ func (sac *SubnetAppController) enqueueApp(ctx context.Context, obj interface{},
 
 	appKey := appWorkQueueKey{
 		MetaNamespaceKey: metaKey,
+		AppAPIVersion:    appAPIVersion(obj),
 		AppKind:          appKind,
 		AppUID:           appUID,
 	}
@@ -484,14 +838,25 @@ func (sac *SubnetAppController) Run(stopCh <-chan struct{}) error {
 	defer sac.workQueue.ShutDown()
 
 	logger.Debug("Waiting for application informers caches to sync")
-	ok := cache.WaitForCacheSync(stopCh,
+	cacheSyncs := []cache.InformerSynced{
 		sac.deploymentInformer.HasSynced,
 		sac.replicaSetInformer.HasSynced,
 		sac.daemonSetInformer.HasSynced,
 		sac.statefulSetInformer.HasSynced,
 		sac.jobInformer.HasSynced,
 		sac.cronJobInformer.HasSynced,
-	)
+		sac.hpaInformer.HasSynced,
+	}
+	// the third-party application informers are only registered if their CRDs are installed
+	for _, informer := range []cache.SharedIndexInformer{sac.cloneSetInformer, sac.advancedStatefulSetInformer, sac.rolloutInformer} {
+		if informer != nil {
+			cacheSyncs = append(cacheSyncs, informer.HasSynced)
+		}
+	}
+	for _, informer := range sac.customWorkloadInformers {
+		cacheSyncs = append(cacheSyncs, informer.HasSynced)
+	}
+	ok := cache.WaitForCacheSync(stopCh, cacheSyncs...)
 	if !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
@@ -582,7 +947,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 	var app metav1.Object
 	var subnetConfig *types.PodSubnetAnnoConfig
 	var podAnno map[string]string
-	var appReplicas int
 	var apiVersion string
 
 	switch appKey.AppKind {
@@ -597,7 +961,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = deployment.Spec.Template.Annotations
-		appReplicas = applicationinformers.GetAppReplicas(deployment.Spec.Replicas)
 		app = deployment.DeepCopy()
 		// deployment.APIVersion is empty string
 		apiVersion = appsv1.SchemeGroupVersion.String()
@@ -613,7 +976,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = replicaSet.Spec.Template.Annotations
-		appReplicas = applicationinformers.GetAppReplicas(replicaSet.Spec.Replicas)
 		app = replicaSet.DeepCopy()
 		// replicaSet.APIVersion is empty string
 		apiVersion = appsv1.SchemeGroupVersion.String()
@@ -629,12 +991,28 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = daemonSet.Spec.Template.Annotations
-		appReplicas = int(daemonSet.Status.DesiredNumberScheduled)
 		app = daemonSet.DeepCopy()
 		// daemonSet.APIVersion is empty string
 		apiVersion = appsv1.SchemeGroupVersion.String()
 
 	case constant.KindStatefulSet:
+		if applicationinformers.IsKruiseAdvancedStatefulSet(appKey.AppAPIVersion, appKey.AppKind) {
+			var statefulSet kruisev1beta1.StatefulSet
+			err := getThirdPartyApp(sac.advancedStatefulSetLister, namespace, name, &statefulSet)
+			if nil != err {
+				if apierrors.IsNotFound(err) {
+					log.Sugar().Debugf("application in work queue no longer exists")
+					return sac.deleteAutoPools(logutils.IntoContext(context.TODO(), log), appKey.AppUID)
+				}
+				return err
+			}
+
+			podAnno = statefulSet.Spec.Template.Annotations
+			app = &statefulSet
+			apiVersion = kruisev1beta1.SchemeGroupVersion.String()
+			break
+		}
+
 		statefulSet, err := sac.statefulSetLister.StatefulSets(namespace).Get(name)
 		if nil != err {
 			if apierrors.IsNotFound(err) {
@@ -645,7 +1023,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = statefulSet.Spec.Template.Annotations
-		appReplicas = applicationinformers.GetAppReplicas(statefulSet.Spec.Replicas)
 		app = statefulSet.DeepCopy()
 		// statefulSet.APIVersion is empty string
 		apiVersion = appsv1.SchemeGroupVersion.String()
@@ -661,7 +1038,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = job.Spec.Template.Annotations
-		appReplicas = applicationinformers.CalculateJobPodNum(job.Spec.Parallelism, job.Spec.Completions)
 		app = job.DeepCopy()
 		// job.APIVersion is empty string
 		apiVersion = batchv1.SchemeGroupVersion.String()
@@ -677,13 +1053,69 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		}
 
 		podAnno = cronJob.Spec.JobTemplate.Spec.Template.Annotations
-		appReplicas = applicationinformers.CalculateJobPodNum(cronJob.Spec.JobTemplate.Spec.Parallelism, cronJob.Spec.JobTemplate.Spec.Completions)
 		app = cronJob.DeepCopy()
 		// cronJob.APIVersion is empty string
 		apiVersion = batchv1.SchemeGroupVersion.String()
 
+	case constant.KindCloneSet:
+		var cloneSet kruisev1alpha1.CloneSet
+		err := getThirdPartyApp(sac.cloneSetLister, namespace, name, &cloneSet)
+		if nil != err {
+			if apierrors.IsNotFound(err) {
+				log.Sugar().Debugf("application in work queue no longer exists")
+				return sac.deleteAutoPools(logutils.IntoContext(context.TODO(), log), appKey.AppUID)
+			}
+			return err
+		}
+
+		podAnno = cloneSet.Spec.Template.Annotations
+		app = &cloneSet
+		apiVersion = kruisev1alpha1.SchemeGroupVersion.String()
+
+	case constant.KindRollout:
+		var rollout unstructured.Unstructured
+		err := getThirdPartyApp(sac.rolloutLister, namespace, name, &rollout)
+		if nil != err {
+			if apierrors.IsNotFound(err) {
+				log.Sugar().Debugf("application in work queue no longer exists")
+				return sac.deleteAutoPools(logutils.IntoContext(context.TODO(), log), appKey.AppUID)
+			}
+			return err
+		}
+
+		podTemplate, err := applicationinformers.GetRolloutPodTemplate(&rollout)
+		if nil != err {
+			return fmt.Errorf("%w: %v", constant.ErrWrongInput, err)
+		}
+
+		podAnno = podTemplate.Annotations
+		app = &rollout
+		apiVersion = constant.ArgoRolloutsAPIVersion
+
 	default:
-		return fmt.Errorf("%w: unexpected appWorkQueueKey in workQueue '%+v'", constant.ErrWrongInput, appKey)
+		cw := sac.workloadKinds.LookupCustom(appKey.AppAPIVersion, appKey.AppKind)
+		if cw == nil {
+			return fmt.Errorf("%w: unexpected appWorkQueueKey in workQueue '%+v'", constant.ErrWrongInput, appKey)
+		}
+
+		var customWorkload unstructured.Unstructured
+		err := getThirdPartyApp(sac.customWorkloadListers[schema.FromAPIVersionAndKind(cw.APIVersion, cw.Kind)], namespace, name, &customWorkload)
+		if nil != err {
+			if apierrors.IsNotFound(err) {
+				log.Sugar().Debugf("application in work queue no longer exists")
+				return sac.deleteAutoPools(logutils.IntoContext(context.TODO(), log), appKey.AppUID)
+			}
+			return err
+		}
+
+		podTemplate, err := cw.PodTemplate(&customWorkload)
+		if nil != err {
+			return fmt.Errorf("%w: %v", constant.ErrWrongInput, err)
+		}
+
+		podAnno = podTemplate.Annotations
+		app = &customWorkload
+		apiVersion = cw.APIVersion
 	}
 
 	subnetConfig, err = applicationinformers.GetSubnetAnnoConfig(podAnno, log)
@@ -691,18 +1123,26 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 		return fmt.Errorf("%w: failed to get pod annotation subnet config, error: %v", constant.ErrWrongInput, err)
 	}
 
+	appNamespacedName := types.AppNamespacedName{
+		APIVersion: apiVersion,
+		Kind:       appKey.AppKind,
+		Namespace:  app.GetNamespace(),
+		Name:       app.GetName(),
+	}
+	appWorkloadKind := sac.workloadKinds.Lookup(apiVersion, appKey.AppKind)
+	if appWorkloadKind == nil {
+		return fmt.Errorf("%w: unexpected appWorkQueueKey in workQueue '%+v'", constant.ErrWrongInput, appKey)
+	}
+	appReplicas, _ := appWorkloadKind.DesiredReplicas(app)
+	appReplicas = sac.autoPoolReplicas(log, subnetConfig, appNamespacedName, app, appReplicas)
+
 	log.Debug("try to apply auto-created IPPool")
 	err = sac.applyAutoIPPool(logutils.IntoContext(context.TODO(), log),
 		*subnetConfig,
 		types.PodTopController{
-			AppNamespacedName: types.AppNamespacedName{
-				APIVersion: apiVersion,
-				Kind:       appKey.AppKind,
-				Namespace:  app.GetNamespace(),
-				Name:       app.GetName(),
-			},
-			UID: app.GetUID(),
-			APP: app,
+			AppNamespacedName: appNamespacedName,
+			UID:               app.GetUID(),
+			APP:               app,
 		},
 		appReplicas)
 	if nil != err {
@@ -712,6 +1152,25 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 	return nil
 }
 
+// getThirdPartyApp gets the third-party application from the dynamic informer lister and converts it to the given object.
+func getThirdPartyApp(lister cache.GenericLister, namespace, name string, into interface{}) error {
+	if lister == nil {
+		return fmt.Errorf("%w: the application is not watched, its CRD may be uninstalled", constant.ErrWrongInput)
+	}
+
+	obj, err := lister.ByNamespace(namespace).Get(name)
+	if nil != err {
+		return err
+	}
+
+	if u, ok := into.(*unstructured.Unstructured); ok {
+		obj.(*unstructured.Unstructured).DeepCopyInto(u)
+		return nil
+	}
+
+	return applicationinformers.FromUnstructured(obj, into)
+}
+
 // applyAutoIPPool try to create an IPPool or mark IPPool desired IP number with the give SpiderSubnet configuration
 func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetConfig types.PodSubnetAnnoConfig,
 	podController types.PodTopController, appReplicas int) error {
@@ -830,6 +1289,91 @@ func (sac *SubnetAppController) applyAutoIPPool(ctx context.Context, podSubnetCo
 	return nil
 }
 
+// autoPoolReplicas returns the application replicas its auto-created IPPools are sized for by the sizing policy.
+func (sac *SubnetAppController) autoPoolReplicas(log *zap.Logger, subnetConfig *types.PodSubnetAnnoConfig, appNamespacedName types.AppNamespacedName,
+	app interface{}, replicas int) int {
+	if subnetConfig == nil {
+		return replicas
+	}
+
+	var hpaMaxReplicas int
+	if subnetConfig.SizingPolicy == constant.AutoPoolSizingHPAMax && sac.hpaLister != nil {
+		hpas, err := sac.hpaLister.HorizontalPodAutoscalers(appNamespacedName.Namespace).List(labels.Everything())
+		if nil != err {
+			log.Sugar().Warnf("failed to list HorizontalPodAutoscalers, size the auto-created IPPool by the replicas: %v", err)
+		} else {
+			hpaMaxReplicas = applicationinformers.GetAppHPAMaxReplicas(hpas, appNamespacedName)
+		}
+	}
+
+	return applicationinformers.GetAutoPoolReplicas(subnetConfig.SizingPolicy, app, replicas, hpaMaxReplicas)
+}
+
+// hpaHandler will return a function that reconciles the application targeted by the HorizontalPodAutoscaler,
+// if its auto-created IPPools are sized to the HorizontalPodAutoscaler max replicas.
+func (sac *SubnetAppController) hpaHandler() applicationinformers.HPAInformersFunc {
+	return func(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+		log := logutils.FromContext(ctx).With(zap.String("HorizontalPodAutoscaler", fmt.Sprintf("%s/%s", hpa.Namespace, hpa.Name)))
+
+		target := hpa.Spec.ScaleTargetRef
+		targetGV, err := schema.ParseGroupVersion(target.APIVersion)
+		if nil != err || targetGV.Group != appsv1.GroupName {
+			log.Sugar().Debugf("scale target %s %s/%s is not a kubernetes application", target.APIVersion, target.Kind, target.Name)
+			return nil
+		}
+
+		var app metav1.Object
+		var podTemplate *corev1.PodTemplateSpec
+		switch target.Kind {
+		case constant.KindDeployment:
+			deployment, err := sac.deploymentsLister.Deployments(hpa.Namespace).Get(target.Name)
+			if nil != err {
+				return client.IgnoreNotFound(err)
+			}
+			app, podTemplate = deployment, &deployment.Spec.Template
+
+		case constant.KindReplicaSet:
+			replicaSet, err := sac.replicaSetLister.ReplicaSets(hpa.Namespace).Get(target.Name)
+			if nil != err {
+				return client.IgnoreNotFound(err)
+			}
+			// the auto-created IPPools belong to the top controller
+			if owner := metav1.GetControllerOf(replicaSet); owner != nil {
+				return nil
+			}
+			app, podTemplate = replicaSet, &replicaSet.Spec.Template
+
+		case constant.KindStatefulSet:
+			statefulSet, err := sac.statefulSetLister.StatefulSets(hpa.Namespace).Get(target.Name)
+			if nil != err {
+				return client.IgnoreNotFound(err)
+			}
+			app, podTemplate = statefulSet, &statefulSet.Spec.Template
+
+		default:
+			log.Sugar().Debugf("scale target kind %s is not supported", target.Kind)
+			return nil
+		}
+
+		// no need reconcile for HostNetwork application
+		if podTemplate.Spec.HostNetwork {
+			return nil
+		}
+
+		subnetConfig, err := applicationinformers.GetSubnetAnnoConfig(podTemplate.Annotations, log)
+		if nil != err {
+			return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
+		}
+		if applicationinformers.IsDefaultIPPoolMode(subnetConfig) || subnetConfig.SizingPolicy != constant.AutoPoolSizingHPAMax {
+			return nil
+		}
+
+		log.Sugar().Debugf("try to add app %s %s/%s to application controller workequeue", target.Kind, hpa.Namespace, target.Name)
+		sac.enqueueApp(logutils.IntoContext(ctx, log), app, target.Kind, app.GetUID())
+		return nil
+	}
+}
+
 // hasSubnetConfigChanged checks whether application subnet configuration changed and the application replicas changed or not.
 // The second parameter newSubnetConfig must not be nil.
 func hasSubnetConfigChanged(ctx context.Context, oldSubnetConfig, newSubnetConfig *types.PodSubnetAnnoConfig,
@@ -924,6 +1468,40 @@ func (sac *SubnetAppController) controllerDeleteHandler() applicationinformers.A
 			}
 			app = object
 
+		case *kruisev1alpha1.CloneSet:
+			appKind = constant.KindCloneSet
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", object.Namespace, object.Name)))
+			owner := metav1.GetControllerOf(object)
+			if owner != nil {
+				log.Sugar().Debugf("the application has a owner '%s/%s', we would not clean up legacy for it", owner.Kind, owner.Name)
+				return nil
+			}
+			app = object
+
+		case *kruisev1beta1.StatefulSet:
+			appKind = constant.KindStatefulSet
+			log = log.With(zap.String("AdvancedStatefulSet", fmt.Sprintf("%s/%s", object.Namespace, object.Name)))
+			owner := metav1.GetControllerOf(object)
+			if owner != nil {
+				log.Sugar().Debugf("the application has a owner '%s/%s', we would not clean up legacy for it", owner.Kind, owner.Name)
+				return nil
+			}
+			app = object
+
+		case *unstructured.Unstructured:
+			if !applicationinformers.IsArgoRollout(object.GetAPIVersion(), object.GetKind()) &&
+				sac.workloadKinds.LookupCustom(object.GetAPIVersion(), object.GetKind()) == nil {
+				return fmt.Errorf("%w: unrecognized application: %+v", constant.ErrWrongInput, obj)
+			}
+			appKind = object.GetKind()
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())))
+			owner := metav1.GetControllerOf(object)
+			if owner != nil {
+				log.Sugar().Debugf("the application has a owner '%s/%s', we would not clean up legacy for it", owner.Kind, owner.Name)
+				return nil
+			}
+			app = object
+
 		default:
 			return fmt.Errorf("%w: unrecognized application: %+v", constant.ErrWrongInput, obj)
 		}
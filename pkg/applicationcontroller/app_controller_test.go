@@ -12,12 +12,19 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
@@ -27,6 +34,8 @@ import (
 
 	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 var _ = Describe("AppController", Label("app_controller_test"), func() {
@@ -36,6 +45,10 @@ var _ = Describe("AppController", Label("app_controller_test"), func() {
 	var statefulSet1 *appsv1.StatefulSet
 	var job1 *batchv1.Job
 	var cronJob1 *batchv1.CronJob
+	var cloneSet1 *v1alpha1.CloneSet
+	var advancedStatefulSet1 *kruisev1beta1.StatefulSet
+	var rollout1 *unstructured.Unstructured
+	var staticSet1 *unstructured.Unstructured
 
 	BeforeEach(func() {
 		deployment1 = &appsv1.Deployment{
@@ -171,7 +184,90 @@ var _ = Describe("AppController", Label("app_controller_test"), func() {
 				},
 			},
 		}
-
+		cloneSet1 = &v1alpha1.CloneSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       constant.KindCloneSet,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cloneset",
+				Namespace: "ns1",
+				UID:       types.UID("123"),
+			},
+			Spec: v1alpha1.CloneSetSpec{
+				Replicas: pointer.Int32(1),
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							constant.AnnoSpiderSubnet:             `{"ipv4": ["subnet-demo-v4"], "ipv6": ["subnet-demo-v6"]}`,
+							constant.AnnoSpiderSubnetPoolIPNumber: "+1",
+						},
+					},
+				},
+			},
+		}
+		advancedStatefulSet1 = &kruisev1beta1.StatefulSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: kruisev1beta1.SchemeGroupVersion.String(),
+				Kind:       constant.KindStatefulSet,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-advanced-statefulset",
+				Namespace: "ns1",
+				UID:       types.UID("123"),
+			},
+			Spec: kruisev1beta1.StatefulSetSpec{
+				Replicas: pointer.Int32(1),
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{
+							constant.AnnoSpiderSubnet:             `{"ipv4": ["subnet-demo-v4"], "ipv6": ["subnet-demo-v6"]}`,
+							constant.AnnoSpiderSubnetPoolIPNumber: "1",
+						},
+					},
+				},
+			},
+		}
+		rollout1 = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": constant.ArgoRolloutsAPIVersion,
+			"kind":       constant.KindRollout,
+			"metadata": map[string]interface{}{
+				"name":      "test-rollout",
+				"namespace": "ns1",
+				"uid":       "123",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							constant.AnnoSpiderSubnet:             `{"ipv4": ["subnet-demo-v4"], "ipv6": ["subnet-demo-v6"]}`,
+							constant.AnnoSpiderSubnetPoolIPNumber: "+1",
+						},
+					},
+				},
+			},
+		}}
+		staticSet1 = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": staticSetWorkload.APIVersion,
+			"kind":       staticSetWorkload.Kind,
+			"metadata": map[string]interface{}{
+				"name":      "test-staticset",
+				"namespace": "ns1",
+				"uid":       "456",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							constant.AnnoSpiderSubnet:             `{"ipv4": ["subnet-demo-v4"], "ipv6": ["subnet-demo-v6"]}`,
+							constant.AnnoSpiderSubnetPoolIPNumber: "+1",
+						},
+					},
+				},
+			},
+		}}
 	})
 
 	Describe("run subnet app controller", func() {
@@ -742,9 +838,98 @@ var _ = Describe("AppController", Label("app_controller_test"), func() {
 			})
 		})
 
+		Context("OpenKruise CloneSet", func() {
+			It("create cloneSet with spider subnet annotation", func() {
+				err := reconcileFunc(ctx, nil, cloneSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("change cloneSet replicas with spider subnet annotation", func() {
+				cloneSet2 := cloneSet1.DeepCopy()
+				cloneSet2.Spec.Replicas = pointer.Int32(2)
+				err := reconcileFunc(ctx, cloneSet1, cloneSet2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("create host network cloneSet", func() {
+				cloneSet1.Spec.Template.Spec.HostNetwork = true
+				err := reconcileFunc(ctx, nil, cloneSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("OpenKruise Advanced StatefulSet", func() {
+			It("create advanced statefulSet with spider subnet annotation", func() {
+				err := reconcileFunc(ctx, nil, advancedStatefulSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("change advanced statefulSet replicas with spider subnet annotation", func() {
+				advancedStatefulSet2 := advancedStatefulSet1.DeepCopy()
+				advancedStatefulSet2.Spec.Replicas = pointer.Int32(2)
+				err := reconcileFunc(ctx, advancedStatefulSet1, advancedStatefulSet2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("Argo Rollout", func() {
+			It("create rollout with spider subnet annotation", func() {
+				err := reconcileFunc(ctx, nil, rollout1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("change rollout replicas with spider subnet annotation", func() {
+				rollout2 := rollout1.DeepCopy()
+				err := unstructured.SetNestedField(rollout2.Object, int64(2), "spec", "replicas")
+				Expect(err).NotTo(HaveOccurred())
+				err = reconcileFunc(ctx, rollout1, rollout2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("create default IPPool mode rollout", func() {
+				unstructured.RemoveNestedField(rollout1.Object, "spec", "template", "metadata", "annotations")
+				err := reconcileFunc(ctx, nil, rollout1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("do not support unstructured object that is not Rollout", func() {
+				rollout1.SetKind("Experiment")
+				err := reconcileFunc(ctx, nil, rollout1)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("custom workload", func() {
+			It("create custom workload with spider subnet annotation", func() {
+				err := reconcileFunc(ctx, nil, staticSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("change custom workload replicas with spider subnet annotation", func() {
+				staticSet2 := staticSet1.DeepCopy()
+				err := unstructured.SetNestedField(staticSet2.Object, int64(2), "spec", "replicas")
+				Expect(err).NotTo(HaveOccurred())
+				err = reconcileFunc(ctx, staticSet1, staticSet2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("create host network custom workload", func() {
+				err := unstructured.SetNestedField(staticSet1.Object, true, "spec", "template", "spec", "hostNetwork")
+				Expect(err).NotTo(HaveOccurred())
+				err = reconcileFunc(ctx, nil, staticSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("do not support the custom workload that is not declared", func() {
+				staticSet1.SetAPIVersion("apps.example.io/v2")
+				err := reconcileFunc(ctx, nil, staticSet1)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			})
+		})
+
 		Context("unrecognized controller", func() {
 			It("do not support third-party controller", func() {
-				err := reconcileFunc(ctx, nil, cloneSet)
+				err := reconcileFunc(ctx, nil, &v1alpha1.DaemonSet{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -848,14 +1033,290 @@ var _ = Describe("AppController", Label("app_controller_test"), func() {
 			})
 		})
 
+		Context("OpenKruise CloneSet", func() {
+			It("delete cloneSet", func() {
+				err := cleanupFunc(ctx, cloneSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("OpenKruise Advanced StatefulSet", func() {
+			It("delete advanced statefulSet", func() {
+				err := cleanupFunc(ctx, advancedStatefulSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("Argo Rollout", func() {
+			It("delete rollout", func() {
+				err := cleanupFunc(ctx, rollout1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("the rollout has owner", func() {
+				err := controllerutil.SetControllerReference(cloneSet, rollout1, scheme)
+				Expect(err).NotTo(HaveOccurred())
+				err = cleanupFunc(ctx, rollout1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("custom workload", func() {
+			It("delete custom workload", func() {
+				err := cleanupFunc(ctx, staticSet1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("do not support the custom workload that is not declared", func() {
+				staticSet1.SetKind("UnknownSet")
+				err := cleanupFunc(ctx, staticSet1)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			})
+		})
+
 		Context("unrecognized controller", func() {
 			It("do not support third-party controller", func() {
-				err := cleanupFunc(ctx, cloneSet)
+				err := cleanupFunc(ctx, &v1alpha1.DaemonSet{})
 				Expect(err).To(HaveOccurred())
 			})
 		})
 	})
 
+	Describe("test third-party application informers", func() {
+		var control *subnetApplicationController
+		var discoveryClient *fakediscovery.FakeDiscovery
+		var dynamicFactory dynamicinformer.DynamicSharedInformerFactory
+
+		BeforeEach(func() {
+			c, err := newController()
+			Expect(err).NotTo(HaveOccurred())
+			control = c
+
+			clientSet := fake.NewSimpleClientset()
+			discoveryClient = clientSet.Discovery().(*fakediscovery.FakeDiscovery)
+			dynamicFactory = dynamicinformer.NewDynamicSharedInformerFactory(dynamicfake.NewSimpleDynamicClient(scheme), 0)
+		})
+
+		It("skip the resources that are not served", func() {
+			unserved, err := control.addThirdPartyEventHandlers(discoveryClient, dynamicFactory)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(control.cloneSetInformer).To(BeNil())
+			Expect(control.advancedStatefulSetInformer).To(BeNil())
+			Expect(control.rolloutInformer).To(BeNil())
+			Expect(control.customWorkloadInformers).To(BeEmpty())
+			Expect(unserved.resources).To(ConsistOf(cloneSetGVR, advancedStatefulSetGVR, rolloutGVR))
+			Expect(unserved.kinds).To(ConsistOf(schema.FromAPIVersionAndKind(staticSetWorkload.APIVersion, staticSetWorkload.Kind)))
+		})
+
+		It("watch the served resources", func() {
+			discoveryClient.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: v1alpha1.SchemeGroupVersion.String(),
+					APIResources: []metav1.APIResource{{Name: "clonesets", Kind: constant.KindCloneSet}},
+				},
+				{
+					GroupVersion: constant.ArgoRolloutsAPIVersion,
+					APIResources: []metav1.APIResource{{Name: "rollouts", Kind: constant.KindRollout}},
+				},
+				{
+					GroupVersion: staticSetWorkload.APIVersion,
+					APIResources: []metav1.APIResource{
+						{Name: "staticsets/scale", Kind: "Scale"},
+						{Name: "staticsets", Kind: staticSetWorkload.Kind},
+					},
+				},
+			}
+
+			unserved, err := control.addThirdPartyEventHandlers(discoveryClient, dynamicFactory)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(unserved.resources).To(ConsistOf(advancedStatefulSetGVR))
+			Expect(unserved.kinds).To(BeEmpty())
+			Expect(control.cloneSetInformer).NotTo(BeNil())
+			Expect(control.cloneSetLister).NotTo(BeNil())
+			Expect(control.advancedStatefulSetInformer).To(BeNil())
+			Expect(control.rolloutInformer).NotTo(BeNil())
+			Expect(control.customWorkloadInformers).To(HaveLen(1))
+			Expect(control.customWorkloadListers).To(HaveKey(schema.FromAPIVersionAndKind(staticSetWorkload.APIVersion, staticSetWorkload.Kind)))
+		})
+
+		It("clean up the auto-created IPPools of the custom workload that no longer exists", func() {
+			discoveryClient.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: staticSetWorkload.APIVersion,
+					APIResources: []metav1.APIResource{{Name: "staticsets", Kind: staticSetWorkload.Kind}},
+				},
+			}
+			_, err := control.addThirdPartyEventHandlers(discoveryClient, dynamicFactory)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = control.syncHandler(appWorkQueueKey{
+				MetaNamespaceKey: "ns1/test-staticset",
+				AppAPIVersion:    staticSetWorkload.APIVersion,
+				AppKind:          staticSetWorkload.Kind,
+				AppUID:           "456",
+			}, logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("do not sync the custom workload that is not declared", func() {
+			err := control.syncHandler(appWorkQueueKey{
+				MetaNamespaceKey: "ns1/test-staticset",
+				AppAPIVersion:    "apps.example.io/v2",
+				AppKind:          staticSetWorkload.Kind,
+			}, logger)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("failed to discover resources", func() {
+			patch := gomonkey.ApplyMethodReturn(discoveryClient, "ServerResourcesForGroupVersion", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			_, err := control.addThirdPartyEventHandlers(discoveryClient, dynamicFactory)
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("watch the unserved resources", func() {
+			var interval time.Duration
+			var unserved unservedResources
+
+			BeforeEach(func() {
+				interval = unservedResourcesRecheckInterval
+				unservedResourcesRecheckInterval = 10 * time.Millisecond
+				DeferCleanup(func() {
+					unservedResourcesRecheckInterval = interval
+				})
+
+				var err error
+				unserved, err = control.addThirdPartyEventHandlers(discoveryClient, dynamicFactory)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("restart the informers once the CRD is installed", func() {
+				discoveryClient.Resources = []*metav1.APIResourceList{
+					{
+						GroupVersion: staticSetWorkload.APIVersion,
+						APIResources: []metav1.APIResource{{Name: "staticsets", Kind: staticSetWorkload.Kind}},
+					},
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go watchUnservedResources(ctx, cancel, discoveryClient, unserved)
+				Eventually(ctx.Done()).Should(BeClosed())
+			})
+
+			It("keep the informers if no CRD is installed", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go watchUnservedResources(ctx, cancel, discoveryClient, unserved)
+				Consistently(ctx.Done(), 100*time.Millisecond).ShouldNot(BeClosed())
+			})
+
+			It("keep the informers if it failed to discover resources", func() {
+				patch := gomonkey.ApplyMethodReturn(discoveryClient, "ServerResourcesForGroupVersion", nil, constant.ErrUnknown)
+				defer patch.Reset()
+
+				served, err := unserved.anyServed(discoveryClient)
+				Expect(err).To(MatchError(constant.ErrUnknown))
+				Expect(served).To(BeFalse())
+			})
+
+			It("do nothing if all the resources are served", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				watchUnservedResources(ctx, cancel, discoveryClient, unservedResources{})
+				Expect(ctx.Err()).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("test HorizontalPodAutoscaler event hook handler", func() {
+		var hpaFunc applicationinformers.HPAInformersFunc
+		var ctx context.Context
+		var control *subnetApplicationController
+		var hpa *autoscalingv2.HorizontalPodAutoscaler
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+
+			c, err := newController()
+			Expect(err).NotTo(HaveOccurred())
+			control = c
+
+			factory := kubeinformers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+			err = control.addEventHandlers(factory)
+			Expect(err).NotTo(HaveOccurred())
+			control.deploymentStore = factory.Apps().V1().Deployments().Informer().GetStore()
+			hpaFunc = control.hpaHandler()
+
+			deployment1.Spec.Template.Annotations[constant.AnnoSpiderSubnetPoolSizing] = constant.AutoPoolSizingHPAMax
+			hpa = &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-hpa",
+					Namespace: deployment1.Namespace,
+				},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindDeployment,
+						Name:       deployment1.Name,
+					},
+					MaxReplicas: 5,
+				},
+			}
+		})
+
+		It("enqueue the target deployment sized to HPA max", func() {
+			err := control.deploymentStore.Add(deployment1)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = hpaFunc(ctx, hpa)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(control.workQueue.Len()).To(Equal(1))
+		})
+
+		It("ignore the target deployment sized to replicas", func() {
+			delete(deployment1.Spec.Template.Annotations, constant.AnnoSpiderSubnetPoolSizing)
+			err := control.deploymentStore.Add(deployment1)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = hpaFunc(ctx, hpa)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(control.workQueue.Len()).To(Equal(0))
+		})
+
+		It("ignore the target that no longer exists", func() {
+			err := hpaFunc(ctx, hpa)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(control.workQueue.Len()).To(Equal(0))
+		})
+
+		It("ignore the target of third-party controller", func() {
+			hpa.Spec.ScaleTargetRef.APIVersion = v1alpha1.SchemeGroupVersion.String()
+			hpa.Spec.ScaleTargetRef.Kind = "CloneSet"
+
+			err := hpaFunc(ctx, hpa)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(control.workQueue.Len()).To(Equal(0))
+		})
+
+		It("size the auto-created IPPool to HPA max", func() {
+			err := control.hpaInformer.GetStore().Add(hpa)
+			Expect(err).NotTo(HaveOccurred())
+
+			subnetConfig, err := applicationinformers.GetSubnetAnnoConfig(deployment1.Spec.Template.Annotations, logutils.Logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			replicas := control.autoPoolReplicas(logutils.Logger, subnetConfig, spiderpooltypes.AppNamespacedName{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       constant.KindDeployment,
+				Namespace:  deployment1.Namespace,
+				Name:       deployment1.Name,
+			}, deployment1, 1)
+			Expect(replicas).To(Equal(5))
+		})
+	})
+
 	Describe("test deleteAutoPools", func() {
 		var ctx context.Context
 		var control *subnetApplicationController
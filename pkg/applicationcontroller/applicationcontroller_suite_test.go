@@ -17,8 +17,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 func TestApplicationcontroller(t *testing.T) {
@@ -59,6 +62,13 @@ var _ = BeforeSuite(func() {
 	}
 })
 
+// staticSetWorkload is a custom workload declared in the configmap.
+var staticSetWorkload = workloadkind.CustomWorkload{
+	APIVersion:   "apps.example.io/v1",
+	Kind:         "StaticSet",
+	ReplicasPath: "spec.replicas",
+}
+
 func newController() (*subnetApplicationController, error) {
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 	apiReader := fake.NewClientBuilder().WithScheme(scheme).Build()
@@ -72,7 +82,18 @@ func newController() (*subnetApplicationController, error) {
 		return nil, err
 	}
 
-	appController, err := NewSubnetAppController(fakeClient, apiReader, subnetManager, subnetAppControllerConfig)
+	stsManager, err := statefulsetmanager.NewStatefulSetManager(fakeClient, apiReader)
+	if nil != err {
+		return nil, err
+	}
+	workloadKinds, err := workloadkind.NewRegistry(fakeClient, apiReader, stsManager, kubevirtmanager.NewKubevirtManager(fakeClient, apiReader), workloadkind.RegistryConfig{
+		CustomWorkloads: []workloadkind.CustomWorkload{staticSetWorkload},
+	})
+	if nil != err {
+		return nil, err
+	}
+
+	appController, err := NewSubnetAppController(fakeClient, apiReader, subnetManager, workloadKinds, subnetAppControllerConfig)
 	if nil != err {
 		return nil, err
 	}
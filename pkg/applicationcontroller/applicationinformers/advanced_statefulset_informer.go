@@ -0,0 +1,66 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// AddAdvancedStatefulSetHandler registers the handlers for the OpenKruise Advanced StatefulSet informer, which is a dynamic one
+// since there's no OpenKruise clientset. The unstructured objects are converted to Advanced StatefulSet for the handlers.
+func (c *Controller) AddAdvancedStatefulSetHandler(informer cache.SharedIndexInformer) error {
+	controllersLogger.Info("Setting up OpenKruise Advanced StatefulSet handlers")
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onAdvancedStatefulSetAdd,
+		UpdateFunc: c.onAdvancedStatefulSetUpdate,
+		DeleteFunc: c.onAdvancedStatefulSetDelete,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) onAdvancedStatefulSetAdd(obj interface{}) {
+	var statefulSet kruisev1beta1.StatefulSet
+	err := FromUnstructured(obj, &statefulSet)
+	if nil == err {
+		err = c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, &statefulSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onAdvancedStatefulSetAdd: %v", err)
+	}
+}
+
+func (c *Controller) onAdvancedStatefulSetUpdate(oldObj interface{}, newObj interface{}) {
+	var oldAdvancedStatefulSet, newAdvancedStatefulSet kruisev1beta1.StatefulSet
+	err := FromUnstructured(oldObj, &oldAdvancedStatefulSet)
+	if nil == err {
+		err = FromUnstructured(newObj, &newAdvancedStatefulSet)
+	}
+	if nil == err {
+		err = c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), &oldAdvancedStatefulSet, &newAdvancedStatefulSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onAdvancedStatefulSetUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onAdvancedStatefulSetDelete(obj interface{}) {
+	var statefulSet kruisev1beta1.StatefulSet
+	err := FromUnstructured(obj, &statefulSet)
+	if nil == err {
+		err = c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), &statefulSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onAdvancedStatefulSetDelete: %v", err)
+	}
+}
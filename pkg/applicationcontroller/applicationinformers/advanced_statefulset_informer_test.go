@@ -0,0 +1,88 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var _ = Describe("AdvancedStatefulSetInformer", Label("unittest"), func() {
+	Context("UT advanced_statefulset_informer", Serial, func() {
+		var controller *Controller
+		var reconciled, cleaned []interface{}
+
+		statefulSet1 := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": kruisev1beta1.SchemeGroupVersion.String(),
+			"kind":       constant.KindStatefulSet,
+			"metadata":   map[string]interface{}{"name": "sts1", "namespace": "default"},
+		}}
+
+		logger := logutils.Logger.Named("ut-test-cloneset-informer")
+
+		BeforeEach(func() {
+			var err error
+			reconciled, cleaned = nil, nil
+			controller, err = NewApplicationController(
+				func(ctx context.Context, oldObj, newObj interface{}) error {
+					reconciled = append(reconciled, oldObj, newObj)
+					return constant.ErrUnknown
+				},
+				func(ctx context.Context, obj interface{}) error {
+					cleaned = append(cleaned, obj)
+					return constant.ErrUnknown
+				},
+				logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("onAdvancedStatefulSetAdd converts the unstructured object", func() {
+			controller.onAdvancedStatefulSetAdd(statefulSet1)
+			Expect(reconciled).To(HaveLen(2))
+			Expect(reconciled[1]).To(BeAssignableToTypeOf(&kruisev1beta1.StatefulSet{}))
+			Expect(reconciled[1].(*kruisev1beta1.StatefulSet).Name).To(Equal("sts1"))
+		})
+
+		It("failed to convert the object", func() {
+			controller.onAdvancedStatefulSetAdd(&kruisev1beta1.StatefulSet{})
+			Expect(reconciled).To(BeEmpty())
+		})
+
+		It("onAdvancedStatefulSetUpdate", func() {
+			controller.onAdvancedStatefulSetUpdate(statefulSet1, statefulSet1.DeepCopy())
+			Expect(reconciled).To(HaveLen(2))
+			Expect(reconciled[0]).To(BeAssignableToTypeOf(&kruisev1beta1.StatefulSet{}))
+		})
+
+		It("onAdvancedStatefulSetDelete", func() {
+			controller.onAdvancedStatefulSetDelete(statefulSet1)
+			Expect(cleaned).To(HaveLen(1))
+			Expect(cleaned[0]).To(BeAssignableToTypeOf(&kruisev1beta1.StatefulSet{}))
+		})
+
+		It("AddAdvancedStatefulSetHandler successfully", func() {
+			informer := dynamicFactory.ForResource(kruisev1beta1.SchemeGroupVersion.WithResource("statefulsets")).Informer()
+
+			err := controller.AddAdvancedStatefulSetHandler(informer)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fail to AddAdvancedStatefulSetHandler", func() {
+			informer := dynamicFactory.ForResource(kruisev1beta1.SchemeGroupVersion.WithResource("statefulsets")).Informer()
+			patch := gomonkey.ApplyMethodReturn(informer, "AddEventHandler", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := controller.AddAdvancedStatefulSetHandler(informer)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
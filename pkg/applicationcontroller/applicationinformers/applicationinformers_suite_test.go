@@ -9,6 +9,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -28,8 +31,10 @@ var fakeCleanupFunc APPInformersDelFunc = func(ctx context.Context, obj interfac
 }
 
 var factory kubeinformers.SharedInformerFactory
+var dynamicFactory dynamicinformer.DynamicSharedInformerFactory
 
 var _ = BeforeSuite(func() {
 	clientSet := fake.NewSimpleClientset()
 	factory = kubeinformers.NewSharedInformerFactory(clientSet, 0)
+	dynamicFactory = dynamicinformer.NewDynamicSharedInformerFactory(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), 0)
 })
@@ -0,0 +1,66 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// AddCloneSetHandler registers the handlers for the OpenKruise CloneSet informer, which is a dynamic one
+// since there's no OpenKruise clientset. The unstructured objects are converted to CloneSet for the handlers.
+func (c *Controller) AddCloneSetHandler(informer cache.SharedIndexInformer) error {
+	controllersLogger.Info("Setting up OpenKruise CloneSet handlers")
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onCloneSetAdd,
+		UpdateFunc: c.onCloneSetUpdate,
+		DeleteFunc: c.onCloneSetDelete,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) onCloneSetAdd(obj interface{}) {
+	var cloneSet kruisev1alpha1.CloneSet
+	err := FromUnstructured(obj, &cloneSet)
+	if nil == err {
+		err = c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, &cloneSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCloneSetAdd: %v", err)
+	}
+}
+
+func (c *Controller) onCloneSetUpdate(oldObj interface{}, newObj interface{}) {
+	var oldCloneSet, newCloneSet kruisev1alpha1.CloneSet
+	err := FromUnstructured(oldObj, &oldCloneSet)
+	if nil == err {
+		err = FromUnstructured(newObj, &newCloneSet)
+	}
+	if nil == err {
+		err = c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), &oldCloneSet, &newCloneSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCloneSetUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onCloneSetDelete(obj interface{}) {
+	var cloneSet kruisev1alpha1.CloneSet
+	err := FromUnstructured(obj, &cloneSet)
+	if nil == err {
+		err = c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), &cloneSet)
+	}
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCloneSetDelete: %v", err)
+	}
+}
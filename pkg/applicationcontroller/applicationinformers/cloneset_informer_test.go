@@ -0,0 +1,88 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var _ = Describe("CloneSetInformer", Label("unittest"), func() {
+	Context("UT cloneset_informer", Serial, func() {
+		var controller *Controller
+		var reconciled, cleaned []interface{}
+
+		cloneSet1 := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": kruisev1alpha1.SchemeGroupVersion.String(),
+			"kind":       constant.KindCloneSet,
+			"metadata":   map[string]interface{}{"name": "clone1", "namespace": "default"},
+		}}
+
+		logger := logutils.Logger.Named("ut-test-cloneset-informer")
+
+		BeforeEach(func() {
+			var err error
+			reconciled, cleaned = nil, nil
+			controller, err = NewApplicationController(
+				func(ctx context.Context, oldObj, newObj interface{}) error {
+					reconciled = append(reconciled, oldObj, newObj)
+					return constant.ErrUnknown
+				},
+				func(ctx context.Context, obj interface{}) error {
+					cleaned = append(cleaned, obj)
+					return constant.ErrUnknown
+				},
+				logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("onCloneSetAdd converts the unstructured object", func() {
+			controller.onCloneSetAdd(cloneSet1)
+			Expect(reconciled).To(HaveLen(2))
+			Expect(reconciled[1]).To(BeAssignableToTypeOf(&kruisev1alpha1.CloneSet{}))
+			Expect(reconciled[1].(*kruisev1alpha1.CloneSet).Name).To(Equal("clone1"))
+		})
+
+		It("failed to convert the object", func() {
+			controller.onCloneSetAdd(&kruisev1alpha1.CloneSet{})
+			Expect(reconciled).To(BeEmpty())
+		})
+
+		It("onCloneSetUpdate", func() {
+			controller.onCloneSetUpdate(cloneSet1, cloneSet1.DeepCopy())
+			Expect(reconciled).To(HaveLen(2))
+			Expect(reconciled[0]).To(BeAssignableToTypeOf(&kruisev1alpha1.CloneSet{}))
+		})
+
+		It("onCloneSetDelete", func() {
+			controller.onCloneSetDelete(cloneSet1)
+			Expect(cleaned).To(HaveLen(1))
+			Expect(cleaned[0]).To(BeAssignableToTypeOf(&kruisev1alpha1.CloneSet{}))
+		})
+
+		It("AddCloneSetHandler successfully", func() {
+			informer := dynamicFactory.ForResource(kruisev1alpha1.SchemeGroupVersion.WithResource("clonesets")).Informer()
+
+			err := controller.AddCloneSetHandler(informer)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fail to AddCloneSetHandler", func() {
+			informer := dynamicFactory.ForResource(kruisev1alpha1.SchemeGroupVersion.WithResource("clonesets")).Informer()
+			patch := gomonkey.ApplyMethodReturn(informer, "AddEventHandler", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := controller.AddCloneSetHandler(informer)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -18,6 +18,7 @@ type APPInformersDelFunc func(ctx context.Context, obj interface{}) error
 type Controller struct {
 	reconcileFunc AppInformersAddOrUpdateFunc
 	cleanupFunc   APPInformersDelFunc
+	hpaFunc       HPAInformersFunc
 }
 
 func NewApplicationController(reconcile AppInformersAddOrUpdateFunc, cleanup APPInformersDelFunc, logger *zap.Logger) (*Controller, error) {
@@ -0,0 +1,54 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// AddCustomWorkloadHandler registers the handlers for the informer of a custom workload declared in
+// the configmap, the handlers get the unstructured objects.
+func (c *Controller) AddCustomWorkloadHandler(informer cache.SharedIndexInformer) error {
+	controllersLogger.Info("Setting up custom workload handlers")
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onCustomWorkloadAdd,
+		UpdateFunc: c.onCustomWorkloadUpdate,
+		DeleteFunc: c.onCustomWorkloadDelete,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) onCustomWorkloadAdd(obj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCustomWorkloadAdd: %v", err)
+	}
+}
+
+func (c *Controller) onCustomWorkloadUpdate(oldObj interface{}, newObj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCustomWorkloadUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onCustomWorkloadDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	err := c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onCustomWorkloadDelete: %v", err)
+	}
+}
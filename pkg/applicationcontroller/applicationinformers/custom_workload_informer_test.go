@@ -0,0 +1,78 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var _ = Describe("CustomWorkloadInformer", Label("unittest"), func() {
+	Context("UT custom_workload_informer", Serial, func() {
+		var controller *Controller
+		var cleaned []interface{}
+
+		staticSetGVR := schema.GroupVersionResource{Group: "apps.example.io", Version: "v1", Resource: "staticsets"}
+		staticSet1 := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps.example.io/v1",
+			"kind":       "StaticSet",
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      "staticset1",
+			},
+		}}
+
+		logger := logutils.Logger.Named("ut-test-custom-workload-informer")
+
+		BeforeEach(func() {
+			var err error
+			cleaned = nil
+			controller, err = NewApplicationController(fakeReconcileFunc,
+				func(ctx context.Context, obj interface{}) error {
+					cleaned = append(cleaned, obj)
+					return constant.ErrUnknown
+				},
+				logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to onCustomWorkloadAdd", func() {
+			controller.onCustomWorkloadAdd(staticSet1)
+		})
+
+		It("failed to onCustomWorkloadUpdate", func() {
+			controller.onCustomWorkloadUpdate(staticSet1, staticSet1.DeepCopy())
+		})
+
+		It("onCustomWorkloadDelete unwraps the tombstone", func() {
+			controller.onCustomWorkloadDelete(cache.DeletedFinalStateUnknown{Obj: staticSet1})
+			Expect(cleaned).To(Equal([]interface{}{staticSet1}))
+		})
+
+		It("AddCustomWorkloadHandler successfully", func() {
+			informer := dynamicFactory.ForResource(staticSetGVR).Informer()
+
+			err := controller.AddCustomWorkloadHandler(informer)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fail to AddCustomWorkloadHandler", func() {
+			informer := dynamicFactory.ForResource(staticSetGVR).Informer()
+			patch := gomonkey.ApplyMethodReturn(informer, "AddEventHandler", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := controller.AddCustomWorkloadHandler(informer)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
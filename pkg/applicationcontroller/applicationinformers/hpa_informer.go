@@ -0,0 +1,100 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// HPAInformersFunc reconciles the application the HorizontalPodAutoscaler targets.
+type HPAInformersFunc func(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error
+
+// AddHPAHandler calls hpaFunc once a HorizontalPodAutoscaler is added or deleted,
+// or its scale target or max replicas changes.
+func (c *Controller) AddHPAHandler(informer cache.SharedIndexInformer, hpaFunc HPAInformersFunc) error {
+	if hpaFunc == nil {
+		return fmt.Errorf("the HorizontalPodAutoscaler informers function must be specified")
+	}
+
+	controllersLogger.Info("Setting up HorizontalPodAutoscaler handlers")
+	c.hpaFunc = hpaFunc
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onHPAAdd,
+		UpdateFunc: c.onHPAUpdate,
+		DeleteFunc: c.onHPADelete,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) onHPAAdd(obj interface{}) {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		controllersLogger.Sugar().Errorf("onHPAAdd: unknown object %+v", obj)
+		return
+	}
+
+	err := c.hpaFunc(logutils.IntoContext(context.TODO(), controllersLogger), hpa)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onHPAAdd: %v", err)
+	}
+}
+
+func (c *Controller) onHPAUpdate(oldObj interface{}, newObj interface{}) {
+	oldHPA, ok := oldObj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		controllersLogger.Sugar().Errorf("onHPAUpdate: unknown object %+v", oldObj)
+		return
+	}
+	newHPA, ok := newObj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		controllersLogger.Sugar().Errorf("onHPAUpdate: unknown object %+v", newObj)
+		return
+	}
+
+	if oldHPA.Spec.ScaleTargetRef == newHPA.Spec.ScaleTargetRef && oldHPA.Spec.MaxReplicas == newHPA.Spec.MaxReplicas {
+		return
+	}
+
+	ctx := logutils.IntoContext(context.TODO(), controllersLogger)
+	// the former scale target is no longer scaled by the HorizontalPodAutoscaler
+	if oldHPA.Spec.ScaleTargetRef != newHPA.Spec.ScaleTargetRef {
+		err := c.hpaFunc(ctx, oldHPA)
+		if nil != err {
+			controllersLogger.Sugar().Errorf("onHPAUpdate: %v", err)
+		}
+	}
+
+	err := c.hpaFunc(ctx, newHPA)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onHPAUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onHPADelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		controllersLogger.Sugar().Errorf("onHPADelete: unknown object %+v", obj)
+		return
+	}
+
+	err := c.hpaFunc(logutils.IntoContext(context.TODO(), controllersLogger), hpa)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onHPADelete: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var _ = Describe("HPAInformer", Label("unittest"), func() {
+	Context("UT hpa_informer", Serial, func() {
+		var controller *Controller
+		var hpa1 *autoscalingv2.HorizontalPodAutoscaler
+		var calls []string
+
+		logger := logutils.Logger.Named("ut-test-hpa-informer")
+
+		BeforeEach(func() {
+			var err error
+			controller, err = NewApplicationController(fakeReconcileFunc, fakeCleanupFunc, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			calls = nil
+			controller.hpaFunc = func(ctx context.Context, hpa *autoscalingv2.HorizontalPodAutoscaler) error {
+				calls = append(calls, hpa.Spec.ScaleTargetRef.Name)
+				return constant.ErrUnknown
+			}
+
+			hpa1 = &autoscalingv2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       constant.KindDeployment,
+						Name:       "deploy1",
+					},
+					MaxReplicas: 3,
+				},
+			}
+		})
+
+		It("onHPAAdd", func() {
+			controller.onHPAAdd(hpa1)
+			Expect(calls).To(Equal([]string{"deploy1"}))
+		})
+
+		It("onHPAAdd with unknown object", func() {
+			controller.onHPAAdd(struct{}{})
+			Expect(calls).To(BeEmpty())
+		})
+
+		It("onHPAUpdate without changes", func() {
+			controller.onHPAUpdate(hpa1, hpa1.DeepCopy())
+			Expect(calls).To(BeEmpty())
+		})
+
+		It("onHPAUpdate with max replicas changed", func() {
+			hpa2 := hpa1.DeepCopy()
+			hpa2.Spec.MaxReplicas = 5
+			controller.onHPAUpdate(hpa1, hpa2)
+			Expect(calls).To(Equal([]string{"deploy1"}))
+		})
+
+		It("onHPAUpdate with scale target changed", func() {
+			hpa2 := hpa1.DeepCopy()
+			hpa2.Spec.ScaleTargetRef.Name = "deploy2"
+			controller.onHPAUpdate(hpa1, hpa2)
+			Expect(calls).To(Equal([]string{"deploy1", "deploy2"}))
+		})
+
+		It("onHPADelete", func() {
+			controller.onHPADelete(cache.DeletedFinalStateUnknown{Obj: hpa1})
+			Expect(calls).To(Equal([]string{"deploy1"}))
+		})
+
+		It("fail to AddHPAHandler without function", func() {
+			hpaInformer := factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+
+			err := controller.AddHPAHandler(hpaInformer, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("AddHPAHandler successfully", func() {
+			hpaInformer := factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+
+			err := controller.AddHPAHandler(hpaInformer, controller.hpaFunc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fail to AddHPAHandler", func() {
+			hpaInformer := factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+			patch := gomonkey.ApplyMethodReturn(hpaInformer, "AddEventHandler", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := controller.AddHPAHandler(hpaInformer, controller.hpaFunc)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
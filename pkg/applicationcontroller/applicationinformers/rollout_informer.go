@@ -0,0 +1,54 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// AddRolloutHandler registers the handlers for the Argo Rollout informer. The Argo Rollouts API
+// is not vendored, so the handlers get the unstructured objects.
+func (c *Controller) AddRolloutHandler(informer cache.SharedIndexInformer) error {
+	controllersLogger.Info("Setting up Argo Rollout handlers")
+
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onRolloutAdd,
+		UpdateFunc: c.onRolloutUpdate,
+		DeleteFunc: c.onRolloutDelete,
+	})
+	if nil != err {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) onRolloutAdd(obj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onRolloutAdd: %v", err)
+	}
+}
+
+func (c *Controller) onRolloutUpdate(oldObj interface{}, newObj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onRolloutUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onRolloutDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	err := c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onRolloutDelete: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package applicationinformers
+
+import (
+	"context"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var _ = Describe("RolloutInformer", Label("unittest"), func() {
+	Context("UT rollout_informer", Serial, func() {
+		var controller *Controller
+		var cleaned []interface{}
+
+		rolloutGVR := schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+		rollout1 := newRollout(nil)
+
+		logger := logutils.Logger.Named("ut-test-rollout-informer")
+
+		BeforeEach(func() {
+			var err error
+			cleaned = nil
+			controller, err = NewApplicationController(fakeReconcileFunc,
+				func(ctx context.Context, obj interface{}) error {
+					cleaned = append(cleaned, obj)
+					return constant.ErrUnknown
+				},
+				logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("failed to onRolloutAdd", func() {
+			controller.onRolloutAdd(rollout1)
+		})
+
+		It("failed to onRolloutUpdate", func() {
+			controller.onRolloutUpdate(rollout1, rollout1.DeepCopy())
+		})
+
+		It("onRolloutDelete unwraps the tombstone", func() {
+			controller.onRolloutDelete(cache.DeletedFinalStateUnknown{Obj: rollout1})
+			Expect(cleaned).To(Equal([]interface{}{rollout1}))
+		})
+
+		It("AddRolloutHandler successfully", func() {
+			informer := dynamicFactory.ForResource(rolloutGVR).Informer()
+
+			err := controller.AddRolloutHandler(informer)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fail to AddRolloutHandler", func() {
+			informer := dynamicFactory.ForResource(rolloutGVR).Informer()
+			patch := gomonkey.ApplyMethodReturn(informer, "AddEventHandler", nil, constant.ErrUnknown)
+			defer patch.Reset()
+
+			err := controller.AddRolloutHandler(informer)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -12,18 +12,24 @@ import (
 	"strconv"
 	"strings"
 
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -240,6 +246,13 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 	}
 	subnetAnnoConfig.ReclaimIPPool = reclaimPool
 
+	// annotation: "ipam.spidernet.io/ippool-sizing", the replicas the flexible IP number is added to (default replicas)
+	sizingPolicy, err := GetAutoPoolSizingPolicy(podAnnotations)
+	if nil != err {
+		return nil, err
+	}
+	subnetAnnoConfig.SizingPolicy = sizingPolicy
+
 	err = mutateAndValidateSubnetAnno(&subnetAnnoConfig)
 	if nil != err {
 		return nil, err
@@ -422,6 +435,106 @@ func ShouldReclaimIPPool(anno map[string]string) (bool, error) {
 	return true, nil
 }
 
+// GetAutoPoolSizingPolicy will check pod annotation "ipam.spidernet.io/ippool-sizing"
+func GetAutoPoolSizingPolicy(anno map[string]string) (string, error) {
+	policy, ok := anno[constant.AnnoSpiderSubnetPoolSizing]
+	if !ok {
+		// no specified sizing policy, default to size the auto-created IPPool by the replicas
+		return constant.AutoPoolSizingReplicas, nil
+	}
+
+	switch policy {
+	case constant.AutoPoolSizingReplicas, constant.AutoPoolSizingSurge, constant.AutoPoolSizingHPAMax:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("invalid spider subnet '%s' value '%s', it must be one of '%s', '%s' and '%s'", constant.AnnoSpiderSubnetPoolSizing, policy,
+		constant.AutoPoolSizingReplicas, constant.AutoPoolSizingSurge, constant.AutoPoolSizingHPAMax)
+}
+
+// GetAutoPoolReplicas returns the replicas of the application the auto-created IPPool is sized for by the sizing policy.
+// The "surge" policy adds the pods the application may create above its replicas during a rolling update,
+// and the "hpa-max" policy takes the max replicas of the HorizontalPodAutoscaler targeting the application if it is larger.
+func GetAutoPoolReplicas(policy string, app interface{}, replicas, hpaMaxReplicas int) int {
+	switch policy {
+	case constant.AutoPoolSizingSurge:
+		return replicas + GetAppMaxSurge(app, replicas)
+	case constant.AutoPoolSizingHPAMax:
+		if hpaMaxReplicas > replicas {
+			return hpaMaxReplicas
+		}
+	}
+
+	return replicas
+}
+
+// GetAppMaxSurge returns how many pods the application may create above its replicas during a rolling update,
+// the percentage is rounded up just like what the Deployment and DaemonSet controllers do.
+// StatefulSet (including the OpenKruise Advanced StatefulSet) replaces its pods one by one with the same names, so it never surges.
+func GetAppMaxSurge(app interface{}, replicas int) int {
+	var maxSurge *intstr.IntOrString
+
+	switch object := app.(type) {
+	case *appsv1.Deployment:
+		if object.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			return 0
+		}
+		// the default max surge of Deployment is 25%
+		defaultMaxSurge := intstr.FromString("25%")
+		maxSurge = &defaultMaxSurge
+		if object.Spec.Strategy.RollingUpdate != nil && object.Spec.Strategy.RollingUpdate.MaxSurge != nil {
+			maxSurge = object.Spec.Strategy.RollingUpdate.MaxSurge
+		}
+	case *appsv1.DaemonSet:
+		if object.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType || object.Spec.UpdateStrategy.RollingUpdate == nil {
+			return 0
+		}
+		maxSurge = object.Spec.UpdateStrategy.RollingUpdate.MaxSurge
+	case *kruisev1alpha1.CloneSet:
+		maxSurge = object.Spec.UpdateStrategy.MaxSurge
+	case *unstructured.Unstructured:
+		if IsArgoRollout(object.GetAPIVersion(), object.GetKind()) {
+			return getRolloutMaxSurge(object, replicas)
+		}
+	}
+
+	if maxSurge == nil {
+		return 0
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(maxSurge, replicas, true)
+	if nil != err || surge < 0 {
+		return 0
+	}
+
+	return surge
+}
+
+// GetAppHPAMaxReplicas returns the max replicas of the HorizontalPodAutoscaler targeting the application, or 0 if there's none.
+func GetAppHPAMaxReplicas(hpas []*autoscalingv2.HorizontalPodAutoscaler, appNamespacedName types.AppNamespacedName) int {
+	appGV, err := schema.ParseGroupVersion(appNamespacedName.APIVersion)
+	if nil != err {
+		return 0
+	}
+
+	for _, hpa := range hpas {
+		if hpa.Namespace != appNamespacedName.Namespace {
+			continue
+		}
+
+		target := hpa.Spec.ScaleTargetRef
+		targetGV, err := schema.ParseGroupVersion(target.APIVersion)
+		if nil != err {
+			continue
+		}
+		if targetGV.Group == appGV.Group && target.Kind == appNamespacedName.Kind && target.Name == appNamespacedName.Name {
+			return int(hpa.Spec.MaxReplicas)
+		}
+	}
+
+	return 0
+}
+
 // IsAppExist will check the application whether exists or not. If it exists, it will return the application corresponding UID
 func IsAppExist(ctx context.Context, cacheClient client.Client, dynamicClient dynamic.Interface, appNamespacedName types.AppNamespacedName) (isExist bool, appUID apitypes.UID, err error) {
 	var object client.Object
@@ -491,19 +604,123 @@ func GenerateGVR(appNamespacedName types.AppNamespacedName) (schema.GroupVersion
 	return gvrPlural, nil
 }
 
+// IsThirdController checks whether the application is a third-party controller which SpiderSubnet has no informer for.
+// The OpenKruise CloneSet, Advanced StatefulSet and the Argo Rollout are supported just like the kubernetes controllers.
 func IsThirdController(appNamespacedName types.AppNamespacedName) bool {
 	isThird := false
 	if slices.Contains(constant.K8sAPIVersions, appNamespacedName.APIVersion) {
 		if !slices.Contains(constant.K8sKinds, appNamespacedName.Kind) {
 			isThird = true
 		}
-	} else {
+	} else if !IsKruiseCloneSet(appNamespacedName.APIVersion, appNamespacedName.Kind) &&
+		!IsKruiseAdvancedStatefulSet(appNamespacedName.APIVersion, appNamespacedName.Kind) &&
+		!IsArgoRollout(appNamespacedName.APIVersion, appNamespacedName.Kind) {
 		isThird = true
 	}
 
 	return isThird
 }
 
+// IsKruiseCloneSet checks whether the application is an OpenKruise CloneSet.
+func IsKruiseCloneSet(apiVersion, kind string) bool {
+	return apiVersion == kruisev1alpha1.SchemeGroupVersion.String() && kind == constant.KindCloneSet
+}
+
+// IsKruiseAdvancedStatefulSet checks whether the application is an OpenKruise Advanced StatefulSet, which is served by both
+// apps.kruise.io/v1alpha1 and apps.kruise.io/v1beta1.
+func IsKruiseAdvancedStatefulSet(apiVersion, kind string) bool {
+	return (apiVersion == kruisev1alpha1.SchemeGroupVersion.String() || apiVersion == kruisev1beta1.SchemeGroupVersion.String()) &&
+		kind == constant.KindStatefulSet
+}
+
+// IsArgoRollout checks whether the application is an Argo Rollout.
+func IsArgoRollout(apiVersion, kind string) bool {
+	return apiVersion == constant.ArgoRolloutsAPIVersion && kind == constant.KindRollout
+}
+
+// FromUnstructured converts the unstructured object watched by the dynamic informer to the given typed object,
+// the tombstone of the deleted object is unwrapped.
+func FromUnstructured(obj interface{}, into interface{}) error {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object %+v, it must be unstructured", obj)
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), into)
+}
+
+// GetRolloutReplicas returns the replicas of the Argo Rollout, it defaults to 1 just like Deployment.
+func GetRolloutReplicas(rollout *unstructured.Unstructured) int {
+	replicas, found, err := unstructured.NestedInt64(rollout.Object, "spec", "replicas")
+	if nil != err || !found {
+		return 1
+	}
+
+	return int(replicas)
+}
+
+// GetRolloutPodTemplate returns the pod template of the Argo Rollout. The Rollout referencing
+// a Deployment with 'workloadRef' has no pod template, an empty one would be returned.
+func GetRolloutPodTemplate(rollout *unstructured.Unstructured) (*corev1.PodTemplateSpec, error) {
+	return GetPodTemplate(rollout, "spec", "template")
+}
+
+// GetPodTemplate returns the pod template of the unstructured application at the given path,
+// an empty one would be returned if it has none.
+func GetPodTemplate(app *unstructured.Unstructured, fields ...string) (*corev1.PodTemplateSpec, error) {
+	var podTemplate corev1.PodTemplateSpec
+
+	template, found, err := unstructured.NestedMap(app.Object, fields...)
+	if nil != err {
+		return nil, fmt.Errorf("failed to get the pod template of %s %s/%s: %w", app.GetKind(), app.GetNamespace(), app.GetName(), err)
+	}
+	if !found {
+		return &podTemplate, nil
+	}
+
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate)
+	if nil != err {
+		return nil, fmt.Errorf("failed to parse the pod template of %s %s/%s: %w", app.GetKind(), app.GetNamespace(), app.GetName(), err)
+	}
+
+	return &podTemplate, nil
+}
+
+// getRolloutMaxSurge returns how many pods the Argo Rollout may create above its replicas during an update.
+// The canary strategy surges by 'maxSurge' which defaults to 25%, and the blue-green strategy brings up
+// the whole preview ReplicaSet, which is sized by 'previewReplicaCount' or the replicas.
+func getRolloutMaxSurge(rollout *unstructured.Unstructured, replicas int) int {
+	if _, found, _ := unstructured.NestedMap(rollout.Object, "spec", "strategy", "blueGreen"); found {
+		previewReplicas, found, err := unstructured.NestedInt64(rollout.Object, "spec", "strategy", "blueGreen", "previewReplicaCount")
+		if nil == err && found {
+			return int(previewReplicas)
+		}
+		return replicas
+	}
+
+	maxSurge := intstr.FromString("25%")
+	value, found, err := unstructured.NestedFieldNoCopy(rollout.Object, "spec", "strategy", "canary", "maxSurge")
+	if nil == err && found {
+		switch v := value.(type) {
+		case int64:
+			maxSurge = intstr.FromInt(int(v))
+		case string:
+			maxSurge = intstr.FromString(v)
+		}
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, replicas, true)
+	if nil != err || surge < 0 {
+		return 0
+	}
+
+	return surge
+}
+
 func IsReclaimAutoPoolLabelValue(isReclaim bool) string {
 	if isReclaim {
 		return constant.True
@@ -12,9 +12,15 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	kruisev1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
@@ -254,6 +260,16 @@ var _ = Describe("Utils", func() {
 			Expect(err).To(HaveOccurred())
 		})
 
+		It("invalid sizing policy", func() {
+			podAnno := map[string]string{
+				constant.AnnoSpiderSubnets:          defaultSubnetsAnno,
+				constant.AnnoSpiderSubnetPoolSizing: "unknown",
+			}
+
+			_, err := GetSubnetAnnoConfig(podAnno, log)
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("negative number", func() {
 			podAnno := map[string]string{
 				constant.AnnoSpiderSubnets:            defaultSubnetsAnno,
@@ -538,6 +554,205 @@ var _ = Describe("Utils", func() {
 		})
 	})
 
+	Context("GetAutoPoolSizingPolicy", Label("unittest", "GetAutoPoolSizingPolicy"), func() {
+		It("default sizing policy", func() {
+			policy, err := GetAutoPoolSizingPolicy(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(constant.AutoPoolSizingReplicas))
+		})
+
+		It("valid sizing policy", func() {
+			policy, err := GetAutoPoolSizingPolicy(map[string]string{constant.AnnoSpiderSubnetPoolSizing: constant.AutoPoolSizingHPAMax})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy).To(Equal(constant.AutoPoolSizingHPAMax))
+		})
+
+		It("invalid sizing policy", func() {
+			_, err := GetAutoPoolSizingPolicy(map[string]string{constant.AnnoSpiderSubnetPoolSizing: "unknown"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GetAppMaxSurge", Label("unittest", "GetAppMaxSurge"), func() {
+		It("Deployment with the default max surge", func() {
+			Expect(GetAppMaxSurge(&appsv1.Deployment{}, 5)).To(Equal(2))
+		})
+
+		It("Deployment with the specified max surge", func() {
+			maxSurge := intstr.FromInt(3)
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{
+						Type:          appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &maxSurge},
+					},
+				},
+			}
+			Expect(GetAppMaxSurge(deployment, 5)).To(Equal(3))
+		})
+
+		It("Deployment with Recreate strategy", func() {
+			deployment := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				},
+			}
+			Expect(GetAppMaxSurge(deployment, 5)).To(Equal(0))
+		})
+
+		It("DaemonSet with the specified max surge", func() {
+			maxSurge := intstr.FromString("50%")
+			daemonSet := &appsv1.DaemonSet{
+				Spec: appsv1.DaemonSetSpec{
+					UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+						Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxSurge: &maxSurge},
+					},
+				},
+			}
+			Expect(GetAppMaxSurge(daemonSet, 3)).To(Equal(2))
+		})
+
+		It("StatefulSet never surges", func() {
+			Expect(GetAppMaxSurge(&appsv1.StatefulSet{}, 5)).To(Equal(0))
+			Expect(GetAppMaxSurge(&kruisev1beta1.StatefulSet{}, 5)).To(Equal(0))
+		})
+
+		It("CloneSet with the specified max surge", func() {
+			maxSurge := intstr.FromString("20%")
+			cloneSet := &kruisev1.CloneSet{
+				Spec: kruisev1.CloneSetSpec{
+					UpdateStrategy: kruisev1.CloneSetUpdateStrategy{MaxSurge: &maxSurge},
+				},
+			}
+			Expect(GetAppMaxSurge(cloneSet, 10)).To(Equal(2))
+			Expect(GetAppMaxSurge(&kruisev1.CloneSet{}, 10)).To(Equal(0))
+		})
+
+		It("Rollout with canary strategy", func() {
+			rollout := newRollout(map[string]interface{}{"canary": map[string]interface{}{}})
+			Expect(GetAppMaxSurge(rollout, 5)).To(Equal(2))
+
+			rollout = newRollout(map[string]interface{}{"canary": map[string]interface{}{"maxSurge": int64(3)}})
+			Expect(GetAppMaxSurge(rollout, 5)).To(Equal(3))
+		})
+
+		It("Rollout with blue-green strategy", func() {
+			rollout := newRollout(map[string]interface{}{"blueGreen": map[string]interface{}{}})
+			Expect(GetAppMaxSurge(rollout, 5)).To(Equal(5))
+
+			rollout = newRollout(map[string]interface{}{"blueGreen": map[string]interface{}{"previewReplicaCount": int64(1)}})
+			Expect(GetAppMaxSurge(rollout, 5)).To(Equal(1))
+		})
+	})
+
+	Context("Argo Rollout", Label("unittest", "Rollout"), func() {
+		It("get replicas", func() {
+			rollout := newRollout(nil)
+			Expect(GetRolloutReplicas(rollout)).To(Equal(3))
+
+			delete(rollout.Object["spec"].(map[string]interface{}), "replicas")
+			Expect(GetRolloutReplicas(rollout)).To(Equal(1))
+		})
+
+		It("get pod template", func() {
+			podTemplate, err := GetRolloutPodTemplate(newRollout(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podTemplate.Annotations).To(HaveKeyWithValue(constant.AnnoSpiderSubnetPoolIPNumber, "+1"))
+		})
+
+		It("get pod template of Rollout referencing a workload", func() {
+			rollout := newRollout(nil)
+			delete(rollout.Object["spec"].(map[string]interface{}), "template")
+
+			podTemplate, err := GetRolloutPodTemplate(rollout)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podTemplate.Annotations).To(BeEmpty())
+		})
+
+		It("failed to get pod template", func() {
+			rollout := newRollout(nil)
+			rollout.Object["spec"].(map[string]interface{})["template"] = "invalid"
+
+			_, err := GetRolloutPodTemplate(rollout)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("FromUnstructured", Label("unittest", "FromUnstructured"), func() {
+		It("convert unstructured object", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": kruisev1.SchemeGroupVersion.String(),
+				"kind":       constant.KindCloneSet,
+				"metadata":   map[string]interface{}{"name": "clone", "namespace": "default"},
+				"spec":       map[string]interface{}{"replicas": int64(2)},
+			}}
+
+			var cloneSet kruisev1.CloneSet
+			err := FromUnstructured(cache.DeletedFinalStateUnknown{Obj: obj}, &cloneSet)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloneSet.Name).To(Equal("clone"))
+			Expect(*cloneSet.Spec.Replicas).To(Equal(int32(2)))
+		})
+
+		It("failed to convert typed object", func() {
+			var cloneSet kruisev1.CloneSet
+			err := FromUnstructured(&appsv1.Deployment{}, &cloneSet)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("GetAutoPoolReplicas", Label("unittest", "GetAutoPoolReplicas"), func() {
+		It("replicas policy", func() {
+			Expect(GetAutoPoolReplicas(constant.AutoPoolSizingReplicas, &appsv1.Deployment{}, 4, 10)).To(Equal(4))
+		})
+
+		It("surge policy", func() {
+			Expect(GetAutoPoolReplicas(constant.AutoPoolSizingSurge, &appsv1.Deployment{}, 4, 10)).To(Equal(5))
+		})
+
+		It("hpa-max policy", func() {
+			Expect(GetAutoPoolReplicas(constant.AutoPoolSizingHPAMax, &appsv1.Deployment{}, 4, 10)).To(Equal(10))
+			Expect(GetAutoPoolReplicas(constant.AutoPoolSizingHPAMax, &appsv1.Deployment{}, 4, 0)).To(Equal(4))
+		})
+	})
+
+	Context("GetAppHPAMaxReplicas", Label("unittest", "GetAppHPAMaxReplicas"), func() {
+		appNamespacedName := types.AppNamespacedName{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       constant.KindDeployment,
+			Namespace:  "default",
+			Name:       "deploy",
+		}
+		newHPA := func(namespace, apiVersion, kind, name string, maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+			return &autoscalingv2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: apiVersion,
+						Kind:       kind,
+						Name:       name,
+					},
+					MaxReplicas: maxReplicas,
+				},
+			}
+		}
+
+		It("no HPA", func() {
+			Expect(GetAppHPAMaxReplicas(nil, appNamespacedName)).To(Equal(0))
+		})
+
+		It("match the HPA of the application", func() {
+			hpas := []*autoscalingv2.HorizontalPodAutoscaler{
+				newHPA("other", "apps/v1", constant.KindDeployment, "deploy", 20),
+				newHPA("default", "apps/v1", constant.KindStatefulSet, "deploy", 30),
+				newHPA("default", "apps/v1", constant.KindDeployment, "other", 40),
+				newHPA("default", "apps/v1", constant.KindDeployment, "deploy", 8),
+			}
+			Expect(GetAppHPAMaxReplicas(hpas, appNamespacedName)).To(Equal(8))
+		})
+	})
+
 	Context("GenerateGVR", Labels{"unittest", "GenerateGVR"}, func() {
 		It("appsv1-deployment", func() {
 			appNamespacedName := types.AppNamespacedName{
@@ -597,6 +812,27 @@ var _ = Describe("Utils", func() {
 			appNamespacedName.APIVersion = kruisev1.SchemeGroupVersion.String()
 			appNamespacedName.Kind = "CloneSet"
 			isThirdController := IsThirdController(appNamespacedName)
+			Expect(isThirdController).To(BeFalse())
+		})
+
+		It("openkruise-advanced-statefulset", func() {
+			appNamespacedName.APIVersion = kruisev1beta1.SchemeGroupVersion.String()
+			appNamespacedName.Kind = constant.KindStatefulSet
+			isThirdController := IsThirdController(appNamespacedName)
+			Expect(isThirdController).To(BeFalse())
+		})
+
+		It("argo-rollout", func() {
+			appNamespacedName.APIVersion = constant.ArgoRolloutsAPIVersion
+			appNamespacedName.Kind = constant.KindRollout
+			isThirdController := IsThirdController(appNamespacedName)
+			Expect(isThirdController).To(BeFalse())
+		})
+
+		It("openkruise-united-deployment", func() {
+			appNamespacedName.APIVersion = kruisev1.SchemeGroupVersion.String()
+			appNamespacedName.Kind = "UnitedDeployment"
+			isThirdController := IsThirdController(appNamespacedName)
 			Expect(isThirdController).To(BeTrue())
 		})
 
@@ -632,3 +868,20 @@ var _ = Describe("Utils", func() {
 		})
 	})
 })
+
+func newRollout(strategy map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": constant.ArgoRolloutsAPIVersion,
+		"kind":       constant.KindRollout,
+		"metadata":   map[string]interface{}{"name": "rollout", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"strategy": strategy,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{constant.AnnoSpiderSubnetPoolIPNumber: "+1"},
+				},
+			},
+		},
+	}}
+}
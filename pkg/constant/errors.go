@@ -14,6 +14,8 @@ var (
 	ErrIPUsedOut        = errors.New("all IP addresses used out")
 )
 
+var ErrIPAllocationDrift = errors.New("IP allocation drift")
+
 var ErrMissingRequiredParam = errors.New("must be specified")
 
 var ErrUnknown = errors.New("unknown")
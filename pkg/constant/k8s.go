@@ -33,8 +33,13 @@ const (
 	KindCronJob     = "CronJob"
 	KindKubevirtVM  = "VirtualMachine"
 	KindKubevirtVMI = "VirtualMachineInstance"
+	KindCloneSet    = "CloneSet"
+	KindRollout     = "Rollout"
 )
 
+// ArgoRolloutsAPIVersion is the API version of Argo Rollouts, whose API is not vendored.
+const ArgoRolloutsAPIVersion = "argoproj.io/v1alpha1"
+
 var K8sKinds = []string{KindPod, KindDeployment, KindReplicaSet, KindDaemonSet, KindStatefulSet, KindJob, KindCronJob}
 var K8sAPIVersions = []string{corev1.SchemeGroupVersion.String(), appsv1.SchemeGroupVersion.String(), batchv1.SchemeGroupVersion.String()}
 var AutoPoolPodAffinities = []string{AutoPoolPodAffinityAppAPIGroup, AutoPoolPodAffinityAppAPIVersion, AutoPoolPodAffinityAppKind, AutoPoolPodAffinityAppNS, AutoPoolPodAffinityAppName}
@@ -56,6 +61,8 @@ const (
 	AnnoPodIPPools      = AnnotationPre + "/ippools"
 	AnnoPodRoutes       = AnnotationPre + "/routes"
 	AnnoPodDNS          = AnnotationPre + "/dns"
+	AnnoPodIPs          = AnnotationPre + "/ips"
+	AnnoPodStickyIP     = AnnotationPre + "/sticky-ip"
 	AnnoNSDefautlV4Pool = AnnotationPre + "/default-ipv4-ippool"
 	AnnoNSDefautlV6Pool = AnnotationPre + "/default-ipv6-ippool"
 
@@ -64,6 +71,7 @@ const (
 	AnnoSpiderSubnets             = AnnotationPre + "/subnets"
 	AnnoSpiderSubnetPoolIPNumber  = AnnotationPre + "/ippool-ip-number"
 	AnnoSpiderSubnetReclaimIPPool = AnnotationPre + "/ippool-reclaim"
+	AnnoSpiderSubnetPoolSizing    = AnnotationPre + "/ippool-sizing"
 
 	LabelIPPoolReclaimIPPool             = AnnoSpiderSubnetReclaimIPPool
 	LabelIPPoolOwnerSpiderSubnet         = AnnotationPre + "/owner-spider-subnet"
@@ -95,6 +103,7 @@ const (
 
 	// Coordinator
 	AnnoDefaultRouteInterface = AnnotationPre + "/default-route-nic"
+	AnnoSpiderCoordinator     = AnnotationPre + "/coordinator"
 )
 
 const (
@@ -136,6 +145,37 @@ const (
 	EventReasonScaleIPPool  = "ScaleIPPool"
 	EventReasonDeleteIPPool = "DeleteIPPool"
 	EventReasonResyncSubnet = "ResyncSubnet"
+
+	EventReasonNearlyExhausted = "NearlyExhausted"
+	EventReasonUsageRecovered  = "UsageRecovered"
+
+	EventReasonNetAttachDefRestored = "NetAttachDefRestored"
+
+	EventReasonCoordinatorNotFound = "CoordinatorNotFound"
+)
+
+// status conditions of the Spiderpool CRs
+const (
+	ConditionReady           = "Ready"
+	ConditionNearlyExhausted = "NearlyExhausted"
+	ConditionDrifted         = "Drifted"
+
+	ConditionReasonAvailable      = "Available"
+	ConditionReasonTerminating    = "Terminating"
+	ConditionReasonDisabled       = "Disabled"
+	ConditionReasonSubnetNotFound = "SubnetNotFound"
+	ConditionReasonOverlapping    = "Overlapping"
+	ConditionReasonNoAvailableIPs = "NoAvailableIPs"
+	ConditionReasonSynced         = "Synced"
+	ConditionReasonSyncing        = "Syncing"
+	ConditionReasonSyncFailed     = "SyncFailed"
+
+	ConditionReasonBelowThresholds          = "BelowThresholds"
+	ConditionReasonWarningThresholdReached  = "WarningThresholdReached"
+	ConditionReasonCriticalThresholdReached = "CriticalThresholdReached"
+
+	ConditionReasonInSync           = "InSync"
+	ConditionReasonManuallyModified = "ManuallyModified"
 )
 
 const ClusterDefaultInterfaceName = "eth0"
@@ -148,6 +188,23 @@ const (
 	ResourceNameOvsCniValue      = "ovs-cni.network.kubevirt.io"
 )
 
+// sizing policies of the auto-created IPPools, which decide the replicas the
+// flexible IP number of annotation ipam.spidernet.io/ippool-ip-number is
+// added to
+const (
+	AutoPoolSizingReplicas = "replicas"
+	AutoPoolSizingSurge    = "surge"
+	AutoPoolSizingHPAMax   = "hpa-max"
+)
+
+// IP allocation strategies of SpiderIPPool and SpiderSubnet
+const (
+	AllocationStrategyLowest                = "lowest"
+	AllocationStrategyRandom                = "random"
+	AllocationStrategyRoundRobin            = "round-robin"
+	AllocationStrategyLeastRecentlyReleased = "least-recently-released"
+)
+
 const (
 	MacvlanCNI = "macvlan"
 	IPVlanCNI  = "ipvlan"
@@ -157,3 +214,9 @@ const (
 	OvsCNI     = "ovs"
 	CustomCNI  = "custom"
 )
+
+// drift policies of SpiderMultusConfig
+const (
+	DriftPolicyRestore = "restore"
+	DriftPolicyReport  = "report"
+)
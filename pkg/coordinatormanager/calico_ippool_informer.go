@@ -9,6 +9,7 @@ import (
 	"reflect"
 
 	calicov1 "github.com/tigera/operator/pkg/apis/crd.projectcalico.org/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -67,13 +68,15 @@ func (r *calicoIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if coordinator.Status.Phase == Synced && reflect.DeepEqual(coordinator.Status.OverlayPodCIDR, podCIDR) {
+	if coordinator.Status.Phase == Synced && reflect.DeepEqual(coordinator.Status.OverlayPodCIDR, podCIDR) &&
+		meta.IsStatusConditionTrue(coordinator.Status.Conditions, constant.ConditionReady) {
 		return ctrl.Result{}, nil
 	}
 
 	origin := coordinator.DeepCopy()
 	coordinator.Status.Phase = Synced
 	coordinator.Status.OverlayPodCIDR = podCIDR
+	setReadyCondition(&coordinator, nil)
 	if err := r.client.Status().Patch(ctx, &coordinator, client.MergeFrom(origin)); err != nil {
 		return ctrl.Result{Requeue: true}, err
 	}
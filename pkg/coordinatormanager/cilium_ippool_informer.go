@@ -10,6 +10,7 @@ import (
 	v2alpha1 "github.com/cilium/cilium/pkg/k8s/apis/cilium.io/v2alpha1"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -71,13 +72,15 @@ func (r *ciliumIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{Requeue: true}, err
 	}
 
-	if coordinator.Status.Phase == Synced && reflect.DeepEqual(coordinator.Status.OverlayPodCIDR, podCIDR) {
+	if coordinator.Status.Phase == Synced && reflect.DeepEqual(coordinator.Status.OverlayPodCIDR, podCIDR) &&
+		meta.IsStatusConditionTrue(coordinator.Status.Conditions, constant.ConditionReady) {
 		return ctrl.Result{}, nil
 	}
 
 	origin := coordinator.DeepCopy()
 	coordinator.Status.Phase = Synced
 	coordinator.Status.OverlayPodCIDR = podCIDR
+	setReadyCondition(&coordinator, nil)
 	if err := r.client.Status().Patch(ctx, &coordinator, client.MergeFrom(origin)); err != nil {
 		return ctrl.Result{Requeue: true}, err
 	}
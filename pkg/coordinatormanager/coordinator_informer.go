@@ -10,7 +10,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
-	"sync/atomic"
+	"sync"
 	"time"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
@@ -30,7 +30,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
@@ -70,12 +72,14 @@ const messageEnqueueCoordiantor = "Enqueue Coordinator"
 var InformerLogger *zap.Logger
 
 type CoordinatorController struct {
-	Manager         ctrl.Manager
-	Client          client.Client
-	APIReader       client.Reader
-	coordinatorName atomic.Value
+	Manager   ctrl.Manager
+	Client    client.Client
+	APIReader client.Reader
 
-	caliCtrlCanncel context.CancelFunc
+	// ipPoolCtrls are the running calico or cilium IPPool controllers
+	// syncing the overlay Pod CIDR, by coordinator name.
+	ipPoolCtrlsLock sync.Mutex
+	ipPoolCtrls     map[string]*ipPoolCtrl
 
 	CoordinatorLister spiderlisters.SpiderCoordinatorLister
 	ConfigmapLister   corelister.ConfigMapLister
@@ -179,7 +183,7 @@ func (cc *CoordinatorController) addEventHandlers(
 	_, err := coordinatorInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    cc.enqueueCoordinatorOnAdd,
 		UpdateFunc: cc.enqueueCoordinatorOnUpdate,
-		DeleteFunc: nil,
+		DeleteFunc: cc.stopIPPoolControllerOnDelete,
 	})
 	if err != nil {
 		return err
@@ -241,14 +245,33 @@ func (cc *CoordinatorController) enqueueCoordinatorOnCiliumConfigChange(obj inte
 		zap.String("Operation", "SYNC"),
 	)
 
-	v := cc.coordinatorName.Load()
-	cn, ok := v.(string)
-	if !ok {
+	coordList, err := cc.CoordinatorLister.List(labels.Everything())
+	if err != nil {
+		logger.Sugar().Errorf("failed to list Coordinators: %v", err)
 		return
 	}
 
-	cc.Workqueue.Add(cn)
-	logger.Debug(messageEnqueueCoordiantor)
+	for _, coord := range coordList {
+		cc.Workqueue.Add(coord.Name)
+		logger.Debug(messageEnqueueCoordiantor, zap.String("CoordinatorName", coord.Name))
+	}
+}
+
+func (cc *CoordinatorController) stopIPPoolControllerOnDelete(obj interface{}) {
+	coord, ok := obj.(*spiderpoolv2beta1.SpiderCoordinator)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		coord, ok = tombstone.Obj.(*spiderpoolv2beta1.SpiderCoordinator)
+		if !ok {
+			return
+		}
+	}
+
+	InformerLogger.Debug("Stop IPPool controller of deleted Coordinator", zap.String("CoordinatorName", coord.Name))
+	cc.stopIPPoolController(coord.Name)
 }
 
 func (cc *CoordinatorController) run(ctx context.Context, workers int) error {
@@ -304,12 +327,6 @@ func (cc *CoordinatorController) processNextWorkItem(ctx context.Context) bool {
 func (cc *CoordinatorController) syncHandler(ctx context.Context, coordinatorName string) (err error) {
 	logger := logutils.FromContext(ctx)
 
-	v := cc.coordinatorName.Load()
-	_, ok := v.(string)
-	if !ok {
-		cc.coordinatorName.Store(coordinatorName)
-	}
-
 	coord, err := cc.CoordinatorLister.Get(coordinatorName)
 	if err != nil {
 		return client.IgnoreNotFound(err)
@@ -320,6 +337,7 @@ func (cc *CoordinatorController) syncHandler(ctx context.Context, coordinatorNam
 	if err != nil {
 		logger.Sugar().Errorf("failed to handle spidercoordinator: %v", err)
 	}
+	setReadyCondition(coordCopy, err)
 
 	if !reflect.DeepEqual(coordCopy.Status, coord.Status) {
 		logger.Sugar().Infof(" Try to patch coordinator's status from %v to %v", coord.Status, coordCopy.Status)
@@ -383,10 +401,7 @@ func (cc *CoordinatorController) fetchPodAndServerCIDR(ctx context.Context, logg
 
 	switch *coordCopy.Spec.PodCIDRType {
 	case cluster:
-		if cc.caliCtrlCanncel != nil {
-			cc.caliCtrlCanncel()
-			cc.caliCtrlCanncel = nil
-		}
+		cc.stopIPPoolController(coordCopy.Name)
 		coordCopy.Status.Phase = Synced
 		coordCopy.Status.OverlayPodCIDR = k8sPodCIDR
 	case calico:
@@ -398,6 +413,7 @@ func (cc *CoordinatorController) fetchPodAndServerCIDR(ctx context.Context, logg
 			return coordCopy, err
 		}
 	case none:
+		cc.stopIPPoolController(coordCopy.Name)
 		coordCopy.Status.Phase = Synced
 		coordCopy.Status.OverlayPodCIDR = []string{}
 	}
@@ -423,10 +439,7 @@ func (cc *CoordinatorController) fetchCalicoIPPools(ctx context.Context, logger
 		return err
 	}
 
-	if cc.caliCtrlCanncel != nil {
-		cc.caliCtrlCanncel()
-		cc.caliCtrlCanncel = nil
-	}
+	cc.stopIPPoolController(coordCopy.Name)
 
 	var calicoController controller.Controller
 	calicoController, err = NewCalicoIPPoolController(cc.Manager, coordCopy.Name)
@@ -435,27 +448,12 @@ func (cc *CoordinatorController) fetchCalicoIPPools(ctx context.Context, logger
 		return err
 	}
 
-	ctx, cc.caliCtrlCanncel = context.WithCancel(ctx)
-	go func() {
-		logger.Info("Starting Calico IPPool controller")
-		if err := calicoController.Start(ctx); err != nil {
-			logger.Sugar().Errorf("Failed to start Calico IPPool controller: %v", err)
-		}
-		logger.Info("Shutdown Calico IPPool controller")
-		if cc.caliCtrlCanncel != nil {
-			cc.caliCtrlCanncel()
-			cc.caliCtrlCanncel = nil
-		}
-	}()
-
+	cc.startIPPoolController(ctx, logger.With(zap.String("IPPoolController", calico)), coordCopy.Name, calicoController)
 	return nil
 }
 
 func (cc *CoordinatorController) fetchCiliumCIDR(ctx context.Context, logger *zap.Logger, k8sPodCIDR []string, coordCopy *spiderpoolv2beta1.SpiderCoordinator) error {
-	if cc.caliCtrlCanncel != nil {
-		cc.caliCtrlCanncel()
-		cc.caliCtrlCanncel = nil
-	}
+	cc.stopIPPoolController(coordCopy.Name)
 
 	ns, name := stringutil.ParseNsAndName(cc.CiliumConfigMap)
 	if ns == "" && name == "" {
@@ -515,18 +513,7 @@ func (cc *CoordinatorController) fetchCiliumCIDR(ctx context.Context, logger *za
 			return err
 		}
 
-		ctx, cc.caliCtrlCanncel = context.WithCancel(ctx)
-		go func() {
-			logger.Info("Starting Cilium IPPool controller")
-			if err := ciliumController.Start(ctx); err != nil {
-				logger.Sugar().Errorf("Failed to start Cilium IPPool controller: %v", err)
-			}
-			logger.Info("Shutdown Cilium IPPool controller")
-			if cc.caliCtrlCanncel != nil {
-				cc.caliCtrlCanncel()
-				cc.caliCtrlCanncel = nil
-			}
-		}()
+		cc.startIPPoolController(ctx, logger.With(zap.String("IPPoolController", cilium)), coordCopy.Name, ciliumController)
 	case option.IPAMKubernetes:
 		coordCopy.Status.OverlayPodCIDR = k8sPodCIDR
 		coordCopy.Status.Phase = Synced
@@ -538,6 +525,50 @@ func (cc *CoordinatorController) fetchCiliumCIDR(ctx context.Context, logger *za
 	return nil
 }
 
+type ipPoolCtrl struct {
+	cancel context.CancelFunc
+}
+
+// startIPPoolController starts the calico or cilium IPPool controller
+// syncing the overlay Pod CIDR of the coordinator, it is stopped when the
+// coordinator no longer needs it or is deleted.
+func (cc *CoordinatorController) startIPPoolController(ctx context.Context, logger *zap.Logger, coordName string, c controller.Controller) {
+	ctx, cancel := context.WithCancel(ctx)
+	ipc := &ipPoolCtrl{cancel: cancel}
+
+	cc.ipPoolCtrlsLock.Lock()
+	if cc.ipPoolCtrls == nil {
+		cc.ipPoolCtrls = map[string]*ipPoolCtrl{}
+	}
+	cc.ipPoolCtrls[coordName] = ipc
+	cc.ipPoolCtrlsLock.Unlock()
+
+	go func() {
+		logger.Info("Starting IPPool controller")
+		if err := c.Start(ctx); err != nil {
+			logger.Sugar().Errorf("Failed to start IPPool controller: %v", err)
+		}
+		logger.Info("Shutdown IPPool controller")
+
+		cancel()
+		cc.ipPoolCtrlsLock.Lock()
+		if cc.ipPoolCtrls[coordName] == ipc {
+			delete(cc.ipPoolCtrls, coordName)
+		}
+		cc.ipPoolCtrlsLock.Unlock()
+	}()
+}
+
+func (cc *CoordinatorController) stopIPPoolController(coordName string) {
+	cc.ipPoolCtrlsLock.Lock()
+	defer cc.ipPoolCtrlsLock.Unlock()
+
+	if ipc, ok := cc.ipPoolCtrls[coordName]; ok {
+		ipc.cancel()
+		delete(cc.ipPoolCtrls, coordName)
+	}
+}
+
 func extractK8sCIDR(kcm *corev1.Pod) ([]string, []string) {
 	var podCIDR, serviceCIDR []string
 
@@ -596,6 +627,34 @@ func fetchType(cniDir string) (string, error) {
 	}
 }
 
+// setReadyCondition sets the Ready condition by the phase of the
+// SpiderCoordinator and the error of syncing it.
+func setReadyCondition(coord *spiderpoolv2beta1.SpiderCoordinator, syncErr error) {
+	condition := metav1.Condition{
+		Type:               constant.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: coord.Generation,
+	}
+
+	switch {
+	case syncErr != nil:
+		condition.Reason = constant.ConditionReasonSyncFailed
+		condition.Message = syncErr.Error()
+	case coord.Status.Phase == Synced:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = constant.ConditionReasonSynced
+		condition.Message = "the pod and service CIDRs are synced"
+	case coord.Status.Phase == NotReady:
+		condition.Reason = constant.ConditionReasonSyncFailed
+		condition.Message = "the pod and service CIDRs could not be synced, see the events for details"
+	default:
+		condition.Reason = constant.ConditionReasonSyncing
+		condition.Message = fmt.Sprintf("waiting for the pod CIDRs of %s", *coord.Spec.PodCIDRType)
+	}
+
+	meta.SetStatusCondition(&coord.Status.Conditions, condition)
+}
+
 func setStatus2NoReady(logger *zap.Logger, copy *spiderpoolv2beta1.SpiderCoordinator) {
 	if copy.Status.Phase != NotReady {
 		logger.Sugar().Infof("set spidercoordinator phase from %s to NotReady", copy.Status.Phase)
@@ -17,6 +17,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/networking/gwconnection"
 )
 
 func mutateCoordinator(ctx context.Context, coord *spiderpoolv2beta1.SpiderCoordinator) error {
@@ -41,11 +42,21 @@ func mutateCoordinator(ctx context.Context, coord *spiderpoolv2beta1.SpiderCoord
 	if coord.Spec.DetectGateway == nil {
 		coord.Spec.DetectGateway = pointer.Bool(false)
 	}
+	if coord.Spec.DetectGatewayMode == nil {
+		coord.Spec.DetectGatewayMode = pointer.String(gwconnection.ModeICMP)
+	}
+	if coord.Spec.DetectGatewayLossThreshold == nil {
+		coord.Spec.DetectGatewayLossThreshold = pointer.Int(0)
+	}
 
 	if coord.Spec.TxQueueLen == nil {
 		coord.Spec.TxQueueLen = pointer.Int(0)
 	}
 
+	if coord.Spec.VethMTU == nil {
+		coord.Spec.VethMTU = pointer.Int(0)
+	}
+
 	if coord.DeletionTimestamp != nil {
 		logger.Info("Terminating Coordinator, noting to mutate")
 		return nil
@@ -21,6 +21,7 @@ var (
 	podMACPrefixField *field.Path = field.NewPath("spec").Child("podMACPrefix")
 	hostRPFilterField *field.Path = field.NewPath("spec").Child("hostRPFilter")
 	txQueueLenField   *field.Path = field.NewPath("spec").Child("txQueueLen")
+	vethMTUField      *field.Path = field.NewPath("spec").Child("vethMTU")
 )
 
 func validateCreateCoordinator(coord *spiderpoolv2beta1.SpiderCoordinator) field.ErrorList {
@@ -75,6 +76,10 @@ func ValidateCoordinatorSpec(spec *spiderpoolv2beta1.CoordinatorSpec, requireOpt
 		return field.Invalid(txQueueLenField, *spec.TxQueueLen, "txQueueLen can't be less than 0")
 	}
 
+	if spec.VethMTU != nil && *spec.VethMTU != 0 && (*spec.VethMTU < 68 || *spec.VethMTU > 65535) {
+		return field.Invalid(vethMTUField, *spec.VethMTU, "vethMTU must be 0 or in range [68,65535]")
+	}
+
 	if requireOptionalType && spec.HostRPFilter == nil {
 		return field.NotSupported(
 			hostRPFilterField,
@@ -6,27 +6,26 @@ package gcmanager
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
-	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
-	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 type GarbageCollectionConfig struct {
 	EnableGCIP                bool
 	EnableGCForTerminatingPod bool
-	EnableStatefulSet         bool
-	EnableKubevirtStaticIP    bool
 
 	ReleaseIPWorkerNum     int
 	GCIPChannelBuffer      int
@@ -44,10 +43,31 @@ type GarbageCollectionConfig struct {
 
 var logger *zap.Logger
 
+// GCScope restricts a round of IP garbage collection to the IP allocations
+// of a single IPPool, namespace or IP. With DryRun set, the IP allocations
+// are only listed rather than released.
+type GCScope struct {
+	IPPool    string
+	Namespace string
+	IP        string
+	DryRun    bool
+}
+
+// GCRecord describes an IP allocation released (or to be released in
+// dry-run mode) by the IP garbage collection.
+type GCRecord struct {
+	IPPool         string
+	IP             string
+	NamespacedName string
+	PodUID         string
+	Reason         string
+}
+
 type GCManager interface {
 	Start(ctx context.Context) <-chan error
 	GetPodDatabase() PodDBer
 	TriggerGCAll()
+	TriggerGCWithScope(ctx context.Context, scope GCScope) ([]GCRecord, error)
 	Health() bool
 }
 
@@ -64,12 +84,11 @@ type SpiderGC struct {
 	gcSignal         chan struct{}
 	gcIPPoolIPSignal chan *PodEntry
 
-	wepMgr      workloadendpointmanager.WorkloadEndpointManager
-	ippoolMgr   ippoolmanager.IPPoolManager
-	podMgr      podmanager.PodManager
-	stsMgr      statefulsetmanager.StatefulSetManager
-	kubevirtMgr kubevirtmanager.KubevirtManager
-	leader      election.SpiderLeaseElector
+	wepMgr        workloadendpointmanager.WorkloadEndpointManager
+	ippoolMgr     ippoolmanager.IPPoolManager
+	podMgr        podmanager.PodManager
+	workloadKinds workloadkind.Registry
+	leader        election.SpiderLeaseElector
 
 	informerFactory informers.SharedInformerFactory
 	gcLimiter       limiter.Limiter
@@ -79,8 +98,7 @@ func NewGCManager(clientSet *kubernetes.Clientset, config *GarbageCollectionConf
 	wepManager workloadendpointmanager.WorkloadEndpointManager,
 	ippoolManager ippoolmanager.IPPoolManager,
 	podManager podmanager.PodManager,
-	stsManager statefulsetmanager.StatefulSetManager,
-	kubevirtMgr kubevirtmanager.KubevirtManager,
+	workloadKinds workloadkind.Registry,
 	spiderControllerLeader election.SpiderLeaseElector) (GCManager, error) {
 	if clientSet == nil {
 		return nil, fmt.Errorf("k8s ClientSet must be specified")
@@ -102,6 +120,10 @@ func NewGCManager(clientSet *kubernetes.Clientset, config *GarbageCollectionConf
 		return nil, fmt.Errorf("pod manager must be specified")
 	}
 
+	if workloadKinds == nil {
+		return nil, fmt.Errorf("workload kind registry must be specified")
+	}
+
 	if spiderControllerLeader == nil {
 		return nil, fmt.Errorf("spiderpool controller leader must be specified")
 	}
@@ -116,11 +138,10 @@ func NewGCManager(clientSet *kubernetes.Clientset, config *GarbageCollectionConf
 		gcSignal:         make(chan struct{}, 1),
 		gcIPPoolIPSignal: make(chan *PodEntry, config.GCIPChannelBuffer),
 
-		wepMgr:      wepManager,
-		ippoolMgr:   ippoolManager,
-		podMgr:      podManager,
-		stsMgr:      stsManager,
-		kubevirtMgr: kubevirtMgr,
+		wepMgr:        wepManager,
+		ippoolMgr:     ippoolManager,
+		podMgr:        podManager,
+		workloadKinds: workloadKinds,
 
 		leader:    spiderControllerLeader,
 		gcLimiter: limiter.NewLimiter(limiter.LimiterConfig{}),
@@ -175,6 +196,25 @@ func (s *SpiderGC) TriggerGCAll() {
 	}
 }
 
+// TriggerGCWithScope scans the IP allocations within the scope at once and
+// returns the ones released, or only the ones to be released in dry-run mode.
+func (s *SpiderGC) TriggerGCWithScope(ctx context.Context, scope GCScope) ([]GCRecord, error) {
+	if !s.gcConfig.EnableGCIP {
+		return nil, fmt.Errorf("IP garbage collection is forbidden")
+	}
+
+	if scope.IP != "" {
+		ip := net.ParseIP(scope.IP)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: invalid IP address '%s'", constant.ErrWrongInput, scope.IP)
+		}
+		scope.IP = ip.String()
+	}
+
+	logger.Sugar().Infof("trigger gc with scope %+v", scope)
+	return s.scanAll(ctx, &scope)
+}
+
 const waitForCacheSyncTimeout = 5 * time.Second
 
 func (s *SpiderGC) Health() bool {
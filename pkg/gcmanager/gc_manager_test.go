@@ -0,0 +1,207 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
+)
+
+// fakeIPPoolManager lists the IPPools and records the IP addresses released,
+// the methods not overridden panic since they are not expected to be called.
+type fakeIPPoolManager struct {
+	ippoolmanager.IPPoolManager
+
+	l        lock.Mutex
+	pools    []spiderpoolv2beta1.SpiderIPPool
+	released map[string][]types.IPAndUID
+}
+
+func (f *fakeIPPoolManager) ListIPPools(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderIPPoolList, error) {
+	return &spiderpoolv2beta1.SpiderIPPoolList{Items: f.pools}, nil
+}
+
+func (f *fakeIPPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUIDs []types.IPAndUID) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	f.released[poolName] = append(f.released[poolName], ipAndUIDs...)
+	return nil
+}
+
+// fakePodManager finds no Pod.
+type fakePodManager struct {
+	podmanager.PodManager
+}
+
+func (f *fakePodManager) GetPodByName(ctx context.Context, namespace, podName string, cached bool) (*corev1.Pod, error) {
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, podName)
+}
+
+// fakeEndpointManager finds no SpiderEndpoint.
+type fakeEndpointManager struct {
+	workloadendpointmanager.WorkloadEndpointManager
+}
+
+func (f *fakeEndpointManager) GetEndpointByName(ctx context.Context, namespace, podName string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "spiderendpoints"}, podName)
+}
+
+type fakeLeader struct {
+	election.SpiderLeaseElector
+}
+
+func (f *fakeLeader) IsElected() bool {
+	return true
+}
+
+type fakeRegistry struct {
+	workloadkind.Registry
+}
+
+var _ = Describe("GCManager", Label("gc_manager_test"), func() {
+	Describe("TriggerGCWithScope", func() {
+		var ctx context.Context
+		var ipPoolManager *fakeIPPoolManager
+		var gcManager gcmanager.GCManager
+
+		newPool := func(name string, records spiderpoolv2beta1.PoolIPAllocations) spiderpoolv2beta1.SpiderIPPool {
+			data, err := convert.MarshalIPPoolAllocatedIPs(records)
+			Expect(err).NotTo(HaveOccurred())
+
+			return spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       spiderpoolv2beta1.IPPoolSpec{IPVersion: pointer.Int64(constant.IPv4)},
+				Status:     spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: data},
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			ipPoolManager = &fakeIPPoolManager{
+				pools: []spiderpoolv2beta1.SpiderIPPool{
+					newPool("pool-a", spiderpoolv2beta1.PoolIPAllocations{
+						"172.18.40.1": {NamespacedName: "ns1/pod1", PodUID: "uid1"},
+						"172.18.40.2": {NamespacedName: "ns2/pod2", PodUID: "uid2"},
+					}),
+					newPool("pool-b", spiderpoolv2beta1.PoolIPAllocations{
+						"172.18.41.1": {NamespacedName: "ns1/pod3", PodUID: "uid3"},
+					}),
+				},
+				released: map[string][]types.IPAndUID{},
+			}
+
+			var err error
+			gcManager, err = gcmanager.NewGCManager(
+				&kubernetes.Clientset{},
+				&gcmanager.GarbageCollectionConfig{EnableGCIP: true},
+				&fakeEndpointManager{},
+				ipPoolManager,
+				&fakePodManager{},
+				&fakeRegistry{},
+				&fakeLeader{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		recordIPs := func(records []gcmanager.GCRecord) []string {
+			ips := make([]string, 0, len(records))
+			for _, r := range records {
+				ips = append(ips, r.IPPool+"/"+r.IP)
+			}
+			return ips
+		}
+
+		It("releases the IP allocations of the whole cluster without scope", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recordIPs(records)).To(ConsistOf("pool-a/172.18.40.1", "pool-a/172.18.40.2", "pool-b/172.18.41.1"))
+			Expect(ipPoolManager.released).To(HaveLen(2))
+		})
+
+		It("only releases the IP allocations of the IPPool", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{IPPool: "pool-b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recordIPs(records)).To(ConsistOf("pool-b/172.18.41.1"))
+			Expect(ipPoolManager.released).To(Equal(map[string][]types.IPAndUID{
+				"pool-b": {{IP: "172.18.41.1", UID: "uid3"}},
+			}))
+		})
+
+		It("only releases the IP allocations of the namespace", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{Namespace: "ns1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recordIPs(records)).To(ConsistOf("pool-a/172.18.40.1", "pool-b/172.18.41.1"))
+			Expect(ipPoolManager.released).To(Equal(map[string][]types.IPAndUID{
+				"pool-a": {{IP: "172.18.40.1", UID: "uid1"}},
+				"pool-b": {{IP: "172.18.41.1", UID: "uid3"}},
+			}))
+		})
+
+		It("only releases the IP allocation of the IP", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{IP: "172.18.40.2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal([]gcmanager.GCRecord{{
+				IPPool:         "pool-a",
+				IP:             "172.18.40.2",
+				NamespacedName: "ns2/pod2",
+				PodUID:         "uid2",
+				Reason:         "pod not found in k8s but still exists in IPPool allocation",
+			}}))
+			Expect(ipPoolManager.released).To(Equal(map[string][]types.IPAndUID{
+				"pool-a": {{IP: "172.18.40.2", UID: "uid2"}},
+			}))
+		})
+
+		It("returns the IP allocations without releasing them in dry-run mode", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{Namespace: "ns1", DryRun: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recordIPs(records)).To(ConsistOf("pool-a/172.18.40.1", "pool-b/172.18.41.1"))
+			Expect(ipPoolManager.released).To(BeEmpty())
+		})
+
+		It("fails with an invalid IP address", func() {
+			records, err := gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{IP: "invalid"})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(records).To(BeEmpty())
+		})
+
+		It("fails once IP garbage collection is disabled", func() {
+			gcManager, err := gcmanager.NewGCManager(
+				&kubernetes.Clientset{},
+				&gcmanager.GarbageCollectionConfig{},
+				&fakeEndpointManager{},
+				ipPoolManager,
+				&fakePodManager{},
+				&fakeRegistry{},
+				&fakeLeader{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = gcManager.TriggerGCWithScope(ctx, gcmanager.GCScope{DryRun: true})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+func TestGCManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GCManager Suite", Label("gcmanager", "unittest"))
+}
+
+var _ = BeforeSuite(func() {
+	_, err := metric.InitMetric(context.TODO(), constant.SpiderpoolController, false, false)
+	Expect(err).NotTo(HaveOccurred())
+	err = metric.InitSpiderpoolControllerMetrics(context.TODO())
+	Expect(err).NotTo(HaveOccurred())
+})
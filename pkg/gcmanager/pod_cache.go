@@ -8,11 +8,9 @@ import (
 	"fmt"
 	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
-	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/lock"
@@ -139,32 +137,26 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 	ownerRef := metav1.GetControllerOf(currentPod)
 	ctx := context.TODO()
 
-	// check StatefulSet pod, we will trace it if its controller StatefulSet object was deleted or decreased its replicas and the pod index was out of the replicas.
-	if s.gcConfig.EnableStatefulSet && ownerRef != nil &&
-		ownerRef.APIVersion == appsv1.SchemeGroupVersion.String() && ownerRef.Kind == constant.KindStatefulSet {
-		isValidStsPod, err := s.stsMgr.IsValidStatefulSetPod(ctx, currentPod.Namespace, currentPod.Name, ownerRef.Kind)
-		if nil != err {
-			return nil, err
-		}
-
-		// StatefulSet pod restarted, no need to trace it.
-		if isValidStsPod {
-			logger.Sugar().Debugf("the StatefulSet pod '%s/%s' just restarts, keep its IPs", currentPod.Namespace, currentPod.Name)
-			return nil, nil
-		}
-	}
-
-	// check kubevirt vm pod, we will trace it if its controller is no longer exist
-	if s.gcConfig.EnableKubevirtStaticIP && ownerRef != nil &&
-		ownerRef.APIVersion == kubevirtv1.SchemeGroupVersion.String() && ownerRef.Kind == constant.KindKubevirtVMI {
-		isValidVMPod, err := s.kubevirtMgr.IsValidVMPod(logutils.IntoContext(ctx, logger), currentPod.Namespace, ownerRef.Kind, ownerRef.Name)
-		if nil != err {
-			return nil, err
-		}
+	// check the pod with stable identity, such as StatefulSet pod or kubevirt vm pod, we will trace it if its controller
+	// was deleted or decreased its replicas and the pod index was out of the replicas.
+	if ownerRef != nil {
+		kind := s.workloadKinds.Lookup(ownerRef.APIVersion, ownerRef.Kind)
+		if _, ok := s.workloadKinds.StableEndpointName(currentPod.Name, types.AppNamespacedName{
+			APIVersion: ownerRef.APIVersion,
+			Kind:       ownerRef.Kind,
+			Namespace:  currentPod.Namespace,
+			Name:       ownerRef.Name,
+		}); ok {
+			isValidPod, err := kind.IsValidPod(logutils.IntoContext(ctx, logger), currentPod.Namespace, currentPod.Name, ownerRef.Name)
+			if nil != err {
+				return nil, err
+			}
 
-		if isValidVMPod {
-			logger.Sugar().Debugf("the kubevirt vm pod '%s/%s' just restarts, keep its IPs", currentPod.Namespace, currentPod.Name)
-			return nil, nil
+			// the pod restarted, no need to trace it.
+			if isValidPod {
+				logger.Sugar().Debugf("the %s pod '%s/%s' just restarts, keep its IPs", ownerRef.Kind, currentPod.Namespace, currentPod.Name)
+				return nil, nil
+			}
 		}
 	}
 
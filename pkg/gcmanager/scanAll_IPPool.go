@@ -21,6 +21,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
 // monitorGCSignal will monitor signal from CLI, DefaultGCInterval
@@ -74,9 +75,17 @@ func (s *SpiderGC) monitorGCSignal(ctx context.Context) {
 	}
 }
 
+// gc reasons of the IP allocations released by scan all
+const (
+	gcReasonPodNotFound   = "pod not found in k8s but still exists in IPPool allocation"
+	gcReasonPodOutOfTime  = "pod is out of time"
+	gcReasonPodUIDChanged = "IPPoolAllocation pod UID is different with pod UID"
+	gcReasonIPNotInUse    = "same pod UID but IPPoolAllocation IP is different with Endpoint IP"
+)
+
 // executeScanAll scans the whole pod and whole IPPoolList
 func (s *SpiderGC) executeScanAll(ctx context.Context) {
-	poolList, err := s.ippoolMgr.ListIPPools(ctx, constant.UseCache)
+	_, err := s.scanAll(ctx, nil)
 	if nil != err {
 		if apierrors.IsNotFound(err) {
 			logger.Sugar().Warnf("scan all failed, ippoolList not found!")
@@ -84,11 +93,61 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 		}
 
 		logger.Sugar().Errorf("scan all failed: '%v'", err)
-		return
+	}
+}
+
+// scanAll scans the pods and IPPools within the scope, a nil scope means
+// the whole cluster. It returns the IP allocations released, or only the
+// ones to be released in dry-run mode.
+func (s *SpiderGC) scanAll(ctx context.Context, scope *GCScope) ([]GCRecord, error) {
+	if scope == nil {
+		scope = &GCScope{}
+	}
+
+	poolList, err := s.ippoolMgr.ListIPPools(ctx, constant.UseCache)
+	if nil != err {
+		return nil, err
+	}
+
+	var lock sync.Mutex
+	records := []GCRecord{}
+	// releaseIP releases the IP allocation, and cleans up the SpiderEndpoint
+	// as well if required. In dry-run mode, it just records the IP allocation.
+	releaseIP := func(ctx context.Context, poolName, poolIP string, poolIPAllocation spiderpoolv2beta1.PoolIPAllocation, reason string, cleanEndpoint bool) error {
+		if scope.DryRun {
+			logutils.FromContext(ctx).Sugar().Infof("dry run, IP '%s' of IPPool '%s' would be released", poolIP, poolName)
+		} else if cleanEndpoint {
+			if err := s.releaseSingleIPAndRemoveWEPFinalizer(ctx, poolName, poolIP, poolIPAllocation); err != nil {
+				return err
+			}
+		} else {
+			err := s.ippoolMgr.ReleaseIP(ctx, poolName, []types.IPAndUID{{
+				IP:  poolIP,
+				UID: poolIPAllocation.PodUID},
+			})
+			if err != nil {
+				return err
+			}
+			logutils.FromContext(ctx).Sugar().Infof("release ip '%s' successfully!", poolIP)
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		records = append(records, GCRecord{
+			IPPool:         poolName,
+			IP:             poolIP,
+			NamespacedName: poolIPAllocation.NamespacedName,
+			PodUID:         poolIPAllocation.PodUID,
+			Reason:         reason,
+		})
+		return nil
 	}
 
 	var v4poolList, v6poolList []spiderpoolv2beta1.SpiderIPPool
 	for i := range poolList.Items {
+		if scope.IPPool != "" && poolList.Items[i].Name != scope.IPPool {
+			continue
+		}
 		if poolList.Items[i].Spec.IPVersion != nil {
 			if *poolList.Items[i].Spec.IPVersion == constant.IPv4 {
 				v4poolList = append(v4poolList, poolList.Items[i])
@@ -108,11 +167,18 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 			}
 
 			for poolIP, poolIPAllocation := range poolAllocatedIPs {
+				if scope.IP != "" && poolIP != scope.IP {
+					continue
+				}
+
 				podNS, podName, err := cache.SplitMetaNamespaceKey(poolIPAllocation.NamespacedName)
 				if err != nil {
 					logger.Error(err.Error())
 					continue
 				}
+				if scope.Namespace != "" && podNS != scope.Namespace {
+					continue
+				}
 
 				scanAllLogger := logger.With(
 					zap.String("podNS", podNS),
@@ -124,7 +190,7 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 				if err != nil {
 					// case: The pod in IPPool's ip-allocationDetail is not exist in k8s
 					if apierrors.IsNotFound(err) {
-						wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod not found in k8s but still exists in IPPool allocation"))
+						wrappedLog := scanAllLogger.With(zap.String("gc-reason", gcReasonPodNotFound))
 						endpoint, err := s.wepMgr.GetEndpointByName(ctx, podNS, podName, constant.UseCache)
 						if nil != err {
 							// just continue if we meet other errors
@@ -133,32 +199,43 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 								continue
 							}
 						} else {
-							if s.gcConfig.EnableStatefulSet && endpoint.Status.OwnerControllerType == constant.KindStatefulSet {
-								isValidStsPod, err := s.stsMgr.IsValidStatefulSetPod(ctx, podNS, podName, constant.KindStatefulSet)
+							if kind := s.workloadKinds.LookupEndpointOwner(endpoint.Status.OwnerControllerAPIVersion, endpoint.Status.OwnerControllerType); kind != nil {
+								isValidPod, err := kind.IsValidPod(logutils.IntoContext(ctx, scanAllLogger), podNS, podName, endpoint.Status.OwnerControllerName)
 								if nil != err {
-									scanAllLogger.Sugar().Errorf("failed to check StatefulSet pod IP '%s' should be cleaned or not, error: %v", poolIP, err)
+									scanAllLogger.Sugar().Errorf("failed to check %s pod IP '%s' should be cleaned or not, error: %v", endpoint.Status.OwnerControllerType, poolIP, err)
 									continue
 								}
-								if isValidStsPod {
-									scanAllLogger.Sugar().Warnf("no need to release IP '%s' for StatefulSet pod ", poolIP)
+								if isValidPod {
+									scanAllLogger.Sugar().Warnf("no need to release IP '%s' for %s pod ", poolIP, endpoint.Status.OwnerControllerType)
 									continue
 								}
 							}
-							if s.gcConfig.EnableKubevirtStaticIP && endpoint.Status.OwnerControllerType == constant.KindKubevirtVMI {
-								isValidVMPod, err := s.kubevirtMgr.IsValidVMPod(logutils.IntoContext(ctx, scanAllLogger), podNS, constant.KindKubevirtVMI, endpoint.Status.OwnerControllerName)
+							if workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+								isValidStickyEndpoint, err := s.wepMgr.IsValidStickyEndpoint(ctx, endpoint)
 								if nil != err {
-									scanAllLogger.Sugar().Errorf("failed to check kubevirt vm pod IP '%s' should be cleaned or not, error: %v", poolIP, err)
+									scanAllLogger.Sugar().Errorf("failed to check sticky IP '%s' should be cleaned or not, error: %v", poolIP, err)
 									continue
 								}
-								if isValidVMPod {
-									scanAllLogger.Sugar().Warnf("no need to release IP '%s' for kubevirt vm pod ", poolIP)
+								if isValidStickyEndpoint {
+									scanAllLogger.Sugar().Debugf("no need to release sticky IP '%s' of %s slot %d", poolIP, endpoint.Status.OwnerControllerType, *endpoint.Status.StickySlot)
+									continue
+								}
+
+								// the pod of the slot beyond the replicas may be still terminating
+								isHeld, err := s.wepMgr.IsStickyEndpointHeld(ctx, endpoint)
+								if nil != err {
+									scanAllLogger.Sugar().Errorf("failed to check sticky IP '%s' is still taken or not, error: %v", poolIP, err)
+									continue
+								}
+								if isHeld {
+									scanAllLogger.Sugar().Debugf("no need to release sticky IP '%s' still taken by pod '%s'", poolIP, endpoint.Status.Current.UID)
 									continue
 								}
 							}
 						}
 
 						wrappedLog.Sugar().Warnf("found IPPool '%s' legacy IP '%s', try to release it", pool.Name, poolIP)
-						err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation)
+						err = releaseIP(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation, gcReasonPodNotFound, true)
 						if nil != err {
 							wrappedLog.Error(err.Error())
 						}
@@ -180,15 +257,15 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 				// case: The pod in IPPool's ip-allocationDetail is also exist in k8s, but the pod is in 'Terminating|Succeeded|Failed' status phase
 				if podEntry != nil {
 					if time.Now().UTC().After(podEntry.TracingStopTime) {
-						wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod is out of time"))
-						err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation)
+						wrappedLog := scanAllLogger.With(zap.String("gc-reason", gcReasonPodOutOfTime))
+						err = releaseIP(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation, gcReasonPodOutOfTime, true)
 						if nil != err {
 							wrappedLog.Error(err.Error())
 							continue
 						}
 					} else {
 						// otherwise, flush the PodEntry database and let tracePodWorker to solve it if the current controller is elected master.
-						if s.leader.IsElected() {
+						if !scope.DryRun && s.leader.IsElected() {
 							err = s.PodDB.ApplyPodEntry(podEntry)
 							if nil != err {
 								scanAllLogger.Error(err.Error())
@@ -204,12 +281,12 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 					if string(podYaml.UID) != poolIPAllocation.PodUID {
 						// Once the static IP Pod restarts, it will retrieve the Pod IP from it SpiderEndpoint.
 						// So at this moment the Pod UID is different from the IPPool's ip-allocationDetail, we should not release it.
-						if podmanager.IsStaticIPPod(s.gcConfig.EnableStatefulSet, s.gcConfig.EnableKubevirtStaticIP, podYaml) {
+						if podmanager.IsStaticIPPod(s.workloadKinds, podYaml) {
 							scanAllLogger.Sugar().Debugf("Static IP Pod just restarts, keep the static IP '%s' from the IPPool", poolIP)
 						} else {
-							wrappedLog := scanAllLogger.With(zap.String("gc-reason", "IPPoolAllocation pod UID is different with pod UID"))
+							wrappedLog := scanAllLogger.With(zap.String("gc-reason", gcReasonPodUIDChanged))
 							// we are afraid that no one removes the old same name Endpoint finalizer
-							err := s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation)
+							err := releaseIP(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation, gcReasonPodUIDChanged, true)
 							if nil != err {
 								wrappedLog.Sugar().Errorf("failed to release ip '%s', error: '%v'", poolIP, err)
 								continue
@@ -225,7 +302,7 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 						if endpoint.Status.Current.UID == string(podYaml.UID) {
 							// case: The pod in IPPool's ip-allocationDetail is also exist in k8s,
 							// and the IPPool IP corresponding allocation pod UID is same with Endpoint pod UID, but the IPPool IP isn't belong to the Endpoint IPs
-							wrappedLog := scanAllLogger.With(zap.String("gc-reason", "same pod UID but IPPoolAllocation IP is different with Endpoint IP"))
+							wrappedLog := scanAllLogger.With(zap.String("gc-reason", gcReasonIPNotInUse))
 							isBadIP := true
 							for _, endpointIP := range endpoint.Status.Current.IPs {
 								if *pool.Spec.IPVersion == constant.IPv4 {
@@ -240,15 +317,11 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 							}
 							if isBadIP {
 								// release IP but no need to clean up SpiderEndpoint object
-								err = s.ippoolMgr.ReleaseIP(ctx, pool.Name, []types.IPAndUID{{
-									IP:  poolIP,
-									UID: poolIPAllocation.PodUID},
-								})
+								err = releaseIP(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation, gcReasonIPNotInUse, false)
 								if nil != err {
 									wrappedLog.Sugar().Errorf("failed to release ip '%s', error: '%v'", poolIP, err)
 									continue
 								}
-								wrappedLog.Sugar().Infof("release ip '%s' successfully!", poolIP)
 							}
 						}
 						// It's impossible that a new IP would be allocated when an old same name Endpoint object exist, because we already avoid it in IPAM
@@ -278,6 +351,8 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 
 	wg.Wait()
 	logger.Sugar().Debugf("IP GC scan all finished")
+
+	return records, nil
 }
 
 // releaseSingleIPAndRemoveWEPFinalizer serves for handleTerminatingPod to gc singleIP and remove wep finalizer
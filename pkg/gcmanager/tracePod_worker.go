@@ -19,6 +19,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
 var errRequeue = fmt.Errorf("requeue")
@@ -89,6 +90,14 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 					return err
 				}
 
+				// the SpiderEndpoint of a replica slot is named after the slot rather than the pod,
+				// its sticky IPs are released by the scan of IPPools once the slot is no longer valid.
+				if workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+					log.Sugar().Infof("SpiderEndpoint '%s/%s' holds the sticky IPs of %s '%s', ignore it",
+						podCache.Namespace, podCache.PodName, endpoint.Status.OwnerControllerType, endpoint.Status.OwnerControllerName)
+					return nil
+				}
+
 				// we need to gather the pod corresponding SpiderEndpoint allocation data to get the used history IPs.
 				podUsedIPs := convert.GroupIPAllocationDetails(endpoint.Status.Current.UID, endpoint.Status.Current.IPs)
 				tickets := podUsedIPs.Pools()
@@ -126,9 +135,9 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 					return errRequeue
 				}
 
-				// delete StatefulSet/kubevirtVMI wep (other controller wep has OwnerReference, its lifecycle is same with pod)
-				if (endpoint.Status.OwnerControllerType == constant.KindStatefulSet || endpoint.Status.OwnerControllerType == constant.KindKubevirtVMI) &&
-					endpoint.DeletionTimestamp == nil {
+				// delete StatefulSet/kubevirtVMI/custom workload wep (other controller wep has OwnerReference, its lifecycle is same with pod)
+				if (endpoint.Status.OwnerControllerType == constant.KindStatefulSet || endpoint.Status.OwnerControllerType == constant.KindKubevirtVMI ||
+					s.workloadKinds.LookupEndpointOwner(endpoint.Status.OwnerControllerAPIVersion, endpoint.Status.OwnerControllerType) != nil) && endpoint.DeletionTimestamp == nil {
 					err = s.wepMgr.DeleteEndpoint(ctx, endpoint)
 					if nil != err {
 						log.Sugar().Errorf("failed to delete '%s' wep '%s/%s', error: '%v'",
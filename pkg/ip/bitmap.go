@@ -0,0 +1,360 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const wordSize = 64
+
+// IPBitmap is a set of IP addresses within a group of IP ranges. Each IP
+// address of the IP ranges is represented by one bit of a sparse bitmap,
+// so the IP ranges never need to be expanded into IP address slices, and
+// finding a clear bit only costs the number of the fully set words ahead
+// of it.
+type IPBitmap struct {
+	ranges []bitmapRange
+	size   uint64
+	words  map[uint64]uint64
+	count  uint64
+}
+
+// bitmapRange is an IP range of the IPBitmap, offset is the position of
+// its first IP address in the bitmap.
+type bitmapRange struct {
+	first  netip.Addr
+	last   netip.Addr
+	offset uint64
+}
+
+// NewIPBitmap creates an empty IPBitmap for the IP addresses of ipRanges
+// which are not in excludedIPRanges.
+func NewIPBitmap(version types.IPVersion, ipRanges, excludedIPRanges []string) (*IPBitmap, error) {
+	included, err := parseAddrRanges(version, ipRanges)
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := parseAddrRanges(version, excludedIPRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &IPBitmap{words: map[uint64]uint64{}}
+	for _, r := range subtractAddrRanges(included, excluded) {
+		n, ok := rangeSize(r.first, r.last)
+		if !ok || n > math.MaxInt64-b.size {
+			return nil, fmt.Errorf("%w: too many IP addresses in IP ranges %v", ErrInvalidIPRangeFormat, ipRanges)
+		}
+		r.offset = b.size
+		b.ranges = append(b.ranges, r)
+		b.size += n
+	}
+
+	return b, nil
+}
+
+// Size returns the number of the IP addresses in the IPBitmap.
+func (b *IPBitmap) Size() uint64 {
+	return b.size
+}
+
+// Count returns the number of the IP addresses set.
+func (b *IPBitmap) Count() uint64 {
+	return b.count
+}
+
+// Free returns the number of the IP addresses not set.
+func (b *IPBitmap) Free() uint64 {
+	return b.size - b.count
+}
+
+// Contains reports whether the IP address is in the IPBitmap.
+func (b *IPBitmap) Contains(ip net.IP) bool {
+	_, ok := b.offsetOf(ip)
+	return ok
+}
+
+// Set marks the IP address as set, it returns false if the IP address is
+// not in the IPBitmap.
+func (b *IPBitmap) Set(ip net.IP) bool {
+	offset, ok := b.offsetOf(ip)
+	if !ok {
+		return false
+	}
+
+	w, mask := offset/wordSize, uint64(1)<<(offset%wordSize)
+	if b.words[w]&mask == 0 {
+		b.words[w] |= mask
+		b.count++
+	}
+
+	return true
+}
+
+// Clear marks the IP address as not set.
+func (b *IPBitmap) Clear(ip net.IP) {
+	offset, ok := b.offsetOf(ip)
+	if !ok {
+		return
+	}
+
+	w, mask := offset/wordSize, uint64(1)<<(offset%wordSize)
+	if b.words[w]&mask == 0 {
+		return
+	}
+
+	b.words[w] &^= mask
+	if b.words[w] == 0 {
+		delete(b.words, w)
+	}
+	b.count--
+}
+
+// IsSet reports whether the IP address is set.
+func (b *IPBitmap) IsSet(ip net.IP) bool {
+	offset, ok := b.offsetOf(ip)
+	if !ok {
+		return false
+	}
+
+	return b.words[offset/wordSize]&(uint64(1)<<(offset%wordSize)) != 0
+}
+
+// IPAt returns the IP address at the position of the IPBitmap, or nil if
+// the position is out of the IPBitmap.
+func (b *IPBitmap) IPAt(offset uint64) net.IP {
+	if offset >= b.size {
+		return nil
+	}
+
+	i := sort.Search(len(b.ranges), func(i int) bool {
+		return b.ranges[i].offset > offset
+	}) - 1
+	r := b.ranges[i]
+
+	return net.IP(addAddr(r.first, offset-r.offset).AsSlice()).To16()
+}
+
+// FirstClear returns the lowest IP address not set, or nil if all the IP
+// addresses are set.
+func (b *IPBitmap) FirstClear() net.IP {
+	return b.nextClear(0)
+}
+
+// NextClear returns the lowest IP address not set which is not less than
+// the given one, or nil if there is none.
+func (b *IPBitmap) NextClear(ip net.IP) net.IP {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return nil
+	}
+
+	return b.nextClear(b.lowerBound(addr.Unmap()))
+}
+
+// RangeClear calls f for each IP address not set in ascending order, until
+// f returns false.
+func (b *IPBitmap) RangeClear(f func(ip net.IP) bool) {
+	for offset := b.nextClearOffset(0); offset < b.size; offset = b.nextClearOffset(offset + 1) {
+		if !f(b.IPAt(offset)) {
+			return
+		}
+	}
+}
+
+func (b *IPBitmap) nextClear(offset uint64) net.IP {
+	return b.IPAt(b.nextClearOffset(offset))
+}
+
+// nextClearOffset returns the first position not set from the given one,
+// which is the size of the IPBitmap if there is none.
+func (b *IPBitmap) nextClearOffset(offset uint64) uint64 {
+	if b.count == 0 || offset >= b.size {
+		return offset
+	}
+
+	w := offset / wordSize
+	free := ^b.words[w] &^ (uint64(1)<<(offset%wordSize) - 1)
+	for free == 0 {
+		w++
+		if w*wordSize >= b.size {
+			return b.size
+		}
+		free = ^b.words[w]
+	}
+
+	if res := w*wordSize + uint64(bits.TrailingZeros64(free)); res < b.size {
+		return res
+	}
+
+	return b.size
+}
+
+// offsetOf returns the position of the IP address in the IPBitmap.
+func (b *IPBitmap) offsetOf(ip net.IP) (uint64, bool) {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return 0, false
+	}
+	addr = addr.Unmap()
+
+	i := sort.Search(len(b.ranges), func(i int) bool {
+		return b.ranges[i].last.Compare(addr) >= 0
+	})
+	if i == len(b.ranges) || b.ranges[i].first.Compare(addr) > 0 {
+		return 0, false
+	}
+
+	n, _ := rangeSize(b.ranges[i].first, addr)
+	return b.ranges[i].offset + n - 1, true
+}
+
+// lowerBound returns the position of the lowest IP address in the IPBitmap
+// which is not less than the given one.
+func (b *IPBitmap) lowerBound(addr netip.Addr) uint64 {
+	i := sort.Search(len(b.ranges), func(i int) bool {
+		return b.ranges[i].last.Compare(addr) >= 0
+	})
+	if i == len(b.ranges) {
+		return b.size
+	}
+	if b.ranges[i].first.Compare(addr) >= 0 {
+		return b.ranges[i].offset
+	}
+
+	n, _ := rangeSize(b.ranges[i].first, addr)
+	return b.ranges[i].offset + n - 1
+}
+
+// parseAddrRanges parses IP ranges as sorted and merged address ranges.
+func parseAddrRanges(version types.IPVersion, ipRanges []string) ([]bitmapRange, error) {
+	if err := IsIPVersion(version); err != nil {
+		return nil, err
+	}
+
+	res := make([]bitmapRange, 0, len(ipRanges))
+	for _, ipRange := range ipRanges {
+		if err := IsIPRange(version, ipRange); err != nil {
+			return nil, err
+		}
+
+		arr := strings.Split(ipRange, "-")
+		first := netip.MustParseAddr(arr[0]).Unmap()
+		last := first
+		if len(arr) == 2 {
+			last = netip.MustParseAddr(arr[1]).Unmap()
+		}
+		res = append(res, bitmapRange{first: first, last: last})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].first.Less(res[j].first)
+	})
+
+	merged := res[:0]
+	for _, r := range res {
+		if n := len(merged); n > 0 {
+			// merge the overlapping or adjacent IP ranges
+			prev := &merged[n-1]
+			if next := prev.last.Next(); !next.IsValid() || r.first.Compare(next) <= 0 {
+				if r.last.Compare(prev.last) > 0 {
+					prev.last = r.last
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	return merged, nil
+}
+
+// subtractAddrRanges returns the parts of the sorted and merged address
+// ranges which are not in the excluded ones.
+func subtractAddrRanges(included, excluded []bitmapRange) []bitmapRange {
+	var res []bitmapRange
+	j := 0
+	for _, r := range included {
+		first := r.first
+		for ; j < len(excluded) && excluded[j].last.Compare(first) < 0; j++ {
+		}
+
+		k := j
+		for ; k < len(excluded) && excluded[k].first.Compare(r.last) <= 0; k++ {
+			e := excluded[k]
+			if e.first.Compare(first) > 0 {
+				res = append(res, bitmapRange{first: first, last: e.first.Prev()})
+			}
+			if e.last.Compare(r.last) >= 0 {
+				first = netip.Addr{}
+				break
+			}
+			first = e.last.Next()
+		}
+
+		if first.IsValid() {
+			res = append(res, bitmapRange{first: first, last: r.last})
+		}
+	}
+
+	return res
+}
+
+// rangeSize returns the number of the IP addresses from first to last, it
+// returns false if the number overflows uint64.
+func rangeSize(first, last netip.Addr) (uint64, bool) {
+	fHi, fLo := addrToUint128(first)
+	lHi, lLo := addrToUint128(last)
+
+	lo, borrow := bits.Sub64(lLo, fLo, 0)
+	hi, _ := bits.Sub64(lHi, fHi, borrow)
+	if hi != 0 || lo == math.MaxUint64 {
+		return 0, false
+	}
+
+	return lo + 1, true
+}
+
+// addAddr returns the IP address n after the given one.
+func addAddr(addr netip.Addr, n uint64) netip.Addr {
+	hi, lo := addrToUint128(addr)
+	lo, carry := bits.Add64(lo, n, 0)
+	hi += carry
+
+	if addr.Is4() {
+		return netip.AddrFrom4([4]byte{byte(lo >> 24), byte(lo >> 16), byte(lo >> 8), byte(lo)})
+	}
+
+	var a [16]byte
+	for i := 0; i < 8; i++ {
+		a[i] = byte(hi >> (56 - 8*i))
+		a[8+i] = byte(lo >> (56 - 8*i))
+	}
+
+	return netip.AddrFrom16(a)
+}
+
+func addrToUint128(addr netip.Addr) (hi, lo uint64) {
+	if addr.Is4() {
+		a := addr.As4()
+		return 0, uint64(a[0])<<24 | uint64(a[1])<<16 | uint64(a[2])<<8 | uint64(a[3])
+	}
+
+	a := addr.As16()
+	for i := 0; i < 8; i++ {
+		hi = hi<<8 | uint64(a[i])
+		lo = lo<<8 | uint64(a[8+i])
+	}
+
+	return hi, lo
+}
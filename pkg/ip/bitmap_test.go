@@ -0,0 +1,175 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip_test
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+)
+
+var _ = Describe("IPBitmap", Label("ip_bitmap_test"), func() {
+	Describe("Test NewIPBitmap", func() {
+		When("Verifying", func() {
+			It("inputs invalid IP version", func() {
+				b, err := spiderpoolip.NewIPBitmap(constant.InvalidIPVersion, []string{"172.18.40.1-172.18.40.2"}, nil)
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPVersion))
+				Expect(b).To(BeNil())
+			})
+
+			It("inputs invalid IP ranges", func() {
+				b, err := spiderpoolip.NewIPBitmap(constant.IPv4, constant.InvalidIPRanges, nil)
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
+				Expect(b).To(BeNil())
+			})
+
+			It("inputs invalid excluded IP ranges", func() {
+				b, err := spiderpoolip.NewIPBitmap(constant.IPv4, []string{"172.18.40.1-172.18.40.2"}, constant.InvalidIPRanges)
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
+				Expect(b).To(BeNil())
+			})
+
+			It("inputs too large IPv6 IP range", func() {
+				b, err := spiderpoolip.NewIPBitmap(constant.IPv6, []string{"abcd:1234::-abcd:1234::ffff:ffff:ffff:ffff"}, nil)
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
+				Expect(b).To(BeNil())
+			})
+		})
+
+		It("assembles the same IPv4 IP addresses as AssembleTotalIPs", func() {
+			ipRanges := []string{"172.18.40.10", "172.18.40.1-172.18.40.5", "172.18.40.4-172.18.40.8", "172.18.41.1-172.18.41.3"}
+			excludedIPRanges := []string{"172.18.40.2", "172.18.40.7-172.18.40.20", "172.18.41.3"}
+			totalIPs, err := spiderpoolip.AssembleTotalIPs(constant.IPv4, ipRanges, excludedIPRanges)
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := spiderpoolip.NewIPBitmap(constant.IPv4, ipRanges, excludedIPRanges)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Size()).To(Equal(uint64(len(totalIPs))))
+
+			var ips []net.IP
+			b.RangeClear(func(ip net.IP) bool {
+				ips = append(ips, ip)
+				return true
+			})
+			Expect(ips).To(ConsistOf(totalIPs))
+		})
+
+		It("assembles the IPv6 IP addresses", func() {
+			b, err := spiderpoolip.NewIPBitmap(constant.IPv6,
+				[]string{"abcd:1234::a", "abcd:1234::1-abcd:1234::2"},
+				[]string{"abcd:1234::a", "abcd:1234::2-abcd:1234::3"},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Size()).To(Equal(uint64(1)))
+			Expect(b.FirstClear()).To(Equal(net.ParseIP("abcd:1234::1")))
+		})
+	})
+
+	Describe("Test set and clear", func() {
+		var b *spiderpoolip.IPBitmap
+
+		BeforeEach(func() {
+			var err error
+			b, err = spiderpoolip.NewIPBitmap(constant.IPv4, []string{"172.18.40.1-172.18.40.100", "172.18.41.1-172.18.41.100"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("ignores the IP addresses out of the IP ranges", func() {
+			Expect(b.Contains(net.ParseIP("172.18.40.101"))).To(BeFalse())
+			Expect(b.Set(net.ParseIP("172.18.40.101"))).To(BeFalse())
+			Expect(b.Set(net.ParseIP("abcd:1234::1"))).To(BeFalse())
+			Expect(b.IsSet(net.ParseIP("172.18.40.101"))).To(BeFalse())
+			Expect(b.Count()).To(BeZero())
+		})
+
+		It("sets and clears the IP addresses", func() {
+			Expect(b.Set(net.ParseIP("172.18.40.1"))).To(BeTrue())
+			Expect(b.Set(net.ParseIP("172.18.40.1"))).To(BeTrue())
+			Expect(b.Set(net.ParseIP("172.18.41.1").To4())).To(BeTrue())
+			Expect(b.IsSet(net.ParseIP("172.18.41.1"))).To(BeTrue())
+			Expect(b.Count()).To(Equal(uint64(2)))
+			Expect(b.Free()).To(Equal(uint64(198)))
+
+			b.Clear(net.ParseIP("172.18.40.1"))
+			b.Clear(net.ParseIP("172.18.40.2"))
+			Expect(b.IsSet(net.ParseIP("172.18.40.1"))).To(BeFalse())
+			Expect(b.Count()).To(Equal(uint64(1)))
+		})
+
+		It("finds the IP addresses not set", func() {
+			for i := 1; i <= 100; i++ {
+				b.Set(net.ParseIP(fmt.Sprintf("172.18.40.%d", i)))
+			}
+			b.Set(net.ParseIP("172.18.41.1"))
+
+			Expect(b.FirstClear()).To(Equal(net.ParseIP("172.18.41.2")))
+			Expect(b.NextClear(net.ParseIP("172.18.40.50"))).To(Equal(net.ParseIP("172.18.41.2")))
+			Expect(b.NextClear(net.ParseIP("172.18.41.50"))).To(Equal(net.ParseIP("172.18.41.50")))
+			Expect(b.NextClear(net.ParseIP("172.18.42.1"))).To(BeNil())
+			Expect(b.IPAt(100)).To(Equal(net.ParseIP("172.18.41.1")))
+			Expect(b.IPAt(200)).To(BeNil())
+		})
+
+		It("finds nothing when all the IP addresses are set", func() {
+			b.RangeClear(func(ip net.IP) bool {
+				b.Set(ip)
+				return true
+			})
+
+			Expect(b.Free()).To(BeZero())
+			Expect(b.FirstClear()).To(BeNil())
+		})
+	})
+})
+
+// newBenchmarkIPPool returns the IP ranges of a /16 IPPool and 50k IP
+// addresses allocated from it.
+func newBenchmarkIPPool() ([]string, []net.IP) {
+	ipRanges := []string{"10.6.0.1-10.6.255.254"}
+	allocated := make([]net.IP, 0, 50000)
+	for i := 0; i < 50000; i++ {
+		allocated = append(allocated, net.IPv4(10, 6, byte((i+1)/256), byte((i+1)%256)))
+	}
+
+	return ipRanges, allocated
+}
+
+func BenchmarkIPBitmapFirstClear(b *testing.B) {
+	ipRanges, allocated := newBenchmarkIPPool()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitmap, err := spiderpoolip.NewIPBitmap(constant.IPv4, ipRanges, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, ip := range allocated {
+			bitmap.Set(ip)
+		}
+		if bitmap.FirstClear() == nil {
+			b.Fatal("no IP address available")
+		}
+	}
+}
+
+func BenchmarkAssembleTotalIPsDiffSet(b *testing.B) {
+	ipRanges, allocated := newBenchmarkIPPool()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		totalIPs, err := spiderpoolip.AssembleTotalIPs(constant.IPv4, ipRanges, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(spiderpoolip.IPsDiffSet(totalIPs, allocated, true)) == 0 {
+			b.Fatal("no IP address available")
+		}
+	}
+}
@@ -5,6 +5,7 @@ package ipam
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -12,7 +13,6 @@ import (
 	"sync"
 
 	"go.uber.org/zap"
-	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -39,68 +39,136 @@ func (i *ipam) Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*mode
 	logger := logutils.FromContext(ctx)
 	logger.Info("Start to allocate")
 
-	pod, err := i.podManager.GetPodByName(ctx, *addArgs.PodNamespace, *addArgs.PodName, constant.UseCache)
+	pod, podTopController, endpoint, err := i.getPodForAllocation(ctx, *addArgs.PodNamespace, *addArgs.PodName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Pod %s/%s: %v", *addArgs.PodNamespace, *addArgs.PodName, err)
+		return nil, err
+	}
+
+	customDNS, err := getCustomDNS(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	addResp, err := i.retrieveIPAllocation(ctx, *addArgs.IfName, pod, endpoint, podTopController)
+	if err != nil {
+		return nil, err
+	}
+	if addResp == nil {
+		logger.Info("Allocate IP addresses in standard mode")
+		addResp, err = i.allocateInStandardMode(ctx, addArgs, pod, endpoint, podTopController)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate IP addresses in standard mode: %w", err)
+		}
+	}
+
+	addResp.DNS, err = i.getDNS(ctx, *addArgs.IfName, customDNS, addResp.Ips)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the DNS of NIC %s: %w", *addArgs.IfName, err)
+	}
+
+	return addResp, nil
+}
+
+// getPodForAllocation gets the alive Pod to allocate IP addresses to, its
+// top controller and its Endpoint, which is nil if it does not exist.
+func (i *ipam) getPodForAllocation(ctx context.Context, namespace, name string) (*corev1.Pod, types.PodTopController, *spiderpoolv2beta1.SpiderEndpoint, error) {
+	logger := logutils.FromContext(ctx)
+
+	pod, err := i.podManager.GetPodByName(ctx, namespace, name, constant.UseCache)
+	if err != nil {
+		return nil, types.PodTopController{}, nil, fmt.Errorf("failed to get Pod %s/%s: %v", namespace, name, err)
 	}
 	isAlive := podmanager.IsPodAlive(pod)
 	if !isAlive {
-		return nil, fmt.Errorf("dead Pod %s/%s, we cannot allocate IP addresees to it", pod.Namespace, pod.Name)
+		return nil, types.PodTopController{}, nil, fmt.Errorf("dead Pod %s/%s, we cannot allocate IP addresees to it", pod.Namespace, pod.Name)
 	}
 
 	podTopController, err := i.podManager.GetPodTopController(ctx, pod)
 	if nil != err {
-		return nil, fmt.Errorf("failed to get the top controller of the Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return nil, types.PodTopController{}, nil, fmt.Errorf("failed to get the top controller of the Pod %s/%s: %v", pod.Namespace, pod.Name, err)
 	}
 	logger.Sugar().Debugf("%s %s/%s is the top controller of the Pod", podTopController.Kind, podTopController.Namespace, podTopController.Name)
 
-	endpointName := pod.Name
-	if i.config.EnableKubevirtStaticIP && podTopController.APIVersion == kubevirtv1.SchemeGroupVersion.String() && podTopController.Kind == constant.KindKubevirtVMI {
-		endpointName = podTopController.Name
+	isStickyIPPod, err := i.endpointManager.IsStickyIPPod(ctx, pod, podTopController)
+	if err != nil {
+		return nil, types.PodTopController{}, nil, fmt.Errorf("failed to check whether the Pod %s/%s requests sticky IP addresses: %v", pod.Namespace, pod.Name, err)
 	}
-	endpoint, err := i.endpointManager.GetEndpointByName(ctx, pod.Namespace, endpointName, constant.UseCache)
-	if client.IgnoreNotFound(err) != nil {
-		return nil, fmt.Errorf("failed to get Endpoint %s/%s: %v", pod.Namespace, pod.Name, err)
+
+	var endpoint *spiderpoolv2beta1.SpiderEndpoint
+	if isStickyIPPod {
+		// The sticky IP addresses are held by the Endpoint of the replica
+		// slot claimed by the Pod rather than the one named after the Pod.
+		endpoint, err = i.endpointManager.ClaimStickyEndpoint(ctx, pod, podTopController)
+		if err != nil {
+			return nil, types.PodTopController{}, nil, fmt.Errorf("failed to claim the Endpoint of a vacant sticky slot: %v", err)
+		}
+	} else {
+		endpointName, ok := i.workloadKinds.StableEndpointName(pod.Name, podTopController.AppNamespacedName)
+		if !ok {
+			endpointName = pod.Name
+		}
+		endpoint, err = i.endpointManager.GetEndpointByName(ctx, pod.Namespace, endpointName, constant.UseCache)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, types.PodTopController{}, nil, fmt.Errorf("failed to get Endpoint %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+		if workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+			return nil, types.PodTopController{}, nil, fmt.Errorf("the name of Endpoint %s/%s is taken by the sticky slot %d of %s %s", endpoint.Namespace, endpoint.Name,
+				*endpoint.Status.StickySlot, endpoint.Status.OwnerControllerType, endpoint.Status.OwnerControllerName)
+		}
 	}
 	if endpoint != nil {
-		logger.Sugar().Debugf("Get Endpoint %s/%s", pod.Namespace, pod.Name)
+		logger.Sugar().Debugf("Get Endpoint %s/%s", endpoint.Namespace, endpoint.Name)
 	} else {
 		logger.Debug("No Endpoint")
 	}
 
-	if (i.config.EnableStatefulSet && podTopController.APIVersion == appsv1.SchemeGroupVersion.String() && podTopController.Kind == constant.KindStatefulSet) ||
-		(i.config.EnableKubevirtStaticIP && podTopController.APIVersion == kubevirtv1.SchemeGroupVersion.String() && podTopController.Kind == constant.KindKubevirtVMI) {
+	return pod, podTopController, endpoint, nil
+}
+
+// retrieveIPAllocation retrieves the IP allocation of the NIC recorded in
+// the Endpoint, it returns nil if there is none.
+func (i *ipam) retrieveIPAllocation(ctx context.Context, nic string, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint, podTopController types.PodTopController) (*models.IpamAddResponse, error) {
+	logger := logutils.FromContext(ctx)
+
+	if _, ok := i.workloadKinds.StableEndpointName(pod.Name, podTopController.AppNamespacedName); ok {
 		logger.Sugar().Infof("Try to retrieve the IP allocation of %s", podTopController.Kind)
-		addResp, err := i.retrieveStaticIPAllocation(ctx, *addArgs.IfName, pod, endpoint)
+		addResp, err := i.retrieveStaticIPAllocation(ctx, nic, pod, endpoint, podTopController)
 		if err != nil {
 			return nil, fmt.Errorf("failed to retrieve the IP allocation of %s/%s/%s: %w", podTopController.Kind, podTopController.Namespace, podTopController.Name, err)
 		}
-		if addResp != nil {
-			return addResp, nil
-		}
-	} else {
-		logger.Debug("Try to retrieve the existing IP allocation")
-		addResp, err := i.retrieveExistingIPAllocation(ctx, string(pod.UID), *addArgs.IfName, endpoint, IsMultipleNicWithNoName(pod.Annotations))
+
+		return addResp, nil
+	}
+
+	if workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+		logger.Sugar().Infof("Try to retrieve the sticky IP allocation of %s", podTopController.Kind)
+		addResp, err := i.retrieveStickyIPAllocation(ctx, nic, pod, endpoint)
 		if err != nil {
-			return nil, fmt.Errorf("failed to retrieve the existing IP allocation: %w", err)
-		}
-		if addResp != nil {
-			return addResp, nil
+			return nil, fmt.Errorf("failed to retrieve the sticky IP allocation of %s/%s/%s: %w", podTopController.Kind, podTopController.Namespace, podTopController.Name, err)
 		}
+
+		return addResp, nil
 	}
 
-	logger.Info("Allocate IP addresses in standard mode")
-	addResp, err := i.allocateInStandardMode(ctx, addArgs, pod, endpoint, podTopController)
+	logger.Debug("Try to retrieve the existing IP allocation")
+	addResp, err := i.retrieveExistingIPAllocation(ctx, string(pod.UID), nic, endpoint, IsMultipleNicWithNoName(pod.Annotations))
 	if err != nil {
-		return nil, fmt.Errorf("failed to allocate IP addresses in standard mode: %w", err)
+		return nil, fmt.Errorf("failed to retrieve the existing IP allocation: %w", err)
 	}
 
 	return addResp, nil
 }
 
-func (i *ipam) retrieveStaticIPAllocation(ctx context.Context, nic string, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint) (*models.IpamAddResponse, error) {
+func (i *ipam) retrieveStaticIPAllocation(ctx context.Context, nic string, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint, podTopController types.PodTopController) (*models.IpamAddResponse, error) {
 	logger := logutils.FromContext(ctx)
 
+	// The IP addresses are retrieved regardless of the UID of the Pod, make
+	// sure they are not the ones of another application sharing the name.
+	if endpoint != nil && !workloadendpointmanager.IsEndpointOwnedBy(endpoint, podTopController.Kind, podTopController.Name) {
+		return nil, fmt.Errorf("endpoint %s/%s records the IP addresses of %s %s rather than %s %s", endpoint.Namespace, endpoint.Name,
+			endpoint.Status.OwnerControllerType, endpoint.Status.OwnerControllerName, podTopController.Kind, podTopController.Name)
+	}
+
 	allocation := workloadendpointmanager.RetrieveIPAllocation(string(pod.UID), nic, endpoint, true)
 	if allocation == nil {
 		// The first allocation or multi-NIC.
@@ -133,15 +201,60 @@ func (i *ipam) retrieveStaticIPAllocation(ctx context.Context, nic string, pod *
 	return addResp, nil
 }
 
-func (i *ipam) reallocateIPPoolIPRecords(ctx context.Context, uid string, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
+// retrieveStickyIPAllocation retrieves the IP allocation of the replica
+// slot claimed by the Pod of Deployment or ReplicaSet. The IP records of
+// IPPools may still belong to the previous Pod of the slot, or to the Pod
+// itself if its last allocation was interrupted, hand them over first.
+func (i *ipam) retrieveStickyIPAllocation(ctx context.Context, nic string, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint) (*models.IpamAddResponse, error) {
 	logger := logutils.FromContext(ctx)
 
+	allocation := workloadendpointmanager.RetrieveIPAllocation(string(pod.UID), nic, endpoint, false)
+	if allocation == nil {
+		// The first allocation of the slot or multi-NIC.
+		logger.Debug("IP allocation is not found, try to allocate IP in standard mode instead of retrieving")
+		return nil, nil
+	}
+
+	logger.Info("Concurrently refresh IP records of IPPools")
+	if err := i.recordStickyIPs(ctx, pod, endpoint, endpoint.Status.Current.IPs); err != nil {
+		return nil, fmt.Errorf("failed to reallocate IPPool IP records, error: %w", err)
+	}
+	logger.Sugar().Infof("Retrieve the sticky IP allocation of slot %d", *endpoint.Status.StickySlot)
+
+	return i.retrieveExistingIPAllocation(ctx, string(pod.UID), nic, endpoint, IsMultipleNicWithNoName(pod.Annotations))
+}
+
+// recordStickyIPs records the IP addresses in the IPPools for the Endpoint
+// of the replica slot with the UID of the Pod claiming it, so that GC keeps
+// them as long as the slot is valid.
+func (i *ipam) recordStickyIPs(ctx context.Context, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint, details []spiderpoolv2beta1.IPAllocationDetail) error {
+	podKey, err := cache.MetaNamespaceKeyFunc(pod)
+	if nil != err {
+		return fmt.Errorf("failed to parse object %+v meta key", pod)
+	}
+	endpointKey, err := cache.MetaNamespaceKeyFunc(endpoint)
+	if nil != err {
+		return fmt.Errorf("failed to parse object %+v meta key", endpoint)
+	}
+
+	return i.transferIPPoolIPRecords(ctx, string(pod.UID), podKey, endpointKey, details)
+}
+
+func (i *ipam) reallocateIPPoolIPRecords(ctx context.Context, uid string, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
 	namespaceKey, err := cache.MetaNamespaceKeyFunc(endpoint)
 	if nil != err {
 		return fmt.Errorf("failed to parse object %+v meta key", endpoint)
 	}
 
-	pius := convert.GroupIPAllocationDetails(uid, endpoint.Status.Current.IPs)
+	return i.transferIPPoolIPRecords(ctx, uid, namespaceKey, namespaceKey, endpoint.Status.Current.IPs)
+}
+
+// transferIPPoolIPRecords records the IP addresses allocated to the Pod
+// namespaceKey in the IPPools for the Pod newNamespaceKey with the UID.
+func (i *ipam) transferIPPoolIPRecords(ctx context.Context, uid, namespaceKey, newNamespaceKey string, details []spiderpoolv2beta1.IPAllocationDetail) error {
+	logger := logutils.FromContext(ctx)
+
+	pius := convert.GroupIPAllocationDetails(uid, details)
 	tickets := pius.Pools()
 	timeRecorder := metric.NewTimeRecorder()
 	if err := i.ipamLimiter.AcquireTicket(ctx, tickets...); err != nil {
@@ -160,7 +273,7 @@ func (i *ipam) reallocateIPPoolIPRecords(ctx context.Context, uid string, endpoi
 		go func(poolName string, ipAndUIDs []types.IPAndUID) {
 			defer wg.Done()
 
-			if err := i.ipPoolManager.UpdateAllocatedIPs(ctx, poolName, namespaceKey, ipAndUIDs); err != nil {
+			if err := i.ipPoolManager.TransferAllocatedIPs(ctx, poolName, namespaceKey, newNamespaceKey, ipAndUIDs); err != nil {
 				logger.Warn(err.Error())
 				errCh <- err
 				return
@@ -227,44 +340,18 @@ func (i *ipam) allocateInStandardMode(ctx context.Context, addArgs *models.IpamA
 	logger := logutils.FromContext(ctx)
 	isMultipleNicWithNoName := IsMultipleNicWithNoName(pod.Annotations)
 
-	logger.Debug("Parse custom routes")
-	customRoutes, err := getCustomRoutes(pod)
+	results, err := i.allocateAndPatchResults(ctx, []*models.IpamAddArgs{addArgs}, pod, endpoint, podController)
 	if err != nil {
-		return nil, err
-	}
-
-	logger.Debug("Generate IPPool candidates")
-	toBeAllocatedSet, err := i.genToBeAllocatedSet(ctx, addArgs, pod, podController)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []*types.AllocationResult
-	defer func() {
-		if err != nil && !isMultipleNicWithNoName {
+		if !isMultipleNicWithNoName {
 			if len(results) != 0 {
 				i.failure.addFailureIPs(string(pod.UID), results)
 			}
-			return
+		} else {
+			i.failure.rmFailureIPs(string(pod.UID))
 		}
-		i.failure.rmFailureIPs(string(pod.UID))
-	}()
-
-	logger.Debug("Concurrently allocate IP addresses from all IPPool candidates")
-	results, err = i.allocateIPsFromAllCandidates(ctx, toBeAllocatedSet, pod, podController)
-	if err != nil {
 		return nil, err
 	}
-
-	logger.Debug("Group custom routes by IP allocation results")
-	if err = groupCustomRoutes(ctx, customRoutes, results); err != nil {
-		return nil, fmt.Errorf("failed to group custom routes %+v: %v", customRoutes, err)
-	}
-
-	logger.Debug("Patch IP allocation results to Endpoint")
-	if err = i.endpointManager.PatchIPAllocationResults(ctx, results, endpoint, pod, podController, isMultipleNicWithNoName); err != nil {
-		return nil, fmt.Errorf("failed to patch IP allocation results to Endpoint: %v", err)
-	}
+	i.failure.rmFailureIPs(string(pod.UID))
 
 	// sort the results in order by NIC sequence in multiple NIC with no name specified mode
 	if isMultipleNicWithNoName {
@@ -310,6 +397,100 @@ func (i *ipam) allocateInStandardMode(ctx context.Context, addArgs *models.IpamA
 	return addResp, nil
 }
 
+// allocateAndPatchResults allocates IP addresses to the NICs of the Pod in
+// one go and patches the results to the Endpoint. The results allocated are
+// returned along with the error if only some of them are done.
+func (i *ipam) allocateAndPatchResults(ctx context.Context, addArgsList []*models.IpamAddArgs, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint, podController types.PodTopController) ([]*types.AllocationResult, error) {
+	logger := logutils.FromContext(ctx)
+	isMultipleNicWithNoName := IsMultipleNicWithNoName(pod.Annotations)
+
+	logger.Debug("Parse custom routes")
+	customRoutes, err := getCustomRoutes(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	isStickyIPPod, err := i.endpointManager.IsStickyIPPod(ctx, pod, podController)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("Generate IPPool candidates")
+	var toBeAllocatedSet ToBeAllocateds
+	for _, addArgs := range addArgsList {
+		tt, err := i.genToBeAllocatedSet(ctx, addArgs, pod, podController)
+		if err != nil {
+			return nil, err
+		}
+		toBeAllocatedSet = mergeToBeAllocateds(toBeAllocatedSet, tt)
+	}
+
+	logger.Debug("Concurrently allocate IP addresses from all IPPool candidates")
+	results, err := i.allocateIPsFromAllCandidates(ctx, toBeAllocatedSet, pod, podController, isStickyIPPod)
+	if err != nil {
+		return results, err
+	}
+
+	logger.Debug("Group custom routes by IP allocation results")
+	if err := groupCustomRoutes(ctx, customRoutes, results); err != nil {
+		return results, fmt.Errorf("failed to group custom routes %+v: %v", customRoutes, err)
+	}
+
+	logger.Debug("Patch IP allocation results to Endpoint")
+	if err := i.endpointManager.PatchIPAllocationResults(ctx, results, endpoint, pod, *addArgsList[0].ContainerID, podController, isMultipleNicWithNoName); err != nil {
+		return results, fmt.Errorf("failed to patch IP allocation results to Endpoint: %v", err)
+	}
+
+	if isStickyIPPod {
+		logger.Debug("Hand over IPPool IP records to the Endpoint of the sticky slot")
+		if err := i.handOverStickyIPs(ctx, pod, endpoint, results, isMultipleNicWithNoName); err != nil {
+			// The IP addresses are recorded in the Endpoint already, keep them
+			// for the next retrieval to hand over instead of rolling back.
+			return nil, fmt.Errorf("failed to hand over IPPool IP records to the Endpoint of the sticky slot: %v", err)
+		}
+	}
+
+	return results, nil
+}
+
+// handOverStickyIPs records the IP addresses just allocated to the Pod in
+// the IPPools for the Endpoint of the replica slot, which is created by
+// patching the results if the Pod has not claimed any slot before.
+func (i *ipam) handOverStickyIPs(ctx context.Context, pod *corev1.Pod, endpoint *spiderpoolv2beta1.SpiderEndpoint, results []*types.AllocationResult, isMultipleNicWithNoName bool) error {
+	if !workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+		var err error
+		endpoint, err = i.endpointManager.GetStickyEndpoint(ctx, pod, constant.IgnoreCache)
+		if err != nil {
+			return err
+		}
+		if endpoint == nil {
+			return fmt.Errorf("no Endpoint of sticky slot is claimed by the Pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+
+	return i.recordStickyIPs(ctx, pod, endpoint, convert.ConvertResultsToIPDetails(results, isMultipleNicWithNoName))
+}
+
+// mergeToBeAllocateds appends the NICs of tt which are not in preliminary
+// yet, since the IPPool candidates of all the NICs may be generated at once
+// through the Pod annotation.
+func mergeToBeAllocateds(preliminary, tt ToBeAllocateds) ToBeAllocateds {
+	for _, t := range tt {
+		exist := false
+		for _, p := range preliminary {
+			if p.NIC == t.NIC {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			preliminary = append(preliminary, t)
+		}
+	}
+
+	return preliminary
+}
+
 func (i *ipam) genToBeAllocatedSet(ctx context.Context, addArgs *models.IpamAddArgs, pod *corev1.Pod, podController types.PodTopController) (ToBeAllocateds, error) {
 	logger := logutils.FromContext(ctx)
 
@@ -339,6 +520,11 @@ func (i *ipam) genToBeAllocatedSet(ctx context.Context, addArgs *models.IpamAddA
 	}
 	logger.Sugar().Infof("Filtered IPPool candidates: %s", preliminary)
 
+	logger.Debug("Pin the specified IP addresses to IPPool candidates")
+	if err := pinSpecifiedIPs(ctx, preliminary, pod); err != nil {
+		return nil, err
+	}
+
 	logger.Debug("Verify IPPool candidates")
 	if err := i.verifyPoolCandidates(preliminary); err != nil {
 		return nil, err
@@ -346,12 +532,12 @@ func (i *ipam) genToBeAllocatedSet(ctx context.Context, addArgs *models.IpamAddA
 	logger.Info("All IPPool candidates are valid")
 
 	// sort IPPool candidates
-	sortPoolCandidates(preliminary)
+	sortPoolCandidates(preliminary, i.config.EnableIPPoolSpreading)
 
 	return preliminary, nil
 }
 
-func (i *ipam) allocateIPsFromAllCandidates(ctx context.Context, tt ToBeAllocateds, pod *corev1.Pod, podController types.PodTopController) ([]*types.AllocationResult, error) {
+func (i *ipam) allocateIPsFromAllCandidates(ctx context.Context, tt ToBeAllocateds, pod *corev1.Pod, podController types.PodTopController, isStickyIPPod bool) ([]*types.AllocationResult, error) {
 	logger := logutils.FromContext(ctx)
 
 	tickets := tt.Pools()
@@ -375,7 +561,7 @@ func (i *ipam) allocateIPsFromAllCandidates(ctx context.Context, tt ToBeAllocate
 
 		clogger := logger.With(zap.String("AllocateHash", fmt.Sprintf("%s-%d-%v", nic, candidate.IPVersion, candidate.Pools)))
 		clogger.Sugar().Debugf("Try to allocate IPv%d IP address to NIC %s from IPPools %v", candidate.IPVersion, nic, candidate.Pools)
-		result, err := i.allocateIPFromCandidate(logutils.IntoContext(ctx, clogger), candidate, nic, cleanGateway, pod, podController)
+		result, err := i.allocateIPFromCandidate(logutils.IntoContext(ctx, clogger), candidate, nic, cleanGateway, pod, podController, isStickyIPPod)
 		if err != nil {
 			clogger.Warn(err.Error())
 			errCh <- err
@@ -412,12 +598,12 @@ func (i *ipam) allocateIPsFromAllCandidates(ctx context.Context, tt ToBeAllocate
 	return results, nil
 }
 
-func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, nic string, cleanGateway bool, pod *corev1.Pod, podController types.PodTopController) (*types.AllocationResult, error) {
+func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, nic string, cleanGateway bool, pod *corev1.Pod, podController types.PodTopController, isStickyIPPod bool) (*types.AllocationResult, error) {
 	logger := logutils.FromContext(ctx)
 
 	for _, oldRes := range i.failure.getFailureIPs(string(pod.UID)) {
 		for _, ipPool := range c.PToIPPool {
-			if oldRes.IP.IPPool == ipPool.Name && *oldRes.IP.Nic == nic {
+			if oldRes.IP.IPPool == ipPool.Name && *oldRes.IP.Nic == nic && (c.IP == nil || strings.Split(*oldRes.IP.Address, "/")[0] == c.IP.String()) {
 				logger.Sugar().Infof("Reuse allocated IPv%d IP %s for NIC %s from IPPool %s", c.IPVersion, *oldRes.IP.Address, nic, ipPool.Name)
 				oldRes.Routes = convert.ConvertSpecRoutesToOAIRoutes(nic, ipPool.Spec.Routes)
 				oldRes.CleanGateway = cleanGateway
@@ -429,7 +615,20 @@ func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, ni
 	var errs []error
 	var result *types.AllocationResult
 	for _, pool := range c.Pools {
-		ip, err := i.ipPoolManager.AllocateIP(ctx, pool, nic, pod, podController)
+		var ip *models.IPConfig
+		var err error
+		if c.IP != nil {
+			ip, err = i.ipPoolManager.AssignIP(ctx, pool, c.IP.String(), nic, pod)
+		} else if i.useIPBlock(c.PToIPPool[pool], podController, isStickyIPPod) {
+			ip, err = i.ipPoolManager.AllocateIPFromBlock(ctx, pool, nic, pod)
+			if errors.Is(err, constant.ErrIPUsedOut) {
+				// the free IP addresses of the IPPool may be out of any IP block
+				logger.Sugar().Infof("No IP block of IPPool %s is available, fall back to allocate from the IPPool directly", pool)
+				ip, err = i.ipPoolManager.AllocateIP(ctx, pool, nic, pod, podController)
+			}
+		} else {
+			ip, err = i.ipPoolManager.AllocateIP(ctx, pool, nic, pod, podController)
+		}
 		if err != nil {
 			logger.Sugar().Warnf("Failed to allocate IPv%d IP address to NIC %s from IPPool %s: %v", c.IPVersion, nic, pool, err)
 			errs = append(errs, err)
@@ -453,6 +652,24 @@ func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, ni
 	return result, nil
 }
 
+// useIPBlock checks whether to allocate the IP address from the IP block of
+// the IPPool leased to the node. The IPPools created for the applications
+// automatically, the static IP addresses of the kubevirt VMs and the sticky
+// IP addresses are always allocated from the IPPool directly.
+func (i *ipam) useIPBlock(ipPool *spiderpoolv2beta1.SpiderIPPool, podController types.PodTopController, isStickyIPPod bool) bool {
+	if !i.config.EnableIPBlock || isStickyIPPod {
+		return false
+	}
+	if ipPool != nil && ippoolmanager.IsAutoCreatedIPPool(ipPool) {
+		return false
+	}
+	if i.config.EnableKubevirtStaticIP && podController.APIVersion == kubevirtv1.SchemeGroupVersion.String() && podController.Kind == constant.KindKubevirtVMI {
+		return false
+	}
+
+	return true
+}
+
 func (i *ipam) precheckPoolCandidates(ctx context.Context, t *ToBeAllocated) error {
 	logger := logutils.FromContext(ctx)
 
@@ -692,20 +909,29 @@ func (i *ipam) verifyPoolCandidates(tt ToBeAllocateds) error {
 	return nil
 }
 
-// sortPoolCandidates would sort IPPool candidates sequence depends on the IPPool multiple affinities.
-func sortPoolCandidates(preliminary ToBeAllocateds) {
+// sortPoolCandidates would sort IPPool candidates sequence depends on the
+// IPPool priorities and multiple affinities. With spread, the candidates
+// ordered equally are spread by their weighted usage.
+func sortPoolCandidates(preliminary ToBeAllocateds, spread bool) {
 	for _, toBeAllocate := range preliminary {
 		for _, poolCandidate := range (*toBeAllocate).PoolCandidates {
 			// new IPPool candidate names
 			poolNameList := []string{}
 
-			// collect all IPPool resource from PoolCandidate.PToIPPool
+			// collect the IPPool resources of the candidates in their
+			// original sequence, which is kept for the equal ones
 			pools := []*spiderpoolv2beta1.SpiderIPPool{}
-			for _, tmpPool := range poolCandidate.PToIPPool {
-				pools = append(pools, tmpPool.DeepCopy())
+			for _, poolName := range poolCandidate.Pools {
+				if tmpPool, ok := poolCandidate.PToIPPool[poolName]; ok {
+					pools = append(pools, tmpPool.DeepCopy())
+				}
+			}
+			if spread {
+				sort.Stable(ippoolmanager.ByPoolSpreading(pools))
+			} else {
+				// make it order with ippoolmanager.ByPoolPriority interface rules
+				sort.Stable(ippoolmanager.ByPoolPriority(pools))
 			}
-			// make it order with ippoolmanager.ByPoolPriority interface rules
-			sort.Sort(ippoolmanager.ByPoolPriority(pools))
 			for _, tmpPool := range pools {
 				poolNameList = append(poolNameList, tmpPool.Name)
 			}
@@ -0,0 +1,132 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/limiter"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+)
+
+var _ = Describe("Allocate", Label("allocate_test"), func() {
+	var ctx context.Context
+	var ipPoolManager *fakeIPPoolManager
+	var endpointManager *fakeEndpointManager
+	var i *ipam
+
+	var pod *corev1.Pod
+	var addArgs *models.IpamAddArgs
+	var endpointT *spiderpoolv2beta1.SpiderEndpoint
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		ipamLimiter := limiter.NewLimiter(limiter.LimiterConfig{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(ipamLimiter.Start(ctx)).To(Succeed())
+		}()
+		Eventually(ipamLimiter.Started).Should(BeTrue())
+
+		// The Pod web-0 of StatefulSet web.
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "web-0",
+				UID:       apitypes.UID("5b0c5d6e-5d2a-4a4e-9c55-2a5f4f7c2b61"),
+				Annotations: map[string]string{
+					constant.AnnoPodIPPools: `[{"interface":"eth0","ipv4":["eth0-pool"]}]`,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		addArgs = &models.IpamAddArgs{
+			ContainerID:  pointer.String("container"),
+			IfName:       pointer.String("eth0"),
+			NetNamespace: pointer.String("/var/run/netns/web-0"),
+			PodNamespace: pointer.String(pod.Namespace),
+			PodName:      pointer.String(pod.Name),
+		}
+		endpointT = &spiderpoolv2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+			Status: spiderpoolv2beta1.WorkloadEndpointStatus{
+				Current: spiderpoolv2beta1.PodIPAllocation{
+					UID: "b3d9b0e4-3f3c-4c0b-8f0e-52f1a7b2f0c9",
+					IPs: []spiderpoolv2beta1.IPAllocationDetail{
+						{NIC: "eth0", IPv4: pointer.String("172.18.40.10/16"), IPv4Pool: pointer.String("eth0-pool")},
+					},
+				},
+			},
+		}
+
+		ipPoolManager = &fakeIPPoolManager{released: map[string][]types.IPAndUID{}}
+		endpointManager = &fakeEndpointManager{}
+		i = &ipam{
+			config:          setDefaultsForIPAMConfig(IPAMConfig{EnableIPv4: true}),
+			ipamLimiter:     ipamLimiter,
+			failure:         newFailureCache(),
+			ipPoolManager:   ipPoolManager,
+			endpointManager: endpointManager,
+			podManager: &fakePodManager{
+				pod: pod,
+				topController: &types.PodTopController{
+					AppNamespacedName: types.AppNamespacedName{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindStatefulSet,
+						Namespace:  pod.Namespace,
+						Name:       "web",
+					},
+				},
+			},
+			workloadKinds: &fakeWorkloadKinds{stableKind: constant.KindStatefulSet},
+		}
+	})
+
+	It("does not take the IP addresses of the sticky slot with the name of the Pod", func() {
+		endpointT.Status.OwnerControllerType = constant.KindDeployment
+		endpointT.Status.OwnerControllerName = "web"
+		endpointT.Status.StickySlot = pointer.Int64(0)
+		endpointManager.endpoint = endpointT
+
+		_, err := i.Allocate(ctx, addArgs)
+		Expect(err).To(MatchError(ContainSubstring("sticky slot 0 of Deployment web")))
+		Expect(ipPoolManager.allocated).To(BeEmpty())
+	})
+
+	It("does not retrieve the IP addresses of the Endpoint owned by another application", func() {
+		endpointT.Status.OwnerControllerType = constant.KindStatefulSet
+		endpointT.Status.OwnerControllerName = "web-other"
+		endpointManager.endpoint = endpointT
+
+		_, err := i.Allocate(ctx, addArgs)
+		Expect(err).To(MatchError(ContainSubstring("StatefulSet web-other rather than StatefulSet web")))
+		Expect(ipPoolManager.allocated).To(BeEmpty())
+	})
+
+	It("allocates IP addresses to the StatefulSet Pod whose Endpoint does not exist", func() {
+		resp, err := i.Allocate(ctx, addArgs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Ips).To(HaveLen(1))
+		Expect(ipPoolManager.allocated).To(ConsistOf("eth0-pool"))
+	})
+
+	It("names the Endpoint of the sticky slot apart from the Pods of StatefulSet", func() {
+		Expect(workloadendpointmanager.StickyEndpointName("web", 0)).NotTo(Equal(pod.Name))
+	})
+})
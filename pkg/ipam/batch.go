@@ -0,0 +1,178 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+// AllocateBatch allocates IP addresses to multiple NICs of a Pod at once,
+// it is used by the callers knowing all NICs of the Pod, while the
+// spiderpool plugin allocates NIC by NIC through Allocate. The NICs without
+// IP allocation are allocated in one go, and if any of them fails, the IP
+// addresses already allocated to the others are kept in the failure cache
+// for the retry of the batch, just as Allocate does. For the Pods whose
+// IPPools of NICs are specified with no name, the NIC names are only known
+// one by one, so the batch must hold only one NIC.
+func (i *ipam) AllocateBatch(ctx context.Context, addArgs *models.IpamBatchAddArgs) (*models.IpamBatchAddResponse, error) {
+	logger := logutils.FromContext(ctx)
+	logger.Info("Start to allocate as a batch")
+
+	nics := make([]string, 0, len(addArgs.Nics))
+	for _, n := range addArgs.Nics {
+		for _, nic := range nics {
+			if nic == *n.IfName {
+				return nil, fmt.Errorf("%w: duplicated NIC %s in the batch", constant.ErrWrongInput, nic)
+			}
+		}
+		nics = append(nics, *n.IfName)
+	}
+
+	pod, podTopController, endpoint, err := i.getPodForAllocation(ctx, *addArgs.PodNamespace, *addArgs.PodName)
+	if err != nil {
+		return nil, err
+	}
+	isMultipleNicWithNoName := IsMultipleNicWithNoName(pod.Annotations)
+	customDNS, err := getCustomDNS(pod)
+	if err != nil {
+		return nil, err
+	}
+	if isMultipleNicWithNoName && len(addArgs.Nics) > 1 {
+		return nil, fmt.Errorf("%w: batch allocation of multiple NICs does not support the IPPools of the NICs with no name specified", constant.ErrWrongInput)
+	}
+
+	var ips []*models.IPConfig
+	var routes []*models.Route
+	var toBeAllocated []*models.IpamAddArgs
+	for _, n := range addArgs.Nics {
+		addResp, err := i.retrieveIPAllocation(ctx, *n.IfName, pod, endpoint, podTopController)
+		if err != nil {
+			return nil, err
+		}
+		if addResp != nil {
+			// the IP allocations of all the NICs are retrieved at once
+			ips, routes = addResp.Ips, addResp.Routes
+			continue
+		}
+
+		nicArgs := &models.IpamAddArgs{
+			ContainerID:       addArgs.ContainerID,
+			IfName:            n.IfName,
+			NetNamespace:      addArgs.NetNamespace,
+			PodNamespace:      addArgs.PodNamespace,
+			PodName:           addArgs.PodName,
+			PodUID:            addArgs.PodUID,
+			DefaultIPV4IPPool: n.DefaultIPV4IPPool,
+			DefaultIPV6IPPool: n.DefaultIPV6IPPool,
+			CleanGateway:      n.CleanGateway,
+		}
+		if isMultipleNicWithNoName {
+			logger.Info("Allocate IP addresses in standard mode")
+			addResp, err = i.allocateInStandardMode(ctx, nicArgs, pod, endpoint, podTopController)
+			if err != nil {
+				return nil, fmt.Errorf("failed to allocate IP addresses in standard mode: %w", err)
+			}
+			ips, routes = addResp.Ips, addResp.Routes
+			continue
+		}
+		toBeAllocated = append(toBeAllocated, nicArgs)
+	}
+
+	if len(toBeAllocated) != 0 {
+		logger.Sugar().Infof("Allocate IP addresses to %d NICs in standard mode", len(toBeAllocated))
+		results, err := i.allocateAndPatchResults(ctx, toBeAllocated, pod, endpoint, podTopController)
+		if err != nil {
+			if len(results) != 0 {
+				i.failure.addFailureIPs(string(pod.UID), results)
+			}
+			return nil, fmt.Errorf("failed to allocate IP addresses in standard mode: %w", err)
+		}
+		i.failure.rmFailureIPs(string(pod.UID))
+
+		resIPs, resRoutes := convert.ConvertResultsToIPConfigsAndAllRoutes(results)
+		ips = mergeIPConfigs(ips, resIPs)
+		routes = append(routes, resRoutes...)
+	}
+
+	batchResp := &models.IpamBatchAddResponse{}
+	for _, nic := range nics {
+		nic := nic
+		nicResult := &models.IpamBatchNicResult{
+			IfName: &nic,
+			Ips:    []*models.IPConfig{},
+		}
+		for _, ip := range ips {
+			if ip.Nic != nil && *ip.Nic == nic {
+				nicResult.Ips = append(nicResult.Ips, ip)
+			}
+		}
+		for _, route := range routes {
+			if route.IfName != nil && *route.IfName == nic {
+				nicResult.Routes = append(nicResult.Routes, route)
+			}
+		}
+		nicResult.DNS, err = i.getDNS(ctx, nic, customDNS, ips)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the DNS of NIC %s: %w", nic, err)
+		}
+		batchResp.Results = append(batchResp.Results, nicResult)
+	}
+
+	result, err := batchResp.MarshalBinary()
+	if nil != err {
+		logger.Sugar().Infof("Succeed to allocate as a batch: %+v", *batchResp)
+	} else {
+		logger.Sugar().Infof("Succeed to allocate as a batch: %s", string(result))
+	}
+
+	return batchResp, nil
+}
+
+// mergeIPConfigs appends the IP configs which are not in ips yet, the IPPools
+// of different NICs may overlap, so the same address of another NIC is not
+// the same IP config.
+func mergeIPConfigs(ips, newIPs []*models.IPConfig) []*models.IPConfig {
+	for _, newIP := range newIPs {
+		exist := false
+		for _, ip := range ips {
+			if *ip.Address == *newIP.Address && pointer.StringDeref(ip.Nic, "") == pointer.StringDeref(newIP.Nic, "") {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			ips = append(ips, newIP)
+		}
+	}
+
+	return ips
+}
+
+// ReleaseBatch releases the IP addresses of multiple NICs of a Pod at once.
+// The Pod and its Endpoint are only looked up once, and the IP addresses of
+// all NICs are released together.
+func (i *ipam) ReleaseBatch(ctx context.Context, delArgs *models.IpamBatchDelArgs) error {
+	logger := logutils.FromContext(ctx)
+	logger.Info("Start to release as a batch")
+
+	if len(delArgs.IfNames) == 0 {
+		return fmt.Errorf("%w: no NIC in the batch", constant.ErrWrongInput)
+	}
+
+	return i.releaseNICs(ctx, &models.IpamDelArgs{
+		ContainerID:  delArgs.ContainerID,
+		NetNamespace: delArgs.NetNamespace,
+		PodNamespace: delArgs.PodNamespace,
+		PodName:      delArgs.PodName,
+		PodUID:       delArgs.PodUID,
+	}, delArgs.IfNames)
+}
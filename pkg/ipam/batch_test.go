@@ -0,0 +1,347 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/limiter"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
+)
+
+// fakeIPPoolManager records the IP addresses allocated and released, the
+// methods not overridden panic since they are not expected to be called.
+type fakeIPPoolManager struct {
+	ippoolmanager.IPPoolManager
+
+	l          lock.Mutex
+	allocated  []string
+	allocErrs  map[string]error
+	released   map[string][]types.IPAndUID
+	releaseErr error
+}
+
+func (f *fakeIPPoolManager) AllocateIP(ctx context.Context, poolName, nic string, pod *corev1.Pod, podController types.PodTopController) (*models.IPConfig, error) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	if err, ok := f.allocErrs[poolName]; ok {
+		return nil, err
+	}
+	f.allocated = append(f.allocated, poolName)
+
+	return &models.IPConfig{
+		Address: pointer.String(fmt.Sprintf("172.18.40.%d/16", 100+len(f.allocated))),
+		IPPool:  poolName,
+		Nic:     pointer.String(nic),
+		Version: pointer.Int64(constant.IPv4),
+	}, nil
+}
+
+func (f *fakeIPPoolManager) GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	return &spiderpoolv2beta1.SpiderIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: spiderpoolv2beta1.IPPoolSpec{
+			IPVersion: pointer.Int64(constant.IPv4),
+			Disable:   pointer.Bool(false),
+		},
+	}, nil
+}
+
+func (f *fakeIPPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUIDs []types.IPAndUID) error {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	if f.releaseErr != nil {
+		return f.releaseErr
+	}
+	f.released[poolName] = append(f.released[poolName], ipAndUIDs...)
+
+	return nil
+}
+
+// fakePodManager returns the Pod, which is not found if it is nil, and
+// its top controller, which is the Pod itself if it is nil. The Pod of
+// spiderpool-agent is returned by its name.
+type fakePodManager struct {
+	podmanager.PodManager
+
+	pod           *corev1.Pod
+	agentPod      *corev1.Pod
+	topController *types.PodTopController
+}
+
+func (f *fakePodManager) GetPodByName(ctx context.Context, namespace, podName string, cached bool) (*corev1.Pod, error) {
+	if f.agentPod != nil && f.agentPod.Namespace == namespace && f.agentPod.Name == podName {
+		return f.agentPod, nil
+	}
+	if f.pod == nil {
+		return nil, apierrors.NewNotFound(corev1.Resource("pods"), podName)
+	}
+
+	return f.pod, nil
+}
+
+func (f *fakePodManager) GetPodTopController(ctx context.Context, pod *corev1.Pod) (types.PodTopController, error) {
+	if f.topController != nil {
+		return *f.topController, nil
+	}
+
+	return types.PodTopController{
+		AppNamespacedName: types.AppNamespacedName{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       constant.KindPod,
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+		},
+		UID: pod.UID,
+	}, nil
+}
+
+// fakeEndpointManager returns the Endpoint, which is not found if it is
+// nil, and records the IP allocation results patched to it.
+type fakeEndpointManager struct {
+	workloadendpointmanager.WorkloadEndpointManager
+
+	endpoint      *spiderpoolv2beta1.SpiderEndpoint
+	gets          int
+	patched       []*types.AllocationResult
+	finalizerGone bool
+}
+
+func (f *fakeEndpointManager) GetEndpointByName(ctx context.Context, namespace, podName string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	f.gets++
+	if f.endpoint == nil {
+		return nil, apierrors.NewNotFound(spiderpoolv2beta1.Resource(constant.KindSpiderEndpoint), podName)
+	}
+
+	return f.endpoint, nil
+}
+
+func (f *fakeEndpointManager) GetPodEndpoint(ctx context.Context, namespace, endpointName, podUID string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	endpoint, err := f.GetEndpointByName(ctx, namespace, endpointName, cached)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return endpoint, err
+}
+
+func (f *fakeEndpointManager) IsStickyIPPod(ctx context.Context, pod *corev1.Pod, podController types.PodTopController) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeEndpointManager) PatchIPAllocationResults(ctx context.Context, results []*types.AllocationResult, endpoint *spiderpoolv2beta1.SpiderEndpoint, pod *corev1.Pod, containerID string, podController types.PodTopController, isMultipleNicWithNoName bool) error {
+	f.patched = append(f.patched, results...)
+	return nil
+}
+
+func (f *fakeEndpointManager) RemoveFinalizer(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
+	f.finalizerGone = true
+	return nil
+}
+
+// fakeWorkloadKinds names the Endpoint after the Pod for the workload kind
+// with stable identity, which is none if it is empty.
+type fakeWorkloadKinds struct {
+	workloadkind.Registry
+
+	stableKind string
+}
+
+func (f *fakeWorkloadKinds) LookupEndpointOwner(ownerControllerAPIVersion, ownerControllerType string) workloadkind.WorkloadKind {
+	return nil
+}
+
+func (f *fakeWorkloadKinds) StableEndpointName(podName string, controller types.AppNamespacedName) (string, bool) {
+	if f.stableKind == "" || controller.Kind != f.stableKind {
+		return "", false
+	}
+
+	return podName, true
+}
+
+var _ = Describe("Batch", Label("batch_test"), func() {
+	var ctx context.Context
+	var ipPoolManager *fakeIPPoolManager
+	var podManager *fakePodManager
+	var endpointManager *fakeEndpointManager
+	var i *ipam
+
+	var uid string
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		ipamLimiter := limiter.NewLimiter(limiter.LimiterConfig{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(ipamLimiter.Start(ctx)).To(Succeed())
+		}()
+		Eventually(ipamLimiter.Started).Should(BeTrue())
+
+		uid = "2ba7a3e9-c7ee-4d6f-8a0b-a6e4f0a0c4b1"
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "pod",
+				UID:       apitypes.UID(uid),
+				Annotations: map[string]string{
+					constant.AnnoPodIPPools: `[{"interface":"eth0","ipv4":["eth0-pool"]},{"interface":"net1","ipv4":["net1-pool"]}]`,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+
+		ipPoolManager = &fakeIPPoolManager{released: map[string][]types.IPAndUID{}}
+		podManager = &fakePodManager{pod: pod}
+		endpointManager = &fakeEndpointManager{}
+		i = &ipam{
+			config:          setDefaultsForIPAMConfig(IPAMConfig{EnableIPv4: true}),
+			ipamLimiter:     ipamLimiter,
+			failure:         newFailureCache(),
+			ipPoolManager:   ipPoolManager,
+			endpointManager: endpointManager,
+			podManager:      podManager,
+			workloadKinds:   &fakeWorkloadKinds{},
+		}
+	})
+
+	Describe("AllocateBatch", func() {
+		var addArgs *models.IpamBatchAddArgs
+
+		BeforeEach(func() {
+			addArgs = &models.IpamBatchAddArgs{
+				ContainerID:  pointer.String("container"),
+				NetNamespace: pointer.String("/var/run/netns/pod"),
+				PodNamespace: pointer.String(pod.Namespace),
+				PodName:      pointer.String(pod.Name),
+				PodUID:       pointer.String(uid),
+				Nics: []*models.IpamBatchNicArgs{
+					{IfName: pointer.String("eth0")},
+					{IfName: pointer.String("net1")},
+				},
+			}
+		})
+
+		It("allocates IP addresses to all NICs in one go", func() {
+			resp, err := i.AllocateBatch(ctx, addArgs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Results).To(HaveLen(2))
+			Expect(*resp.Results[0].IfName).To(Equal("eth0"))
+			Expect(resp.Results[0].Ips).To(HaveLen(1))
+			Expect(*resp.Results[1].IfName).To(Equal("net1"))
+			Expect(resp.Results[1].Ips).To(HaveLen(1))
+
+			Expect(ipPoolManager.allocated).To(ConsistOf("eth0-pool", "net1-pool"))
+			Expect(endpointManager.patched).To(HaveLen(2))
+			Expect(i.failure.getFailureIPs(uid)).To(BeNil())
+		})
+
+		It("rejects the duplicated NICs", func() {
+			addArgs.Nics = append(addArgs.Nics, &models.IpamBatchNicArgs{IfName: pointer.String("eth0")})
+
+			_, err := i.AllocateBatch(ctx, addArgs)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("keeps the IP addresses of the NICs allocated when the others fail for the retry", func() {
+			ipPoolManager.allocErrs = map[string]error{"net1-pool": constant.ErrIPUsedOut}
+
+			_, err := i.AllocateBatch(ctx, addArgs)
+			Expect(err).To(MatchError(constant.ErrIPUsedOut))
+			Expect(ipPoolManager.allocated).To(ConsistOf("eth0-pool"))
+			Expect(ipPoolManager.released).To(BeEmpty())
+			Expect(i.failure.getFailureIPs(uid)).To(HaveLen(1))
+
+			ipPoolManager.allocErrs = nil
+			resp, err := i.AllocateBatch(ctx, addArgs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Results).To(HaveLen(2))
+
+			// eth0 reuses the IP address allocated by the failed batch
+			Expect(ipPoolManager.allocated).To(ConsistOf("eth0-pool", "net1-pool"))
+			Expect(ipPoolManager.released).To(BeEmpty())
+			Expect(i.failure.getFailureIPs(uid)).To(BeNil())
+		})
+	})
+
+	Describe("ReleaseBatch", func() {
+		var delArgs *models.IpamBatchDelArgs
+
+		BeforeEach(func() {
+			podManager.pod = nil
+			endpointManager.endpoint = &spiderpoolv2beta1.SpiderEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+				Status: spiderpoolv2beta1.WorkloadEndpointStatus{
+					Current: spiderpoolv2beta1.PodIPAllocation{
+						UID: uid,
+						IPs: []spiderpoolv2beta1.IPAllocationDetail{
+							{NIC: "eth0", IPv4: pointer.String("172.18.40.10/16"), IPv4Pool: pointer.String("eth0-pool")},
+							{NIC: "net1", IPv4: pointer.String("172.19.40.10/16"), IPv4Pool: pointer.String("net1-pool")},
+						},
+					},
+					OwnerControllerType: constant.KindPod,
+				},
+			}
+			delArgs = &models.IpamBatchDelArgs{
+				ContainerID:  pointer.String("container"),
+				PodNamespace: pointer.String(pod.Namespace),
+				PodName:      pointer.String(pod.Name),
+				PodUID:       pointer.String(uid),
+				IfNames:      []string{"eth0", "net1"},
+			}
+		})
+
+		It("releases the IP addresses of all NICs at once", func() {
+			Expect(i.ReleaseBatch(ctx, delArgs)).To(Succeed())
+
+			Expect(endpointManager.gets).To(Equal(1))
+			Expect(ipPoolManager.released).To(HaveLen(2))
+			Expect(ipPoolManager.released["eth0-pool"]).To(ConsistOf(types.IPAndUID{IP: "172.18.40.10", UID: uid}))
+			Expect(ipPoolManager.released["net1-pool"]).To(ConsistOf(types.IPAndUID{IP: "172.19.40.10", UID: uid}))
+			Expect(endpointManager.finalizerGone).To(BeTrue())
+		})
+
+		It("releases the IP addresses even if only the other NIC is recorded", func() {
+			delArgs.IfNames = []string{"net2", "net1"}
+			Expect(i.ReleaseBatch(ctx, delArgs)).To(Succeed())
+
+			Expect(ipPoolManager.released).To(HaveLen(2))
+		})
+
+		It("releases nothing for the NICs not recorded", func() {
+			delArgs.IfNames = []string{"net2"}
+			Expect(i.ReleaseBatch(ctx, delArgs)).To(Succeed())
+
+			Expect(ipPoolManager.released).To(BeEmpty())
+			Expect(endpointManager.finalizerGone).To(BeFalse())
+		})
+
+		It("rejects the batch without NIC", func() {
+			delArgs.IfNames = nil
+			Expect(i.ReleaseBatch(ctx, delArgs)).To(MatchError(constant.ErrWrongInput))
+		})
+	})
+})
@@ -0,0 +1,131 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+// Check verifies that the IP addresses of the container still match the
+// current IP allocation of the NIC recorded in the Endpoint, and that the
+// IPPools still record them for the Pod. An error wrapping
+// constant.ErrIPAllocationDrift is returned if they diverge.
+func (i *ipam) Check(ctx context.Context, checkArgs *models.IpamCheckArgs) error {
+	logger := logutils.FromContext(ctx)
+	logger.Info("Start to check")
+
+	pod, err := i.podManager.GetPodByName(ctx, *checkArgs.PodNamespace, *checkArgs.PodName, constant.IgnoreCache)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: Pod %s/%s does not exist", constant.ErrIPAllocationDrift, *checkArgs.PodNamespace, *checkArgs.PodName)
+		}
+		return fmt.Errorf("failed to get Pod %s/%s: %v", *checkArgs.PodNamespace, *checkArgs.PodName, err)
+	}
+
+	uid := *checkArgs.PodUID
+	if len(uid) == 0 {
+		uid = string(pod.UID)
+	}
+
+	endpointName := i.getEndpointName(pod)
+	// the sticky IP addresses are held by the Endpoint of the replica slot claimed by the Pod
+	endpoint, err := i.endpointManager.GetPodEndpoint(ctx, pod.Namespace, endpointName, string(pod.UID), constant.IgnoreCache)
+	if err != nil {
+		return fmt.Errorf("failed to get Endpoint %s/%s: %v", pod.Namespace, endpointName, err)
+	}
+	if endpoint == nil {
+		return fmt.Errorf("%w: Endpoint %s/%s does not exist", constant.ErrIPAllocationDrift, pod.Namespace, endpointName)
+	}
+	if endpoint.Status.Current.UID != uid {
+		return fmt.Errorf("%w: the current IP allocation of Endpoint %s/%s belongs to Pod UID %s rather than %s", constant.ErrIPAllocationDrift, endpoint.Namespace, endpoint.Name, endpoint.Status.Current.UID, uid)
+	}
+
+	var nicDetails []spiderpoolv2beta1.IPAllocationDetail
+	var expected []string
+	for _, d := range endpoint.Status.Current.IPs {
+		if d.NIC != *checkArgs.IfName {
+			continue
+		}
+		nicDetails = append(nicDetails, d)
+		if d.IPv4 != nil {
+			expected = append(expected, *d.IPv4)
+		}
+		if d.IPv6 != nil {
+			expected = append(expected, *d.IPv6)
+		}
+	}
+	actual, err := normalizeCIDRs(checkArgs.Ips)
+	if err != nil {
+		return fmt.Errorf("%w: %v", constant.ErrWrongInput, err)
+	}
+	expected, err = normalizeCIDRs(expected)
+	if err != nil {
+		return err
+	}
+	if strings.Join(actual, ",") != strings.Join(expected, ",") {
+		return fmt.Errorf("%w: the IP addresses %v of NIC %s differ from %v recorded in Endpoint %s/%s", constant.ErrIPAllocationDrift, actual, *checkArgs.IfName, expected, endpoint.Namespace, endpoint.Name)
+	}
+
+	for poolName, ipAndUIDs := range convert.GroupIPAllocationDetails(uid, nicDetails) {
+		ipPool, err := i.ipPoolManager.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("%w: IPPool %s does not exist", constant.ErrIPAllocationDrift, poolName)
+			}
+			return fmt.Errorf("failed to get IPPool %s: %v", poolName, err)
+		}
+
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+		if err != nil {
+			return err
+		}
+		for _, iu := range ipAndUIDs {
+			record, ok := records[iu.IP]
+			if !ok && i.config.EnableIPBlock {
+				// the IP address allocated from the IP block may not be
+				// written to the IPPool yet
+				record, ok = i.ipPoolManager.GetPendingIPBlockAllocation(poolName, iu.IP)
+			}
+			if !ok {
+				return fmt.Errorf("%w: IP %s is not recorded in IPPool %s", constant.ErrIPAllocationDrift, iu.IP, poolName)
+			}
+			if record.PodUID != iu.UID {
+				return fmt.Errorf("%w: IP %s is recorded for Pod %s (UID %s) in IPPool %s", constant.ErrIPAllocationDrift, iu.IP, record.NamespacedName, record.PodUID, poolName)
+			}
+		}
+	}
+
+	logger.Info("Succeed to check")
+
+	return nil
+}
+
+// normalizeCIDRs parses the IP addresses in CIDR notation and returns them
+// in canonical form and sorted.
+func normalizeCIDRs(cidrs []string) ([]string, error) {
+	res := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ip, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		ones, _ := ipNet.Mask.Size()
+		res = append(res, fmt.Sprintf("%s/%d", ip, ones))
+	}
+	sort.Strings(res)
+
+	return res, nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 )
 
+const defaultIPBlockFlushDuration = time.Second
+
 type IPAMConfig struct {
 	EnableIPv4 bool
 	EnableIPv6 bool
@@ -25,11 +27,21 @@ type IPAMConfig struct {
 	OperationRetries     int
 	OperationGapDuration time.Duration
 
+	EnableIPBlock        bool
+	IPBlockFlushDuration time.Duration
+
+	EnableIPPoolSpreading bool
+
 	MultusClusterNetwork *string
 	AgentNamespace       string
+	AgentPodName         string
 }
 
 func setDefaultsForIPAMConfig(config IPAMConfig) IPAMConfig {
+	if config.IPBlockFlushDuration <= 0 {
+		config.IPBlockFlushDuration = defaultIPBlockFlushDuration
+	}
+
 	return config
 }
 
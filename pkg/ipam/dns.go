@@ -0,0 +1,106 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/strings/slices"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+func getCustomDNS(pod *corev1.Pod) (*models.DNS, error) {
+	anno, ok := pod.Annotations[constant.AnnoPodDNS]
+	if !ok {
+		return nil, nil
+	}
+
+	var annoPodDNS types.AnnoPodDNSValue
+	errPrefix := fmt.Errorf("%w, invalid format of Pod annotation '%s'", constant.ErrWrongInput, constant.AnnoPodDNS)
+	err := json.Unmarshal([]byte(anno), &annoPodDNS)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errPrefix, err)
+	}
+
+	for _, ns := range annoPodDNS.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return nil, fmt.Errorf("%w: invalid nameserver '%s'", errPrefix, ns)
+		}
+	}
+
+	return convert.ConvertAnnoPodDNSToOAIDNS(annoPodDNS), nil
+}
+
+// getDNS generates the DNS configuration of the NIC. The custom DNS parsed
+// from the Pod annotation ipam.spidernet.io/dns takes precedence, otherwise
+// the DNS configurations of the IPPools the IP addresses of the NIC belong
+// to are merged. It returns nil if there is none. The annotation is parsed
+// by the caller before allocating, so that an invalid one fails the Pod
+// without leaking any IP address.
+func (i *ipam) getDNS(ctx context.Context, nic string, customDNS *models.DNS, ips []*models.IPConfig) (*models.DNS, error) {
+	logger := logutils.FromContext(ctx)
+
+	if customDNS != nil {
+		logger.Sugar().Debugf("Use the DNS of Pod annotation '%s'", constant.AnnoPodDNS)
+		return customDNS, nil
+	}
+
+	var dns *models.DNS
+	for _, ip := range ips {
+		if ip.Nic == nil || *ip.Nic != nic || ip.IPPool == "" {
+			continue
+		}
+
+		ipPool, err := i.ipPoolManager.GetIPPoolByName(ctx, ip.IPPool, constant.UseCache)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get IPPool %s: %w", ip.IPPool, err)
+		}
+		dns = mergeDNS(dns, convert.ConvertSpecDNSToOAIDNS(ipPool.Spec.DNS))
+	}
+
+	return dns, nil
+}
+
+// mergeDNS appends the nameservers, search domains and options of newDNS
+// which are not in dns yet. The domain of dns is kept if it is set.
+func mergeDNS(dns, newDNS *models.DNS) *models.DNS {
+	if newDNS == nil {
+		return dns
+	}
+	if dns == nil {
+		return newDNS
+	}
+
+	if dns.Domain == "" {
+		dns.Domain = newDNS.Domain
+	}
+	dns.Nameservers = appendIfMissing(dns.Nameservers, newDNS.Nameservers...)
+	dns.Search = appendIfMissing(dns.Search, newDNS.Search...)
+	dns.Options = appendIfMissing(dns.Options, newDNS.Options...)
+
+	return dns
+}
+
+func appendIfMissing(items []string, newItems ...string) []string {
+	for _, n := range newItems {
+		if !slices.Contains(items, n) {
+			items = append(items, n)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,206 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// fakeDNSIPPoolManager fails to get any IPPool.
+type fakeDNSIPPoolManager struct {
+	fakeStatusIPPoolManager
+}
+
+func (f *fakeDNSIPPoolManager) GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	return nil, constant.ErrUnknown
+}
+
+var _ = Describe("DNS", Label("dns_test"), func() {
+	newPod := func(anno string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}}
+		if anno != "" {
+			pod.Annotations = map[string]string{constant.AnnoPodDNS: anno}
+		}
+
+		return pod
+	}
+
+	Describe("getCustomDNS", func() {
+		It("returns nil without the annotation", func() {
+			dns, err := getCustomDNS(newPod(""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns).To(BeNil())
+		})
+
+		It("fails with the annotation in invalid format", func() {
+			dns, err := getCustomDNS(newPod(`{"nameservers": "10.0.0.10"}`))
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(dns).To(BeNil())
+		})
+
+		It("fails with an invalid nameserver", func() {
+			dns, err := getCustomDNS(newPod(`{"nameservers": ["10.0.0.300"]}`))
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(dns).To(BeNil())
+		})
+
+		It("parses the annotation", func() {
+			dns, err := getCustomDNS(newPod(`{"nameservers": ["10.0.0.10"], "domain": "cluster.local", "search": ["svc.cluster.local"]}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns.Nameservers).To(Equal([]string{"10.0.0.10"}))
+			Expect(dns.Domain).To(Equal("cluster.local"))
+			Expect(dns.Search).To(Equal([]string{"svc.cluster.local"}))
+		})
+	})
+
+	Describe("getDNS", func() {
+		var ctx context.Context
+		var i *ipam
+
+		newIPPool := func(name string, dns *spiderpoolv2beta1.DNS) *spiderpoolv2beta1.SpiderIPPool {
+			return &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       spiderpoolv2beta1.IPPoolSpec{DNS: dns},
+			}
+		}
+
+		newIP := func(nic, pool string) *models.IPConfig {
+			return &models.IPConfig{Nic: pointer.String(nic), IPPool: pool}
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			i = &ipam{
+				ipPoolManager: &fakeStatusIPPoolManager{
+					pools: []*spiderpoolv2beta1.SpiderIPPool{
+						newIPPool("v4-pool", &spiderpoolv2beta1.DNS{
+							Nameservers: []string{"10.0.0.10"},
+							Domain:      "v4.example.com",
+							Search:      []string{"example.com"},
+							Options:     []string{"ndots:5"},
+						}),
+						newIPPool("v6-pool", &spiderpoolv2beta1.DNS{
+							Nameservers: []string{"10.0.0.10", "fd00::10"},
+							Domain:      "v6.example.com",
+							Search:      []string{"example.com", "v6.example.com"},
+							Options:     []string{"ndots:5", "timeout:2"},
+						}),
+						newIPPool("net1-pool", &spiderpoolv2beta1.DNS{Nameservers: []string{"172.16.0.10"}}),
+						newIPPool("no-dns-pool", nil),
+					},
+				},
+			}
+		})
+
+		It("returns nil if none of the IPPools has DNS", func() {
+			dns, err := i.getDNS(ctx, "eth0", nil, []*models.IPConfig{newIP("eth0", "no-dns-pool")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns).To(BeNil())
+		})
+
+		It("merges the DNS of the IPPools of the NIC without duplicates", func() {
+			dns, err := i.getDNS(ctx, "eth0", nil, []*models.IPConfig{
+				newIP("eth0", "v4-pool"),
+				newIP("eth0", "no-dns-pool"),
+				newIP("eth0", "v6-pool"),
+				newIP("net1", "net1-pool"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns).To(Equal(&models.DNS{
+				Nameservers: []string{"10.0.0.10", "fd00::10"},
+				Domain:      "v4.example.com",
+				Search:      []string{"example.com", "v6.example.com"},
+				Options:     []string{"ndots:5", "timeout:2"},
+			}))
+		})
+
+		It("only takes the DNS of the IPPools of the NIC", func() {
+			dns, err := i.getDNS(ctx, "net1", nil, []*models.IPConfig{
+				newIP("eth0", "v4-pool"),
+				newIP("net1", "net1-pool"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns.Nameservers).To(Equal([]string{"172.16.0.10"}))
+		})
+
+		It("takes the DNS of the Pod annotation in precedence", func() {
+			customDNS, err := getCustomDNS(newPod(`{"nameservers": ["192.168.0.10"], "search": ["custom.example.com"]}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			dns, err := i.getDNS(ctx, "eth0", customDNS, []*models.IPConfig{newIP("eth0", "v4-pool")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns).To(Equal(&models.DNS{
+				Nameservers: []string{"192.168.0.10"},
+				Search:      []string{"custom.example.com"},
+				Options:     []string{},
+			}))
+		})
+
+		It("skips the IPPools that no longer exist", func() {
+			dns, err := i.getDNS(ctx, "eth0", nil, []*models.IPConfig{
+				newIP("eth0", "deleted-pool"),
+				newIP("eth0", "net1-pool"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dns.Nameservers).To(Equal([]string{"172.16.0.10"}))
+		})
+
+		It("fails to get the IPPool", func() {
+			i.ipPoolManager = &fakeDNSIPPoolManager{}
+
+			dns, err := i.getDNS(ctx, "eth0", nil, []*models.IPConfig{newIP("eth0", "v4-pool")})
+			Expect(err).To(MatchError(constant.ErrUnknown))
+			Expect(dns).To(BeNil())
+		})
+
+		It("does not modify the IPPools", func() {
+			_, err := i.getDNS(ctx, "eth0", nil, []*models.IPConfig{
+				newIP("eth0", "net1-pool"),
+				newIP("eth0", "v4-pool"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			pool, err := i.ipPoolManager.GetIPPoolByName(ctx, "net1-pool", constant.UseCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Spec.DNS.Nameservers).To(Equal([]string{"172.16.0.10"}))
+		})
+	})
+
+	Describe("mergeDNS", func() {
+		It("returns the other one if either is nil", func() {
+			dns := &models.DNS{Nameservers: []string{"10.0.0.10"}}
+			Expect(mergeDNS(nil, dns)).To(Equal(dns))
+			Expect(mergeDNS(dns, nil)).To(Equal(dns))
+			Expect(mergeDNS(nil, nil)).To(BeNil())
+		})
+
+		It("keeps the domain set", func() {
+			dns := mergeDNS(&models.DNS{Domain: "a.example.com"}, &models.DNS{Domain: "b.example.com"})
+			Expect(dns.Domain).To(Equal("a.example.com"))
+
+			dns = mergeDNS(&models.DNS{}, &models.DNS{Domain: "b.example.com"})
+			Expect(dns.Domain).To(Equal("b.example.com"))
+		})
+
+		It("appends the missing items in order", func() {
+			dns := mergeDNS(
+				&models.DNS{Nameservers: []string{"10.0.0.10"}, Search: []string{"a.example.com"}, Options: []string{"ndots:5"}},
+				&models.DNS{Nameservers: []string{"10.0.0.11", "10.0.0.10"}, Search: []string{"a.example.com", "b.example.com"}, Options: []string{"ndots:5"}},
+			)
+			Expect(dns.Nameservers).To(Equal([]string{"10.0.0.10", "10.0.0.11"}))
+			Expect(dns.Search).To(Equal([]string{"a.example.com", "b.example.com"}))
+			Expect(dns.Options).To(Equal([]string{"ndots:5"}))
+		})
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// GC releases the IP allocations of the Endpoints on the node, whose
+// container IDs are not in the valid attachments handed over by the
+// runtime. The IP allocations of the Pods still alive are kept by Release,
+// so are the ones of StatefulSet and KubeVirt VMs to be reused.
+func (i *ipam) GC(ctx context.Context, gcArgs *models.IpamGCArgs) error {
+	logger := logutils.FromContext(ctx)
+	logger.Info("Start to garbage collect")
+
+	validContainerIDs := make(map[string]struct{}, len(gcArgs.ValidAttachments))
+	for _, attachment := range gcArgs.ValidAttachments {
+		validContainerIDs[*attachment.ContainerID] = struct{}{}
+	}
+
+	agentPod, err := i.podManager.GetPodByName(ctx, i.config.AgentNamespace, i.config.AgentPodName, constant.UseCache)
+	if err != nil {
+		return fmt.Errorf("failed to get the node of spiderpool-agent: %v", err)
+	}
+	nodeName := agentPod.Spec.NodeName
+
+	endpointList, err := i.endpointManager.ListEndpoints(ctx, constant.UseCache)
+	if err != nil {
+		return fmt.Errorf("failed to list Endpoints: %v", err)
+	}
+
+	var errs []error
+	for _, endpoint := range endpointList.Items {
+		current := endpoint.Status.Current
+		// The IP allocations recorded before the container ID do not
+		// belong to any container as far as we know, leave them to the
+		// GC of spiderpool-controller.
+		if current.Node != nodeName || len(current.ContainerID) == 0 || len(current.IPs) == 0 {
+			continue
+		}
+		if _, ok := validContainerIDs[current.ContainerID]; ok {
+			continue
+		}
+		if endpoint.Status.OwnerControllerType == constant.KindKubevirtVMI {
+			continue
+		}
+
+		namespace, name := endpoint.Namespace, endpoint.Name
+		nic, uid, containerID := current.IPs[0].NIC, current.UID, current.ContainerID
+		gcLogger := logger.With(
+			zap.String("PodNamespace", namespace),
+			zap.String("PodName", name),
+			zap.String("PodUID", uid),
+			zap.String("ContainerID", containerID),
+		)
+		gcLogger.Info("Release the IP allocation of the container not in the valid attachments")
+
+		err := i.Release(logutils.IntoContext(ctx, gcLogger), &models.IpamDelArgs{
+			ContainerID:  &containerID,
+			IfName:       &nic,
+			PodNamespace: &namespace,
+			PodName:      &name,
+			PodUID:       &uid,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to release the IP allocation of Endpoint %s/%s: %w", namespace, name, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	logger.Info("Succeed to garbage collect")
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/limiter"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// fakeGCEndpointManager lists the Endpoints and gets them by name.
+type fakeGCEndpointManager struct {
+	fakeEndpointManager
+
+	endpoints []spiderpoolv2beta1.SpiderEndpoint
+}
+
+func (f *fakeGCEndpointManager) ListEndpoints(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderEndpointList, error) {
+	return &spiderpoolv2beta1.SpiderEndpointList{Items: f.endpoints}, nil
+}
+
+func (f *fakeGCEndpointManager) GetEndpointByName(ctx context.Context, namespace, podName string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	for i := range f.endpoints {
+		if f.endpoints[i].Namespace == namespace && f.endpoints[i].Name == podName {
+			return &f.endpoints[i], nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(spiderpoolv2beta1.Resource(constant.KindSpiderEndpoint), podName)
+}
+
+func (f *fakeGCEndpointManager) GetPodEndpoint(ctx context.Context, namespace, endpointName, podUID string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	endpoint, err := f.GetEndpointByName(ctx, namespace, endpointName, cached)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return endpoint, err
+}
+
+var _ = Describe("GC", Label("gc_test"), func() {
+	var ctx context.Context
+	var ipPoolManager *fakeIPPoolManager
+	var endpointManager *fakeGCEndpointManager
+	var i *ipam
+
+	newEndpoint := func(name, node, containerID, ip string) spiderpoolv2beta1.SpiderEndpoint {
+		return spiderpoolv2beta1.SpiderEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status: spiderpoolv2beta1.WorkloadEndpointStatus{
+				Current: spiderpoolv2beta1.PodIPAllocation{
+					UID:         name + "-uid",
+					Node:        node,
+					ContainerID: containerID,
+					IPs: []spiderpoolv2beta1.IPAllocationDetail{
+						{NIC: "eth0", IPv4: pointer.String(ip + "/16"), IPv4Pool: pointer.String("pool")},
+					},
+				},
+				OwnerControllerType: constant.KindPod,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		ipamLimiter := limiter.NewLimiter(limiter.LimiterConfig{})
+		go func() {
+			defer GinkgoRecover()
+			Expect(ipamLimiter.Start(ctx)).To(Succeed())
+		}()
+		Eventually(ipamLimiter.Started).Should(BeTrue())
+
+		ipPoolManager = &fakeIPPoolManager{released: map[string][]types.IPAndUID{}}
+		endpointManager = &fakeGCEndpointManager{
+			endpoints: []spiderpoolv2beta1.SpiderEndpoint{
+				newEndpoint("valid", "node1", "valid-container", "172.18.40.10"),
+				newEndpoint("stale", "node1", "stale-container", "172.18.40.11"),
+			},
+		}
+		i = &ipam{
+			config: setDefaultsForIPAMConfig(IPAMConfig{
+				EnableIPv4:     true,
+				AgentNamespace: "kube-system",
+				AgentPodName:   "spiderpool-agent",
+			}),
+			ipamLimiter:   ipamLimiter,
+			failure:       newFailureCache(),
+			ipPoolManager: ipPoolManager,
+			podManager: &fakePodManager{
+				agentPod: &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "spiderpool-agent"},
+					Spec:       corev1.PodSpec{NodeName: "node1"},
+				},
+			},
+			endpointManager: endpointManager,
+			workloadKinds:   &fakeWorkloadKinds{},
+		}
+	})
+
+	It("keeps the Endpoints whose containers are in the valid attachments", func() {
+		err := i.GC(ctx, &models.IpamGCArgs{
+			ValidAttachments: []*models.IpamAttachment{
+				{ContainerID: pointer.String("valid-container"), IfName: pointer.String("eth0")},
+				{ContainerID: pointer.String("stale-container"), IfName: pointer.String("eth0")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ipPoolManager.released).To(BeEmpty())
+	})
+
+	It("releases the stale Endpoints on the node", func() {
+		err := i.GC(ctx, &models.IpamGCArgs{
+			ValidAttachments: []*models.IpamAttachment{
+				{ContainerID: pointer.String("valid-container"), IfName: pointer.String("eth0")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ipPoolManager.released).To(Equal(map[string][]types.IPAndUID{
+			"pool": {{IP: "172.18.40.11", UID: "stale-uid"}},
+		}))
+		Expect(endpointManager.finalizerGone).To(BeTrue())
+	})
+
+	It("leaves the Endpoints of other nodes, without container ID or of KubeVirt VMs", func() {
+		vm := newEndpoint("vm", "node1", "vm-container", "172.18.40.12")
+		vm.Status.OwnerControllerType = constant.KindKubevirtVMI
+		endpointManager.endpoints = []spiderpoolv2beta1.SpiderEndpoint{
+			newEndpoint("remote", "node2", "remote-container", "172.18.40.10"),
+			newEndpoint("legacy", "node1", "", "172.18.40.11"),
+			vm,
+		}
+
+		err := i.GC(ctx, &models.IpamGCArgs{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ipPoolManager.released).To(BeEmpty())
+	})
+
+	It("fails to release the stale Endpoints", func() {
+		ipPoolManager.releaseErr = constant.ErrUnknown
+
+		err := i.GC(ctx, &models.IpamGCArgs{})
+		Expect(err).To(MatchError(constant.ErrUnknown))
+	})
+
+	It("fails to get the node of spiderpool-agent", func() {
+		i.config.AgentPodName = "unknown"
+
+		err := i.GC(ctx, &models.IpamGCArgs{})
+		Expect(err).To(HaveOccurred())
+		Expect(ipPoolManager.released).To(BeEmpty())
+	})
+})
@@ -7,24 +7,31 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
-	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
 	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
-	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 type IPAM interface {
 	Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*models.IpamAddResponse, error)
 	Release(ctx context.Context, delArgs *models.IpamDelArgs) error
+	AllocateBatch(ctx context.Context, addArgs *models.IpamBatchAddArgs) (*models.IpamBatchAddResponse, error)
+	ReleaseBatch(ctx context.Context, delArgs *models.IpamBatchDelArgs) error
+	Check(ctx context.Context, checkArgs *models.IpamCheckArgs) error
+	Status(ctx context.Context, statusArgs *models.IpamStatusArgs) error
+	GC(ctx context.Context, gcArgs *models.IpamGCArgs) error
 	Start(ctx context.Context) error
 }
 
@@ -38,9 +45,8 @@ type ipam struct {
 	nodeManager     nodemanager.NodeManager
 	nsManager       namespacemanager.NamespaceManager
 	podManager      podmanager.PodManager
-	stsManager      statefulsetmanager.StatefulSetManager
 	subnetManager   subnetmanager.SubnetManager
-	kubevirtManager kubevirtmanager.KubevirtManager
+	workloadKinds   workloadkind.Registry
 }
 
 func NewIPAM(
@@ -50,9 +56,8 @@ func NewIPAM(
 	nodeManager nodemanager.NodeManager,
 	nsManager namespacemanager.NamespaceManager,
 	podManager podmanager.PodManager,
-	stsManager statefulsetmanager.StatefulSetManager,
 	subnetManager subnetmanager.SubnetManager,
-	kubevirtManager kubevirtmanager.KubevirtManager,
+	workloadKinds workloadkind.Registry,
 ) (IPAM, error) {
 	if ipPoolManager == nil {
 		return nil, fmt.Errorf("ippool manager %w", constant.ErrMissingRequiredParam)
@@ -69,14 +74,11 @@ func NewIPAM(
 	if podManager == nil {
 		return nil, fmt.Errorf("pod manager %w", constant.ErrMissingRequiredParam)
 	}
-	if stsManager == nil {
-		return nil, fmt.Errorf("statefulset manager %w", constant.ErrMissingRequiredParam)
-	}
 	if config.EnableSpiderSubnet && subnetManager == nil {
 		return nil, fmt.Errorf("subnet manager %w", constant.ErrMissingRequiredParam)
 	}
-	if kubevirtManager == nil {
-		return nil, fmt.Errorf("kubevirt manager %w", constant.ErrMissingRequiredParam)
+	if workloadKinds == nil {
+		return nil, fmt.Errorf("workload kind registry %w", constant.ErrMissingRequiredParam)
 	}
 
 	return &ipam{
@@ -88,9 +90,8 @@ func NewIPAM(
 		nodeManager:     nodeManager,
 		nsManager:       nsManager,
 		podManager:      podManager,
-		stsManager:      stsManager,
 		subnetManager:   subnetManager,
-		kubevirtManager: kubevirtManager,
+		workloadKinds:   workloadKinds,
 	}, nil
 }
 
@@ -102,6 +103,10 @@ func (i *ipam) Start(ctx context.Context) error {
 		}
 	}()
 
+	if i.config.EnableIPBlock {
+		go wait.UntilWithContext(ctx, i.flushIPBlockAllocations, i.config.IPBlockFlushDuration)
+	}
+
 	select {
 	case <-ctx.Done():
 		return nil
@@ -110,6 +115,16 @@ func (i *ipam) Start(ctx context.Context) error {
 	}
 }
 
+// flushIPBlockAllocations writes the IP addresses allocated from the IP
+// blocks of the node to the IPPools.
+func (i *ipam) flushIPBlockAllocations(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	if err := i.ipPoolManager.FlushIPBlockAllocations(ctx); err != nil {
+		logger.Sugar().Errorf("Failed to flush IP block allocations: %v", err)
+	}
+}
+
 type failureCache struct {
 	l       lock.RWMutex
 	entries map[string][]*types.AllocationResult
@@ -14,7 +14,6 @@ import (
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
@@ -116,10 +115,12 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 
 	// This only serves for third party controller application, because we'll create or scale the auto-created IPPool here.
 	// For those kubernetes applications(such as deployment and replicaset), the spiderpool-controller will create or scale the auto-created IPPool asynchronously.
-	poolIPNum, err := getAutoPoolIPNumber(pod, podController)
+	poolIPNum, err := getAutoPoolIPNumber(i.workloadKinds, pod, podController)
 	if nil != err {
 		return nil, err
 	}
+	// spiderpool-controller sizes the auto-created IPPool to the max replicas of HorizontalPodAutoscaler
+	isLowerBound := subnetAnnoConfig.FlexibleIPNum != nil && subnetAnnoConfig.SizingPolicy == constant.AutoPoolSizingHPAMax
 
 	var v4PoolCandidate, v6PoolCandidate *spiderpoolv2beta1.SpiderIPPool
 	var errV4, errV6 error
@@ -131,7 +132,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 		go func() {
 			defer wg.Done()
 
-			if !slices.Contains(constant.K8sAPIVersions, podController.APIVersion) || !slices.Contains(constant.K8sKinds, podController.Kind) {
+			if isThirdController(i.workloadKinds, podController.AppNamespacedName) {
 				v4PoolCandidate, errV4 = i.applyThirdControllerAutoPool(ctx, subnetItem.IPv4[0], podController, types.AutoPoolProperty{
 					DesiredIPNumber:     poolIPNum,
 					IPVersion:           constant.IPv4,
@@ -140,7 +141,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 					AnnoPoolIPNumberVal: strconv.Itoa(poolIPNum),
 				})
 			} else {
-				v4PoolCandidate, errV4 = i.findAppAutoPool(ctx, subnetItem.IPv4[0], nic, constant.LabelValueIPVersionV4, poolIPNum, podController)
+				v4PoolCandidate, errV4 = i.findAppAutoPool(ctx, subnetItem.IPv4[0], nic, constant.LabelValueIPVersionV4, poolIPNum, isLowerBound, podController)
 			}
 
 			if nil != errV4 {
@@ -155,7 +156,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 		go func() {
 			defer wg.Done()
 
-			if !slices.Contains(constant.K8sAPIVersions, podController.APIVersion) || !slices.Contains(constant.K8sKinds, podController.Kind) {
+			if isThirdController(i.workloadKinds, podController.AppNamespacedName) {
 				v6PoolCandidate, errV6 = i.applyThirdControllerAutoPool(ctx, subnetItem.IPv6[0], podController, types.AutoPoolProperty{
 					DesiredIPNumber:     poolIPNum,
 					IPVersion:           constant.IPv6,
@@ -164,7 +165,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 					AnnoPoolIPNumberVal: strconv.Itoa(poolIPNum),
 				})
 			} else {
-				v6PoolCandidate, errV6 = i.findAppAutoPool(ctx, subnetItem.IPv6[0], nic, constant.LabelValueIPVersionV6, poolIPNum, podController)
+				v6PoolCandidate, errV6 = i.findAppAutoPool(ctx, subnetItem.IPv6[0], nic, constant.LabelValueIPVersionV6, poolIPNum, isLowerBound, podController)
 			}
 
 			if nil != errV6 {
@@ -200,7 +201,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 }
 
 // findAppAutoPool only fetches kubernetes basic controller(like Deployment, StatefulSet etc...) corresponding auto-created IPPools.
-func (i *ipam) findAppAutoPool(ctx context.Context, subnetName, ifName, labelIPPoolIPVersionValue string, desiredIPNumber int, podController types.PodTopController) (*spiderpoolv2beta1.SpiderIPPool, error) {
+func (i *ipam) findAppAutoPool(ctx context.Context, subnetName, ifName, labelIPPoolIPVersionValue string, desiredIPNumber int, isLowerBound bool, podController types.PodTopController) (*spiderpoolv2beta1.SpiderIPPool, error) {
 	log := logutils.FromContext(ctx)
 
 	var pool *spiderpoolv2beta1.SpiderIPPool
@@ -229,7 +230,7 @@ func (i *ipam) findAppAutoPool(ctx context.Context, subnetName, ifName, labelIPP
 			log.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' with matchLabel '%v'", subnetName, pool.Name, matchLabels)
 
 			// we fetched Auto-created IPPool but it doesn't have any IPs, just wait for a while and let the IPPool informer to allocate IPs for it
-			if !isPoolIPsDesired(pool, desiredIPNumber) {
+			if !isPoolIPsDesired(pool, desiredIPNumber, isLowerBound) {
 				log.Sugar().Warnf("fetch SubnetIPPool %d times: retrieved IPPool '%s' but doesn't have the desiredIPNumber IPs, wait for a second and get a retry", j, pool.Name)
 				time.Sleep(i.config.OperationGapDuration)
 				continue
@@ -10,10 +10,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
-	kubevirtv1 "kubevirt.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
@@ -31,6 +28,14 @@ func (i *ipam) Release(ctx context.Context, delArgs *models.IpamDelArgs) error {
 	logger := logutils.FromContext(ctx)
 	logger.Info("Start to release")
 
+	return i.releaseNICs(ctx, delArgs, []string{*delArgs.IfName})
+}
+
+// releaseNICs releases the IP addresses of the Pod if any of the NICs is
+// recorded in its Endpoint, the IfName of delArgs is ignored.
+func (i *ipam) releaseNICs(ctx context.Context, delArgs *models.IpamDelArgs, nics []string) error {
+	logger := logutils.FromContext(ctx)
+
 	pod, err := i.podManager.GetPodByName(ctx, *delArgs.PodNamespace, *delArgs.PodName, constant.IgnoreCache)
 	if client.IgnoreNotFound(err) != nil {
 		return fmt.Errorf("failed to get Pod %s/%s: %v", *delArgs.PodNamespace, *delArgs.PodName, err)
@@ -80,22 +85,19 @@ func (i *ipam) Release(ctx context.Context, delArgs *models.IpamDelArgs) error {
 	endpointName := *delArgs.PodName
 	// if the kubevirt vm pod is not exist, the gc will release the legacy IP later
 	if pod != nil {
-		ownerReference := metav1.GetControllerOf(pod)
-		if ownerReference != nil && i.config.EnableKubevirtStaticIP &&
-			ownerReference.APIVersion == kubevirtv1.SchemeGroupVersion.String() && ownerReference.Kind == constant.KindKubevirtVMI {
-			endpointName = ownerReference.Name
-		}
+		endpointName = i.getEndpointName(pod)
 	}
-	endpoint, err := i.endpointManager.GetEndpointByName(ctx, *delArgs.PodNamespace, endpointName, constant.IgnoreCache)
+	// the sticky IP addresses are held by the Endpoint of the replica slot claimed by the Pod
+	endpoint, err := i.endpointManager.GetPodEndpoint(ctx, *delArgs.PodNamespace, endpointName, *delArgs.PodUID, constant.IgnoreCache)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.Info("Endpoint does not exist, ignore release")
-			return nil
-		}
 		return fmt.Errorf("failed to get Endpoint %s/%s: %v", *delArgs.PodNamespace, *delArgs.PodName, err)
 	}
+	if endpoint == nil {
+		logger.Info("Endpoint does not exist, ignore release")
+		return nil
+	}
 
-	if err := i.releaseForAllNICs(ctx, *delArgs.PodUID, *delArgs.IfName, endpoint); err != nil {
+	if err := i.releaseForAllNICs(ctx, *delArgs.PodUID, nics, endpoint); err != nil {
 		return err
 	}
 	logger.Info("Succeed to release")
@@ -103,19 +105,20 @@ func (i *ipam) Release(ctx context.Context, delArgs *models.IpamDelArgs) error {
 	return nil
 }
 
-func (i *ipam) releaseForAllNICs(ctx context.Context, uid, nic string, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
+func (i *ipam) releaseForAllNICs(ctx context.Context, uid string, nics []string, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
 	logger := logutils.FromContext(ctx)
 
-	// Check whether an StatefulSet needs to release its currently allocated IP addresses.
+	// Check whether the Pod with stable identity, such as the Pod of StatefulSet
+	// or kubevirt VM, needs to release its currently allocated IP addresses.
 	// It is discussed in https://github.com/spidernet-io/spiderpool/issues/1045
-	if i.config.EnableStatefulSet && endpoint.Status.OwnerControllerType == constant.KindStatefulSet {
-		isValidStatefulSetPod, err := i.stsManager.IsValidStatefulSetPod(ctx, endpoint.Namespace, endpoint.Name, endpoint.Status.OwnerControllerType)
+	if kind := i.workloadKinds.LookupEndpointOwner(endpoint.Status.OwnerControllerAPIVersion, endpoint.Status.OwnerControllerType); kind != nil {
+		isValidPod, err := kind.IsValidPod(ctx, endpoint.Namespace, endpoint.Name, endpoint.Status.OwnerControllerName)
 		if nil != err {
-			return fmt.Errorf("failed to check pod '%s/%s' whether is a valid StatefulSet pod, error: %w", endpoint.Namespace, endpoint.Name, err)
+			return fmt.Errorf("failed to check pod '%s/%s' whether is a valid %s pod, error: %w", endpoint.Namespace, endpoint.Name, endpoint.Status.OwnerControllerType, err)
 		}
 
-		if isValidStatefulSetPod {
-			logger.Info("There is no need to release the IP allocation of StatefulSet")
+		if isValidPod {
+			logger.Sugar().Infof("There is no need to release the IP allocation of %s", endpoint.Status.OwnerControllerType)
 			return nil
 		}
 
@@ -124,15 +127,16 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, uid, nic string, endpoint
 		}
 	}
 
-	// Check whether the kubevirt VM pod needs to keep its IP allocation.
-	if i.config.EnableKubevirtStaticIP && endpoint.Status.OwnerControllerType == constant.KindKubevirtVMI {
-		isValidVMPod, err := i.kubevirtManager.IsValidVMPod(ctx, endpoint.Namespace, endpoint.Status.OwnerControllerType, endpoint.Status.OwnerControllerName)
+	// Check whether the replica slot of Deployment or ReplicaSet needs to
+	// keep its sticky IP addresses.
+	if workloadendpointmanager.IsStickyIPEndpoint(endpoint) {
+		isValidStickyEndpoint, err := i.endpointManager.IsValidStickyEndpoint(ctx, endpoint)
 		if nil != err {
-			return fmt.Errorf("failed to check pod '%s/%s' whether is a valid kubevirt VM pod, error: %w", endpoint.Namespace, endpoint.Name, err)
+			return fmt.Errorf("failed to check Endpoint '%s/%s' whether holds a valid sticky slot, error: %w", endpoint.Namespace, endpoint.Name, err)
 		}
 
-		if isValidVMPod {
-			logger.Info("There is no need to release the IP allocation of kubevirt VM")
+		if isValidStickyEndpoint {
+			logger.Sugar().Infof("There is no need to release the sticky IP allocation of %s slot %d", endpoint.Status.OwnerControllerType, *endpoint.Status.StickySlot)
 			return nil
 		}
 
@@ -141,7 +145,12 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, uid, nic string, endpoint
 		}
 	}
 
-	allocation := workloadendpointmanager.RetrieveIPAllocation(uid, nic, endpoint, false)
+	var allocation *spiderpoolv2beta1.PodIPAllocation
+	for _, nic := range nics {
+		if allocation = workloadendpointmanager.RetrieveIPAllocation(uid, nic, endpoint, false); allocation != nil {
+			break
+		}
+	}
 	if allocation == nil {
 		logger.Info("Nothing retrieved for releasing")
 		return nil
@@ -0,0 +1,97 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// pinSpecifiedIPs sets the IP addresses specified by the Pod annotation
+// ipam.spidernet.io/ips to the IPPool candidates of the NICs, only the
+// IPPools containing them are kept as the candidates. The items of the NICs
+// which are not the NICs of the Pod fail the allocation, rather than being
+// ignored silently.
+func pinSpecifiedIPs(ctx context.Context, tt ToBeAllocateds, pod *corev1.Pod) error {
+	logger := logutils.FromContext(ctx)
+
+	specifiedIPs, err := podmanager.ValidateAnnoPodIPs(pod)
+	if err != nil {
+		return err
+	}
+	if len(specifiedIPs) == 0 {
+		return nil
+	}
+
+	// In multiple NIC with no name specified mode, the NICs to be allocated
+	// are named with their sequence, which is the index of the Pod NICs.
+	nics, err := podmanager.GetPodNICs(pod)
+	if err != nil {
+		return fmt.Errorf("%w, %v", constant.ErrWrongInput, err)
+	}
+	nicName := func(nic string) string {
+		if !IsMultipleNicWithNoName(pod.Annotations) {
+			return nic
+		}
+		idx, err := strconv.Atoi(nic)
+		if err != nil || idx < 0 || idx >= len(nics) {
+			return nic
+		}
+
+		return nics[idx]
+	}
+
+	for _, item := range specifiedIPs {
+		for _, t := range tt {
+			if nicName(t.NIC) != item.NIC {
+				continue
+			}
+
+			for _, v := range []struct {
+				version types.IPVersion
+				ip      string
+			}{{constant.IPv4, item.IPv4}, {constant.IPv6, item.IPv6}} {
+				version, ip := v.version, v.ip
+				if ip == "" {
+					continue
+				}
+
+				c := t.candidateOf(version)
+				if c == nil {
+					return fmt.Errorf("%w, no IPv%d IPPool candidate of NIC %s for IP %s of Pod annotation '%s'", constant.ErrWrongInput, version, t.NIC, ip, constant.AnnoPodIPs)
+				}
+
+				var pools []string
+				for _, pool := range c.Pools {
+					poolIPs, err := spiderpoolip.NewIPBitmap(version, c.PToIPPool[pool].Spec.IPs, c.PToIPPool[pool].Spec.ExcludeIPs)
+					if err != nil {
+						return err
+					}
+					if poolIPs.Contains(net.ParseIP(ip)) {
+						pools = append(pools, pool)
+					}
+				}
+				if len(pools) == 0 {
+					return fmt.Errorf("%w, IP %s of Pod annotation '%s' does not belong to any IPv%d IPPool candidate %v of NIC %s", constant.ErrWrongInput, ip, constant.AnnoPodIPs, version, c.Pools, t.NIC)
+				}
+
+				logger.Sugar().Infof("Pin IPv%d IP %s of NIC %s to IPPools %v", version, ip, t.NIC, pools)
+				c.IP = net.ParseIP(ip)
+				c.Pools = pools
+			}
+		}
+	}
+
+	return nil
+}
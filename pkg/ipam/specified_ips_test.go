@@ -0,0 +1,140 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("Specified IPs", Label("specified_ips_test"), func() {
+	var ctx context.Context
+	var pod *corev1.Pod
+
+	newCandidate := func(version types.IPVersion, poolIPs map[string][]string) *PoolCandidate {
+		c := &PoolCandidate{IPVersion: version, PToIPPool: PoolNameToIPPool{}}
+		for name, ips := range poolIPs {
+			c.Pools = append(c.Pools, name)
+			c.PToIPPool[name] = &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: spiderpoolv2beta1.IPPoolSpec{
+					IPVersion: pointer.Int64(version),
+					IPs:       ips,
+				},
+			}
+		}
+
+		return c
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pod",
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			},
+		}
+	})
+
+	Describe("pinSpecifiedIPs", func() {
+		It("does nothing without the annotation", func() {
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.IP).To(BeNil())
+		})
+
+		It("pins the IP address to the IPPools containing it", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "eth0", "ipv4": "172.18.40.45"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{
+				"pool1": {"172.18.40.40-172.18.40.50"},
+				"pool2": {"172.18.41.40-172.18.41.50"},
+			})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.IP.Equal(net.ParseIP("172.18.40.45"))).To(BeTrue())
+			Expect(c.Pools).To(Equal([]string{"pool1"}))
+		})
+
+		It("fails with duplicate NICs", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"ipv4": "172.18.40.45"}, {"interface": "eth0", "ipv4": "172.18.40.46"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("fails with the IP address of the wrong family", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "eth0", "ipv4": "fc00::45"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("fails without IPPool candidate of the IP family", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "eth0", "ipv6": "fc00::45"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("fails with the IP address out of the IPPool candidates", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "eth0", "ipv4": "172.18.40.60"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(c.IP).To(BeNil())
+		})
+
+		It("fails with the NIC the Pod does not have", func() {
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "net1", "ipv4": "172.18.41.45"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("leaves the NIC of the Pod allocated by another call", func() {
+			pod.Annotations[constant.MultusNetworkAttachmentAnnot] = "kube-system/macvlan"
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "net1", "ipv4": "172.18.41.45"}]`
+			c := newCandidate(constant.IPv4, map[string][]string{"pool": {"172.18.40.40-172.18.40.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{{NIC: "eth0", PoolCandidates: []*PoolCandidate{c}}}, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.IP).To(BeNil())
+		})
+
+		It("pins the IP address to the NIC with no name specified", func() {
+			pod.Annotations[constant.MultusNetworkAttachmentAnnot] = "kube-system/macvlan"
+			pod.Annotations[constant.AnnoPodIPPools] = `[{"ipv4": ["pool1"]}, {"ipv4": ["pool2"]}]`
+			pod.Annotations[constant.AnnoPodIPs] = `[{"interface": "net1", "ipv4": "172.18.41.45"}]`
+			c0 := newCandidate(constant.IPv4, map[string][]string{"pool1": {"172.18.40.40-172.18.40.50"}})
+			c1 := newCandidate(constant.IPv4, map[string][]string{"pool2": {"172.18.41.40-172.18.41.50"}})
+
+			err := pinSpecifiedIPs(ctx, ToBeAllocateds{
+				{NIC: "0", PoolCandidates: []*PoolCandidate{c0}},
+				{NIC: "1", PoolCandidates: []*PoolCandidate{c1}},
+			}, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c0.IP).To(BeNil())
+			Expect(c1.IP.Equal(net.ParseIP("172.18.41.45"))).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,94 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// Status checks whether the IPPools to allocate from are usable. They are
+// the default IPPools of the CNI network configuration if specified,
+// otherwise the cluster default IPPools. For each enabled IP version, at
+// least one of them has to exist, not be terminating or disabled, and have
+// free IP addresses. An error wrapping constant.ErrNoAvailablePool is
+// returned if not.
+func (i *ipam) Status(ctx context.Context, statusArgs *models.IpamStatusArgs) error {
+	logger := logutils.FromContext(ctx)
+	logger.Debug("Start to check status")
+
+	v4Pools, v6Pools := statusArgs.DefaultIPV4IPPool, statusArgs.DefaultIPV6IPPool
+	if len(v4Pools) == 0 && len(v6Pools) == 0 {
+		ipPoolList, err := i.ipPoolManager.ListIPPools(
+			ctx,
+			constant.UseCache,
+			client.MatchingFields{"spec.default": strconv.FormatBool(true)},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to list cluster default IPPools: %v", err)
+		}
+		for _, ipPool := range ipPoolList.Items {
+			if *ipPool.Spec.IPVersion == constant.IPv4 {
+				v4Pools = append(v4Pools, ipPool.Name)
+			} else {
+				v6Pools = append(v6Pools, ipPool.Name)
+			}
+		}
+	}
+
+	// Without any default IPPools, the IPPools are specified by Pods, which
+	// is not able to be checked here.
+	if i.config.EnableIPv4 && len(v4Pools) != 0 {
+		if err := i.checkPoolsUsable(ctx, constant.IPv4, v4Pools); err != nil {
+			return err
+		}
+	}
+	if i.config.EnableIPv6 && len(v6Pools) != 0 {
+		if err := i.checkPoolsUsable(ctx, constant.IPv6, v6Pools); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *ipam) checkPoolsUsable(ctx context.Context, ipVersion types.IPVersion, pools []string) error {
+	logger := logutils.FromContext(ctx)
+
+	for _, pool := range pools {
+		ipPool, err := i.ipPoolManager.GetIPPoolByName(ctx, pool, constant.UseCache)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Sugar().Debugf("IPPool %s does not exist", pool)
+				continue
+			}
+			return fmt.Errorf("failed to get IPPool %s: %v", pool, err)
+		}
+
+		switch {
+		case ipPool.DeletionTimestamp != nil:
+			logger.Sugar().Debugf("IPPool %s is terminating", pool)
+		case ipPool.Spec.Disable != nil && *ipPool.Spec.Disable:
+			logger.Sugar().Debugf("IPPool %s is disabled", pool)
+		case *ipPool.Spec.IPVersion != ipVersion:
+			logger.Sugar().Debugf("IPPool %s is not an IPv%d IPPool", pool, ipVersion)
+		case ipPool.Status.TotalIPCount != nil && ipPool.Status.AllocatedIPCount != nil &&
+			*ipPool.Status.AllocatedIPCount >= *ipPool.Status.TotalIPCount:
+			logger.Sugar().Debugf("IPPool %s has no free IP addresses", pool)
+		default:
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: none of IPv%d IPPools %v is usable", constant.ErrNoAvailablePool, ipVersion, pools)
+}
@@ -0,0 +1,140 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// fakeStatusIPPoolManager gets the IPPools by name, all of them are
+// listed as the cluster default IPPools.
+type fakeStatusIPPoolManager struct {
+	ippoolmanager.IPPoolManager
+
+	pools []*spiderpoolv2beta1.SpiderIPPool
+}
+
+func (f *fakeStatusIPPoolManager) GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	for _, pool := range f.pools {
+		if pool.Name == poolName {
+			return pool, nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(spiderpoolv2beta1.Resource(constant.KindSpiderIPPool), poolName)
+}
+
+func (f *fakeStatusIPPoolManager) ListIPPools(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderIPPoolList, error) {
+	poolList := &spiderpoolv2beta1.SpiderIPPoolList{}
+	for _, pool := range f.pools {
+		poolList.Items = append(poolList.Items, *pool)
+	}
+
+	return poolList, nil
+}
+
+var _ = Describe("Status", Label("status_test"), func() {
+	var ctx context.Context
+	var ipPoolManager *fakeStatusIPPoolManager
+	var i *ipam
+
+	newIPPool := func(name string, ipVersion int64) *spiderpoolv2beta1.SpiderIPPool {
+		return &spiderpoolv2beta1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: spiderpoolv2beta1.IPPoolSpec{
+				IPVersion: pointer.Int64(ipVersion),
+				Disable:   pointer.Bool(false),
+			},
+			Status: spiderpoolv2beta1.IPPoolStatus{
+				TotalIPCount:     pointer.Int64(2),
+				AllocatedIPCount: pointer.Int64(1),
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		ipPoolManager = &fakeStatusIPPoolManager{
+			pools: []*spiderpoolv2beta1.SpiderIPPool{
+				newIPPool("v4-pool", constant.IPv4),
+				newIPPool("v6-pool", constant.IPv6),
+			},
+		}
+		i = &ipam{
+			config:        setDefaultsForIPAMConfig(IPAMConfig{EnableIPv4: true, EnableIPv6: true}),
+			ipPoolManager: ipPoolManager,
+		}
+	})
+
+	It("checks the cluster default IPPools", func() {
+		Expect(i.Status(ctx, &models.IpamStatusArgs{})).To(Succeed())
+	})
+
+	It("checks the default IPPools of the CNI network configuration", func() {
+		Expect(i.Status(ctx, &models.IpamStatusArgs{
+			DefaultIPV4IPPool: []string{"missing-pool", "v4-pool"},
+		})).To(Succeed())
+	})
+
+	It("skips the check without any default IPPools", func() {
+		ipPoolManager.pools = nil
+		Expect(i.Status(ctx, &models.IpamStatusArgs{})).To(Succeed())
+	})
+
+	It("fails if the default IPPools are missing", func() {
+		err := i.Status(ctx, &models.IpamStatusArgs{
+			DefaultIPV4IPPool: []string{"missing-pool"},
+		})
+		Expect(err).To(MatchError(constant.ErrNoAvailablePool))
+	})
+
+	It("fails if the default IPPools of an IP version are unusable", func() {
+		ipPoolManager.pools[1].Spec.Disable = pointer.Bool(true)
+
+		err := i.Status(ctx, &models.IpamStatusArgs{})
+		Expect(err).To(MatchError(constant.ErrNoAvailablePool))
+	})
+
+	Describe("checkPoolsUsable", func() {
+		It("finds the usable IPPool", func() {
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"v4-pool"})).To(Succeed())
+		})
+
+		It("fails if the IPPool is terminating", func() {
+			ipPoolManager.pools[0].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"v4-pool"})).To(MatchError(constant.ErrNoAvailablePool))
+		})
+
+		It("fails if the IPPool is disabled", func() {
+			ipPoolManager.pools[0].Spec.Disable = pointer.Bool(true)
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"v4-pool"})).To(MatchError(constant.ErrNoAvailablePool))
+		})
+
+		It("fails if the IPPool is of the other IP version", func() {
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"v6-pool"})).To(MatchError(constant.ErrNoAvailablePool))
+		})
+
+		It("fails if the IPPool has no free IP addresses", func() {
+			ipPoolManager.pools[0].Status.AllocatedIPCount = pointer.Int64(2)
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"v4-pool"})).To(MatchError(constant.ErrNoAvailablePool))
+		})
+
+		It("fails if the IPPool does not exist", func() {
+			Expect(i.checkPoolsUsable(ctx, constant.IPv4, []string{"missing-pool"})).To(MatchError(constant.ErrNoAvailablePool))
+		})
+	})
+})
@@ -5,6 +5,7 @@ package ipam
 
 import (
 	"fmt"
+	"net"
 
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	"github.com/spidernet-io/spiderpool/pkg/types"
@@ -45,6 +46,18 @@ func (t *ToBeAllocated) Pools() []string {
 	return pools
 }
 
+// candidateOf returns the IPPool candidate of the IP version, or nil if
+// there is none.
+func (t *ToBeAllocated) candidateOf(version types.IPVersion) *PoolCandidate {
+	for _, c := range t.PoolCandidates {
+		if c.IPVersion == version {
+			return c
+		}
+	}
+
+	return nil
+}
+
 func (t *ToBeAllocated) String() string {
 	return fmt.Sprintf("%+v", *t)
 }
@@ -53,6 +66,9 @@ type PoolCandidate struct {
 	IPVersion types.IPVersion
 	Pools     []string
 	PToIPPool PoolNameToIPPool
+	// IP is the IP address specified by the Pod annotation
+	// ipam.spidernet.io/ips, which is assigned instead of selected.
+	IP net.IP
 }
 
 func (c *PoolCandidate) String() string {
@@ -10,10 +10,8 @@ import (
 	"net"
 	"strconv"
 
-	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/utils/strings/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
@@ -23,6 +21,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 func getCustomRoutes(pod *corev1.Pod) ([]*models.Route, error) {
@@ -78,40 +77,47 @@ func groupCustomRoutes(ctx context.Context, customRoutes []*models.Route, result
 	return nil
 }
 
+// getEndpointName returns the name of the Endpoint of the given pod, the pods with
+// stable identity share the Endpoint across their re-creation, such as the pods of kubevirt VM.
+func (i *ipam) getEndpointName(pod *corev1.Pod) string {
+	ownerReference := metav1.GetControllerOf(pod)
+	if ownerReference == nil {
+		return pod.Name
+	}
+
+	endpointName, ok := i.workloadKinds.StableEndpointName(pod.Name, types.AppNamespacedName{
+		APIVersion: ownerReference.APIVersion,
+		Kind:       ownerReference.Kind,
+		Namespace:  pod.Namespace,
+		Name:       ownerReference.Name,
+	})
+	if !ok {
+		return pod.Name
+	}
+
+	return endpointName
+}
+
+// isThirdController reports whether the auto-created IPPools of the application are created by IPAM, because the
+// SpiderSubnet application controller does not watch it. The custom workloads declared in the configmap are watched.
+func isThirdController(workloadKinds workloadkind.Registry, app types.AppNamespacedName) bool {
+	return subnetmanagercontrollers.IsThirdController(app) && workloadKinds.LookupCustom(app.APIVersion, app.Kind) == nil
+}
+
 // getAutoPoolIPNumber calculates the auto-created IPPool IP number with the given params pod and pod top controller.
 // If it's an orphan pod, it will return 1.
-func getAutoPoolIPNumber(pod *corev1.Pod, podController types.PodTopController) (int, error) {
+func getAutoPoolIPNumber(workloadKinds workloadkind.Registry, pod *corev1.Pod, podController types.PodTopController) (int, error) {
+	// orphan pod
+	if podController.APIVersion == corev1.SchemeGroupVersion.String() && podController.Kind == constant.KindPod {
+		return 1, nil
+	}
+
 	var appReplicas int
-	var isThirdPartyController bool
-
-	if slices.Contains(constant.K8sAPIVersions, podController.APIVersion) {
-		switch podController.Kind {
-		// orphan pod
-		case constant.KindPod:
-			return 1, nil
-		case constant.KindDeployment:
-			deployment := podController.APP.(*appsv1.Deployment)
-			appReplicas = subnetmanagercontrollers.GetAppReplicas(deployment.Spec.Replicas)
-		case constant.KindReplicaSet:
-			replicaSet := podController.APP.(*appsv1.ReplicaSet)
-			appReplicas = subnetmanagercontrollers.GetAppReplicas(replicaSet.Spec.Replicas)
-		case constant.KindStatefulSet:
-			statefulSet := podController.APP.(*appsv1.StatefulSet)
-			appReplicas = subnetmanagercontrollers.GetAppReplicas(statefulSet.Spec.Replicas)
-		case constant.KindDaemonSet:
-			daemonSet := podController.APP.(*appsv1.DaemonSet)
-			appReplicas = int(daemonSet.Status.DesiredNumberScheduled)
-		case constant.KindJob:
-			job := podController.APP.(*batchv1.Job)
-			appReplicas = subnetmanagercontrollers.CalculateJobPodNum(job.Spec.Parallelism, job.Spec.Completions)
-		case constant.KindCronJob:
-			cronJob := podController.APP.(*batchv1.CronJob)
-			appReplicas = subnetmanagercontrollers.CalculateJobPodNum(cronJob.Spec.JobTemplate.Spec.Parallelism, cronJob.Spec.JobTemplate.Spec.Completions)
-		default:
-			isThirdPartyController = true
-		}
-	} else {
-		isThirdPartyController = true
+	isThirdPartyController := true
+	if kind := workloadKinds.Lookup(podController.APIVersion, podController.Kind); kind != nil {
+		var ok bool
+		appReplicas, ok = kind.DesiredReplicas(podController.APP)
+		isThirdPartyController = !ok
 	}
 
 	var flexibleIPNum int
@@ -143,20 +149,32 @@ func getAutoPoolIPNumber(pod *corev1.Pod, podController types.PodTopController)
 		flexibleIPNum = 0
 	}
 
+	// size the auto-created IPPool by the sizing policy, the max replicas of HorizontalPodAutoscaler is
+	// unknown here, so the IPPool sized by spiderpool-controller is only checked with the lower bound.
+	sizingPolicy, err := subnetmanagercontrollers.GetAutoPoolSizingPolicy(pod.Annotations)
+	if nil != err {
+		return -1, err
+	}
+	appReplicas = subnetmanagercontrollers.GetAutoPoolReplicas(sizingPolicy, podController.APP, appReplicas, 0)
+
 	// collect application replicas and custom flexible IP number
 	poolIPNum := appReplicas + flexibleIPNum
 
 	return poolIPNum, nil
 }
 
-// isPoolIPsDesired checks the auto-created IPPool's IPs whether matches its AutoDesiredIPCount
-func isPoolIPsDesired(pool *spiderpoolv2beta1.SpiderIPPool, desiredIPCount int) bool {
-	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+// isPoolIPsDesired checks the auto-created IPPool's IPs whether matches its AutoDesiredIPCount,
+// or is not less than it if the desiredIPCount is only a lower bound.
+func isPoolIPsDesired(pool *spiderpoolv2beta1.SpiderIPPool, desiredIPCount int, isLowerBound bool) bool {
+	totalIPs, err := spiderpoolip.NewIPBitmap(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
 	if nil != err {
 		return false
 	}
 
-	if len(totalIPs) == desiredIPCount {
+	if totalIPs.Size() == uint64(desiredIPCount) {
+		return true
+	}
+	if isLowerBound && totalIPs.Size() > uint64(desiredIPCount) {
 		return true
 	}
 
@@ -0,0 +1,192 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+// ipSelector picks one IP address from the available IP addresses of the
+// IPPool, which are the ones not set in the IPBitmap and never empty.
+type ipSelector func(ipPool *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error)
+
+// ipSelectors are the implementations of the IP allocation strategies.
+var ipSelectors = map[string]ipSelector{
+	constant.AllocationStrategyLowest:                selectLowestIP,
+	constant.AllocationStrategyRandom:                selectRandomIP,
+	constant.AllocationStrategyRoundRobin:            selectRoundRobinIP,
+	constant.AllocationStrategyLeastRecentlyReleased: selectLeastRecentlyReleasedIP,
+}
+
+// AllocationStrategy returns the IP allocation strategy of the IPPool,
+// which defaults to 'lowest'.
+func AllocationStrategy(ipPool *spiderpoolv2beta1.SpiderIPPool) string {
+	if ipPool.Spec.AllocationStrategy == nil || *ipPool.Spec.AllocationStrategy == "" {
+		return constant.AllocationStrategyLowest
+	}
+
+	return *ipPool.Spec.AllocationStrategy
+}
+
+// ValidateAllocationStrategy checks whether the IP allocation strategy is
+// supported.
+func ValidateAllocationStrategy(fieldPath *field.Path, strategy *string) *field.Error {
+	if strategy == nil || *strategy == "" {
+		return nil
+	}
+
+	if _, ok := ipSelectors[*strategy]; !ok {
+		return field.NotSupported(
+			fieldPath,
+			*strategy,
+			[]string{
+				constant.AllocationStrategyLowest,
+				constant.AllocationStrategyRandom,
+				constant.AllocationStrategyRoundRobin,
+				constant.AllocationStrategyLeastRecentlyReleased,
+			},
+		)
+	}
+
+	return nil
+}
+
+func selectIP(ipPool *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error) {
+	strategy := AllocationStrategy(ipPool)
+	selector, ok := ipSelectors[strategy]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown allocation strategy '%s' of IPPool %s", constant.ErrWrongInput, strategy, ipPool.Name)
+	}
+
+	return selector(ipPool, availableIPs)
+}
+
+func selectLowestIP(_ *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error) {
+	return availableIPs.FirstClear(), nil
+}
+
+// selectRandomIP picks the first available IP address from a random
+// position of the IPPool.
+func selectRandomIP(_ *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error) {
+	// #nosec G404 -- the IP address to allocate needs no cryptographic randomness.
+	start := availableIPs.IPAt(uint64(rand.Int63n(int64(availableIPs.Size()))))
+	if ip := availableIPs.NextClear(start); ip != nil {
+		return ip, nil
+	}
+
+	return availableIPs.FirstClear(), nil
+}
+
+// selectRoundRobinIP picks the next available IP address after the one
+// allocated lastly, and starts over from the lowest one when reaching the
+// end.
+func selectRoundRobinIP(ipPool *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error) {
+	if ipPool.Status.LastAllocatedIP == nil {
+		return availableIPs.FirstClear(), nil
+	}
+
+	lastIP := net.ParseIP(*ipPool.Status.LastAllocatedIP)
+	if lastIP == nil {
+		return availableIPs.FirstClear(), nil
+	}
+
+	if ip := availableIPs.NextClear(spiderpoolip.NextIP(lastIP)); ip != nil {
+		return ip, nil
+	}
+
+	return availableIPs.FirstClear(), nil
+}
+
+// selectLeastRecentlyReleasedIP picks the lowest available IP address that
+// was never released, or the one released earliest.
+func selectLeastRecentlyReleasedIP(ipPool *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) (net.IP, error) {
+	releasedRecords, err := convert.UnmarshalIPPoolReleasedIPs(ipPool.Status.ReleasedIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the available IP addresses ahead of the first one never released
+	// need to be visited
+	var resIP net.IP
+	var resRecord spiderpoolv2beta1.PoolIPRelease
+	availableIPs.RangeClear(func(ip net.IP) bool {
+		record, ok := releasedRecords[ip.String()]
+		if !ok {
+			resIP = ip
+			return false
+		}
+
+		if resIP == nil || record.ReleaseTime.Before(&resRecord.ReleaseTime) {
+			resIP = ip
+			resRecord = record
+		}
+		return true
+	})
+
+	return resIP, nil
+}
+
+// recordAllocatedIP updates the status of the IPPool used by the allocation
+// strategy after the IP address is allocated.
+func recordAllocatedIP(ipPool *spiderpoolv2beta1.SpiderIPPool, ip net.IP) error {
+	switch AllocationStrategy(ipPool) {
+	case constant.AllocationStrategyRoundRobin:
+		lastIP := ip.String()
+		ipPool.Status.LastAllocatedIP = &lastIP
+	case constant.AllocationStrategyLeastRecentlyReleased:
+		releasedRecords, err := convert.UnmarshalIPPoolReleasedIPs(ipPool.Status.ReleasedIPs)
+		if err != nil {
+			return err
+		}
+		if _, ok := releasedRecords[ip.String()]; !ok {
+			return nil
+		}
+
+		delete(releasedRecords, ip.String())
+		data, err := convert.MarshalIPPoolReleasedIPs(releasedRecords)
+		if err != nil {
+			return err
+		}
+		ipPool.Status.ReleasedIPs = data
+	}
+
+	return nil
+}
+
+// recordReleasedIPs updates the status of the IPPool used by the allocation
+// strategy after the IP addresses are released.
+func recordReleasedIPs(ipPool *spiderpoolv2beta1.SpiderIPPool, ips []string, releaseTime metav1.Time) error {
+	if AllocationStrategy(ipPool) != constant.AllocationStrategyLeastRecentlyReleased {
+		return nil
+	}
+
+	releasedRecords, err := convert.UnmarshalIPPoolReleasedIPs(ipPool.Status.ReleasedIPs)
+	if err != nil {
+		return err
+	}
+	if releasedRecords == nil {
+		releasedRecords = spiderpoolv2beta1.PoolIPReleases{}
+	}
+	for _, ip := range ips {
+		releasedRecords[ip] = spiderpoolv2beta1.PoolIPRelease{ReleaseTime: releaseTime}
+	}
+
+	data, err := convert.MarshalIPPoolReleasedIPs(releasedRecords)
+	if err != nil {
+		return err
+	}
+	ipPool.Status.ReleasedIPs = data
+
+	return nil
+}
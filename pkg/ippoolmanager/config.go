@@ -4,12 +4,30 @@
 package ippoolmanager
 
 const (
+	// defaultMaxAllocatedIPs keeps the status.allocatedIPs of an IPPool, which
+	// records all allocations in one string, well below the object size
+	// limit of etcd.
 	defaultMaxAllocatedIPs = 5000
 )
 
 type IPPoolManagerConfig struct {
 	MaxAllocatedIPs        *int
 	EnableKubevirtStaticIP bool
+
+	// EnableCompactAllocatedIPs records the status.allocatedIPs of IPPools
+	// in the compact format, which takes about a third of the size of JSON.
+	// The records in both formats are always parsed, so it should only be
+	// enabled once all spiderpool-agents and spiderpool-controllers are
+	// upgraded, the IPPools are converted on their next allocation or release.
+	EnableCompactAllocatedIPs bool
+
+	// IPBlockSize is the number of IP addresses leased to the node at a
+	// time, the node-local IP block is disabled if it is not positive.
+	IPBlockSize int
+
+	// NodeName is the node where spiderpool-agent runs, the IP blocks leased
+	// to it before the restart of spiderpool-agent are found with it.
+	NodeName string
 }
 
 func setDefaultsForIPPoolManagerConfig(config IPPoolManagerConfig) IPPoolManagerConfig {
@@ -0,0 +1,32 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// ValidateDNS checks whether the nameservers of the DNS configuration are
+// valid IP addresses.
+func ValidateDNS(fieldPath *field.Path, dns *spiderpoolv2beta1.DNS) *field.Error {
+	if dns == nil {
+		return nil
+	}
+
+	for i, ns := range dns.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return field.Invalid(
+				fieldPath.Child("nameservers").Index(i),
+				ns,
+				"must be a valid IP address",
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,785 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/utils/retry"
+)
+
+// nodeIPBlock is the state of the IP block of an IPPool leased to the node
+// where the spiderpool-agent runs. The IP allocations from the IP block are
+// kept in memory and written to the IPPool in batches afterwards.
+type nodeIPBlock struct {
+	// lock serializes the allocations and the writes of the IP block, so
+	// that an allocation never misses the IP addresses being written.
+	lock lock.Mutex
+
+	// recovered is whether the IP allocations not written before the
+	// restart of spiderpool-agent are recovered from the Endpoints.
+	recovered bool
+
+	// pending are the IP allocations not written to the IPPool yet.
+	pending spiderpoolv2beta1.PoolIPAllocations
+
+	// nodeName is the node which the IP block is leased to.
+	nodeName string
+
+	// lastAllocatedIP is the IP address allocated from the IP block lastly,
+	// which is written to the IPPool for the round-robin strategy.
+	lastAllocatedIP net.IP
+}
+
+func (im *ipPoolManager) getNodeIPBlock(poolName string) *nodeIPBlock {
+	im.ipBlocksLock.Lock()
+	defer im.ipBlocksLock.Unlock()
+
+	if im.ipBlocks == nil {
+		im.ipBlocks = map[string]*nodeIPBlock{}
+	}
+	block, ok := im.ipBlocks[poolName]
+	if !ok {
+		block = &nodeIPBlock{pending: spiderpoolv2beta1.PoolIPAllocations{}}
+		im.ipBlocks[poolName] = block
+	}
+
+	return block
+}
+
+// AllocateIPFromBlock allocates an IP address to the Pod from the IP block
+// of the IPPool leased to the node of the Pod, a new IP block is leased
+// if the IP block is used out. The IP allocation is only recorded in memory
+// and written to the IPPool by FlushIPBlockAllocations afterwards. It fails
+// with constant.ErrIPUsedOut if no IP block could be leased, the other nodes
+// are requested to return their free IP addresses then.
+func (im *ipPoolManager) AllocateIPFromBlock(ctx context.Context, poolName, nic string, pod *corev1.Pod) (*models.IPConfig, error) {
+	logger := logutils.FromContext(ctx)
+
+	if im.config.IPBlockSize <= 0 {
+		return nil, fmt.Errorf("%w: IP block is disabled", constant.ErrWrongInput)
+	}
+	nodeName := pod.Spec.NodeName
+	if nodeName == "" {
+		return nil, fmt.Errorf("%w: Pod %s/%s is not scheduled", constant.ErrWrongInput, pod.Namespace, pod.Name)
+	}
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	block := im.getNodeIPBlock(poolName)
+	block.lock.Lock()
+	defer block.lock.Unlock()
+	block.nodeName = nodeName
+
+	ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+	if err != nil {
+		return nil, err
+	}
+
+	if !block.recovered {
+		if err := im.recoverIPBlockAllocations(ctx, ipPool, nodeName, block); err != nil {
+			return nil, err
+		}
+		block.recovered = true
+	}
+
+	allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+	if err != nil {
+		return nil, err
+	}
+	var allocatedIPCount int64
+	if ipPool.Status.AllocatedIPCount != nil {
+		allocatedIPCount = *ipPool.Status.AllocatedIPCount
+	}
+	if allocatedIPCount+int64(len(block.pending)) >= int64(*im.config.MaxAllocatedIPs) {
+		return nil, fmt.Errorf("%w, threshold of IP records(<=%d) for IPPool %s exceeded", constant.ErrIPUsedOut, *im.config.MaxAllocatedIPs, ipPool.Name)
+	}
+
+	ip, err := im.selectIPFromBlock(ctx, ipPool, pod, allocatedRecords, block)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip == nil {
+		logger.Sugar().Infof("IP block of IPPool %s is used out, lease a new one for node %s", poolName, nodeName)
+		ipPool, err = im.leaseIPBlock(ctx, poolName, nodeName)
+		if err != nil {
+			return nil, err
+		}
+
+		allocatedRecords, err = convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+		if err != nil {
+			return nil, err
+		}
+		ip, err = im.selectIPFromBlock(ctx, ipPool, pod, allocatedRecords, block)
+		if err != nil {
+			return nil, err
+		}
+		if ip == nil {
+			return nil, constant.ErrIPUsedOut
+		}
+	}
+
+	block.pending[ip.String()] = spiderpoolv2beta1.PoolIPAllocation{
+		NamespacedName: key,
+		PodUID:         string(pod.UID),
+	}
+	block.lastAllocatedIP = ip
+	logger.Sugar().Debugf("Allocate IP %s from the IP block of IPPool %s, which is pending to be written", ip, poolName)
+
+	return convert.GenIPConfigResult(ip, nic, ipPool), nil
+}
+
+// selectIPFromBlock picks an available IP address in the IP block of the
+// Pod's node with the allocation strategy of the IPPool, or returns nil if
+// there is none. Like genIP, the reserved and cooling IP addresses are not
+// available.
+func (im *ipPoolManager) selectIPFromBlock(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, pod *corev1.Pod, allocatedRecords spiderpoolv2beta1.PoolIPAllocations, block *nodeIPBlock) (net.IP, error) {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return nil, err
+	}
+	nodeBlock, ok := blocks[pod.Spec.NodeName]
+	if !ok || len(nodeBlock.IPs) == 0 {
+		return nil, nil
+	}
+
+	availableIPs, err := im.assembleAvailableIPs(ctx, ipPool, pod.Namespace, allocatedRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	// the IP block gets an IPBitmap of its own, where the IP addresses not
+	// available in the IPPool and the pending ones are set
+	blockIPs, err := spiderpoolip.NewIPBitmap(*ipPool.Spec.IPVersion, nodeBlock.IPs, nil)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, nodeBlock.IPs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if !availableIPs.Contains(ip) || availableIPs.IsSet(ip) {
+			blockIPs.Set(ip)
+		}
+	}
+	for ip := range block.pending {
+		blockIPs.Set(net.ParseIP(ip))
+	}
+	if blockIPs.Free() == 0 {
+		return nil, nil
+	}
+
+	// the allocations from the IP block are not written to the IPPool yet,
+	// so the round-robin strategy goes on from the one kept in memory
+	selectPool := *ipPool
+	if block.lastAllocatedIP != nil {
+		lastIP := block.lastAllocatedIP.String()
+		selectPool.Status.LastAllocatedIP = &lastIP
+	}
+
+	return selectIP(&selectPool, blockIPs)
+}
+
+// leaseIPBlock leases the free IP addresses of the IPPool to the node, which
+// are not allocated or leased to any node.
+func (im *ipPoolManager) leaseIPBlock(ctx context.Context, poolName, nodeName string) (*spiderpoolv2beta1.SpiderIPPool, error) {
+	logger := logutils.FromContext(ctx)
+
+	backoff := retry.DefaultRetry
+	steps := backoff.Steps
+	var ipPool *spiderpoolv2beta1.SpiderIPPool
+	var usedOut bool
+	err := retry.RetryOnConflictWithContext(ctx, backoff, func(ctx context.Context) error {
+		logger := logger.With(
+			zap.String("IPPoolName", poolName),
+			zap.Int("Times", steps-backoff.Steps+1),
+		)
+		logger.Debug("Re-get IPPool for IP block lease")
+		var err error
+		ipPool, err = im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			return err
+		}
+
+		allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+		if err != nil {
+			return err
+		}
+		// the IP addresses reserved for some namespaces may be leased, they
+		// are skipped once allocated from the IP block
+		availableIPs, err := im.assembleAvailableIPs(ctx, ipPool, "", allocatedRecords)
+		if err != nil {
+			return err
+		}
+		if err := markIPBlocks(ipPool, availableIPs); err != nil {
+			return err
+		}
+
+		var leasedIPs []net.IP
+		for len(leasedIPs) < im.config.IPBlockSize {
+			ip := availableIPs.FirstClear()
+			if ip == nil {
+				break
+			}
+			availableIPs.Set(ip)
+			leasedIPs = append(leasedIPs, ip)
+		}
+		usedOut = len(leasedIPs) == 0
+		if usedOut {
+			// the free IP addresses may be idle in the IP blocks of the other
+			// nodes, which only their spiderpool-agents could give back
+			if err := requestIPBlockReturn(ipPool, nodeName, metav1.Now()); err != nil {
+				return err
+			}
+		} else if err := addIPBlock(ipPool, nodeName, leasedIPs, metav1.Now()); err != nil {
+			return err
+		}
+
+		resourceVersion := ipPool.ResourceVersion
+		logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).
+			Sugar().Debugf("Try to lease IP block %v of IPPool to node %s", leasedIPs, nodeName)
+		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+			if apierrors.IsConflict(err) {
+				metric.IpamAllocationUpdateIPPoolConflictCounts.Add(ctx, 1)
+				logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).Warn("An conflict occurred when leasing IP block of IPPool")
+			}
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			err = fmt.Errorf("%w (%d times), failed to lease IP block of IPPool %s", constant.ErrRetriesExhausted, steps, poolName)
+		}
+		return nil, err
+	}
+	if usedOut {
+		logger.Sugar().Infof("No free IP of IPPool %s could be leased to node %s, request the other nodes to return their IP blocks", poolName, nodeName)
+		return nil, constant.ErrIPUsedOut
+	}
+
+	return ipPool, nil
+}
+
+// recoverIPBlockAllocations recovers the IP allocations from the IP block
+// of the node which were lost before written to the IPPool, the Endpoints
+// of the Pods still record them.
+func (im *ipPoolManager) recoverIPBlockAllocations(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, nodeName string, block *nodeIPBlock) error {
+	logger := logutils.FromContext(ctx)
+
+	blockIPs, err := getIPBlock(ipPool, nodeName)
+	if err != nil {
+		return err
+	}
+	if len(blockIPs) == 0 {
+		return nil
+	}
+	inBlock := map[string]bool{}
+	for _, ip := range blockIPs {
+		inBlock[ip.String()] = true
+	}
+
+	allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+	if err != nil {
+		return err
+	}
+
+	var endpointList spiderpoolv2beta1.SpiderEndpointList
+	if err := im.client.List(ctx, &endpointList); err != nil {
+		return err
+	}
+	for _, endpoint := range endpointList.Items {
+		if endpoint.Status.Current.Node != nodeName {
+			continue
+		}
+
+		for _, detail := range endpoint.Status.Current.IPs {
+			for _, d := range []struct{ ip, pool *string }{{detail.IPv4, detail.IPv4Pool}, {detail.IPv6, detail.IPv6Pool}} {
+				if d.ip == nil || d.pool == nil || *d.pool != ipPool.Name {
+					continue
+				}
+				ip, _, err := net.ParseCIDR(*d.ip)
+				if err != nil || !inBlock[ip.String()] {
+					continue
+				}
+				if _, ok := allocatedRecords[ip.String()]; ok {
+					continue
+				}
+
+				logger.Sugar().Infof("Recover IP allocation %s of Endpoint %s/%s from the IP block of IPPool %s", ip, endpoint.Namespace, endpoint.Name, ipPool.Name)
+				block.pending[ip.String()] = spiderpoolv2beta1.PoolIPAllocation{
+					NamespacedName: endpoint.Namespace + "/" + endpoint.Name,
+					PodUID:         endpoint.Status.Current.UID,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FlushIPBlockAllocations writes the pending IP allocations from the IP
+// blocks to the IPPools, and returns the free IP addresses of the IP blocks
+// once another node requests.
+func (im *ipPoolManager) FlushIPBlockAllocations(ctx context.Context) error {
+	if err := im.loadIPBlocks(ctx); err != nil {
+		return err
+	}
+
+	im.ipBlocksLock.Lock()
+	poolNames := make([]string, 0, len(im.ipBlocks))
+	for poolName := range im.ipBlocks {
+		poolNames = append(poolNames, poolName)
+	}
+	im.ipBlocksLock.Unlock()
+
+	var errs []error
+	for _, poolName := range poolNames {
+		if err := im.flushIPBlockAllocations(ctx, poolName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("failed to flush IP block allocations: %v", errs)
+	}
+
+	return nil
+}
+
+// GetPendingIPBlockAllocation returns the allocation of the IP address from
+// the IP block of the IPPool which is not written to the IPPool yet.
+func (im *ipPoolManager) GetPendingIPBlockAllocation(poolName, ip string) (spiderpoolv2beta1.PoolIPAllocation, bool) {
+	im.ipBlocksLock.Lock()
+	block, ok := im.ipBlocks[poolName]
+	im.ipBlocksLock.Unlock()
+	if !ok {
+		return spiderpoolv2beta1.PoolIPAllocation{}, false
+	}
+
+	block.lock.Lock()
+	defer block.lock.Unlock()
+	record, ok := block.pending[ip]
+
+	return record, ok
+}
+
+func (im *ipPoolManager) flushIPBlockAllocations(ctx context.Context, poolName string) error {
+	logger := logutils.FromContext(ctx)
+
+	block := im.getNodeIPBlock(poolName)
+	block.lock.Lock()
+	defer block.lock.Unlock()
+
+	nodeName := block.nodeName
+	if nodeName == "" {
+		nodeName = im.config.NodeName
+	}
+	returnIPs, err := im.shouldReturnIPBlock(ctx, poolName, nodeName)
+	if err != nil {
+		return err
+	}
+	if len(block.pending) == 0 && !returnIPs {
+		return nil
+	}
+
+	backoff := retry.DefaultRetry
+	steps := backoff.Steps
+	err = retry.RetryOnConflictWithContext(ctx, backoff, func(ctx context.Context) error {
+		logger := logger.With(
+			zap.String("IPPoolName", poolName),
+			zap.Int("Times", steps-backoff.Steps+1),
+		)
+		logger.Debug("Re-get IPPool for writing IP block allocations")
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			return err
+		}
+
+		allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+		if err != nil {
+			return err
+		}
+		if allocatedRecords == nil {
+			allocatedRecords = spiderpoolv2beta1.PoolIPAllocations{}
+		}
+		if ipPool.Status.AllocatedIPCount == nil {
+			ipPool.Status.AllocatedIPCount = new(int64)
+		}
+
+		for ip, pending := range block.pending {
+			if record, ok := allocatedRecords[ip]; ok {
+				if record.PodUID != pending.PodUID {
+					logger.Sugar().Errorf("IP %s of the IP block is already taken by Pod %s (UID %s), discard the allocation of Pod %s (UID %s)",
+						ip, record.NamespacedName, record.PodUID, pending.NamespacedName, pending.PodUID)
+				}
+				continue
+			}
+			allocatedRecords[ip] = pending
+			*ipPool.Status.AllocatedIPCount++
+			if err := recordAllocatedIP(ipPool, net.ParseIP(ip)); err != nil {
+				return err
+			}
+		}
+		if len(block.pending) != 0 && block.lastAllocatedIP != nil && AllocationStrategy(ipPool) == constant.AllocationStrategyRoundRobin {
+			lastIP := block.lastAllocatedIP.String()
+			ipPool.Status.LastAllocatedIP = &lastIP
+		}
+
+		data, err := im.marshalAllocatedIPs(allocatedRecords)
+		if err != nil {
+			return err
+		}
+		ipPool.Status.AllocatedIPs = data
+
+		if returnIPs {
+			returned, err := returnFreeIPBlock(ipPool, nodeName, allocatedRecords)
+			if err != nil {
+				return err
+			}
+			if len(returned) == 0 && len(block.pending) == 0 {
+				return nil
+			}
+			logger.Sugar().Infof("Return free IPs %v of the IP block of node %s to IPPool", returned, nodeName)
+		}
+
+		resourceVersion := ipPool.ResourceVersion
+		logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).
+			Sugar().Debugf("Try to write %d IP block allocations to IPPool", len(block.pending))
+		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+			if apierrors.IsConflict(err) {
+				metric.IpamAllocationUpdateIPPoolConflictCounts.Add(ctx, 1)
+				logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).Warn("An conflict occurred when writing IP block allocations to IPPool")
+			}
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Sugar().Warnf("IPPool %s no longer exists, discard its IP block allocations", poolName)
+			block.pending = spiderpoolv2beta1.PoolIPAllocations{}
+			return nil
+		}
+		if wait.Interrupted(err) {
+			err = fmt.Errorf("%w (%d times), failed to write IP block allocations to IPPool %s", constant.ErrRetriesExhausted, steps, poolName)
+		}
+		return err
+	}
+
+	block.pending = spiderpoolv2beta1.PoolIPAllocations{}
+
+	return nil
+}
+
+// loadIPBlocks finds the IP blocks leased to the node before the restart
+// of spiderpool-agent once, so that they could be returned even though no
+// IP address is allocated from them since then.
+func (im *ipPoolManager) loadIPBlocks(ctx context.Context) error {
+	if im.config.NodeName == "" || im.ipBlocksLoaded {
+		return nil
+	}
+
+	ipPoolList, err := im.ListIPPools(ctx, constant.UseCache)
+	if err != nil {
+		return err
+	}
+	for _, ipPool := range ipPoolList.Items {
+		blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+		if err != nil {
+			return err
+		}
+		if _, ok := blocks[im.config.NodeName]; ok {
+			im.getNodeIPBlock(ipPool.Name)
+		}
+	}
+	im.ipBlocksLoaded = true
+
+	return nil
+}
+
+// shouldReturnIPBlock checks whether another node requests to return the IP
+// blocks after the one of the node is leased, and whether the IP block of
+// the node has any free IP address. The IPPool is got from the cache, so the
+// check costs no API request in the usual case.
+func (im *ipPoolManager) shouldReturnIPBlock(ctx context.Context, poolName, nodeName string) (bool, error) {
+	if nodeName == "" {
+		return false, nil
+	}
+
+	ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.UseCache)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return false, err
+	}
+	nodeBlock, ok := blocks[nodeName]
+	if !ok || len(nodeBlock.IPs) == 0 {
+		return false, nil
+	}
+
+	requested := false
+	for name, b := range blocks {
+		if name != nodeName && b.ReturnRequestTime != nil && nodeBlock.LeaseTime.Before(b.ReturnRequestTime) {
+			requested = true
+			break
+		}
+	}
+	if !requested {
+		return false, nil
+	}
+
+	allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+	if err != nil {
+		return false, err
+	}
+	blockIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, nodeBlock.IPs)
+	if err != nil {
+		return false, err
+	}
+	for _, ip := range blockIPs {
+		if _, ok := allocatedRecords[ip.String()]; !ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// releasePendingIPs drops the IP allocations from the IP block of the
+// IPPool which are not written yet.
+func (im *ipPoolManager) releasePendingIPs(poolName string, ipAndUIDs []types.IPAndUID) {
+	im.ipBlocksLock.Lock()
+	block, ok := im.ipBlocks[poolName]
+	im.ipBlocksLock.Unlock()
+	if !ok {
+		return
+	}
+
+	block.lock.Lock()
+	defer block.lock.Unlock()
+
+	for _, iu := range ipAndUIDs {
+		if record, ok := block.pending[iu.IP]; ok && record.PodUID == iu.UID {
+			delete(block.pending, iu.IP)
+		}
+	}
+}
+
+// getIPBlock returns the IP addresses leased to the node.
+func getIPBlock(ipPool *spiderpoolv2beta1.SpiderIPPool, nodeName string) ([]net.IP, error) {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := blocks[nodeName]
+	if !ok {
+		return nil, nil
+	}
+
+	return spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, block.IPs)
+}
+
+// getIPBlockNode returns the node which the IP address is leased to as a
+// part of its IP block, or "" if there is none.
+func getIPBlockNode(ipPool *spiderpoolv2beta1.SpiderIPPool, ip net.IP) (string, error) {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return "", err
+	}
+
+	for nodeName, block := range blocks {
+		blockIPs, err := spiderpoolip.NewIPBitmap(*ipPool.Spec.IPVersion, block.IPs, nil)
+		if err != nil {
+			return "", err
+		}
+		if blockIPs.Contains(ip) {
+			return nodeName, nil
+		}
+	}
+
+	return "", nil
+}
+
+// markIPBlocks sets the IP addresses leased to all the nodes in the
+// IPBitmap.
+func markIPBlocks(ipPool *spiderpoolv2beta1.SpiderIPPool, availableIPs *spiderpoolip.IPBitmap) error {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range blocks {
+		ips, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, block.IPs)
+		if err != nil {
+			return err
+		}
+		for _, ip := range ips {
+			availableIPs.Set(ip)
+		}
+	}
+
+	return nil
+}
+
+// addIPBlock leases the IP addresses to the node.
+func addIPBlock(ipPool *spiderpoolv2beta1.SpiderIPPool, nodeName string, ips []net.IP, leaseTime metav1.Time) error {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return err
+	}
+	if blocks == nil {
+		blocks = spiderpoolv2beta1.PoolIPBlocks{}
+	}
+
+	blockIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, blocks[nodeName].IPs)
+	if err != nil {
+		return err
+	}
+	ipRanges, err := spiderpoolip.ConvertIPsToIPRanges(*ipPool.Spec.IPVersion, append(blockIPs, ips...))
+	if err != nil {
+		return err
+	}
+	blocks[nodeName] = spiderpoolv2beta1.PoolIPBlock{
+		IPs:       ipRanges,
+		LeaseTime: leaseTime,
+	}
+
+	data, err := convert.MarshalIPPoolIPBlocks(blocks)
+	if err != nil {
+		return err
+	}
+	ipPool.Status.IPBlocks = data
+
+	return nil
+}
+
+// requestIPBlockReturn records that the node finds no free IP address to
+// lease, the IP addresses already leased to the node are kept.
+func requestIPBlockReturn(ipPool *spiderpoolv2beta1.SpiderIPPool, nodeName string, requestTime metav1.Time) error {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return err
+	}
+	if blocks == nil {
+		blocks = spiderpoolv2beta1.PoolIPBlocks{}
+	}
+
+	block := blocks[nodeName]
+	block.ReturnRequestTime = &requestTime
+	blocks[nodeName] = block
+
+	data, err := convert.MarshalIPPoolIPBlocks(blocks)
+	if err != nil {
+		return err
+	}
+	ipPool.Status.IPBlocks = data
+
+	return nil
+}
+
+// returnFreeIPBlock takes back the IP addresses of the IP block leased to
+// the node which are not allocated, it returns the IP addresses taken back.
+func returnFreeIPBlock(ipPool *spiderpoolv2beta1.SpiderIPPool, nodeName string, allocatedRecords spiderpoolv2beta1.PoolIPAllocations) ([]net.IP, error) {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return nil, err
+	}
+	block, ok := blocks[nodeName]
+	if !ok {
+		return nil, nil
+	}
+
+	blockIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, block.IPs)
+	if err != nil {
+		return nil, err
+	}
+	var keptIPs, returnedIPs []net.IP
+	for _, ip := range blockIPs {
+		if _, ok := allocatedRecords[ip.String()]; ok {
+			keptIPs = append(keptIPs, ip)
+		} else {
+			returnedIPs = append(returnedIPs, ip)
+		}
+	}
+	if len(returnedIPs) == 0 {
+		return nil, nil
+	}
+
+	if len(keptIPs) == 0 && block.ReturnRequestTime == nil {
+		delete(blocks, nodeName)
+	} else {
+		block.IPs, err = spiderpoolip.ConvertIPsToIPRanges(*ipPool.Spec.IPVersion, keptIPs)
+		if err != nil {
+			return nil, err
+		}
+		blocks[nodeName] = block
+	}
+
+	data, err := convert.MarshalIPPoolIPBlocks(blocks)
+	if err != nil {
+		return nil, err
+	}
+	ipPool.Status.IPBlocks = data
+
+	return returnedIPs, nil
+}
+
+// RemoveIPBlocks takes back the IP blocks leased to the nodes, it returns
+// whether the IPPool is changed.
+func RemoveIPBlocks(ipPool *spiderpoolv2beta1.SpiderIPPool, nodeNames ...string) (bool, error) {
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, nodeName := range nodeNames {
+		if _, ok := blocks[nodeName]; ok {
+			delete(blocks, nodeName)
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	data, err := convert.MarshalIPPoolIPBlocks(blocks)
+	if err != nil {
+		return false, err
+	}
+	ipPool.Status.IPBlocks = data
+
+	return true, nil
+}
@@ -7,18 +7,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"reflect"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	otelapi "go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/pointer"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -31,9 +39,12 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
 	informers "github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions/spiderpool.spidernet.io/v2beta1"
 	listers "github.com/spidernet-io/spiderpool/pkg/k8s/client/listers/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
 )
 
 var informerLogger *zap.Logger
@@ -42,9 +53,38 @@ type IPPoolController struct {
 	IPPoolControllerConfig
 	client        client.Client
 	dynamicClient dynamic.Interface
+	informers     ctrlcache.Informers
 	poolLister    listers.SpiderIPPoolLister
 	poolSynced    cache.InformerSynced
 	poolWorkqueue workqueue.RateLimitingInterface
+
+	rIPInformer     ctrlcache.Informer
+	rIPRegistration cache.ResourceEventHandlerRegistration
+
+	// readyChecks caches the checks of the Ready condition by IPPool name.
+	// readyChecksEpoch is increased once some of them are dropped, the epoch
+	// is recorded in readyChecksDropped by IPPool name, or in readyChecksReset
+	// if all of them are dropped, so that the checks of an IPPool done
+	// meanwhile are not cached.
+	readyChecksLock    lock.Mutex
+	readyChecks        map[string]readyCheck
+	readyChecksEpoch   uint64
+	readyChecksDropped map[string]uint64
+	readyChecksReset   uint64
+}
+
+// readyCheck is the result of the checks of the Ready condition which go
+// through the other IPPools and the SpiderReservedIPs. It is valid until the
+// IPPool or its SpiderSubnet changes, or the first reservation in effect
+// expires, and it is dropped once an IPPool in the same subnet or a
+// SpiderReservedIP applying to the IPPool changes.
+type readyCheck struct {
+	generation      int64
+	labels          string
+	subnetLabels    string
+	nextExpiry      *time.Time
+	overlappingPool string
+	noAvailableIPs  bool
 }
 
 type IPPoolControllerConfig struct {
@@ -57,13 +97,15 @@ type IPPoolControllerConfig struct {
 	ResyncPeriod                  time.Duration
 }
 
-func NewIPPoolController(poolControllerConfig IPPoolControllerConfig, client client.Client, dynamicClient dynamic.Interface) *IPPoolController {
+func NewIPPoolController(poolControllerConfig IPPoolControllerConfig, client client.Client, dynamicClient dynamic.Interface, informers ctrlcache.Informers) *IPPoolController {
 	informerLogger = logutils.Logger.Named("SpiderIPPool-Informer")
 
 	c := &IPPoolController{
 		IPPoolControllerConfig: poolControllerConfig,
 		client:                 client,
 		dynamicClient:          dynamicClient,
+		informers:              informers,
+		readyChecks:            map[string]readyCheck{},
 	}
 
 	return c
@@ -113,6 +155,11 @@ func (ic *IPPoolController) SetupInformer(ctx context.Context, client crdclients
 				informerLogger.Error(err.Error())
 				continue
 			}
+			err = ic.addReservedIPEventHandlers(innerCtx)
+			if nil != err {
+				informerLogger.Error(err.Error())
+				continue
+			}
 			factory.Start(innerCtx.Done())
 
 			if err := ic.Run(innerCtx.Done()); nil != err {
@@ -131,15 +178,33 @@ func (ic *IPPoolController) addEventHandlers(poolInformer informers.SpiderIPPool
 
 	ic.poolWorkqueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SpiderIPPools")
 
+	// the events missed during the last leader term may leave them stale
+	ic.readyChecksLock.Lock()
+	ic.readyChecks = map[string]readyCheck{}
+	ic.readyChecksEpoch++
+	ic.readyChecksDropped = map[string]uint64{}
+	ic.readyChecksReset = ic.readyChecksEpoch
+	ic.readyChecksLock.Unlock()
+
 	// for all IPPool processing
 	_, err := poolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc: ic.enqueueIPPool,
+		AddFunc: func(obj interface{}) {
+			ic.dropSubnetReadyChecks(obj)
+			ic.enqueueIPPool(obj)
+		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPool := oldObj.(*spiderpoolv2beta1.SpiderIPPool)
+			newPool := newObj.(*spiderpoolv2beta1.SpiderIPPool)
+			if !reflect.DeepEqual(oldPool.Spec.IPs, newPool.Spec.IPs) ||
+				!reflect.DeepEqual(oldPool.Spec.ExcludeIPs, newPool.Spec.ExcludeIPs) ||
+				oldPool.Spec.Subnet != newPool.Spec.Subnet ||
+				(oldPool.DeletionTimestamp == nil) != (newPool.DeletionTimestamp == nil) {
+				ic.dropSubnetReadyChecks(oldObj)
+				ic.dropSubnetReadyChecks(newObj)
+			}
 			ic.enqueueIPPool(newObj)
 		},
-		DeleteFunc: func(obj interface{}) {
-
-		},
+		DeleteFunc: ic.dropSubnetReadyChecks,
 	})
 	if nil != err {
 		return err
@@ -148,6 +213,142 @@ func (ic *IPPoolController) addEventHandlers(poolInformer informers.SpiderIPPool
 	return nil
 }
 
+// addReservedIPEventHandlers drops the cached checks of the Ready condition
+// once the SpiderReservedIPs change. The handler added by the last leader
+// term is removed first.
+func (ic *IPPoolController) addReservedIPEventHandlers(ctx context.Context) error {
+	if ic.rIPRegistration != nil {
+		if err := ic.rIPInformer.RemoveEventHandler(ic.rIPRegistration); err != nil {
+			return fmt.Errorf("failed to remove SpiderReservedIP event handler: %w", err)
+		}
+		ic.rIPRegistration = nil
+	}
+
+	rIPInformer, err := ic.informers.GetInformer(ctx, &spiderpoolv2beta1.SpiderReservedIP{})
+	if err != nil {
+		return fmt.Errorf("failed to get SpiderReservedIP informer: %w", err)
+	}
+
+	registration, err := rIPInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ic.dropReservedIPReadyChecks(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldRIP := oldObj.(*spiderpoolv2beta1.SpiderReservedIP)
+			newRIP := newObj.(*spiderpoolv2beta1.SpiderReservedIP)
+			if reflect.DeepEqual(oldRIP.Spec, newRIP.Spec) &&
+				(oldRIP.DeletionTimestamp == nil) == (newRIP.DeletionTimestamp == nil) {
+				return
+			}
+			ic.dropReservedIPReadyChecks(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			ic.dropReservedIPReadyChecks(obj)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add SpiderReservedIP event handler: %w", err)
+	}
+	ic.rIPInformer = rIPInformer
+	ic.rIPRegistration = registration
+
+	return nil
+}
+
+// dropSubnetReadyChecks drops the cached checks of the Ready condition of the
+// IPPools in the same subnet as the given one, and enqueues them, for the
+// IPPool may overlap with them. Until the informer has synced, all IPPools
+// are being added and none of them is checked yet, so only the given one is
+// dropped.
+func (ic *IPPoolController) dropSubnetReadyChecks(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	pool, ok := obj.(*spiderpoolv2beta1.SpiderIPPool)
+	if !ok {
+		informerLogger.Sugar().Errorf("expected SpiderIPPool but got %+v", obj)
+		return
+	}
+
+	if !ic.poolSynced() {
+		ic.dropReadyChecks(pool.Name)
+		return
+	}
+
+	pools, err := ic.poolLister.List(labels.Everything())
+	if nil != err {
+		informerLogger.Sugar().Errorf("failed to list SpiderIPPools: %v", err)
+		return
+	}
+
+	names := []string{pool.Name}
+	var siblings []*spiderpoolv2beta1.SpiderIPPool
+	for _, p := range pools {
+		if p.Name != pool.Name && p.Spec.Subnet == pool.Spec.Subnet {
+			names = append(names, p.Name)
+			siblings = append(siblings, p)
+		}
+	}
+	ic.dropReadyChecks(names...)
+	for _, p := range siblings {
+		ic.enqueueIPPool(p)
+	}
+}
+
+// dropReservedIPReadyChecks drops the cached checks of the Ready condition of
+// the IPPools the SpiderReservedIP applies to before or after it changes, and
+// enqueues them.
+func (ic *IPPoolController) dropReservedIPReadyChecks(objs ...interface{}) {
+	pools, err := ic.poolLister.List(labels.Everything())
+	if nil != err {
+		informerLogger.Sugar().Errorf("failed to list SpiderIPPools: %v", err)
+		return
+	}
+
+	inScope := map[string]*spiderpoolv2beta1.SpiderIPPool{}
+	for _, obj := range objs {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		rIP, ok := obj.(*spiderpoolv2beta1.SpiderReservedIP)
+		if !ok {
+			informerLogger.Sugar().Errorf("expected SpiderReservedIP but got %+v", obj)
+			return
+		}
+
+		rIPPools, err := reservedipmanager.IPPoolsInScope(context.TODO(), ic.client, rIP, pools)
+		if nil != err {
+			informerLogger.Sugar().Warnf("failed to get the IPPools in the scope of SpiderReservedIP '%s', drop the checks of all IPPools: %v", rIP.Name, err)
+			rIPPools = pools
+		}
+		for _, p := range rIPPools {
+			inScope[p.Name] = p
+		}
+	}
+
+	names := make([]string, 0, len(inScope))
+	for name := range inScope {
+		names = append(names, name)
+	}
+	ic.dropReadyChecks(names...)
+	for _, p := range inScope {
+		ic.enqueueIPPool(p)
+	}
+}
+
+func (ic *IPPoolController) dropReadyChecks(poolNames ...string) {
+	ic.readyChecksLock.Lock()
+	defer ic.readyChecksLock.Unlock()
+
+	ic.readyChecksEpoch++
+	for _, name := range poolNames {
+		delete(ic.readyChecks, name)
+		ic.readyChecksDropped[name] = ic.readyChecksEpoch
+	}
+}
+
 // enqueueIPPool will check the given pool and enqueue them into different workqueue
 func (ic *IPPoolController) enqueueIPPool(obj interface{}) {
 	pool := obj.(*spiderpoolv2beta1.SpiderIPPool)
@@ -216,6 +417,8 @@ func (ic *IPPoolController) processNextWorkItem() bool {
 			// processing.
 			if apierrors.IsNotFound(err) {
 				ic.poolWorkqueue.Forget(obj)
+				metric.IPPoolUsageLevel.Delete(attribute.String(constant.KindSpiderIPPool, poolName))
+				metric.IPPoolCoolingIPCounts.Delete(attribute.String(constant.KindSpiderIPPool, poolName))
 				informerLogger.Sugar().Debugf("IPPool '%s' in work queue no longer exists", poolName)
 				return nil
 			}
@@ -280,6 +483,24 @@ func (ic *IPPoolController) handleIPPool(ctx context.Context, pool *spiderpoolv2
 		return err
 	}
 
+	// set the Ready and NearlyExhausted conditions
+	err = ic.syncConditions(ctx, pool)
+	if nil != err {
+		return err
+	}
+
+	// release the IPs whose quarantine has expired
+	err = ic.expireCoolingIPs(ctx, pool)
+	if nil != err {
+		return err
+	}
+
+	// take back the IP blocks leased to the nodes which no longer exist
+	err = ic.reclaimIPBlocks(ctx, pool)
+	if nil != err {
+		return err
+	}
+
 	// metrics
 	if pool.Status.TotalIPCount != nil {
 		attr := attribute.String(constant.KindSpiderIPPool, pool.Name)
@@ -316,14 +537,14 @@ func (ic *IPPoolController) syncHandler(ctx context.Context, pool *spiderpoolv2b
 		informerLogger.Sugar().Infof("initial SpiderIPPool '%s' status AllocatedIPCount to 0", pool.Name)
 	}
 
-	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	totalIPs, err := spiderpoolip.NewIPBitmap(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
 	if nil != err {
 		return fmt.Errorf("%w: failed to calculate SpiderIPPool '%s' total IP count, error: %v", constant.ErrWrongInput, pool.Name, err)
 	}
 
-	if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount != int64(len(totalIPs)) {
+	if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount != int64(totalIPs.Size()) {
 		needUpdate = true
-		pool.Status.TotalIPCount = pointer.Int64(int64(len(totalIPs)))
+		pool.Status.TotalIPCount = pointer.Int64(int64(totalIPs.Size()))
 	}
 
 	if needUpdate {
@@ -337,6 +558,305 @@ func (ic *IPPoolController) syncHandler(ctx context.Context, pool *spiderpoolv2b
 	return nil
 }
 
+// syncConditions updates the Ready and NearlyExhausted conditions of the
+// SpiderIPPool status, and emits an event once the usage level changes.
+func (ic *IPPoolController) syncConditions(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool) error {
+	attr := attribute.String(constant.KindSpiderIPPool, pool.Name)
+	if pool.DeletionTimestamp != nil {
+		metric.IPPoolUsageLevel.Delete(attr)
+		// the IPPool is gone once its finalizer is removed
+		if !controllerutil.ContainsFinalizer(pool, constant.SpiderFinalizer) {
+			return nil
+		}
+	}
+
+	readyCondition, err := ic.readyCondition(ctx, pool)
+	if nil != err {
+		return err
+	}
+
+	oldConditions := make([]metav1.Condition, len(pool.Status.Conditions))
+	copy(oldConditions, pool.Status.Conditions)
+	meta.SetStatusCondition(&pool.Status.Conditions, readyCondition)
+	changed := !reflect.DeepEqual(oldConditions, pool.Status.Conditions)
+
+	oldLevel := UsageLevelOf(pool.Status.Conditions)
+	if pool.DeletionTimestamp == nil {
+		var allocatedIPCount, totalIPCount int64
+		if pool.Status.AllocatedIPCount != nil {
+			allocatedIPCount = *pool.Status.AllocatedIPCount
+		}
+		if pool.Status.TotalIPCount != nil {
+			totalIPCount = *pool.Status.TotalIPCount
+		}
+		if SetUsageCondition(&pool.Status.Conditions, pool.Spec.UsageThresholds, allocatedIPCount, totalIPCount, pool.Generation) {
+			changed = true
+		}
+	}
+
+	if changed {
+		err := ic.client.Status().Update(ctx, pool)
+		if nil != err {
+			return fmt.Errorf("failed to update pool: %w", err)
+		}
+		informerLogger.Sugar().Debugf("update SpiderIPPool '%s' status conditions successfully", pool.Name)
+	}
+
+	if pool.DeletionTimestamp == nil {
+		newLevel := UsageLevelOf(pool.Status.Conditions)
+		RecordUsageEvent(pool, pool.Status.Conditions, oldLevel, newLevel)
+		metric.IPPoolUsageLevel.Record(int64(newLevel), attr)
+	}
+
+	return nil
+}
+
+// readyCondition tells whether the SpiderIPPool could be allocated from, and
+// why not.
+func (ic *IPPoolController) readyCondition(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool) (metav1.Condition, error) {
+	notReady := func(reason, messageFmt string, args ...interface{}) metav1.Condition {
+		return metav1.Condition{
+			Type:               constant.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             reason,
+			Message:            fmt.Sprintf(messageFmt, args...),
+			ObservedGeneration: pool.Generation,
+		}
+	}
+
+	if pool.DeletionTimestamp != nil {
+		return notReady(constant.ConditionReasonTerminating, "the IPPool is terminating"), nil
+	}
+	if pool.Spec.Disable != nil && *pool.Spec.Disable {
+		return notReady(constant.ConditionReasonDisabled, "the IPPool is disabled"), nil
+	}
+
+	var subnetLabels map[string]string
+	if subnetName, ok := pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]; ok && ic.EnableSpiderSubnet {
+		var subnet spiderpoolv2beta1.SpiderSubnet
+		err := ic.client.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet)
+		if apierrors.IsNotFound(err) {
+			return notReady(constant.ConditionReasonSubnetNotFound, "the SpiderSubnet %s of the IPPool no longer exists", subnetName), nil
+		}
+		if nil != err {
+			return metav1.Condition{}, fmt.Errorf("failed to get SpiderSubnet '%s': %w", subnetName, err)
+		}
+		subnetLabels = subnet.Labels
+	}
+
+	now := time.Now()
+	check, err := ic.getReadyCheck(ctx, pool, subnetLabels, now)
+	if nil != err {
+		return metav1.Condition{}, err
+	}
+	// sync the IPPool again once the first reservation in effect expires
+	if check.nextExpiry != nil {
+		ic.poolWorkqueue.AddAfter(pool.Name, check.nextExpiry.Sub(now))
+	}
+	if check.overlappingPool != "" {
+		return notReady(constant.ConditionReasonOverlapping, "the IP addresses of the IPPool overlap with IPPool %s", check.overlappingPool), nil
+	}
+	if check.noAvailableIPs {
+		return notReady(constant.ConditionReasonNoAvailableIPs, "the IPPool has no IP addresses which are not reserved"), nil
+	}
+
+	return metav1.Condition{
+		Type:               constant.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             constant.ConditionReasonAvailable,
+		Message:            "the IPPool is ready to allocate IP addresses",
+		ObservedGeneration: pool.Generation,
+	}, nil
+}
+
+// getReadyCheck returns the cached checks of the Ready condition of the
+// IPPool, they are done again only if they are no longer valid, since they
+// go through all the IP addresses of the other IPPools in the same subnet.
+func (ic *IPPoolController) getReadyCheck(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool, subnetLabels map[string]string, now time.Time) (readyCheck, error) {
+	poolLabels := labels.Set(pool.Labels).String()
+	ownerSubnetLabels := labels.Set(subnetLabels).String()
+
+	ic.readyChecksLock.Lock()
+	check, ok := ic.readyChecks[pool.Name]
+	epoch := ic.readyChecksEpoch
+	ic.readyChecksLock.Unlock()
+	if ok && check.generation == pool.Generation && check.labels == poolLabels && check.subnetLabels == ownerSubnetLabels &&
+		(check.nextExpiry == nil || now.Before(*check.nextExpiry)) {
+		return check, nil
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if nil != err {
+		return readyCheck{}, fmt.Errorf("%w: failed to assemble SpiderIPPool '%s' total IPs, error: %v", constant.ErrWrongInput, pool.Name, err)
+	}
+
+	overlappingPool, err := ic.findOverlappingIPPool(pool, totalIPs)
+	if nil != err {
+		return readyCheck{}, err
+	}
+
+	reservedIPs, nextExpiry, err := ic.reservedIPs(ctx, pool, now)
+	if nil != err {
+		return readyCheck{}, err
+	}
+
+	check = readyCheck{
+		generation:      pool.Generation,
+		labels:          poolLabels,
+		subnetLabels:    ownerSubnetLabels,
+		nextExpiry:      nextExpiry,
+		overlappingPool: overlappingPool,
+		noAvailableIPs:  len(spiderpoolip.IPsDiffSet(totalIPs, reservedIPs, false)) == 0,
+	}
+
+	ic.readyChecksLock.Lock()
+	// only the checks of the IPPool dropped meanwhile are stale
+	if ic.readyChecksReset <= epoch && ic.readyChecksDropped[pool.Name] <= epoch {
+		ic.readyChecks[pool.Name] = check
+	}
+	ic.readyChecksLock.Unlock()
+
+	return check, nil
+}
+
+// findOverlappingIPPool returns the IPPool in the same subnet whose IP
+// addresses overlap with the given ones, the webhook does not prevent it
+// once its check races with another one.
+func (ic *IPPoolController) findOverlappingIPPool(pool *spiderpoolv2beta1.SpiderIPPool, totalIPs []net.IP) (string, error) {
+	if len(totalIPs) == 0 {
+		return "", nil
+	}
+
+	pools, err := ic.poolLister.List(labels.Everything())
+	if nil != err {
+		return "", fmt.Errorf("failed to list SpiderIPPools: %w", err)
+	}
+
+	for _, p := range pools {
+		if p.Name == pool.Name || p.DeletionTimestamp != nil || p.Spec.Subnet != pool.Spec.Subnet {
+			continue
+		}
+
+		ips, err := spiderpoolip.AssembleTotalIPs(*p.Spec.IPVersion, p.Spec.IPs, p.Spec.ExcludeIPs)
+		if nil != err {
+			continue
+		}
+		if len(spiderpoolip.IPsIntersectionSet(totalIPs, ips, false)) != 0 {
+			return p.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// reservedIPs returns the IP addresses of the SpiderReservedIPs in effect for
+// the IPPool, and when the first of them expires. The ones limited to some
+// namespaces are left out.
+func (ic *IPPoolController) reservedIPs(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool, now time.Time) ([]net.IP, *time.Time, error) {
+	var rIPList spiderpoolv2beta1.SpiderReservedIPList
+	if err := ic.client.List(ctx, &rIPList); nil != err {
+		return nil, nil, fmt.Errorf("failed to list SpiderReservedIPs: %w", err)
+	}
+
+	version := *pool.Spec.IPVersion
+	var nextExpiry *time.Time
+	var ranges []string
+	for i := range rIPList.Items {
+		r := &rIPList.Items[i]
+		if r.DeletionTimestamp != nil || r.Spec.IPVersion == nil || *r.Spec.IPVersion != version || reservedipmanager.IsReservedIPExpired(r, now) {
+			continue
+		}
+
+		inScope, err := reservedipmanager.IsReservedIPInScope(ctx, ic.client, r, reservedipmanager.ReservedIPScope{IPPool: pool})
+		if nil != err {
+			return nil, nil, fmt.Errorf("failed to check the scope of SpiderReservedIP '%s': %w", r.Name, err)
+		}
+		if !inScope {
+			continue
+		}
+
+		ranges = append(ranges, r.Spec.IPs...)
+		if r.Spec.ExpireTime != nil && (nextExpiry == nil || r.Spec.ExpireTime.Time.Before(*nextExpiry)) {
+			nextExpiry = &r.Spec.ExpireTime.Time
+		}
+	}
+
+	ips, err := spiderpoolip.ParseIPRanges(version, ranges)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	return ips, nextExpiry, nil
+}
+
+// expireCoolingIPs removes the quarantine records which have expired from the
+// SpiderIPPool status, and requeues the IPPool to wait for the next one.
+func (ic *IPPoolController) expireCoolingIPs(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool) error {
+	if pool.DeletionTimestamp != nil {
+		metric.IPPoolCoolingIPCounts.Delete(attribute.String(constant.KindSpiderIPPool, pool.Name))
+		return nil
+	}
+
+	changed, coolingCount, nextExpiry, err := ExpireCoolingIPs(pool, time.Now())
+	if nil != err {
+		return fmt.Errorf("%w: failed to parse SpiderIPPool '%s' status coolingIPs, error: %v", constant.ErrWrongInput, pool.Name, err)
+	}
+
+	if changed {
+		err = ic.client.Status().Update(ctx, pool)
+		if nil != err {
+			return fmt.Errorf("failed to update pool: %w", err)
+		}
+		informerLogger.Sugar().Debugf("update SpiderIPPool '%s' status coolingIPs with '%d' IPs still cooling successfully", pool.Name, coolingCount)
+	}
+
+	metric.IPPoolCoolingIPCounts.Record(int64(coolingCount), attribute.String(constant.KindSpiderIPPool, pool.Name))
+	if nextExpiry > 0 {
+		ic.poolWorkqueue.AddAfter(pool.Name, nextExpiry)
+	}
+
+	return nil
+}
+
+// reclaimIPBlocks removes the IP blocks of the nodes which no longer exist
+// from the SpiderIPPool status, so that their IPs could be leased again.
+func (ic *IPPoolController) reclaimIPBlocks(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool) error {
+	if pool.DeletionTimestamp != nil || pool.Status.IPBlocks == nil {
+		return nil
+	}
+
+	blocks, err := convert.UnmarshalIPPoolIPBlocks(pool.Status.IPBlocks)
+	if nil != err {
+		return fmt.Errorf("%w: failed to parse SpiderIPPool '%s' status ipBlocks, error: %v", constant.ErrWrongInput, pool.Name, err)
+	}
+
+	var goneNodes []string
+	for nodeName := range blocks {
+		err := ic.client.Get(ctx, apitypes.NamespacedName{Name: nodeName}, &corev1.Node{})
+		if apierrors.IsNotFound(err) {
+			goneNodes = append(goneNodes, nodeName)
+			continue
+		}
+		if nil != err {
+			return fmt.Errorf("failed to get node '%s': %w", nodeName, err)
+		}
+	}
+
+	changed, err := RemoveIPBlocks(pool, goneNodes...)
+	if nil != err {
+		return err
+	}
+	if changed {
+		err = ic.client.Status().Update(ctx, pool)
+		if nil != err {
+			return fmt.Errorf("failed to update pool: %w", err)
+		}
+		informerLogger.Sugar().Infof("reclaim SpiderIPPool '%s' IP blocks of nodes %v successfully", pool.Name, goneNodes)
+	}
+
+	return nil
+}
+
 // removeFinalizer removes SpiderIPPool finalizer
 func (ic *IPPoolController) removeFinalizer(ctx context.Context, pool *spiderpoolv2beta1.SpiderIPPool) error {
 	if !controllerutil.ContainsFinalizer(pool, constant.SpiderFinalizer) {
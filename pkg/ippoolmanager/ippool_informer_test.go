@@ -13,12 +13,17 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
@@ -27,6 +32,7 @@ import (
 	spiderpoolfake "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned/fake"
 	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
 )
 
 var _ = Describe("IPPool-informer", Label("unittest"), Ordered, func() {
@@ -69,6 +75,8 @@ var _ = Describe("IPPool-informer", Label("unittest"), Ordered, func() {
 			Expect(err).NotTo(HaveOccurred())
 			err = appsv1.AddToScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
+			err = corev1.AddToScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
 
 			control = newController()
 			fakeClientSet := spiderpoolfake.NewSimpleClientset()
@@ -197,6 +205,375 @@ var _ = Describe("IPPool-informer", Label("unittest"), Ordered, func() {
 			})
 		})
 
+		Context("expire cooling IPs", func() {
+			It("removes the expired quarantine records of an IPPool", func() {
+				ctx := context.TODO()
+				coolingIPs, err := convert.MarshalIPPoolCoolingIPs(spiderpoolv2beta1.PoolIPCoolings{
+					"10.1.0.1": {ExpireTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+					"10.1.0.2": {ExpireTime: metav1.NewTime(time.Now().Add(time.Hour))},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				pool.Spec.QuarantineSeconds = pointer.Int64(3600)
+				pool.Status.CoolingIPs = coolingIPs
+
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy()).
+					Build()
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				records, err := convert.UnmarshalIPPoolCoolingIPs(ipPool.Status.CoolingIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).NotTo(HaveKey("10.1.0.1"))
+				Expect(records).To(HaveKey("10.1.0.2"))
+			})
+		})
+
+		Context("sync usage condition", func() {
+			It("sets the NearlyExhausted condition once the warning threshold is reached", func() {
+				ctx := context.TODO()
+				pool.Spec.UsageThresholds = &spiderpoolv2beta1.UsageThresholds{
+					Warning:  pointer.Int64(80),
+					Critical: pointer.Int64(95),
+				}
+				pool.Status.AllocatedIPCount = pointer.Int64(9)
+
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy()).
+					Build()
+				err := control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				condition := meta.FindStatusCondition(ipPool.Status.Conditions, constant.ConditionNearlyExhausted)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonWarningThresholdReached))
+			})
+
+			It("does not change the NearlyExhausted condition while the usage level stays", func() {
+				thresholds := &spiderpoolv2beta1.UsageThresholds{
+					Warning:  pointer.Int64(80),
+					Critical: pointer.Int64(95),
+				}
+				var conditions []metav1.Condition
+
+				Expect(SetUsageCondition(&conditions, thresholds, 80, 100, 1)).To(BeTrue())
+				Expect(SetUsageCondition(&conditions, thresholds, 90, 100, 1)).To(BeFalse())
+				Expect(UsageLevelOf(conditions)).To(Equal(UsageLevelWarning))
+
+				Expect(SetUsageCondition(&conditions, thresholds, 95, 100, 1)).To(BeTrue())
+				Expect(UsageLevelOf(conditions)).To(Equal(UsageLevelCritical))
+			})
+
+			It("removes the NearlyExhausted condition without usage thresholds", func() {
+				ctx := context.TODO()
+				pool.Status.Conditions = []metav1.Condition{{
+					Type:               constant.ConditionNearlyExhausted,
+					Status:             metav1.ConditionTrue,
+					Reason:             constant.ConditionReasonCriticalThresholdReached,
+					LastTransitionTime: metav1.Now(),
+				}}
+
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy()).
+					Build()
+				err := control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(meta.FindStatusCondition(ipPool.Status.Conditions, constant.ConditionNearlyExhausted)).To(BeNil())
+			})
+		})
+
+		Context("sync ready condition", func() {
+			It("sets the Ready condition of an available IPPool", func() {
+				ctx := context.TODO()
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy()).
+					Build()
+				err := control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(meta.IsStatusConditionTrue(ipPool.Status.Conditions, constant.ConditionReady)).To(BeTrue())
+			})
+
+			It("sets the Ready condition of a disabled IPPool to false", func() {
+				ctx := context.TODO()
+				pool.Spec.Disable = pointer.Bool(true)
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy()).
+					Build()
+				err := control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				condition := meta.FindStatusCondition(ipPool.Status.Conditions, constant.ConditionReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonDisabled))
+			})
+
+			It("sets the Ready condition of a fully reserved IPPool to false", func() {
+				ctx := context.TODO()
+				rIP := &spiderpoolv2beta1.SpiderReservedIP{
+					ObjectMeta: metav1.ObjectMeta{Name: "reserved"},
+					Spec: spiderpoolv2beta1.ReservedIPSpec{
+						IPVersion: pointer.Int64(constant.IPv4),
+						IPs:       []string{"10.1.0.1-10.1.0.10"},
+					},
+				}
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy(), rIP).
+					Build()
+				err := control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				condition := meta.FindStatusCondition(ipPool.Status.Conditions, constant.ConditionReady)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonNoAvailableIPs))
+			})
+
+			It("caches the checks until the SpiderReservedIPs change", func() {
+				ctx := context.TODO()
+				rIP := &spiderpoolv2beta1.SpiderReservedIP{
+					ObjectMeta: metav1.ObjectMeta{Name: "reserved"},
+					Spec: spiderpoolv2beta1.ReservedIPSpec{
+						IPVersion: pointer.Int64(constant.IPv4),
+						IPs:       []string{"10.1.0.1-10.1.0.10"},
+					},
+				}
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(rIP).
+					Build()
+				err := control.ipPoolStore.Add(pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				condition, err := control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonNoAvailableIPs))
+
+				err = control.client.Delete(ctx, rIP)
+				Expect(err).NotTo(HaveOccurred())
+				condition, err = control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonNoAvailableIPs))
+
+				control.dropReservedIPReadyChecks(rIP)
+				condition, err = control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			})
+
+			It("keeps the cached checks of the IPPools a SpiderReservedIP does not apply to", func() {
+				ctx := context.TODO()
+				rIP := &spiderpoolv2beta1.SpiderReservedIP{
+					ObjectMeta: metav1.ObjectMeta{Name: "reserved"},
+					Spec: spiderpoolv2beta1.ReservedIPSpec{
+						IPVersion:  pointer.Int64(constant.IPv4),
+						IPs:        []string{"10.1.0.1-10.1.0.10"},
+						IPPoolName: []string{"other-ippool"},
+					},
+				}
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					Build()
+				err := control.ipPoolStore.Add(pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(control.readyChecks).To(HaveKey(pool.Name))
+
+				control.dropReservedIPReadyChecks(rIP)
+				Expect(control.readyChecks).To(HaveKey(pool.Name))
+
+				rIP.Spec.IPPoolName = []string{pool.Name}
+				control.dropReservedIPReadyChecks(rIP)
+				Expect(control.readyChecks).NotTo(HaveKey(pool.Name))
+			})
+
+			It("checks again once the reservation expires", func() {
+				ctx := context.TODO()
+				now := time.Now()
+				rIP := &spiderpoolv2beta1.SpiderReservedIP{
+					ObjectMeta: metav1.ObjectMeta{Name: "reserved"},
+					Spec: spiderpoolv2beta1.ReservedIPSpec{
+						IPVersion:  pointer.Int64(constant.IPv4),
+						IPs:        []string{"10.1.0.1-10.1.0.10"},
+						ExpireTime: &metav1.Time{Time: now.Add(time.Hour)},
+					},
+				}
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(rIP).
+					Build()
+
+				check, err := control.getReadyCheck(ctx, pool, nil, now)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(check.noAvailableIPs).To(BeTrue())
+				Expect(check.nextExpiry).NotTo(BeNil())
+
+				check, err = control.getReadyCheck(ctx, pool, nil, now.Add(2*time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(check.noAvailableIPs).To(BeFalse())
+				Expect(check.nextExpiry).To(BeNil())
+			})
+
+			It("sets the Ready condition of an overlapping IPPool to false until the other one is deleted", func() {
+				ctx := context.TODO()
+				overlappingPool := pool.DeepCopy()
+				overlappingPool.Name = "overlapping-ippool"
+				overlappingPool.Spec.IPs = []string{"10.1.0.10-10.1.0.20"}
+				err := control.ipPoolStore.Add(pool)
+				Expect(err).NotTo(HaveOccurred())
+				err = control.ipPoolStore.Add(overlappingPool)
+				Expect(err).NotTo(HaveOccurred())
+
+				condition, err := control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Reason).To(Equal(constant.ConditionReasonOverlapping))
+
+				err = control.ipPoolStore.Delete(overlappingPool)
+				Expect(err).NotTo(HaveOccurred())
+				control.poolSynced = func() bool { return true }
+				control.dropSubnetReadyChecks(overlappingPool)
+				condition, err = control.readyCondition(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			})
+
+			It("caches the checks of an IPPool while the other IPPools are dropped", func() {
+				ctx := context.TODO()
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+							control.dropReadyChecks("other-ippool")
+							return c.List(ctx, list, opts...)
+						},
+					}).
+					Build()
+
+				_, err := control.getReadyCheck(ctx, pool, nil, time.Now())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(control.readyChecks).To(HaveKey(pool.Name))
+			})
+
+			It("does not cache the checks of an IPPool dropped meanwhile", func() {
+				ctx := context.TODO()
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{
+						List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+							control.dropReadyChecks(pool.Name)
+							return c.List(ctx, list, opts...)
+						},
+					}).
+					Build()
+
+				_, err := control.getReadyCheck(ctx, pool, nil, time.Now())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(control.readyChecks).NotTo(HaveKey(pool.Name))
+			})
+
+			It("only drops the checks of the IPPool itself until the informer has synced", func() {
+				siblingPool := pool.DeepCopy()
+				siblingPool.Name = "sibling-ippool"
+				siblingPool.Spec.IPs = []string{"10.1.0.20-10.1.0.30"}
+				err := control.ipPoolStore.Add(pool)
+				Expect(err).NotTo(HaveOccurred())
+				control.readyChecks[pool.Name] = readyCheck{}
+
+				control.poolSynced = func() bool { return false }
+				control.dropSubnetReadyChecks(siblingPool)
+				Expect(control.readyChecks).To(HaveKey(pool.Name))
+				Expect(control.poolWorkqueue.Len()).To(Equal(0))
+
+				control.poolSynced = func() bool { return true }
+				control.dropSubnetReadyChecks(siblingPool)
+				Expect(control.readyChecks).NotTo(HaveKey(pool.Name))
+				Expect(control.poolWorkqueue.Len()).To(Equal(1))
+			})
+		})
+
+		Context("reclaim IP blocks", func() {
+			It("removes the IP blocks of the nodes which no longer exist", func() {
+				ctx := context.TODO()
+				leaseTime := metav1.Now()
+				ipBlocks, err := convert.MarshalIPPoolIPBlocks(spiderpoolv2beta1.PoolIPBlocks{
+					"node1": {IPs: []string{"10.1.0.1-10.1.0.2"}, LeaseTime: leaseTime},
+					"node2": {IPs: []string{"10.1.0.3-10.1.0.4"}, LeaseTime: leaseTime},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				pool.Status.IPBlocks = ipBlocks
+
+				control.client = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithStatusSubresource(&spiderpoolv2beta1.SpiderIPPool{}).
+					WithObjects(pool.DeepCopy(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}).
+					Build()
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = control.handleIPPool(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = control.client.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				blocks, err := convert.UnmarshalIPPoolIPBlocks(ipPool.Status.IPBlocks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blocks).To(HaveKey("node1"))
+				Expect(blocks).NotTo(HaveKey("node2"))
+			})
+		})
 	})
 
 })
@@ -223,7 +600,7 @@ func newController() *poolController {
 		ResyncPeriod:                  10 * time.Second,
 	}
 
-	pController := NewIPPoolController(poolControllerConfig, fakeClient, fakeDynamicClient)
+	pController := NewIPPoolController(poolControllerConfig, fakeClient, fakeDynamicClient, nil)
 
 	return &poolController{
 		IPPoolController: pController,
@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -21,6 +23,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
@@ -33,8 +36,13 @@ type IPPoolManager interface {
 	GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv2beta1.SpiderIPPool, error)
 	ListIPPools(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderIPPoolList, error)
 	AllocateIP(ctx context.Context, poolName, nic string, pod *corev1.Pod, podController types.PodTopController) (*models.IPConfig, error)
+	AssignIP(ctx context.Context, poolName, ip, nic string, pod *corev1.Pod) (*models.IPConfig, error)
 	ReleaseIP(ctx context.Context, poolName string, ipAndUIDs []types.IPAndUID) error
 	UpdateAllocatedIPs(ctx context.Context, poolName, namespacedName string, ipAndCIDs []types.IPAndUID) error
+	TransferAllocatedIPs(ctx context.Context, poolName, namespacedName, newNamespacedName string, ipAndUIDs []types.IPAndUID) error
+	AllocateIPFromBlock(ctx context.Context, poolName, nic string, pod *corev1.Pod) (*models.IPConfig, error)
+	FlushIPBlockAllocations(ctx context.Context) error
+	GetPendingIPBlockAllocation(poolName, ip string) (spiderpoolv2beta1.PoolIPAllocation, bool)
 }
 
 type ipPoolManager struct {
@@ -42,6 +50,11 @@ type ipPoolManager struct {
 	client     client.Client
 	apiReader  client.Reader
 	rIPManager reservedipmanager.ReservedIPManager
+
+	// ipBlocks are the IP blocks of the IPPools leased to the node.
+	ipBlocksLock   lock.Mutex
+	ipBlocks       map[string]*nodeIPBlock
+	ipBlocksLoaded bool
 }
 
 func NewIPPoolManager(config IPPoolManagerConfig, client client.Client, apiReader client.Reader, rIPManager reservedipmanager.ReservedIPManager) (IPPoolManager, error) {
@@ -60,6 +73,7 @@ func NewIPPoolManager(config IPPoolManagerConfig, client client.Client, apiReade
 		client:     client,
 		apiReader:  apiReader,
 		rIPManager: rIPManager,
+		ipBlocks:   map[string]*nodeIPBlock{},
 	}, nil
 }
 
@@ -108,15 +122,15 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, nic string, p
 			return err
 		}
 
-		logger.Debug("Generate a random IP address")
-		allocatedIP, err := im.genRandomIP(ctx, ipPool, pod, podController)
+		logger.Sugar().Debugf("Select an IP address with allocation strategy %s", AllocationStrategy(ipPool))
+		allocatedIP, err := im.genIP(ctx, ipPool, pod, podController)
 		if err != nil {
 			return err
 		}
 
 		resourceVersion := ipPool.ResourceVersion
 		logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).
-			Sugar().Debugf("Try to update the allocation status of IPPool using random IP %s", allocatedIP)
+			Sugar().Debugf("Try to update the allocation status of IPPool using IP %s", allocatedIP)
 		if err := im.client.Status().Update(ctx, ipPool); err != nil {
 			if apierrors.IsConflict(err) {
 				metric.IpamAllocationUpdateIPPoolConflictCounts.Add(ctx, 1)
@@ -139,7 +153,7 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, nic string, p
 	return ipConfig, nil
 }
 
-func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) (net.IP, error) {
+func (im *ipPoolManager) genIP(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) (net.IP, error) {
 	logger := logutils.FromContext(ctx)
 
 	var tmpPod *corev1.Pod
@@ -154,32 +168,23 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 		return nil, err
 	}
 
-	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion)
-	if err != nil {
-		return nil, err
-	}
-
 	allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
 	if err != nil {
 		return nil, err
 	}
 
-	var used []string
-	for ip := range allocatedRecords {
-		used = append(used, ip)
-	}
-	usedIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, used)
+	availableIPs, err := im.assembleAvailableIPs(ctx, ipPool, pod.Namespace, allocatedRecords)
 	if err != nil {
 		return nil, err
 	}
-
-	totalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
-	if err != nil {
+	// the IP addresses leased to the nodes are only allocated by their
+	// spiderpool-agents
+	if err := markIPBlocks(ipPool, availableIPs); err != nil {
 		return nil, err
 	}
 
-	availableIPs := spiderpoolip.IPsDiffSet(totalIPs, append(reservedIPs, usedIPs...), false)
-	if len(availableIPs) == 0 {
+	var resIP net.IP
+	if availableIPs.Free() == 0 {
 		// traverse the usedIPs to find the previous allocated IPs if there be
 		// reference issue: https://github.com/spidernet-io/spiderpool/issues/2517
 		allocatedIPFromRecords, hasFound := findAllocatedIPFromRecords(allocatedRecords, key, string(pod.UID))
@@ -187,13 +192,20 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 			return nil, constant.ErrIPUsedOut
 		}
 
-		availableIPs, err = spiderpoolip.ParseIPRange(*ipPool.Spec.IPVersion, allocatedIPFromRecords)
-		if nil != err {
-			return nil, err
+		resIP = net.ParseIP(allocatedIPFromRecords)
+		if resIP == nil {
+			return nil, fmt.Errorf("%w: invalid IP '%s' recorded in IPPool %s", constant.ErrWrongInput, allocatedIPFromRecords, ipPool.Name)
 		}
 		logger.Sugar().Warnf("find previous IP '%s' from IPPool '%s' recorded IP allocations", allocatedIPFromRecords, ipPool.Name)
+	} else {
+		resIP, err = selectIP(ipPool, availableIPs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := recordAllocatedIP(ipPool, resIP); err != nil {
+		return nil, err
 	}
-	resIP := availableIPs[0]
 
 	if allocatedRecords == nil {
 		allocatedRecords = spiderpoolv2beta1.PoolIPAllocations{}
@@ -203,7 +215,7 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 		PodUID:         string(pod.UID),
 	}
 
-	data, err := convert.MarshalIPPoolAllocatedIPs(allocatedRecords)
+	data, err := im.marshalAllocatedIPs(allocatedRecords)
 	if err != nil {
 		return nil, err
 	}
@@ -221,9 +233,183 @@ func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv2be
 	return resIP, nil
 }
 
+// assembleAvailableIPs returns the IP addresses of the IPPool in an
+// IPBitmap, where the reserved, allocated and cooling ones are set. The IP
+// addresses reserved for the namespace are only set if it is not empty.
+func (im *ipPoolManager) assembleAvailableIPs(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, namespace string, allocatedRecords spiderpoolv2beta1.PoolIPAllocations) (*spiderpoolip.IPBitmap, error) {
+	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion, reservedipmanager.ReservedIPScope{
+		IPPool:    ipPool,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cooling, err := getCoolingIPs(ipPool, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// mark all the unavailable IP addresses in the bitmap of the IPPool, so
+	// that the IP ranges never need to be expanded one by one
+	availableIPs, err := spiderpoolip.NewIPBitmap(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range reservedIPs {
+		availableIPs.Set(ip)
+	}
+	for ip := range allocatedRecords {
+		availableIPs.Set(net.ParseIP(ip))
+	}
+	for _, ip := range cooling {
+		availableIPs.Set(net.ParseIP(ip))
+	}
+
+	return availableIPs, nil
+}
+
+// AssignIP records the specified IP address of the IPPool as taken by the
+// Pod, it fails if the IP address is out of the IPPool, reserved or already
+// taken by another Pod.
+func (im *ipPoolManager) AssignIP(ctx context.Context, poolName, ip, nic string, pod *corev1.Pod) (*models.IPConfig, error) {
+	logger := logutils.FromContext(ctx)
+
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := retry.DefaultRetry
+	steps := backoff.Steps
+	var ipConfig *models.IPConfig
+	err = retry.RetryOnConflictWithContext(ctx, backoff, func(ctx context.Context) error {
+		logger := logger.With(
+			zap.String("IPPoolName", poolName),
+			zap.Int("Times", steps-backoff.Steps+1),
+		)
+		logger.Debug("Re-get IPPool for IP assignment")
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			return err
+		}
+
+		assignedIP := net.ParseIP(ip)
+		if err := im.checkAssignableIP(ctx, ipPool, assignedIP, pod.Namespace); err != nil {
+			return err
+		}
+
+		allocatedRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+		if err != nil {
+			return err
+		}
+
+		if record, ok := allocatedRecords[assignedIP.String()]; ok {
+			if record.PodUID != string(pod.UID) {
+				return fmt.Errorf("IP %s of IPPool %s is already taken by Pod %s (UID %s)", assignedIP, poolName, record.NamespacedName, record.PodUID)
+			}
+			ipConfig = convert.GenIPConfigResult(assignedIP, nic, ipPool)
+			return nil
+		}
+
+		if allocatedRecords == nil {
+			allocatedRecords = spiderpoolv2beta1.PoolIPAllocations{}
+		}
+		allocatedRecords[assignedIP.String()] = spiderpoolv2beta1.PoolIPAllocation{
+			NamespacedName: key,
+			PodUID:         string(pod.UID),
+		}
+
+		data, err := im.marshalAllocatedIPs(allocatedRecords)
+		if err != nil {
+			return err
+		}
+		ipPool.Status.AllocatedIPs = data
+		if err := recordAllocatedIP(ipPool, assignedIP); err != nil {
+			return err
+		}
+		if err := removeCoolingIP(ipPool, assignedIP); err != nil {
+			return err
+		}
+
+		if ipPool.Status.AllocatedIPCount == nil {
+			ipPool.Status.AllocatedIPCount = new(int64)
+		}
+
+		*ipPool.Status.AllocatedIPCount++
+		if *ipPool.Status.AllocatedIPCount > int64(*im.config.MaxAllocatedIPs) {
+			return fmt.Errorf("%w, threshold of IP records(<=%d) for IPPool %s exceeded", constant.ErrIPUsedOut, im.config.MaxAllocatedIPs, ipPool.Name)
+		}
+
+		resourceVersion := ipPool.ResourceVersion
+		logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).
+			Sugar().Debugf("Try to update the allocation status of IPPool using specified IP %s", assignedIP)
+		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+			if apierrors.IsConflict(err) {
+				metric.IpamAllocationUpdateIPPoolConflictCounts.Add(ctx, 1)
+				logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).Warn("An conflict occurred when updating the status of IPPool")
+			}
+			return err
+		}
+		ipConfig = convert.GenIPConfigResult(assignedIP, nic, ipPool)
+
+		return nil
+	})
+	if err != nil {
+		if wait.Interrupted(err) {
+			err = fmt.Errorf("%w (%d times), failed to assign IP %s from IPPool %s", constant.ErrRetriesExhausted, steps, ip, poolName)
+		}
+
+		return nil, err
+	}
+
+	return ipConfig, nil
+}
+
+// checkAssignableIP checks whether the IP address belongs to the IPPool's
+// total IP addresses, is not reserved by any SpiderReservedIP applying to the
+// namespace and is not leased to any node as a part of its IP block.
+func (im *ipPoolManager) checkAssignableIP(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool, ip net.IP, namespace string) error {
+	if ip == nil {
+		return fmt.Errorf("%w: invalid IP address", constant.ErrWrongInput)
+	}
+
+	totalIPs, err := spiderpoolip.NewIPBitmap(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+	if err != nil {
+		return err
+	}
+	if !totalIPs.Contains(ip) {
+		return fmt.Errorf("%w: IP %s does not belong to IPPool %s", constant.ErrWrongInput, ip, ipPool.Name)
+	}
+
+	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion, reservedipmanager.ReservedIPScope{
+		IPPool:    ipPool,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return err
+	}
+	if len(spiderpoolip.IPsIntersectionSet(reservedIPs, []net.IP{ip}, false)) != 0 {
+		return fmt.Errorf("%w: IP %s is reserved", constant.ErrWrongInput, ip)
+	}
+
+	nodeName, err := getIPBlockNode(ipPool, ip)
+	if err != nil {
+		return err
+	}
+	if nodeName != "" {
+		return fmt.Errorf("%w: IP %s is leased to node %s as a part of its IP block", constant.ErrWrongInput, ip, nodeName)
+	}
+
+	return nil
+}
+
 func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUIDs []types.IPAndUID) error {
 	logger := logutils.FromContext(ctx)
 
+	// the IP addresses allocated from the IP block may not be written yet
+	im.releasePendingIPs(poolName, ipAndUIDs)
+
 	backoff := retry.DefaultRetry
 	steps := backoff.Steps
 	err := retry.RetryOnConflictWithContext(ctx, backoff, func(ctx context.Context) error {
@@ -246,26 +432,33 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUI
 			ipPool.Status.AllocatedIPCount = new(int64)
 		}
 
-		release := false
+		var releasedIPs []string
 		for _, iu := range ipAndUIDs {
 			if record, ok := allocatedRecords[iu.IP]; ok {
 				if record.PodUID == iu.UID {
 					delete(allocatedRecords, iu.IP)
 					*ipPool.Status.AllocatedIPCount--
-					release = true
+					releasedIPs = append(releasedIPs, iu.IP)
 				}
 			}
 		}
 
-		if !release {
+		if len(releasedIPs) == 0 {
 			return nil
 		}
 
-		data, err := convert.MarshalIPPoolAllocatedIPs(allocatedRecords)
+		data, err := im.marshalAllocatedIPs(allocatedRecords)
 		if err != nil {
 			return err
 		}
 		ipPool.Status.AllocatedIPs = data
+		releaseTime := metav1.Now()
+		if err := recordReleasedIPs(ipPool, releasedIPs, releaseTime); err != nil {
+			return err
+		}
+		if err := recordCoolingIPs(ipPool, releasedIPs, releaseTime); err != nil {
+			return err
+		}
 
 		resourceVersion := ipPool.ResourceVersion
 		logger.With(zap.String("IPPool-ResourceVersion", resourceVersion)).
@@ -291,6 +484,14 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndUI
 }
 
 func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName, namespacedName string, ipAndUIDs []types.IPAndUID) error {
+	return im.TransferAllocatedIPs(ctx, poolName, namespacedName, namespacedName, ipAndUIDs)
+}
+
+// TransferAllocatedIPs records the IP addresses allocated to the Pod
+// namespacedName for the Pod newNamespacedName with the new UIDs, it is
+// used to hand over the sticky IP addresses of a replica slot to another
+// Pod. The IP addresses already recorded for the new Pod are skipped.
+func (im *ipPoolManager) TransferAllocatedIPs(ctx context.Context, poolName, namespacedName, newNamespacedName string, ipAndUIDs []types.IPAndUID) error {
 	logger := logutils.FromContext(ctx)
 
 	backoff := retry.DefaultRetry
@@ -314,11 +515,12 @@ func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName, names
 		recreate := false
 		for _, iu := range ipAndUIDs {
 			if record, ok := allocatedRecords[iu.IP]; ok {
-				if record.NamespacedName != namespacedName {
+				if record.NamespacedName != namespacedName && record.NamespacedName != newNamespacedName {
 					return fmt.Errorf("failed to update allocated IP because of data broken: IPPool %s IP %s allocation detail %v mistach namespacedName %s",
 						poolName, iu.IP, record, namespacedName)
 				}
-				if record.PodUID != iu.UID {
+				if record.NamespacedName != newNamespacedName || record.PodUID != iu.UID {
+					record.NamespacedName = newNamespacedName
 					record.PodUID = iu.UID
 					allocatedRecords[iu.IP] = record
 					recreate = true
@@ -330,7 +532,7 @@ func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName, names
 			return nil
 		}
 
-		data, err := convert.MarshalIPPoolAllocatedIPs(allocatedRecords)
+		data, err := im.marshalAllocatedIPs(allocatedRecords)
 		if err != nil {
 			return err
 		}
@@ -356,3 +558,14 @@ func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName, names
 
 	return nil
 }
+
+// marshalAllocatedIPs records the IP allocations of the IPPool in the compact
+// format if it is enabled, otherwise in JSON which all the versions of
+// spiderpool could parse.
+func (im *ipPoolManager) marshalAllocatedIPs(records spiderpoolv2beta1.PoolIPAllocations) (*string, error) {
+	if im.config.EnableCompactAllocatedIPs {
+		return convert.MarshalIPPoolAllocatedIPsCompact(records)
+	}
+
+	return convert.MarshalIPPoolAllocatedIPs(records)
+}
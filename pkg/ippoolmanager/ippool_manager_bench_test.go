@@ -0,0 +1,104 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	mock_reservedipmanager "github.com/spidernet-io/spiderpool/pkg/reservedipmanager/mock"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// BenchmarkGenIP measures the IP allocation of a /16 IPPool with 50k IP
+// addresses allocated, including the parse and the rewrite of the
+// allocation records in JSON and in the compact format.
+func BenchmarkGenIP(b *testing.B) {
+	for _, strategy := range []string{
+		constant.AllocationStrategyLowest,
+		constant.AllocationStrategyRandom,
+		constant.AllocationStrategyRoundRobin,
+		constant.AllocationStrategyLeastRecentlyReleased,
+	} {
+		for _, compact := range []bool{false, true} {
+			name := strategy + "/json"
+			if compact {
+				name = strategy + "/compact"
+			}
+			b.Run(name, func(b *testing.B) {
+				benchmarkGenIP(b, strategy, compact)
+			})
+		}
+	}
+}
+
+func benchmarkGenIP(b *testing.B, strategy string, compact bool) {
+	mockCtrl := gomock.NewController(b)
+	mockRIPManager := mock_reservedipmanager.NewMockReservedIPManager(mockCtrl)
+	mockRIPManager.EXPECT().
+		AssembleReservedIPs(gomock.Any(), gomock.Eq(constant.IPv4), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	im := &ipPoolManager{
+		config: setDefaultsForIPPoolManagerConfig(IPPoolManagerConfig{
+			MaxAllocatedIPs:           pointer.Int(65536),
+			EnableCompactAllocatedIPs: compact,
+		}),
+		rIPManager: mockRIPManager,
+	}
+
+	records := spiderpoolv2beta1.PoolIPAllocations{}
+	for i := 1; i <= 50000; i++ {
+		records[fmt.Sprintf("10.6.%d.%d", i/256, i%256)] = spiderpoolv2beta1.PoolIPAllocation{
+			NamespacedName: fmt.Sprintf("default/pod-%d", i),
+			PodUID:         string(uuid.NewUUID()),
+		}
+	}
+	allocatedIPs, err := im.marshalAllocatedIPs(records)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ipPool := &spiderpoolv2beta1.SpiderIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "benchmark"},
+		Spec: spiderpoolv2beta1.IPPoolSpec{
+			IPVersion:          pointer.Int64(constant.IPv4),
+			Subnet:             "10.6.0.0/16",
+			IPs:                []string{"10.6.0.1-10.6.255.254"},
+			AllocationStrategy: pointer.String(strategy),
+		},
+		Status: spiderpoolv2beta1.IPPoolStatus{
+			AllocatedIPs:     allocatedIPs,
+			AllocatedIPCount: pointer.Int64(int64(len(records))),
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "default",
+			UID:       uuid.NewUUID(),
+		},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tmpPool := ipPool.DeepCopy()
+		b.StartTimer()
+
+		if _, err := im.genIP(context.TODO(), tmpPool, pod, types.PodTopController{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
@@ -26,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
@@ -272,7 +275,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("failed to assemble the reserved IP addresses due to some unknown errors", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, constant.ErrUnknown).
 					Times(1)
 
@@ -291,7 +294,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("failed to update IPPool due to some unknown errors", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, nil).
 					Times(1)
 
@@ -313,7 +316,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("runs out of retries to update IPPool, but conflicts still occur", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, nil).
 					Times(5)
 
@@ -335,7 +338,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("allocate IP address with normal pod", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, nil).
 					Times(1)
 
@@ -367,7 +370,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("allocate IP address with kubevirt vm pod", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, nil).
 					Times(1)
 
@@ -411,7 +414,7 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 
 			It("allocate IP address from the previous records", func() {
 				mockRIPManager.EXPECT().
-					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4)).
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
 					Return(nil, nil).
 					Times(1)
 
@@ -462,6 +465,676 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 			})
 		})
 
+		Describe("AllocateIP with allocation strategy", func() {
+			var nic string
+
+			newPod := func() *corev1.Pod {
+				return &corev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Pod",
+						APIVersion: corev1.SchemeGroupVersion.String(),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("pod-%s", uuid.NewUUID()),
+						Namespace: "default",
+						UID:       uuid.NewUUID(),
+					},
+					Spec: corev1.PodSpec{},
+				}
+			}
+
+			createIPPool := func(records spiderpoolv2beta1.PoolIPAllocations) {
+				allocatedIPs, err := convert.MarshalIPPoolAllocatedIPs(records)
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.AllocatedIPs = allocatedIPs
+				ipPoolT.Status.AllocatedIPCount = pointer.Int64(int64(len(records)))
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			getIPPool := func() *spiderpoolv2beta1.SpiderIPPool {
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err := fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolName}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+
+				return &ipPool
+			}
+
+			// syncIPPool makes the IPPool updated through the client visible
+			// to the API reader.
+			syncIPPool := func() {
+				err := tracker.Update(
+					schema.GroupVersionResource{
+						Group:    constant.SpiderpoolAPIGroup,
+						Version:  constant.SpiderpoolAPIVersion,
+						Resource: "spiderippools",
+					},
+					getIPPool(),
+					"",
+				)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			allocateIP := func(pod *corev1.Pod) string {
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, pod, spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				syncIPPool()
+
+				return *res.Address
+			}
+
+			releaseIP := func(ip string, pod *corev1.Pod) {
+				err := ipPoolManager.ReleaseIP(ctx, ipPoolName, []spiderpooltypes.IPAndUID{{IP: ip, UID: string(pod.UID)}})
+				Expect(err).NotTo(HaveOccurred())
+				syncIPPool()
+			}
+
+			expectAssembleReservedIPs := func(times int) {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(times)
+			}
+
+			BeforeEach(func() {
+				nic = "eth0"
+				ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				ipPoolT.Spec.Subnet = "172.18.40.0/24"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.40-172.18.40.43")
+			})
+
+			It("allocates the lowest free IP address by default", func() {
+				expectAssembleReservedIPs(2)
+				createIPPool(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.40": {NamespacedName: "default/other", PodUID: string(uuid.NewUUID())},
+				})
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.42/24"))
+			})
+
+			It("reallocates the IP address just released with 'lowest' strategy", func() {
+				expectAssembleReservedIPs(2)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyLowest)
+				createIPPool(nil)
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.40/24"))
+				releaseIP("172.18.40.40", podT)
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.40/24"))
+			})
+
+			It("allocates random free IP addresses with 'random' strategy", func() {
+				expectAssembleReservedIPs(3)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyRandom)
+				ipPoolT.Spec.IPs = []string{"172.18.40.1-172.18.40.254"}
+				createIPPool(nil)
+
+				var allocatedIPs []string
+				for i := 0; i < 3; i++ {
+					ip := allocateIP(newPod())
+					contains, err := spiderpoolip.IPRangeContainsIP(constant.IPv4, "172.18.40.1-172.18.40.254", strings.Split(ip, "/")[0])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(contains).To(BeTrue())
+					allocatedIPs = append(allocatedIPs, ip)
+				}
+
+				Expect(allocatedIPs).NotTo(Equal([]string{"172.18.40.1/24", "172.18.40.2/24", "172.18.40.3/24"}))
+				Expect(*getIPPool().Status.AllocatedIPCount).To(Equal(int64(3)))
+			})
+
+			It("allocates the next free IP address after the last allocated one with 'round-robin' strategy", func() {
+				expectAssembleReservedIPs(3)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyRoundRobin)
+				ipPoolT.Status.LastAllocatedIP = pointer.String("172.18.40.41")
+				createIPPool(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.42": {NamespacedName: "default/other", PodUID: string(uuid.NewUUID())},
+				})
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.43/24"))
+				Expect(getIPPool().Status.LastAllocatedIP).To(Equal(pointer.String("172.18.40.43")))
+				releaseIP("172.18.40.43", podT)
+
+				// start over from the lowest one after reaching the end of the IPPool
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.40/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				Expect(getIPPool().Status.LastAllocatedIP).To(Equal(pointer.String("172.18.40.41")))
+			})
+
+			It("allocates the IP address never released firstly with 'least-recently-released' strategy", func() {
+				expectAssembleReservedIPs(1)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyLeastRecentlyReleased)
+				releasedIPs, err := convert.MarshalIPPoolReleasedIPs(spiderpoolv2beta1.PoolIPReleases{
+					"172.18.40.40": {ReleaseTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					"172.18.40.41": {ReleaseTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.ReleasedIPs = releasedIPs
+				createIPPool(nil)
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.42/24"))
+			})
+
+			It("allocates the IP address released earliest with 'least-recently-released' strategy", func() {
+				expectAssembleReservedIPs(4)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyLeastRecentlyReleased)
+				releasedIPs, err := convert.MarshalIPPoolReleasedIPs(spiderpoolv2beta1.PoolIPReleases{
+					"172.18.40.40": {ReleaseTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+					"172.18.40.41": {ReleaseTime: metav1.NewTime(time.Now().Add(-time.Hour))},
+					"172.18.40.42": {ReleaseTime: metav1.NewTime(time.Now().Add(-2 * time.Hour))},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.ReleasedIPs = releasedIPs
+				createIPPool(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.43": {NamespacedName: "default/other", PodUID: string(uuid.NewUUID())},
+				})
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.42/24"))
+				records, err := convert.UnmarshalIPPoolReleasedIPs(getIPPool().Status.ReleasedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).NotTo(HaveKey("172.18.40.42"))
+
+				// the IP address just released is the last one to be allocated
+				releaseIP("172.18.40.42", podT)
+				records, err = convert.UnmarshalIPPoolReleasedIPs(getIPPool().Status.ReleasedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveKey("172.18.40.42"))
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.40/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.42/24"))
+			})
+
+			It("does not allocate the IP address in quarantine", func() {
+				expectAssembleReservedIPs(2)
+				ipPoolT.Spec.QuarantineSeconds = pointer.Int64(600)
+				createIPPool(nil)
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.40/24"))
+				releaseIP("172.18.40.40", podT)
+				records, err := convert.UnmarshalIPPoolCoolingIPs(getIPPool().Status.CoolingIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveKey("172.18.40.40"))
+				Expect(records["172.18.40.40"].ExpireTime.Time).To(BeTemporally("~", time.Now().Add(600*time.Second), time.Minute))
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+			})
+
+			It("allocates the IP address whose quarantine has expired", func() {
+				expectAssembleReservedIPs(1)
+				ipPoolT.Spec.QuarantineSeconds = pointer.Int64(600)
+				coolingIPs, err := convert.MarshalIPPoolCoolingIPs(spiderpoolv2beta1.PoolIPCoolings{
+					"172.18.40.40": {ExpireTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+					"172.18.40.41": {ExpireTime: metav1.NewTime(time.Now().Add(time.Hour))},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.CoolingIPs = coolingIPs
+				createIPPool(nil)
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.40/24"))
+			})
+
+			It("fails to allocate IP address when all the free IP addresses are in quarantine", func() {
+				expectAssembleReservedIPs(1)
+				ipPoolT.Spec.QuarantineSeconds = pointer.Int64(600)
+				expireTime := metav1.NewTime(time.Now().Add(time.Hour))
+				coolingIPs, err := convert.MarshalIPPoolCoolingIPs(spiderpoolv2beta1.PoolIPCoolings{
+					"172.18.40.40": {ExpireTime: expireTime},
+					"172.18.40.41": {ExpireTime: expireTime},
+					"172.18.40.42": {ExpireTime: expireTime},
+					"172.18.40.43": {ExpireTime: expireTime},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.CoolingIPs = coolingIPs
+				createIPPool(nil)
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, newPod(), spiderpooltypes.PodTopController{})
+				Expect(err).To(MatchError(constant.ErrIPUsedOut))
+				Expect(res).To(BeNil())
+			})
+		})
+
+		Describe("AllocateIPFromBlock", func() {
+			var nic, nodeName string
+			var blockManager ippoolmanager.IPPoolManager
+
+			newPod := func() *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("pod-%s", uuid.NewUUID()),
+						Namespace: "default",
+						UID:       uuid.NewUUID(),
+					},
+					Spec: corev1.PodSpec{NodeName: nodeName},
+				}
+			}
+
+			createIPPool := func(records spiderpoolv2beta1.PoolIPAllocations, blocks spiderpoolv2beta1.PoolIPBlocks) {
+				allocatedIPs, err := convert.MarshalIPPoolAllocatedIPs(records)
+				Expect(err).NotTo(HaveOccurred())
+				ipBlocks, err := convert.MarshalIPPoolIPBlocks(blocks)
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.AllocatedIPs = allocatedIPs
+				ipPoolT.Status.AllocatedIPCount = pointer.Int64(int64(len(records)))
+				ipPoolT.Status.IPBlocks = ipBlocks
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			getIPPool := func() *spiderpoolv2beta1.SpiderIPPool {
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err := fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolName}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+
+				return &ipPool
+			}
+
+			// syncIPPool makes the IPPool updated through the client visible
+			// to the API reader.
+			syncIPPool := func() {
+				err := tracker.Update(
+					schema.GroupVersionResource{
+						Group:    constant.SpiderpoolAPIGroup,
+						Version:  constant.SpiderpoolAPIVersion,
+						Resource: "spiderippools",
+					},
+					getIPPool(),
+					"",
+				)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			allocateIP := func(pod *corev1.Pod) string {
+				res, err := blockManager.AllocateIPFromBlock(ctx, ipPoolName, nic, pod)
+				Expect(err).NotTo(HaveOccurred())
+				syncIPPool()
+
+				return *res.Address
+			}
+
+			expectAssembleReservedIPs := func(times int) {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(times)
+			}
+
+			BeforeEach(func() {
+				nic = "eth0"
+				nodeName = "node1"
+				ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				ipPoolT.Spec.Subnet = "172.18.40.0/24"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.40-172.18.40.43")
+
+				var err error
+				blockManager, err = ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{IPBlockSize: 2},
+					fakeClient,
+					fakeAPIReader,
+					mockRIPManager,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails to allocate IP address when the IP block is disabled", func() {
+				res, err := ipPoolManager.AllocateIPFromBlock(ctx, ipPoolName, nic, newPod())
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(res).To(BeNil())
+			})
+
+			It("leases the IP blocks and writes the allocations behind", func() {
+				expectAssembleReservedIPs(6)
+				createIPPool(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.40": {NamespacedName: "default/other", PodUID: string(uuid.NewUUID())},
+				}, nil)
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.42/24"))
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.43/24"))
+
+				blocks, err := convert.UnmarshalIPPoolIPBlocks(getIPPool().Status.IPBlocks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blocks).To(HaveKey(nodeName))
+				Expect(blocks[nodeName].IPs).To(Equal([]string{"172.18.40.41-172.18.40.43"}))
+
+				// the allocations are kept in memory until flushed
+				Expect(*getIPPool().Status.AllocatedIPCount).To(Equal(int64(1)))
+				err = blockManager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPool := getIPPool()
+				records, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(4))
+				Expect(*ipPool.Status.AllocatedIPCount).To(Equal(int64(4)))
+			})
+
+			It("allocates IP address from the IPPool whose allocated IP count is not synced", func() {
+				expectAssembleReservedIPs(2)
+				ipPoolT.Status.AllocatedIPCount = nil
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.40/24"))
+			})
+
+			It("exposes the allocations not written yet", func() {
+				expectAssembleReservedIPs(2)
+				createIPPool(nil, nil)
+
+				pod := newPod()
+				Expect(allocateIP(pod)).To(Equal("172.18.40.40/24"))
+				record, ok := blockManager.GetPendingIPBlockAllocation(ipPoolName, "172.18.40.40")
+				Expect(ok).To(BeTrue())
+				Expect(record.PodUID).To(Equal(string(pod.UID)))
+
+				err := blockManager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				_, ok = blockManager.GetPendingIPBlockAllocation(ipPoolName, "172.18.40.40")
+				Expect(ok).To(BeFalse())
+			})
+
+			It("allocates IP address from the IP block with the allocation strategy", func() {
+				expectAssembleReservedIPs(3)
+				ipPoolT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyRoundRobin)
+				createIPPool(nil, nil)
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.40/24"))
+				err := blockManager.ReleaseIP(ctx, ipPoolName, []spiderpooltypes.IPAndUID{{IP: "172.18.40.40", UID: string(podT.UID)}})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				err = blockManager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getIPPool().Status.LastAllocatedIP).To(Equal(pointer.String("172.18.40.41")))
+			})
+
+			It("requests the other nodes to return their free IP addresses when the IPPool is used out", func() {
+				expectAssembleReservedIPs(3)
+				createIPPool(spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.40": {NamespacedName: "default/other", PodUID: string(uuid.NewUUID())},
+				}, spiderpoolv2beta1.PoolIPBlocks{
+					"node2": {IPs: []string{"172.18.40.40-172.18.40.43"}, LeaseTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+				})
+
+				res, err := blockManager.AllocateIPFromBlock(ctx, ipPoolName, nic, newPod())
+				Expect(err).To(MatchError(constant.ErrIPUsedOut))
+				Expect(res).To(BeNil())
+				syncIPPool()
+
+				blocks, err := convert.UnmarshalIPPoolIPBlocks(getIPPool().Status.IPBlocks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blocks).To(HaveKey(nodeName))
+				Expect(blocks[nodeName].ReturnRequestTime).NotTo(BeNil())
+
+				node2Manager, err := ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{IPBlockSize: 2, NodeName: "node2"},
+					fakeClient,
+					fakeAPIReader,
+					mockRIPManager,
+				)
+				Expect(err).NotTo(HaveOccurred())
+				err = node2Manager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				syncIPPool()
+
+				blocks, err = convert.UnmarshalIPPoolIPBlocks(getIPPool().Status.IPBlocks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blocks).To(HaveKey("node2"))
+				Expect(blocks["node2"].IPs).To(Equal([]string{"172.18.40.40"}))
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				blocks, err = convert.UnmarshalIPPoolIPBlocks(getIPPool().Status.IPBlocks)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(blocks[nodeName].ReturnRequestTime).To(BeNil())
+			})
+
+			It("does not allocate the IP addresses leased to other nodes", func() {
+				expectAssembleReservedIPs(1)
+				createIPPool(nil, spiderpoolv2beta1.PoolIPBlocks{
+					"node2": {IPs: []string{"172.18.40.40-172.18.40.41"}, LeaseTime: metav1.Now()},
+				})
+
+				res, err := ipPoolManager.AllocateIP(ctx, ipPoolName, nic, newPod(), spiderpooltypes.PodTopController{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Address).To(Equal("172.18.40.42/24"))
+			})
+
+			It("drops the allocations not written yet when releasing", func() {
+				expectAssembleReservedIPs(2)
+				createIPPool(nil, nil)
+
+				podT := newPod()
+				Expect(allocateIP(podT)).To(Equal("172.18.40.40/24"))
+				err := blockManager.ReleaseIP(ctx, ipPoolName, []spiderpooltypes.IPAndUID{{IP: "172.18.40.40", UID: string(podT.UID)}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = blockManager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				records, err := convert.UnmarshalIPPoolAllocatedIPs(getIPPool().Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+
+			It("recovers the allocations not written from the Endpoints", func() {
+				expectAssembleReservedIPs(1)
+				createIPPool(nil, spiderpoolv2beta1.PoolIPBlocks{
+					nodeName: {IPs: []string{"172.18.40.40-172.18.40.41"}, LeaseTime: metav1.Now()},
+				})
+
+				endpoint := &spiderpoolv2beta1.SpiderEndpoint{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "recovered",
+						Namespace: "default",
+					},
+					Status: spiderpoolv2beta1.WorkloadEndpointStatus{
+						Current: spiderpoolv2beta1.PodIPAllocation{
+							UID:  string(uuid.NewUUID()),
+							Node: nodeName,
+							IPs: []spiderpoolv2beta1.IPAllocationDetail{{
+								NIC:      nic,
+								IPv4:     pointer.String("172.18.40.40/24"),
+								IPv4Pool: pointer.String(ipPoolName),
+							}},
+						},
+					},
+				}
+				err := fakeClient.Create(ctx, endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				defer func() {
+					err := fakeClient.Delete(ctx, endpoint)
+					Expect(err).NotTo(HaveOccurred())
+				}()
+
+				Expect(allocateIP(newPod())).To(Equal("172.18.40.41/24"))
+				err = blockManager.FlushIPBlockAllocations(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				records, err := convert.UnmarshalIPPoolAllocatedIPs(getIPPool().Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveKey("172.18.40.40"))
+				Expect(records["172.18.40.40"].NamespacedName).To(Equal("default/recovered"))
+				Expect(records).To(HaveKey("172.18.40.41"))
+			})
+		})
+
+		Describe("AssignIP", func() {
+			var nic string
+			var podT *corev1.Pod
+
+			BeforeEach(func() {
+				nic = "eth0"
+				podT = &corev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Pod",
+						APIVersion: corev1.SchemeGroupVersion.String(),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "pod",
+						Namespace: "default",
+						UID:       uuid.NewUUID(),
+					},
+					Spec: corev1.PodSpec{},
+				}
+
+				ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				ipPoolT.Spec.Subnet = "172.18.40.0/24"
+				ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.40-172.18.40.41")
+			})
+
+			It("assigns IP address from non-existent IPPool", func() {
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.40", nic, podT)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(res).To(BeNil())
+			})
+
+			It("assigns IP address out of the IPPool", func() {
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.50", nic, podT)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(res).To(BeNil())
+			})
+
+			It("assigns reserved IP address", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return([]net.IP{net.ParseIP("172.18.40.40")}, nil).
+					Times(1)
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.40", nic, podT)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(res).To(BeNil())
+			})
+
+			It("assigns IP address leased to a node as IP block", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+
+				ipBlocks, err := convert.MarshalIPPoolIPBlocks(spiderpoolv2beta1.PoolIPBlocks{
+					"node1": {IPs: []string{"172.18.40.40"}, LeaseTime: metav1.Now()},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.IPBlocks = ipBlocks
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.40", nic, podT)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(res).To(BeNil())
+			})
+
+			It("assigns IP address taken by another Pod", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+
+				records := spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.40": spiderpoolv2beta1.PoolIPAllocation{
+						NamespacedName: "default/other",
+						PodUID:         string(uuid.NewUUID()),
+					},
+				}
+				allocatedIPs, err := json.Marshal(records)
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Status.AllocatedIPs = pointer.String(string(allocatedIPs))
+				ipPoolT.Status.AllocatedIPCount = pointer.Int64(1)
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.40", nic, podT)
+				Expect(err).To(HaveOccurred())
+				Expect(res).To(BeNil())
+			})
+
+			It("assigns the specified IP address", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.41", nic, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Nic).To(Equal(nic))
+				Expect(*res.Address).To(Equal("172.18.40.41/24"))
+				Expect(res.IPPool).To(Equal(ipPoolName))
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolName}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipPool.Status.AllocatedIPCount).To(Equal(int64(1)))
+
+				records, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveKeyWithValue("172.18.40.41", spiderpoolv2beta1.PoolIPAllocation{
+					NamespacedName: "default/pod",
+					PodUID:         string(podT.UID),
+				}))
+			})
+
+			It("assigns the IP address in quarantine", func() {
+				mockRIPManager.EXPECT().
+					AssembleReservedIPs(gomock.Eq(ctx), gomock.Eq(constant.IPv4), gomock.Any()).
+					Return(nil, nil).
+					Times(1)
+
+				coolingIPs, err := convert.MarshalIPPoolCoolingIPs(spiderpoolv2beta1.PoolIPCoolings{
+					"172.18.40.41": {ExpireTime: metav1.NewTime(time.Now().Add(time.Hour))},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				ipPoolT.Spec.QuarantineSeconds = pointer.Int64(3600)
+				ipPoolT.Status.CoolingIPs = coolingIPs
+
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := ipPoolManager.AssignIP(ctx, ipPoolName, "172.18.40.41", nic, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*res.Address).To(Equal("172.18.40.41/24"))
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolName}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.CoolingIPs).To(BeNil())
+			})
+		})
+
 		Describe("ReleaseIP", func() {
 			var ip string
 			var uid string
@@ -550,6 +1223,42 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(newRecords).To(BeEmpty())
 			})
+
+			It("converts the IP records in JSON into the compact format", func() {
+				compactManager, err := ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{EnableCompactAllocatedIPs: true},
+					fakeClient,
+					fakeAPIReader,
+					mockRIPManager,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				remaining := spiderpoolv2beta1.PoolIPAllocation{
+					NamespacedName: "default/pod-1",
+					PodUID:         string(uuid.NewUUID()),
+				}
+				records["172.18.40.41"] = remaining
+				data, err := convert.MarshalIPPoolAllocatedIPs(records)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Status.AllocatedIPs = data
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = compactManager.ReleaseIP(ctx, ipPoolName, []spiderpooltypes.IPAndUID{{IP: ip, UID: uid}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolT.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipPool.Status.AllocatedIPs).To(HavePrefix(convert.CompactAllocatedIPsPrefix))
+
+				newRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newRecords).To(Equal(spiderpoolv2beta1.PoolIPAllocations{"172.18.40.41": remaining}))
+			})
 		})
 
 		Describe("UpdateAllocatedIPs", func() {
@@ -658,6 +1367,30 @@ var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(newRecords[ip].PodUID).To(Equal(newUID))
 			})
+
+			It("transfers the allocated IP record to another Pod", func() {
+				data, err := convert.MarshalIPPoolAllocatedIPs(records)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Status.AllocatedIPs = data
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				newUID := string(uuid.NewUUID())
+				err = ipPoolManager.TransferAllocatedIPs(ctx, ipPoolName, "default/pod", "default/new-pod", []spiderpooltypes.IPAndUID{{IP: ip, UID: newUID}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv2beta1.SpiderIPPool
+				err = fakeClient.Get(ctx, types.NamespacedName{Name: ipPoolT.Name}, &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+
+				newRecords, err := convert.UnmarshalIPPoolAllocatedIPs(ipPool.Status.AllocatedIPs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newRecords[ip].NamespacedName).To(Equal("default/new-pod"))
+				Expect(newRecords[ip].PodUID).To(Equal(newUID))
+			})
 		})
 	})
 })
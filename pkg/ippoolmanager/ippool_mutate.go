@@ -154,4 +154,16 @@ func InheritSubnetProperties(subnet *spiderpoolv2beta1.SpiderSubnet, ipPool *spi
 		copy(routes, subnet.Spec.Routes)
 		ipPool.Spec.Routes = routes
 	}
+
+	if subnet.Spec.AllocationStrategy != nil && ipPool.Spec.AllocationStrategy == nil {
+		ipPool.Spec.AllocationStrategy = pointer.String(*subnet.Spec.AllocationStrategy)
+	}
+
+	if subnet.Spec.QuarantineSeconds != nil && ipPool.Spec.QuarantineSeconds == nil {
+		ipPool.Spec.QuarantineSeconds = pointer.Int64(*subnet.Spec.QuarantineSeconds)
+	}
+
+	if subnet.Spec.DNS != nil && ipPool.Spec.DNS == nil {
+		ipPool.Spec.DNS = subnet.Spec.DNS.DeepCopy()
+	}
 }
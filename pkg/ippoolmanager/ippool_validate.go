@@ -28,6 +28,11 @@ var (
 	gatewayField     *field.Path = field.NewPath("spec").Child("gateway")
 	routesField      *field.Path = field.NewPath("spec").Child("routes")
 	podAffinityField *field.Path = field.NewPath("spec").Child("podAffinity")
+
+	allocationStrategyField *field.Path = field.NewPath("spec").Child("allocationStrategy")
+	quarantineSecondsField  *field.Path = field.NewPath("spec").Child("quarantineSeconds")
+	dnsField                *field.Path = field.NewPath("spec").Child("dns")
+	usageThresholdsField    *field.Path = field.NewPath("spec").Child("usageThresholds")
 )
 
 func (iw *IPPoolWebhook) validateCreateIPPool(ctx context.Context, ipPool *spiderpoolv2beta1.SpiderIPPool) field.ErrorList {
@@ -112,6 +117,18 @@ func (iw *IPPoolWebhook) validateIPPoolSpec(ctx context.Context, ipPool *spiderp
 	if err := validateIPPoolGateway(ipPool); err != nil {
 		return err
 	}
+	if err := ValidateAllocationStrategy(allocationStrategyField, ipPool.Spec.AllocationStrategy); err != nil {
+		return err
+	}
+	if err := ValidateQuarantineSeconds(quarantineSecondsField, ipPool.Spec.QuarantineSeconds); err != nil {
+		return err
+	}
+	if err := ValidateDNS(dnsField, ipPool.Spec.DNS); err != nil {
+		return err
+	}
+	if err := ValidateUsageThresholds(usageThresholdsField, ipPool.Spec.UsageThresholds); err != nil {
+		return err
+	}
 
 	return validateIPPoolRoutes(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.Routes)
 }
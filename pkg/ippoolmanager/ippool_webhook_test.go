@@ -548,6 +548,12 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 						Gw:  "172.18.50.0",
 					},
 				}
+				subnetT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyRoundRobin)
+				subnetT.Spec.QuarantineSeconds = pointer.Int64(300)
+				subnetT.Spec.DNS = &spiderpoolv2beta1.DNS{
+					Nameservers: []string{"172.18.50.53"},
+					Search:      []string{"vlan50.example.com"},
+				}
 
 				err = fakeClient.Create(ctx, subnetT)
 				Expect(err).NotTo(HaveOccurred())
@@ -565,6 +571,9 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 
 				Expect(ipPoolT.Spec.Gateway).To(Equal(subnetT.Spec.Gateway))
 				Expect(ipPoolT.Spec.Routes).To(Equal(subnetT.Spec.Routes))
+				Expect(ipPoolT.Spec.AllocationStrategy).To(Equal(subnetT.Spec.AllocationStrategy))
+				Expect(ipPoolT.Spec.QuarantineSeconds).To(Equal(subnetT.Spec.QuarantineSeconds))
+				Expect(ipPoolT.Spec.DNS).To(Equal(subnetT.Spec.DNS))
 			})
 		})
 
@@ -963,6 +972,121 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'spec.allocationStrategy'", func() {
+				It("inputs unsupported allocation strategy", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.AllocationStrategy = pointer.String("highest")
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				DescribeTable("inputs supported allocation strategy",
+					func(strategy string) {
+						ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+						ipPoolT.Spec.Subnet = "172.18.40.0/24"
+						ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+						ipPoolT.Spec.AllocationStrategy = pointer.String(strategy)
+
+						warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(warns).To(BeNil())
+					},
+					Entry("lowest", constant.AllocationStrategyLowest),
+					Entry("random", constant.AllocationStrategyRandom),
+					Entry("round-robin", constant.AllocationStrategyRoundRobin),
+					Entry("least-recently-released", constant.AllocationStrategyLeastRecentlyReleased),
+				)
+			})
+
+			When("Validating 'spec.quarantineSeconds'", func() {
+				It("inputs negative quarantine seconds", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.QuarantineSeconds = pointer.Int64(-1)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs valid quarantine seconds", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.QuarantineSeconds = pointer.Int64(300)
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.dns'", func() {
+				It("inputs invalid nameserver", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.DNS = &spiderpoolv2beta1.DNS{
+						Nameservers: []string{"dns.example.com"},
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs valid DNS", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.DNS = &spiderpoolv2beta1.DNS{
+						Nameservers: []string{"172.18.40.53", "abcd:1234::53"},
+						Domain:      "example.com",
+						Search:      []string{"vlan40.example.com"},
+						Options:     []string{"ndots:2"},
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.usageThresholds'", func() {
+				It("inputs warning threshold greater than critical threshold", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.UsageThresholds = &spiderpoolv2beta1.UsageThresholds{
+						Warning:  pointer.Int64(90),
+						Critical: pointer.Int64(80),
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs valid usage thresholds", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					ipPoolT.Spec.UsageThresholds = &spiderpoolv2beta1.UsageThresholds{
+						Warning:  pointer.Int64(80),
+						Critical: pointer.Int64(90),
+					}
+
+					warns, err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			When("Validating 'spec.routes'", func() {
 				It("inputs default route", func() {
 					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
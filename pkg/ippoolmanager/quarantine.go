@@ -0,0 +1,144 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"net"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+// QuarantineDuration returns how long the released IP addresses of the
+// IPPool stay cooling before they could be allocated again.
+func QuarantineDuration(ipPool *spiderpoolv2beta1.SpiderIPPool) time.Duration {
+	if ipPool.Spec.QuarantineSeconds == nil || *ipPool.Spec.QuarantineSeconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(*ipPool.Spec.QuarantineSeconds) * time.Second
+}
+
+// ValidateQuarantineSeconds checks whether the quarantine period is
+// non-negative.
+func ValidateQuarantineSeconds(fieldPath *field.Path, seconds *int64) *field.Error {
+	if seconds != nil && *seconds < 0 {
+		return field.Invalid(
+			fieldPath,
+			*seconds,
+			"must not be negative",
+		)
+	}
+
+	return nil
+}
+
+// getCoolingIPs returns the IP addresses of the IPPool which are still in
+// quarantine at the given time.
+func getCoolingIPs(ipPool *spiderpoolv2beta1.SpiderIPPool, now time.Time) ([]string, error) {
+	coolingRecords, err := convert.UnmarshalIPPoolCoolingIPs(ipPool.Status.CoolingIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	var coolingIPs []string
+	for ip, record := range coolingRecords {
+		if record.ExpireTime.Time.After(now) {
+			coolingIPs = append(coolingIPs, ip)
+		}
+	}
+
+	return coolingIPs, nil
+}
+
+// recordCoolingIPs puts the released IP addresses into quarantine if the
+// IPPool has a quarantine period.
+func recordCoolingIPs(ipPool *spiderpoolv2beta1.SpiderIPPool, ips []string, releaseTime metav1.Time) error {
+	quarantine := QuarantineDuration(ipPool)
+	if quarantine == 0 {
+		return nil
+	}
+
+	coolingRecords, err := convert.UnmarshalIPPoolCoolingIPs(ipPool.Status.CoolingIPs)
+	if err != nil {
+		return err
+	}
+	if coolingRecords == nil {
+		coolingRecords = spiderpoolv2beta1.PoolIPCoolings{}
+	}
+	expireTime := metav1.NewTime(releaseTime.Add(quarantine))
+	for _, ip := range ips {
+		coolingRecords[ip] = spiderpoolv2beta1.PoolIPCooling{ExpireTime: expireTime}
+	}
+
+	data, err := convert.MarshalIPPoolCoolingIPs(coolingRecords)
+	if err != nil {
+		return err
+	}
+	ipPool.Status.CoolingIPs = data
+
+	return nil
+}
+
+// removeCoolingIP takes the IP address out of quarantine, it is used when
+// the IP address is assigned explicitly.
+func removeCoolingIP(ipPool *spiderpoolv2beta1.SpiderIPPool, ip net.IP) error {
+	coolingRecords, err := convert.UnmarshalIPPoolCoolingIPs(ipPool.Status.CoolingIPs)
+	if err != nil {
+		return err
+	}
+	if _, ok := coolingRecords[ip.String()]; !ok {
+		return nil
+	}
+
+	delete(coolingRecords, ip.String())
+	data, err := convert.MarshalIPPoolCoolingIPs(coolingRecords)
+	if err != nil {
+		return err
+	}
+	ipPool.Status.CoolingIPs = data
+
+	return nil
+}
+
+// ExpireCoolingIPs removes the records of the IP addresses whose quarantine
+// has expired at the given time. It returns whether the IPPool is changed,
+// the number of IP addresses still cooling and how long until the next one
+// expires, which is 0 if none is cooling.
+func ExpireCoolingIPs(ipPool *spiderpoolv2beta1.SpiderIPPool, now time.Time) (changed bool, coolingCount int, nextExpiry time.Duration, err error) {
+	coolingRecords, err := convert.UnmarshalIPPoolCoolingIPs(ipPool.Status.CoolingIPs)
+	if err != nil {
+		return false, 0, 0, err
+	}
+
+	for ip, record := range coolingRecords {
+		remaining := record.ExpireTime.Time.Sub(now)
+		if remaining <= 0 {
+			delete(coolingRecords, ip)
+			changed = true
+			continue
+		}
+
+		coolingCount++
+		if nextExpiry == 0 || remaining < nextExpiry {
+			nextExpiry = remaining
+		}
+	}
+
+	if !changed {
+		return false, coolingCount, nextExpiry, nil
+	}
+
+	data, err := convert.MarshalIPPoolCoolingIPs(coolingRecords)
+	if err != nil {
+		return false, 0, 0, err
+	}
+	ipPool.Status.CoolingIPs = data
+
+	return true, coolingCount, nextExpiry, nil
+}
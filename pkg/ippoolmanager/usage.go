@@ -0,0 +1,152 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// UsageLevel is how close the IP addresses are to being exhausted by the
+// usage thresholds.
+type UsageLevel int64
+
+const (
+	UsageLevelNormal UsageLevel = iota
+	UsageLevelWarning
+	UsageLevelCritical
+)
+
+func (l UsageLevel) String() string {
+	switch l {
+	case UsageLevelWarning:
+		return "warning"
+	case UsageLevelCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+// GetUsageLevel returns the usage level of the allocated IP addresses in all
+// the IP addresses, a threshold is reached once the usage is not below it.
+func GetUsageLevel(thresholds *spiderpoolv2beta1.UsageThresholds, allocated, total int64) UsageLevel {
+	if thresholds == nil || total <= 0 {
+		return UsageLevelNormal
+	}
+
+	reached := func(threshold *int64) bool {
+		return threshold != nil && allocated*100 >= *threshold*total
+	}
+	if reached(thresholds.Critical) {
+		return UsageLevelCritical
+	}
+	if reached(thresholds.Warning) {
+		return UsageLevelWarning
+	}
+
+	return UsageLevelNormal
+}
+
+// UsageLevelOf returns the usage level recorded by the NearlyExhausted
+// condition.
+func UsageLevelOf(conditions []metav1.Condition) UsageLevel {
+	condition := meta.FindStatusCondition(conditions, constant.ConditionNearlyExhausted)
+	if condition == nil {
+		return UsageLevelNormal
+	}
+
+	switch condition.Reason {
+	case constant.ConditionReasonCriticalThresholdReached:
+		return UsageLevelCritical
+	case constant.ConditionReasonWarningThresholdReached:
+		return UsageLevelWarning
+	default:
+		return UsageLevelNormal
+	}
+}
+
+// SetUsageCondition sets the NearlyExhausted condition by the usage of the
+// IP addresses, the condition is removed if there is no usage threshold.
+// The condition only tells the usage level rather than the IP counts, so it
+// is not rewritten by each allocation. It returns whether the conditions are
+// changed.
+func SetUsageCondition(conditions *[]metav1.Condition, thresholds *spiderpoolv2beta1.UsageThresholds, allocated, total, generation int64) bool {
+	oldConditions := make([]metav1.Condition, len(*conditions))
+	copy(oldConditions, *conditions)
+
+	if thresholds == nil || (thresholds.Warning == nil && thresholds.Critical == nil) {
+		meta.RemoveStatusCondition(conditions, constant.ConditionNearlyExhausted)
+		return len(oldConditions) != len(*conditions)
+	}
+
+	condition := metav1.Condition{
+		Type:               constant.ConditionNearlyExhausted,
+		Status:             metav1.ConditionFalse,
+		Reason:             constant.ConditionReasonBelowThresholds,
+		Message:            "The usage of IP addresses is below the thresholds",
+		ObservedGeneration: generation,
+	}
+	switch GetUsageLevel(thresholds, allocated, total) {
+	case UsageLevelCritical:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = constant.ConditionReasonCriticalThresholdReached
+		condition.Message = fmt.Sprintf("The usage of IP addresses reaches the critical threshold %d%%", *thresholds.Critical)
+	case UsageLevelWarning:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = constant.ConditionReasonWarningThresholdReached
+		condition.Message = fmt.Sprintf("The usage of IP addresses reaches the warning threshold %d%%", *thresholds.Warning)
+	}
+	meta.SetStatusCondition(conditions, condition)
+
+	return !reflect.DeepEqual(oldConditions, *conditions)
+}
+
+// RecordUsageEvent emits a Warning event once the usage level rises, and a
+// Normal one once it drops.
+func RecordUsageEvent(obj runtime.Object, conditions []metav1.Condition, oldLevel, newLevel UsageLevel) {
+	if oldLevel == newLevel {
+		return
+	}
+
+	message := fmt.Sprintf("Usage level changes from %s to %s", oldLevel, newLevel)
+	if condition := meta.FindStatusCondition(conditions, constant.ConditionNearlyExhausted); condition != nil {
+		message += ": " + condition.Message
+	}
+
+	if newLevel > oldLevel {
+		event.EventRecorder.Event(obj, corev1.EventTypeWarning, constant.EventReasonNearlyExhausted, message)
+		return
+	}
+
+	event.EventRecorder.Event(obj, corev1.EventTypeNormal, constant.EventReasonUsageRecovered, message)
+}
+
+// ValidateUsageThresholds checks whether the warning threshold is not above
+// the critical one.
+func ValidateUsageThresholds(fieldPath *field.Path, thresholds *spiderpoolv2beta1.UsageThresholds) *field.Error {
+	if thresholds == nil || thresholds.Warning == nil || thresholds.Critical == nil {
+		return nil
+	}
+
+	if *thresholds.Warning > *thresholds.Critical {
+		return field.Invalid(
+			fieldPath.Child("warning"),
+			*thresholds.Warning,
+			fmt.Sprintf("must not be greater than the critical threshold %d", *thresholds.Critical),
+		)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@
 package ippoolmanager
 
 import (
+	"math"
 	"sort"
 	"strings"
 
@@ -70,6 +71,8 @@ func IsMatchAutoPoolAffinity(podAffinity *metav1.LabelSelector, podTopController
 // ByPoolPriority implements sort.Interface
 var _ sort.Interface = &ByPoolPriority{}
 
+// ByPoolPriority orders the IPPools by the priority first, and then by how
+// specific their affinities are.
 type ByPoolPriority []*spiderpoolv2beta1.SpiderIPPool
 
 func (b ByPoolPriority) Len() int { return len(b) }
@@ -77,6 +80,11 @@ func (b ByPoolPriority) Len() int { return len(b) }
 func (b ByPoolPriority) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
 
 func (b ByPoolPriority) Less(i, j int) bool {
+	// Priority
+	if pi, pj := GetPoolPriority(b[i]), GetPoolPriority(b[j]); pi != pj {
+		return pi > pj
+	}
+
 	// Pod Affinity
 	if b[i].Spec.PodAffinity != nil && b[j].Spec.PodAffinity == nil {
 		return true
@@ -124,6 +132,64 @@ func (b ByPoolPriority) Less(i, j int) bool {
 	return false
 }
 
+// ByPoolSpreading implements sort.Interface
+var _ sort.Interface = &ByPoolSpreading{}
+
+// ByPoolSpreading orders the IPPools as ByPoolPriority does, and the ones
+// ordered equally are spread by the weighted usage, the least utilized
+// one goes first. So that the IP allocations are shared by the IPPools in
+// proportion to their weights.
+type ByPoolSpreading []*spiderpoolv2beta1.SpiderIPPool
+
+func (b ByPoolSpreading) Len() int { return len(b) }
+
+func (b ByPoolSpreading) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+
+func (b ByPoolSpreading) Less(i, j int) bool {
+	if ByPoolPriority(b).Less(i, j) {
+		return true
+	}
+	if ByPoolPriority(b).Less(j, i) {
+		return false
+	}
+
+	return GetPoolWeightedUsage(b[i]) < GetPoolWeightedUsage(b[j])
+}
+
+// GetPoolPriority returns the priority of the IPPool, it defaults to 0.
+func GetPoolPriority(pool *spiderpoolv2beta1.SpiderIPPool) int64 {
+	if pool.Spec.Priority == nil {
+		return 0
+	}
+
+	return *pool.Spec.Priority
+}
+
+// GetPoolWeight returns the weight of the IPPool, it defaults to 1.
+func GetPoolWeight(pool *spiderpoolv2beta1.SpiderIPPool) int64 {
+	if pool.Spec.Weight == nil || *pool.Spec.Weight < 1 {
+		return 1
+	}
+
+	return *pool.Spec.Weight
+}
+
+// GetPoolWeightedUsage returns the usage of the IP addresses of the IPPool
+// divided by its weight. The IPPool without any IP address is regarded as
+// fully used.
+func GetPoolWeightedUsage(pool *spiderpoolv2beta1.SpiderIPPool) float64 {
+	if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount <= 0 {
+		return math.MaxFloat64
+	}
+
+	var allocated int64
+	if pool.Status.AllocatedIPCount != nil {
+		allocated = *pool.Status.AllocatedIPCount
+	}
+
+	return float64(allocated) / float64(*pool.Status.TotalIPCount) / float64(GetPoolWeight(pool))
+}
+
 // findAllocatedIPFromRecords try to find pod NIC previous allocated IP from the IPPool.Status.AllocatedIPs
 // this function serves for the issue: https://github.com/spidernet-io/spiderpool/issues/2517
 func findAllocatedIPFromRecords(allocatedRecords spiderpoolv2beta1.PoolIPAllocations, namespacedName, podUID string) (previousIP string, hasFound bool) {
@@ -12,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types2 "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/pointer"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
@@ -290,6 +291,91 @@ var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
 			sort.Sort(byPoolPriority)
 			Expect(byPoolPriority).Should(Equal(ByPoolPriority{pool2, pool1}))
 		})
+
+		It("pool priority takes precedence over affinities", func() {
+			pool1 := poolTemplate.DeepCopy()
+			pool1.SetName("pool1")
+			pool1.Spec.PodAffinity = &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"PodAffinityKey": "PodAffinityValue1",
+				},
+			}
+
+			pool2 := poolTemplate.DeepCopy()
+			pool2.SetName("pool2")
+			pool2.Spec.Priority = pointer.Int64(10)
+
+			pool3 := poolTemplate.DeepCopy()
+			pool3.SetName("pool3")
+			pool3.Spec.Priority = pointer.Int64(-1)
+
+			byPoolPriority := ByPoolPriority{pool3, pool1, pool2}
+			sort.Sort(byPoolPriority)
+			Expect(byPoolPriority).Should(Equal(ByPoolPriority{pool2, pool1, pool3}))
+		})
+	})
+
+	Context("Test IPAM pool candidates spreading", func() {
+		newPool := func(name string, weight, allocated, total int64) *spiderpoolv2beta1.SpiderIPPool {
+			pool := &spiderpoolv2beta1.SpiderIPPool{}
+			pool.SetName(name)
+			if weight > 0 {
+				pool.Spec.Weight = pointer.Int64(weight)
+			}
+			pool.Status.AllocatedIPCount = pointer.Int64(allocated)
+			pool.Status.TotalIPCount = pointer.Int64(total)
+
+			return pool
+		}
+
+		It("least utilized first", func() {
+			pool1 := newPool("pool1", 0, 8, 10)
+			pool2 := newPool("pool2", 0, 2, 10)
+			pool3 := newPool("pool3", 0, 10, 100)
+
+			byPoolSpreading := ByPoolSpreading{pool1, pool2, pool3}
+			sort.Stable(byPoolSpreading)
+			Expect(byPoolSpreading).Should(Equal(ByPoolSpreading{pool3, pool2, pool1}))
+		})
+
+		It("fill by weight", func() {
+			pool1 := newPool("pool1", 1, 3, 10)
+			pool2 := newPool("pool2", 3, 6, 10)
+
+			byPoolSpreading := ByPoolSpreading{pool1, pool2}
+			sort.Stable(byPoolSpreading)
+			Expect(byPoolSpreading).Should(Equal(ByPoolSpreading{pool2, pool1}))
+		})
+
+		It("keep the sequence of the equally utilized pools", func() {
+			pool1 := newPool("pool1", 0, 5, 10)
+			pool2 := newPool("pool2", 0, 5, 10)
+
+			byPoolSpreading := ByPoolSpreading{pool2, pool1}
+			sort.Stable(byPoolSpreading)
+			Expect(byPoolSpreading).Should(Equal(ByPoolSpreading{pool2, pool1}))
+		})
+
+		It("pool without IP addresses goes last", func() {
+			pool1 := newPool("pool1", 0, 0, 0)
+			pool2 := newPool("pool2", 0, 9, 10)
+
+			byPoolSpreading := ByPoolSpreading{pool1, pool2}
+			sort.Stable(byPoolSpreading)
+			Expect(byPoolSpreading).Should(Equal(ByPoolSpreading{pool2, pool1}))
+		})
+
+		It("priority and affinities take precedence over usage", func() {
+			pool1 := newPool("pool1", 0, 9, 10)
+			pool1.Spec.Priority = pointer.Int64(1)
+			pool2 := newPool("pool2", 0, 8, 10)
+			pool2.Spec.NodeName = []string{"master"}
+			pool3 := newPool("pool3", 0, 1, 10)
+
+			byPoolSpreading := ByPoolSpreading{pool3, pool2, pool1}
+			sort.Stable(byPoolSpreading)
+			Expect(byPoolSpreading).Should(Equal(ByPoolSpreading{pool1, pool2, pool3}))
+		})
 	})
 
 	Context("Test findAllocatedIPFromRecords", func() {
@@ -328,4 +414,23 @@ var _ = Describe("IPPoolManager-utils", Label("ippool_manager_utils"), func() {
 			Expect(hasFound).To(BeFalse())
 		})
 	})
+
+	Context("GetUsageLevel", Labels{"unittest", "GetUsageLevel"}, func() {
+		thresholds := &spiderpoolv2beta1.UsageThresholds{
+			Warning:  pointer.Int64(80),
+			Critical: pointer.Int64(95),
+		}
+
+		DescribeTable("usage levels",
+			func(thresholds *spiderpoolv2beta1.UsageThresholds, allocated, total int64, level UsageLevel) {
+				Expect(GetUsageLevel(thresholds, allocated, total)).To(Equal(level))
+			},
+			Entry("no usage thresholds", nil, int64(10), int64(10), UsageLevelNormal),
+			Entry("no IP addresses", thresholds, int64(0), int64(0), UsageLevelNormal),
+			Entry("below the warning threshold", thresholds, int64(7), int64(10), UsageLevelNormal),
+			Entry("reach the warning threshold", thresholds, int64(8), int64(10), UsageLevelWarning),
+			Entry("reach the critical threshold", thresholds, int64(19), int64(20), UsageLevelCritical),
+			Entry("only the critical threshold", &spiderpoolv2beta1.UsageThresholds{Critical: pointer.Int64(50)}, int64(4), int64(10), UsageLevelNormal),
+		)
+	})
 })
@@ -41,11 +41,31 @@ type CoordinatorSpec struct {
 	// +kubebuilder:validation:Optional
 	TxQueueLen *int `json:"txQueueLen,omitempty"`
 
+	// VethMTU is the MTU of the veth pair created in underlay mode, it
+	// follows the MTU of the pod's first NIC if it is 0.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	VethMTU *int `json:"vethMTU,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	DetectIPConflict *bool `json:"detectIPConflict,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	DetectGateway *bool `json:"detectGateway,omitempty"`
+
+	// DetectGatewayMode is how to detect the gateway, icmp sends ICMP echo
+	// requests, l2 resolves the gateway with ARP for IPv4 and NDP for IPv6.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=icmp;l2
+	DetectGatewayMode *string `json:"detectGatewayMode,omitempty"`
+
+	// DetectGatewayLossThreshold is the percentage of the lost probes
+	// tolerated when detecting the gateway.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99
+	DetectGatewayLossThreshold *int `json:"detectGatewayLossThreshold,omitempty"`
 }
 
 // CoordinationStatus defines the observed state of SpiderCoordinator.
@@ -58,6 +78,13 @@ type CoordinatorStatus struct {
 
 	// +kubebuilder:validation:Optional
 	ServiceCIDR []string `json:"serviceCIDR,omitempty"`
+
+	// Conditions are the latest observations of the SpiderCoordinator's
+	// state, the Ready condition tells whether the CIDRs are synced.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spidercoordinators",scope="Cluster",shortName={scc},singular="spidercoordinator"
@@ -17,6 +17,20 @@ type WorkloadEndpointStatus struct {
 
 	// +kubebuilder:validation:Required
 	OwnerControllerName string `json:"ownerControllerName"`
+
+	// OwnerControllerAPIVersion is the API version of the owner controller,
+	// it tells apart the controllers of the same kind, such as StatefulSet
+	// and OpenKruise Advanced StatefulSet. It is empty for the Endpoints
+	// created by the former versions.
+	// +kubebuilder:validation:Optional
+	OwnerControllerAPIVersion string `json:"ownerControllerAPIVersion,omitempty"`
+
+	// StickySlot is the replica slot of the Deployment or ReplicaSet whose
+	// IP addresses stick to, it is only set for the Pods requesting sticky
+	// IP addresses.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	StickySlot *int64 `json:"stickySlot,omitempty"`
 }
 
 type PodIPAllocation struct {
@@ -26,6 +40,9 @@ type PodIPAllocation struct {
 	// +kubebuilder:validation:Required
 	Node string `json:"node"`
 
+	// +kubebuilder:validation:Optional
+	ContainerID string `json:"containerID,omitempty"`
+
 	// +kubebuilder:validation:Required
 	IPs []IPAllocationDetail `json:"ips"`
 }
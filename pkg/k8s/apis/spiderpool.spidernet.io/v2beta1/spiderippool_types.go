@@ -59,6 +59,56 @@ type IPPoolSpec struct {
 	// +kubebuilder:default=false
 	// +kubebuilder:validation:Optional
 	Disable *bool `json:"disable,omitempty"`
+
+	// AllocationStrategy determines which of the free IP addresses is
+	// allocated, the lowest one is allocated if it is not specified.
+	// +kubebuilder:validation:Enum=lowest;random;round-robin;least-recently-released
+	// +kubebuilder:validation:Optional
+	AllocationStrategy *string `json:"allocationStrategy,omitempty"`
+
+	// QuarantineSeconds is how long a released IP address stays cooling
+	// before it could be allocated again, it is reusable at once if it is
+	// not specified or 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	QuarantineSeconds *int64 `json:"quarantineSeconds,omitempty"`
+
+	// DNS is returned to the containers through the CNI result, the Pod
+	// annotation ipam.spidernet.io/dns takes precedence over it.
+	// +kubebuilder:validation:Optional
+	DNS *DNS `json:"dns,omitempty"`
+
+	// UsageThresholds are when the IPPool is considered nearly exhausted.
+	// +kubebuilder:validation:Optional
+	UsageThresholds *UsageThresholds `json:"usageThresholds,omitempty"`
+
+	// Priority orders the IPPool among the candidates of a NIC, the ones with
+	// higher priority are tried first. It defaults to 0.
+	// +kubebuilder:validation:Optional
+	Priority *int64 `json:"priority,omitempty"`
+
+	// Weight is the share of the IP allocations the IPPool takes among the
+	// candidates with the same priority once the candidates are spread. It
+	// defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Optional
+	Weight *int64 `json:"weight,omitempty"`
+}
+
+// UsageThresholds are the percentages of the allocated IP addresses in all
+// the IP addresses, the NearlyExhausted condition is set once the usage
+// reaches either of them.
+type UsageThresholds struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Optional
+	Warning *int64 `json:"warning,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Optional
+	Critical *int64 `json:"critical,omitempty"`
 }
 
 type Route struct {
@@ -69,6 +119,20 @@ type Route struct {
 	Gw string `json:"gw"`
 }
 
+type DNS struct {
+	// +kubebuilder:validation:Optional
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Domain string `json:"domain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Search []string `json:"search,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Options []string `json:"options,omitempty"`
+}
+
 // IPPoolStatus defines the observed state of SpiderIPPool.
 type IPPoolStatus struct {
 	// +kubebuilder:validation:Optional
@@ -81,6 +145,32 @@ type IPPoolStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// LastAllocatedIP is the IP address allocated lastly, which is only
+	// recorded for the 'round-robin' allocation strategy.
+	// +kubebuilder:validation:Optional
+	LastAllocatedIP *string `json:"lastAllocatedIP,omitempty"`
+
+	// ReleasedIPs records when the free IP addresses were released, which is
+	// only recorded for the 'least-recently-released' allocation strategy.
+	// +kubebuilder:validation:Optional
+	ReleasedIPs *string `json:"releasedIPs,omitempty"`
+
+	// CoolingIPs records the released IP addresses which are still in
+	// quarantine and when they expire.
+	// +kubebuilder:validation:Optional
+	CoolingIPs *string `json:"coolingIPs,omitempty"`
+
+	// IPBlocks records the IP addresses leased to the nodes as node-local
+	// blocks, which are only allocated by the spiderpool-agent of the node.
+	// +kubebuilder:validation:Optional
+	IPBlocks *string `json:"ipBlocks,omitempty"`
+
+	// Conditions are the latest observations of the IPPool's state.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // PoolIPAllocations is a map of IP allocation details indexed by IP address.
@@ -91,6 +181,33 @@ type PoolIPAllocation struct {
 	PodUID         string `json:"podUid"`
 }
 
+// PoolIPReleases is a map of IP release details indexed by IP address.
+type PoolIPReleases map[string]PoolIPRelease
+
+type PoolIPRelease struct {
+	ReleaseTime metav1.Time `json:"releaseTime"`
+}
+
+// PoolIPCoolings is a map of IP quarantine details indexed by IP address.
+type PoolIPCoolings map[string]PoolIPCooling
+
+type PoolIPCooling struct {
+	ExpireTime metav1.Time `json:"expireTime"`
+}
+
+// PoolIPBlocks is a map of node-local IP blocks indexed by node name.
+type PoolIPBlocks map[string]PoolIPBlock
+
+type PoolIPBlock struct {
+	IPs       []string    `json:"ips"`
+	LeaseTime metav1.Time `json:"leaseTime"`
+
+	// ReturnRequestTime is set once the node finds no free IP address to
+	// lease, then the other nodes return the free IP addresses of their IP
+	// blocks leased before it.
+	ReturnRequestTime *metav1.Time `json:"returnRequestTime,omitempty"`
+}
+
 // +kubebuilder:resource:categories={spiderpool},path="spiderippools",scope="Cluster",shortName={sp},singular="spiderippool"
 // +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
 // +kubebuilder:printcolumn:JSONPath=".spec.subnet",description="subnet",name="SUBNET",type=string
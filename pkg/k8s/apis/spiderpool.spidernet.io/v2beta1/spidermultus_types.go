@@ -7,14 +7,17 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 // +kubebuilder:resource:categories={spiderpool},path="spidermultusconfigs",scope="Namespaced",shortName={smc},singular="spidermultusconfig"
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +genclient
-// +genclient:noStatus
 type SpiderMultusConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// Spec is the specification of the MultusCNIConfig
 	Spec MultusCNIConfigSpec `json:"spec,omitempty"`
+
+	// Status is the observed state of the MultusCNIConfig
+	Status MultusCNIConfigStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -62,9 +65,47 @@ type MultusCNIConfigSpec struct {
 	// +kubebuilder:validation:Optional
 	CoordinatorConfig *CoordinatorSpec `json:"coordinator,omitempty"`
 
+	// CoordinatorName is the SpiderCoordinator the coordinator plugin takes
+	// the configuration from, it defaults to the default SpiderCoordinator.
+	// +kubebuilder:validation:Optional
+	CoordinatorName *string `json:"coordinatorName,omitempty"`
+
 	// OtherCniTypeConfig only used for CniType custom, valid json format, can be empty
 	// +kubebuilder:validation:Optional
 	CustomCNIConfig *string `json:"customCNI,omitempty"`
+
+	// DriftPolicy is how to handle the manual changes of the generated
+	// NetworkAttachmentDefinition, "restore" reverts them while "report"
+	// keeps them and sets the Drifted condition.
+	// +kubebuilder:default=restore
+	// +kubebuilder:validation:Enum=restore;report
+	// +kubebuilder:validation:Optional
+	DriftPolicy *string `json:"driftPolicy,omitempty"`
+}
+
+// MultusCNIConfigStatus defines the observed state of SpiderMultusConfig.
+type MultusCNIConfigStatus struct {
+	// NetAttachDefName is the name of the generated NetworkAttachmentDefinition.
+	// +kubebuilder:validation:Optional
+	NetAttachDefName string `json:"netAttachDefName,omitempty"`
+
+	// ConfigHash is the SHA-256 hash of the rendered CNI configuration.
+	// +kubebuilder:validation:Optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// LastSyncTime is the last time the NetworkAttachmentDefinition was
+	// created or updated.
+	// +kubebuilder:validation:Optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Conditions are the latest observations of the SpiderMultusConfig's
+	// state, the Ready condition tells whether the generated
+	// NetworkAttachmentDefinition is up to date, and the Drifted condition
+	// tells whether it is modified manually.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type SpiderMacvlanCniConfig struct {
@@ -76,6 +117,12 @@ type SpiderMacvlanCniConfig struct {
 	// +kubebuilder:validation:Maximum=4094
 	VlanID *int32 `json:"vlanID,omitempty"`
 
+	// MTU of the interface created by the CNI, the CNI default is used if it is not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=65535
+	MTU *int32 `json:"mtu,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Bond *BondConfig `json:"bond,omitempty"`
 
@@ -92,6 +139,12 @@ type SpiderIPvlanCniConfig struct {
 	// +kubebuilder:validation:Maximum=4094
 	VlanID *int32 `json:"vlanID,omitempty"`
 
+	// MTU of the interface created by the CNI, the CNI default is used if it is not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=65535
+	MTU *int32 `json:"mtu,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Bond *BondConfig `json:"bond,omitempty"`
 
@@ -108,6 +161,12 @@ type SpiderSRIOVCniConfig struct {
 	// +kubebuilder:validation:Maximum=4094
 	VlanID *int32 `json:"vlanID,omitempty"`
 
+	// MTU of the interface created by the CNI, the CNI default is used if it is not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=65535
+	MTU *int32 `json:"mtu,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Minimum=0
 	MinTxRateMbps *int `json:"minTxRateMbps,omitempty"` // Mbps, 0 = disable rate limiting
@@ -161,6 +220,11 @@ type SpiderOvsCniConfig struct {
 	BrName string `json:"bridge"`
 	// +kubebuilder:validation:Optional
 	VlanTag *int32 `json:"vlan,omitempty"`
+	// MTU of the interface created by the CNI, the CNI default is used if it is not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=68
+	// +kubebuilder:validation:Maximum=65535
+	MTU *int32 `json:"mtu,omitempty"`
 	// +kubebuilder:validation:Optional
 	Trunk []*Trunk `json:"trunk,omitempty"`
 	// +kubebuilder:validation:Optional
@@ -15,18 +15,77 @@ type ReservedIPSpec struct {
 
 	// +kubebuilder:validation:Optional
 	IPs []string `json:"ips,omitempty"`
+
+	// IPPoolName limits the reservation to the SpiderIPPools with the names,
+	// it takes precedence over IPPoolAffinity. The reservation applies to all
+	// the SpiderIPPools if none of the IPPool and Subnet scopes is set.
+	// +kubebuilder:validation:Optional
+	IPPoolName []string `json:"ippoolName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	IPPoolAffinity *metav1.LabelSelector `json:"ippoolAffinity,omitempty"`
+
+	// SubnetName limits the reservation to the SpiderIPPools controlled by the
+	// SpiderSubnets with the names, it takes precedence over SubnetAffinity.
+	// +kubebuilder:validation:Optional
+	SubnetName []string `json:"subnetName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	SubnetAffinity *metav1.LabelSelector `json:"subnetAffinity,omitempty"`
+
+	// NamespaceName limits the reservation to the Pods in the namespaces with
+	// the names, it takes precedence over NamespaceAffinity.
+	// +kubebuilder:validation:Optional
+	NamespaceName []string `json:"namespaceName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	NamespaceAffinity *metav1.LabelSelector `json:"namespaceAffinity,omitempty"`
+
+	// ExpireTime is the time after which the reservation lapses.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Format=date-time
+	ExpireTime *metav1.Time `json:"expireTime,omitempty"`
+}
+
+// ReservedIPStatus defines the observed state of SpiderReservedIP.
+type ReservedIPStatus struct {
+	// AllocatedIPs lists the reserved IP addresses which are still allocated
+	// from the SpiderIPPools in the scope of the reservation, such as the ones
+	// allocated before the reservation is created.
+	// +kubebuilder:validation:Optional
+	AllocatedIPs []ReservedIPAllocation `json:"allocatedIPs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+}
+
+type ReservedIPAllocation struct {
+	// +kubebuilder:validation:Required
+	IP string `json:"ip"`
+
+	// +kubebuilder:validation:Required
+	IPPool string `json:"ippool"`
+
+	// Pod is the namespaced name of the Pod which the IP address is allocated to.
+	// +kubebuilder:validation:Required
+	Pod string `json:"pod"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spiderreservedips",scope="Cluster",shortName={sr},singular="spiderreservedip"
 // +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.allocatedIPCount",description="allocatedIPCount",name="ALLOCATED-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".spec.expireTime",description="expireTime",name="EXPIRE-TIME",type=date
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // SpiderReservedIP is the Schema for the spiderreservedips API.
 type SpiderReservedIP struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ReservedIPSpec `json:"spec,omitempty"`
+	Spec   ReservedIPSpec   `json:"spec,omitempty"`
+	Status ReservedIPStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
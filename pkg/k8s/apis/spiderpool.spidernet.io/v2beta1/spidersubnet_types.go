@@ -33,6 +33,29 @@ type SubnetSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Routes []Route `json:"routes,omitempty"`
+
+	// AllocationStrategy is inherited by the IPPools of the SpiderSubnet
+	// which do not specify their own.
+	// +kubebuilder:validation:Enum=lowest;random;round-robin;least-recently-released
+	// +kubebuilder:validation:Optional
+	AllocationStrategy *string `json:"allocationStrategy,omitempty"`
+
+	// QuarantineSeconds is inherited by the IPPools of the SpiderSubnet
+	// which do not specify their own.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	QuarantineSeconds *int64 `json:"quarantineSeconds,omitempty"`
+
+	// DNS is inherited by the IPPools of the SpiderSubnet which do not
+	// specify their own.
+	// +kubebuilder:validation:Optional
+	DNS *DNS `json:"dns,omitempty"`
+
+	// UsageThresholds are when the SpiderSubnet is considered nearly
+	// exhausted by the IP addresses its IPPools take, they are not
+	// inherited by the IPPools.
+	// +kubebuilder:validation:Optional
+	UsageThresholds *UsageThresholds `json:"usageThresholds,omitempty"`
 }
 
 // SubnetStatus defines the observed state of SpiderSubnet.
@@ -47,6 +70,12 @@ type SubnetStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// Conditions are the latest observations of the Subnet's state.
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // PoolIPPreAllocations is a map of pool IP pre-allocation details indexed by pool name.
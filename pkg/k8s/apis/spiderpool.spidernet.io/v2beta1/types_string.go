@@ -47,6 +47,12 @@ func (in *IPPoolSpec) String() string {
 		`MultusName:` + fmt.Sprintf("%v", in.MultusName) + `,`,
 		`Default:` + stringutil.ValueToStringGenerated(in.Default) + `,`,
 		`Disable:` + stringutil.ValueToStringGenerated(in.Disable) + `,`,
+		`AllocationStrategy:` + stringutil.ValueToStringGenerated(in.AllocationStrategy) + `,`,
+		`QuarantineSeconds:` + stringutil.ValueToStringGenerated(in.QuarantineSeconds) + `,`,
+		`DNS:` + fmt.Sprintf("%+v", in.DNS) + `,`,
+		`UsageThresholds:` + fmt.Sprintf("%+v", in.UsageThresholds) + `,`,
+		`Priority:` + stringutil.ValueToStringGenerated(in.Priority) + `,`,
+		`Weight:` + stringutil.ValueToStringGenerated(in.Weight) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -62,6 +68,11 @@ func (in *IPPoolStatus) String() string {
 		`AllocatedIPs:` + stringutil.ValueToStringGenerated(in.AllocatedIPs) + `,`,
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
+		`LastAllocatedIP:` + stringutil.ValueToStringGenerated(in.LastAllocatedIP) + `,`,
+		`ReleasedIPs:` + stringutil.ValueToStringGenerated(in.ReleasedIPs) + `,`,
+		`CoolingIPs:` + stringutil.ValueToStringGenerated(in.CoolingIPs) + `,`,
+		`IPBlocks:` + stringutil.ValueToStringGenerated(in.IPBlocks) + `,`,
+		`Conditions:` + fmt.Sprintf("%+v", in.Conditions) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -91,6 +102,8 @@ func (in *WorkloadEndpointStatus) String() string {
 		`Current:` + fmt.Sprintf("%v", in.Current.String()) + `,`,
 		`OwnerControllerType:` + fmt.Sprintf("%v", in.OwnerControllerType) + `,`,
 		`OwnerControllerName:` + fmt.Sprintf("%v", in.OwnerControllerName) + `,`,
+		`OwnerControllerAPIVersion:` + fmt.Sprintf("%v", in.OwnerControllerAPIVersion) + `,`,
+		`StickySlot:` + stringutil.ValueToStringGenerated(in.StickySlot) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -196,6 +209,10 @@ func (in *SubnetSpec) String() string {
 		`Gateway:` + stringutil.ValueToStringGenerated(in.Gateway) + `,`,
 		`Vlan:` + stringutil.ValueToStringGenerated(in.Vlan) + `,`,
 		`Routes:` + fmt.Sprintf("%+v", in.Routes) + `,`,
+		`AllocationStrategy:` + stringutil.ValueToStringGenerated(in.AllocationStrategy) + `,`,
+		`QuarantineSeconds:` + stringutil.ValueToStringGenerated(in.QuarantineSeconds) + `,`,
+		`DNS:` + fmt.Sprintf("%+v", in.DNS) + `,`,
+		`UsageThresholds:` + fmt.Sprintf("%+v", in.UsageThresholds) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -211,6 +228,7 @@ func (in *SubnetStatus) String() string {
 		`ControlledIPPools:` + stringutil.ValueToStringGenerated(in.ControlledIPPools) + `,`,
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
+		`Conditions:` + fmt.Sprintf("%+v", in.Conditions) + `,`,
 		`}`,
 	}, "")
 	return s
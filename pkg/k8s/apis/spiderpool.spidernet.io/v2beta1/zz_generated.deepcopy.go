@@ -81,6 +81,11 @@ func (in *CoordinatorSpec) DeepCopyInto(out *CoordinatorSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.VethMTU != nil {
+		in, out := &in.VethMTU, &out.VethMTU
+		*out = new(int)
+		**out = **in
+	}
 	if in.DetectIPConflict != nil {
 		in, out := &in.DetectIPConflict, &out.DetectIPConflict
 		*out = new(bool)
@@ -91,6 +96,16 @@ func (in *CoordinatorSpec) DeepCopyInto(out *CoordinatorSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DetectGatewayMode != nil {
+		in, out := &in.DetectGatewayMode, &out.DetectGatewayMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.DetectGatewayLossThreshold != nil {
+		in, out := &in.DetectGatewayLossThreshold, &out.DetectGatewayLossThreshold
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorSpec.
@@ -116,6 +131,13 @@ func (in *CoordinatorStatus) DeepCopyInto(out *CoordinatorStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorStatus.
@@ -128,6 +150,36 @@ func (in *CoordinatorStatus) DeepCopy() *CoordinatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNS) DeepCopyInto(out *DNS) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
+func (in *DNS) DeepCopy() *DNS {
+	if in == nil {
+		return nil
+	}
+	out := new(DNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAllocationDetail) DeepCopyInto(out *IPAllocationDetail) {
 	*out = *in
@@ -261,6 +313,36 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllocationStrategy != nil {
+		in, out := &in.AllocationStrategy, &out.AllocationStrategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.QuarantineSeconds != nil {
+		in, out := &in.QuarantineSeconds, &out.QuarantineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageThresholds != nil {
+		in, out := &in.UsageThresholds, &out.UsageThresholds
+		*out = new(UsageThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
@@ -291,6 +373,33 @@ func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.LastAllocatedIP != nil {
+		in, out := &in.LastAllocatedIP, &out.LastAllocatedIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReleasedIPs != nil {
+		in, out := &in.ReleasedIPs, &out.ReleasedIPs
+		*out = new(string)
+		**out = **in
+	}
+	if in.CoolingIPs != nil {
+		in, out := &in.CoolingIPs, &out.CoolingIPs
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPBlocks != nil {
+		in, out := &in.IPBlocks, &out.IPBlocks
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
@@ -356,11 +465,21 @@ func (in *MultusCNIConfigSpec) DeepCopyInto(out *MultusCNIConfigSpec) {
 		*out = new(CoordinatorSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CoordinatorName != nil {
+		in, out := &in.CoordinatorName, &out.CoordinatorName
+		*out = new(string)
+		**out = **in
+	}
 	if in.CustomCNIConfig != nil {
 		in, out := &in.CustomCNIConfig, &out.CustomCNIConfig
 		*out = new(string)
 		**out = **in
 	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusCNIConfigSpec.
@@ -373,6 +492,32 @@ func (in *MultusCNIConfigSpec) DeepCopy() *MultusCNIConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultusCNIConfigStatus) DeepCopyInto(out *MultusCNIConfigStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultusCNIConfigStatus.
+func (in *MultusCNIConfigStatus) DeepCopy() *MultusCNIConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultusCNIConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIPAllocation) DeepCopyInto(out *PodIPAllocation) {
 	*out = *in
@@ -431,6 +576,89 @@ func (in PoolIPAllocations) DeepCopy() PoolIPAllocations {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolIPBlock) DeepCopyInto(out *PoolIPBlock) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LeaseTime.DeepCopyInto(&out.LeaseTime)
+	if in.ReturnRequestTime != nil {
+		in, out := &in.ReturnRequestTime, &out.ReturnRequestTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPBlock.
+func (in *PoolIPBlock) DeepCopy() *PoolIPBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolIPBlocks) DeepCopyInto(out *PoolIPBlocks) {
+	{
+		in := &in
+		*out = make(PoolIPBlocks, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPBlocks.
+func (in PoolIPBlocks) DeepCopy() PoolIPBlocks {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPBlocks)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolIPCooling) DeepCopyInto(out *PoolIPCooling) {
+	*out = *in
+	in.ExpireTime.DeepCopyInto(&out.ExpireTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPCooling.
+func (in *PoolIPCooling) DeepCopy() *PoolIPCooling {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPCooling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolIPCoolings) DeepCopyInto(out *PoolIPCoolings) {
+	{
+		in := &in
+		*out = make(PoolIPCoolings, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPCoolings.
+func (in PoolIPCoolings) DeepCopy() PoolIPCoolings {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPCoolings)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolIPPreAllocation) DeepCopyInto(out *PoolIPPreAllocation) {
 	*out = *in
@@ -477,6 +705,58 @@ func (in PoolIPPreAllocations) DeepCopy() PoolIPPreAllocations {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolIPRelease) DeepCopyInto(out *PoolIPRelease) {
+	*out = *in
+	in.ReleaseTime.DeepCopyInto(&out.ReleaseTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPRelease.
+func (in *PoolIPRelease) DeepCopy() *PoolIPRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolIPReleases) DeepCopyInto(out *PoolIPReleases) {
+	{
+		in := &in
+		*out = make(PoolIPReleases, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPReleases.
+func (in PoolIPReleases) DeepCopy() PoolIPReleases {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPReleases)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedIPAllocation) DeepCopyInto(out *ReservedIPAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedIPAllocation.
+func (in *ReservedIPAllocation) DeepCopy() *ReservedIPAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedIPAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReservedIPSpec) DeepCopyInto(out *ReservedIPSpec) {
 	*out = *in
@@ -490,6 +770,40 @@ func (in *ReservedIPSpec) DeepCopyInto(out *ReservedIPSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPPoolName != nil {
+		in, out := &in.IPPoolName, &out.IPPoolName
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPPoolAffinity != nil {
+		in, out := &in.IPPoolAffinity, &out.IPPoolAffinity
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubnetName != nil {
+		in, out := &in.SubnetName, &out.SubnetName
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetAffinity != nil {
+		in, out := &in.SubnetAffinity, &out.SubnetAffinity
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceName != nil {
+		in, out := &in.NamespaceName, &out.NamespaceName
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceAffinity != nil {
+		in, out := &in.NamespaceAffinity, &out.NamespaceAffinity
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpireTime != nil {
+		in, out := &in.ExpireTime, &out.ExpireTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedIPSpec.
@@ -502,6 +816,31 @@ func (in *ReservedIPSpec) DeepCopy() *ReservedIPSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedIPStatus) DeepCopyInto(out *ReservedIPStatus) {
+	*out = *in
+	if in.AllocatedIPs != nil {
+		in, out := &in.AllocatedIPs, &out.AllocatedIPs
+		*out = make([]ReservedIPAllocation, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllocatedIPCount != nil {
+		in, out := &in.AllocatedIPCount, &out.AllocatedIPCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedIPStatus.
+func (in *ReservedIPStatus) DeepCopy() *ReservedIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -746,6 +1085,11 @@ func (in *SpiderIPvlanCniConfig) DeepCopyInto(out *SpiderIPvlanCniConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Bond != nil {
 		in, out := &in.Bond, &out.Bond
 		*out = new(BondConfig)
@@ -801,6 +1145,11 @@ func (in *SpiderMacvlanCniConfig) DeepCopyInto(out *SpiderMacvlanCniConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Bond != nil {
 		in, out := &in.Bond, &out.Bond
 		*out = new(BondConfig)
@@ -829,6 +1178,7 @@ func (in *SpiderMultusConfig) DeepCopyInto(out *SpiderMultusConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderMultusConfig.
@@ -889,6 +1239,11 @@ func (in *SpiderOvsCniConfig) DeepCopyInto(out *SpiderOvsCniConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Trunk != nil {
 		in, out := &in.Trunk, &out.Trunk
 		*out = make([]*Trunk, len(*in))
@@ -923,6 +1278,7 @@ func (in *SpiderReservedIP) DeepCopyInto(out *SpiderReservedIP) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderReservedIP.
@@ -983,6 +1339,11 @@ func (in *SpiderSRIOVCniConfig) DeepCopyInto(out *SpiderSRIOVCniConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
 	if in.MinTxRateMbps != nil {
 		in, out := &in.MinTxRateMbps, &out.MinTxRateMbps
 		*out = new(int)
@@ -1127,6 +1488,26 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = make([]Route, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllocationStrategy != nil {
+		in, out := &in.AllocationStrategy, &out.AllocationStrategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.QuarantineSeconds != nil {
+		in, out := &in.QuarantineSeconds, &out.QuarantineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageThresholds != nil {
+		in, out := &in.UsageThresholds, &out.UsageThresholds
+		*out = new(UsageThresholds)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -1157,6 +1538,13 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
@@ -1199,10 +1587,40 @@ func (in *Trunk) DeepCopy() *Trunk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageThresholds) DeepCopyInto(out *UsageThresholds) {
+	*out = *in
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Critical != nil {
+		in, out := &in.Critical, &out.Critical
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageThresholds.
+func (in *UsageThresholds) DeepCopy() *UsageThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadEndpointStatus) DeepCopyInto(out *WorkloadEndpointStatus) {
 	*out = *in
 	in.Current.DeepCopyInto(&out.Current)
+	if in.StickySlot != nil {
+		in, out := &in.StickySlot, &out.StickySlot
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadEndpointStatus.
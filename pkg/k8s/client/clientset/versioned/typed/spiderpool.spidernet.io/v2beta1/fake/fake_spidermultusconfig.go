@@ -89,6 +89,18 @@ func (c *FakeSpiderMultusConfigs) Update(ctx context.Context, spiderMultusConfig
 	return obj.(*v2beta1.SpiderMultusConfig), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSpiderMultusConfigs) UpdateStatus(ctx context.Context, spiderMultusConfig *v2beta1.SpiderMultusConfig, opts v1.UpdateOptions) (*v2beta1.SpiderMultusConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(spidermultusconfigsResource, "status", c.ns, spiderMultusConfig), &v2beta1.SpiderMultusConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v2beta1.SpiderMultusConfig), err
+}
+
 // Delete takes name of the spiderMultusConfig and deletes it. Returns an error if one occurs.
 func (c *FakeSpiderMultusConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -65,10 +65,13 @@ const (
 	total_ippool_counts                   = metricPrefix + "total_ippool_counts"
 	ippool_total_ip_counts                = metricPrefix + debugPrefix + "ippool_total_ip_counts"
 	ippool_available_ip_counts            = metricPrefix + debugPrefix + "ippool_available_ip_counts"
+	ippool_cooling_ip_counts              = metricPrefix + debugPrefix + "ippool_cooling_ip_counts"
+	ippool_usage_level                    = metricPrefix + "ippool_usage_level"
 	total_subnet_counts                   = metricPrefix + "total_subnet_counts"
 	subnet_ippool_counts                  = metricPrefix + debugPrefix + "subnet_ippool_counts"
 	subnet_total_ip_counts                = metricPrefix + debugPrefix + "subnet_total_ip_counts"
 	subnet_available_ip_counts            = metricPrefix + debugPrefix + "subnet_available_ip_counts"
+	subnet_usage_level                    = metricPrefix + "subnet_usage_level"
 	auto_pool_waited_for_available_counts = metricPrefix + debugPrefix + "auto_pool_waited_for_available_counts"
 )
 
@@ -117,10 +120,13 @@ var (
 	TotalIPPoolCounts       = new(asyncInt64Gauge)
 	IPPoolTotalIPCounts     api.Int64Counter
 	IPPoolAvailableIPCounts api.Int64Counter
+	IPPoolCoolingIPCounts   = new(asyncInt64GaugeVec)
+	IPPoolUsageLevel        = new(asyncInt64GaugeVec)
 	TotalSubnetCounts       = new(asyncInt64Gauge)
 	SubnetPoolCounts        = new(asyncInt64Gauge)
 	SubnetTotalIPCounts     api.Int64Counter
 	SubnetAvailableIPCounts api.Int64Counter
+	SubnetUsageLevel        = new(asyncInt64GaugeVec)
 
 	// SpiderSubnet feature performance monitoring metric in spiderpool-agent
 	AutoPoolWaitedForAvailableCounts api.Int64Counter
@@ -218,6 +224,68 @@ func (a *asyncInt64Gauge) Record(value int64, attrs ...attribute.KeyValue) {
 	a.observerLock.Unlock()
 }
 
+type int64Observation struct {
+	value int64
+	attrs attribute.Set
+}
+
+// asyncInt64GaugeVec is custom otel int64 gauge which reports a value for
+// each attribute set, such as one for each SpiderIPPool
+type asyncInt64GaugeVec struct {
+	gaugeMetric  api.Int64ObservableGauge
+	observations map[attribute.Distinct]int64Observation
+	observerLock lock.RWMutex
+}
+
+// initGauge will new an otel int64 gauge metric and register a call back function
+func (a *asyncInt64GaugeVec) initGauge(metricName string, description string, isDebugLevel bool) error {
+	m := meter
+	if isDebugLevel {
+		m = debugLevelMeter
+	}
+
+	tmpGauge, err := newMetricInt64Gauge(metricName, description, isDebugLevel)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool metric '%s', error: %v", metricName, err)
+	}
+
+	a.gaugeMetric = tmpGauge
+	_, err = m.RegisterCallback(func(_ context.Context, observer api.Observer) error {
+		a.observerLock.RLock()
+		defer a.observerLock.RUnlock()
+		for _, o := range a.observations {
+			observer.ObserveInt64(a.gaugeMetric, o.value, api.WithAttributeSet(o.attrs))
+		}
+		return nil
+	}, a.gaugeMetric)
+	if nil != err {
+		return fmt.Errorf("failed to register callback for spiderpool metric '%s', error: %v", metricName, err)
+	}
+
+	return nil
+}
+
+// Record sets the value reported for the attribute set
+func (a *asyncInt64GaugeVec) Record(value int64, attrs ...attribute.KeyValue) {
+	set := attribute.NewSet(attrs...)
+
+	a.observerLock.Lock()
+	if a.observations == nil {
+		a.observations = map[attribute.Distinct]int64Observation{}
+	}
+	a.observations[set.Equivalent()] = int64Observation{value: value, attrs: set}
+	a.observerLock.Unlock()
+}
+
+// Delete stops reporting the attribute set, e.g. once the object is gone
+func (a *asyncInt64GaugeVec) Delete(attrs ...attribute.KeyValue) {
+	set := attribute.NewSet(attrs...)
+
+	a.observerLock.Lock()
+	delete(a.observations, set.Equivalent())
+	a.observerLock.Unlock()
+}
+
 // InitSpiderpoolAgentMetrics serves for spiderpool agent metrics initialization
 func InitSpiderpoolAgentMetrics(ctx context.Context) error {
 	err := initSpiderpoolAgentAllocationMetrics(ctx)
@@ -536,10 +604,25 @@ func initSpiderpoolControllerCRMetrics(ctx context.Context) error {
 	}
 	IPPoolAvailableIPCounts = poolAvailableIPCounts
 
+	err = IPPoolCoolingIPCounts.initGauge(ippool_cooling_ip_counts, "spiderpool single SpiderIPPool corresponding cooling IP counts", true)
+	if nil != err {
+		return err
+	}
+
 	err = SubnetPoolCounts.initGauge(subnet_ippool_counts, "spider subnet corresponding ippools counts", true)
 	if nil != err {
 		return err
 	}
 
+	err = IPPoolUsageLevel.initGauge(ippool_usage_level, "spiderpool single SpiderIPPool usage level by its usage thresholds, 0 for normal, 1 for warning and 2 for critical", false)
+	if nil != err {
+		return err
+	}
+
+	err = SubnetUsageLevel.initGauge(subnet_usage_level, "spiderpool single SpiderSubnet usage level by its usage thresholds, 0 for normal, 1 for warning and 2 for critical", false)
+	if nil != err {
+		return err
+	}
+
 	return nil
 }
@@ -5,21 +5,28 @@ package multuscniconfig
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -28,6 +35,7 @@ import (
 	spiderpoolcmd "github.com/spidernet-io/spiderpool/cmd/spiderpool/cmd"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
 	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
@@ -41,9 +49,13 @@ var informerLogger *zap.Logger
 type MultusConfigController struct {
 	MultusConfigControllerConfig
 	client                client.Client
+	informers             ctrlcache.Informers
 	multusConfigLister    listers.SpiderMultusConfigLister
 	multusConfigSynced    cache.InformerSynced
 	multusConfigWorkqueue workqueue.RateLimitingInterface
+
+	netAttachDefInformer     ctrlcache.Informer
+	netAttachDefRegistration cache.ResourceEventHandlerRegistration
 }
 
 type MultusConfigControllerConfig struct {
@@ -54,12 +66,15 @@ type MultusConfigControllerConfig struct {
 	ResyncPeriod                  time.Duration
 }
 
-func NewMultusConfigController(multusConfigControllerConfig MultusConfigControllerConfig, client client.Client) *MultusConfigController {
+// NewMultusConfigController creates the controller generating the
+// net-attach-defs, the informers are used to watch the net-attach-defs.
+func NewMultusConfigController(multusConfigControllerConfig MultusConfigControllerConfig, client client.Client, informers ctrlcache.Informers) *MultusConfigController {
 	informerLogger = logutils.Logger.Named("MultusConfig-Informer")
 
 	m := &MultusConfigController{
 		MultusConfigControllerConfig: multusConfigControllerConfig,
 		client:                       client,
+		informers:                    informers,
 	}
 
 	return m
@@ -104,7 +119,7 @@ func (mcc *MultusConfigController) SetupInformer(ctx context.Context, client crd
 
 			informerLogger.Info("create MultusConfig informer")
 			factory := externalversions.NewSharedInformerFactory(client, mcc.ResyncPeriod)
-			err := mcc.addEventHandlers(factory.Spiderpool().V2beta1().SpiderMultusConfigs())
+			err := mcc.addEventHandlers(innerCtx, factory.Spiderpool().V2beta1().SpiderMultusConfigs())
 			if nil != err {
 				informerLogger.Error(err.Error())
 				continue
@@ -121,7 +136,7 @@ func (mcc *MultusConfigController) SetupInformer(ctx context.Context, client crd
 	return nil
 }
 
-func (mcc *MultusConfigController) addEventHandlers(multusConfigInformer informers.SpiderMultusConfigInformer) error {
+func (mcc *MultusConfigController) addEventHandlers(ctx context.Context, multusConfigInformer informers.SpiderMultusConfigInformer) error {
 	mcc.multusConfigLister = multusConfigInformer.Lister()
 	mcc.multusConfigSynced = multusConfigInformer.Informer().HasSynced
 
@@ -138,9 +153,66 @@ func (mcc *MultusConfigController) addEventHandlers(multusConfigInformer informe
 		return err
 	}
 
+	return mcc.addNetAttachDefEventHandler(ctx)
+}
+
+// addNetAttachDefEventHandler enqueues the SpiderMultusConfig owning the
+// net-attach-def once it is updated or deleted, so that the drift is
+// detected in time. The handler added by the last leader term is removed
+// first.
+func (mcc *MultusConfigController) addNetAttachDefEventHandler(ctx context.Context) error {
+	if mcc.informers == nil {
+		return nil
+	}
+
+	if mcc.netAttachDefRegistration != nil {
+		if err := mcc.netAttachDefInformer.RemoveEventHandler(mcc.netAttachDefRegistration); err != nil {
+			return fmt.Errorf("failed to remove net-attach-def event handler: %w", err)
+		}
+		mcc.netAttachDefRegistration = nil
+	}
+
+	netAttachDefInformer, err := mcc.informers.GetInformer(ctx, &netv1.NetworkAttachmentDefinition{})
+	if err != nil {
+		return fmt.Errorf("failed to get net-attach-def informer: %w", err)
+	}
+
+	registration, err := netAttachDefInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			mcc.enqueueOwnerMultusConfig(newObj)
+		},
+		DeleteFunc: mcc.enqueueOwnerMultusConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add net-attach-def event handler: %w", err)
+	}
+	mcc.netAttachDefInformer = netAttachDefInformer
+	mcc.netAttachDefRegistration = registration
+
 	return nil
 }
 
+func (mcc *MultusConfigController) enqueueOwnerMultusConfig(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	netAttachDef, ok := obj.(*netv1.NetworkAttachmentDefinition)
+	if !ok {
+		informerLogger.Sugar().Errorf("expected net-attach-def but got %+v", obj)
+		return
+	}
+
+	owner := metav1.GetControllerOf(netAttachDef)
+	if owner == nil || owner.Kind != constant.KindSpiderMultusConfig {
+		return
+	}
+
+	key := netAttachDef.Namespace + "/" + owner.Name
+	mcc.multusConfigWorkqueue.Add(key)
+	informerLogger.Sugar().Debugf("added %s to MultusConfig workqueue for the change of net-attach-def %s/%s", key, netAttachDef.Namespace, netAttachDef.Name)
+}
+
 func (mcc *MultusConfigController) enqueueMultusConfig(obj interface{}) {
 	key, err := cache.MetaNamespaceKeyFunc(obj)
 	if nil != err {
@@ -257,6 +329,84 @@ func (mcc *MultusConfigController) syncHandler(ctx context.Context, multusConfig
 		return nil
 	}
 
+	result, syncErr := mcc.syncNetAttachDef(ctx, multusConfig)
+	if err := mcc.syncStatus(ctx, multusConfig, result, syncErr); err != nil {
+		return errors.Join(syncErr, err)
+	}
+
+	return syncErr
+}
+
+// netAttachDefSyncResult is what syncing the net-attach-def of a
+// SpiderMultusConfig ends up with.
+type netAttachDefSyncResult struct {
+	name       string
+	configHash string
+	// updated is true if the net-attach-def is created or updated.
+	updated bool
+	// drifts are the manual changes of the net-attach-def kept by the
+	// report drift policy.
+	drifts []string
+}
+
+// syncStatus records the generated net-attach-def in the SpiderMultusConfig
+// status, and sets the Ready and Drifted conditions.
+func (mcc *MultusConfigController) syncStatus(ctx context.Context, multusConfig *spiderpoolv2beta1.SpiderMultusConfig, result *netAttachDefSyncResult, syncErr error) error {
+	oldStatus := multusConfig.Status.DeepCopy()
+
+	ready := metav1.Condition{
+		Type:               constant.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             constant.ConditionReasonSynced,
+		Message:            "the net-attach-def is generated",
+		ObservedGeneration: multusConfig.Generation,
+	}
+	if syncErr != nil {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = constant.ConditionReasonSyncFailed
+		ready.Message = syncErr.Error()
+	}
+	meta.SetStatusCondition(&multusConfig.Status.Conditions, ready)
+
+	if result != nil {
+		multusConfig.Status.NetAttachDefName = result.name
+		multusConfig.Status.ConfigHash = result.configHash
+		if result.updated || multusConfig.Status.LastSyncTime == nil {
+			now := metav1.Now()
+			multusConfig.Status.LastSyncTime = &now
+		}
+
+		drifted := metav1.Condition{
+			Type:               constant.ConditionDrifted,
+			Status:             metav1.ConditionFalse,
+			Reason:             constant.ConditionReasonInSync,
+			Message:            fmt.Sprintf("the net-attach-def %s matches the SpiderMultusConfig", result.name),
+			ObservedGeneration: multusConfig.Generation,
+		}
+		if len(result.drifts) != 0 {
+			drifted.Status = metav1.ConditionTrue
+			drifted.Reason = constant.ConditionReasonManuallyModified
+			drifted.Message = fmt.Sprintf("the %s of the net-attach-def %s are modified manually", strings.Join(result.drifts, ", "), result.name)
+		}
+		meta.SetStatusCondition(&multusConfig.Status.Conditions, drifted)
+	}
+
+	if reflect.DeepEqual(*oldStatus, multusConfig.Status) {
+		return nil
+	}
+
+	if err := mcc.client.Status().Update(ctx, multusConfig); err != nil {
+		return fmt.Errorf("failed to update MultusConfig %s/%s status: %w", multusConfig.Namespace, multusConfig.Name, err)
+	}
+
+	return nil
+}
+
+// syncNetAttachDef creates or updates the net-attach-def generated from the
+// SpiderMultusConfig. The net-attach-def is drifted if it differs from the
+// generated one while the rendered configuration stays the same as the last
+// synced one, which is restored or kept by the drift policy.
+func (mcc *MultusConfigController) syncNetAttachDef(ctx context.Context, multusConfig *spiderpoolv2beta1.SpiderMultusConfig) (*netAttachDefSyncResult, error) {
 	// use the annotation specified name as the CNI configuration name if set
 	netAttachName := multusConfig.Name
 	if tmpName, ok := multusConfig.Annotations[constant.AnnoNetAttachConfName]; ok {
@@ -273,35 +423,44 @@ func (mcc *MultusConfigController) syncHandler(ctx context.Context, multusConfig
 		if apierrors.IsNotFound(err) {
 			isExist = false
 		} else {
-			return err
+			return nil, err
 		}
 	}
 
 	newNetAttachDef, err := generateNetAttachDef(netAttachName, multusConfig)
 	if err != nil {
-		return fmt.Errorf("failed to generate net-attach-def, error: %w", err)
+		return nil, fmt.Errorf("failed to generate net-attach-def, error: %w", err)
 	}
 
 	err = controllerutil.SetControllerReference(multusConfig, newNetAttachDef, mcc.client.Scheme())
 	if err != nil {
-		return fmt.Errorf("failed to set net-attach-def %s owner reference with MultusConfig %s/%s, error: %w",
+		return nil, fmt.Errorf("failed to set net-attach-def %s owner reference with MultusConfig %s/%s, error: %w",
 			newNetAttachDef.Name, multusConfig.Namespace, multusConfig.Name, err)
 	}
 
+	configHash, err := hashNetAttachDef(newNetAttachDef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash net-attach-def %s, error: %w", newNetAttachDef.Name, err)
+	}
+	result := &netAttachDefSyncResult{
+		name:       netAttachName,
+		configHash: configHash,
+	}
+
 	if isExist {
 		// we need to wait and let the kubernetes delete this Net-Attach-Def first.
 		if netAttachDef.DeletionTimestamp != nil {
-			return fmt.Errorf("the old net-attach-def %s/%s is terminating, wait for a while", netAttachDef.Namespace, netAttachDef.Name)
+			return nil, fmt.Errorf("the old net-attach-def %s/%s is terminating, wait for a while", netAttachDef.Namespace, netAttachDef.Name)
 		}
 
-		isNeedUpdate := false
+		var changes []string
 
 		// the annotations updated
 		if !reflect.DeepEqual(netAttachDef.Annotations, newNetAttachDef.Annotations) {
 			informerLogger.Sugar().Debugf("MultusConfig %s/%s annotation changed, the old one is %v, and the new one is %v",
 				multusConfig.Namespace, multusConfig.Name, netAttachDef.Annotations, newNetAttachDef.Annotations)
 			netAttachDef.SetAnnotations(newNetAttachDef.Annotations)
-			isNeedUpdate = true
+			changes = append(changes, "annotations")
 		}
 
 		// the MultusConfig CNI configuration changed
@@ -309,33 +468,75 @@ func (mcc *MultusConfigController) syncHandler(ctx context.Context, multusConfig
 			informerLogger.Sugar().Debugf("MultusConfig %s/%s CNI configuration changed, the old one is %v, and the new one is %v",
 				multusConfig.Namespace, multusConfig.Name, netAttachDef.Spec.Config, newNetAttachDef.Spec.Config)
 			netAttachDef.Spec.Config = newNetAttachDef.Spec.Config
-			isNeedUpdate = true
+			changes = append(changes, "CNI configuration")
 		}
 
 		// the net-attach-def ownerRef was removed
 		if !metav1.IsControlledBy(netAttachDef, multusConfig) {
 			informerLogger.Sugar().Debugf("net-attach-def ownerReference was removed, try to add it")
 			netAttachDef.SetOwnerReferences(newNetAttachDef.GetOwnerReferences())
-			isNeedUpdate = true
+			changes = append(changes, "owner reference")
 		}
 
-		if isNeedUpdate {
-			informerLogger.Sugar().Infof("try to update net-attach-def %v", netAttachDef)
-			err := mcc.client.Update(ctx, netAttachDef)
-			if nil != err {
-				return fmt.Errorf("failed to update net-attach-def %v, error: %w", netAttachDef, err)
-			}
+		if len(changes) == 0 {
+			return result, nil
 		}
 
-		return nil
+		isDrifted := multusConfig.Status.NetAttachDefName == netAttachName && multusConfig.Status.ConfigHash == configHash
+		if isDrifted && getDriftPolicy(multusConfig) == constant.DriftPolicyReport {
+			informerLogger.Sugar().Warnf("net-attach-def %s/%s is drifted in %v, keep it by the drift policy %s",
+				netAttachDef.Namespace, netAttachDef.Name, changes, constant.DriftPolicyReport)
+			result.drifts = changes
+			return result, nil
+		}
+
+		informerLogger.Sugar().Infof("try to update net-attach-def %v", netAttachDef)
+		err := mcc.client.Update(ctx, netAttachDef)
+		if nil != err {
+			return nil, fmt.Errorf("failed to update net-attach-def %v, error: %w", netAttachDef, err)
+		}
+		result.updated = true
+
+		if isDrifted {
+			event.EventRecorder.Eventf(multusConfig, corev1.EventTypeWarning, constant.EventReasonNetAttachDefRestored,
+				"Restore the manually modified %s of net-attach-def %s", strings.Join(changes, ", "), netAttachName)
+		}
+
+		return result, nil
 	}
 
 	informerLogger.Sugar().Infof("try to create net-attach-def %v for MultusConfg %s/%s", newNetAttachDef, multusConfig.Namespace, multusConfig.Name)
 	err = mcc.client.Create(ctx, newNetAttachDef)
 	if nil != err {
-		return fmt.Errorf("failed to create net-attach-def %v, error: %w", newNetAttachDef, err)
+		return nil, fmt.Errorf("failed to create net-attach-def %v, error: %w", newNetAttachDef, err)
 	}
-	return nil
+	result.updated = true
+
+	return result, nil
+}
+
+// hashNetAttachDef returns the SHA-256 hash of the rendered CNI configuration
+// and annotations of the net-attach-def.
+func hashNetAttachDef(netAttachDef *netv1.NetworkAttachmentDefinition) (string, error) {
+	// the keys of the map are sorted by json.Marshal
+	anno, err := json.Marshal(netAttachDef.Annotations)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(netAttachDef.Spec.Config))
+	hash.Write(anno)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func getDriftPolicy(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) string {
+	if multusConfig.Spec.DriftPolicy == nil {
+		return constant.DriftPolicyRestore
+	}
+
+	return *multusConfig.Spec.DriftPolicy
 }
 
 func generateNetAttachDef(netAttachName string, multusConf *spiderpoolv2beta1.SpiderMultusConfig) (*netv1.NetworkAttachmentDefinition, error) {
@@ -351,7 +552,7 @@ func generateNetAttachDef(netAttachName string, multusConf *spiderpoolv2beta1.Sp
 	// with Kubernetes OpenAPI validation, multusConfSpec.EnableCoordinator must not be nil
 	hasCoordinator := *multusConfSpec.EnableCoordinator
 	if hasCoordinator {
-		coordinatorCNIConf := generateCoordinatorCNIConf(multusConfSpec.CoordinatorConfig)
+		coordinatorCNIConf := generateCoordinatorCNIConf(multusConfSpec.CoordinatorConfig, multusConfSpec.CoordinatorName)
 		// head insertion later
 		plugins = append(plugins, coordinatorCNIConf)
 	}
@@ -510,6 +711,7 @@ func generateMacvlanCNIConf(disableIPAM bool, multusConfSpec spiderpoolv2beta1.M
 		Type:   constant.MacvlanCNI,
 		Master: masterName,
 		Mode:   "bridge",
+		MTU:    multusConfSpec.MacvlanConfig.MTU,
 	}
 
 	if !disableIPAM {
@@ -545,6 +747,7 @@ func generateIPvlanCNIConf(disableIPAM bool, multusConfSpec spiderpoolv2beta1.Mu
 	netConf := IPvlanNetConf{
 		Type:   constant.IPVlanCNI,
 		Master: masterName,
+		MTU:    multusConfSpec.IPVlanConfig.MTU,
 	}
 
 	if !disableIPAM {
@@ -589,6 +792,10 @@ func generateSriovCNIConf(disableIPAM bool, multusConfSpec spiderpoolv2beta1.Mul
 		netConf.MinTxRate = multusConfSpec.SriovConfig.MinTxRateMbps
 	}
 
+	if multusConfSpec.SriovConfig.MTU != nil {
+		netConf.MTU = multusConfSpec.SriovConfig.MTU
+	}
+
 	return netConf
 }
 
@@ -676,6 +883,10 @@ func generateOvsCNIConf(disableIPAM bool, multusConfSpec *spiderpoolv2beta1.Mult
 			netConf.Trunk = multusConfSpec.OvsConfig.Trunk
 		}
 
+		if multusConfSpec.OvsConfig.MTU != nil {
+			netConf.MTU = multusConfSpec.OvsConfig.MTU
+		}
+
 		netConf.BrName = multusConfSpec.OvsConfig.BrName
 		netConf.DeviceID = multusConfSpec.OvsConfig.DeviceID
 	}
@@ -696,11 +907,15 @@ func generateIfacer(master []string, vlanID int32, bond *spiderpoolv2beta1.BondC
 	return netConf
 }
 
-func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSpec) interface{} {
+func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSpec, coordinatorName *string) interface{} {
 	coordinatorNetConf := CoordinatorConfig{
 		Type: constant.Coordinator,
 	}
 
+	if coordinatorName != nil {
+		coordinatorNetConf.CoordinatorName = *coordinatorName
+	}
+
 	// coordinatorSpec could be nil, and we just need the coorinator CNI specified and use the default configuration
 	if coordinatorSpec != nil {
 		if coordinatorSpec.Mode != nil {
@@ -721,6 +936,17 @@ func generateCoordinatorCNIConf(coordinatorSpec *spiderpoolv2beta1.CoordinatorSp
 		if coordinatorSpec.DetectGateway != nil {
 			coordinatorNetConf.DetectGateway = coordinatorSpec.DetectGateway
 		}
+		if coordinatorSpec.VethMTU != nil && *coordinatorSpec.VethMTU > 0 {
+			coordinatorNetConf.VethMTU = pointer.Int64(int64(*coordinatorSpec.VethMTU))
+		}
+		if coordinatorSpec.DetectGatewayMode != nil || coordinatorSpec.DetectGatewayLossThreshold != nil {
+			coordinatorNetConf.DetectOptions = &coordinatorcmd.DetectOptions{
+				LossThreshold: coordinatorSpec.DetectGatewayLossThreshold,
+			}
+			if coordinatorSpec.DetectGatewayMode != nil {
+				coordinatorNetConf.DetectOptions.GatewayMode = *coordinatorSpec.DetectGatewayMode
+			}
+		}
 	}
 
 	return coordinatorNetConf
@@ -22,6 +22,9 @@ func mutateSpiderMultusConfig(ctx context.Context, smc *spiderpoolv2beta1.Spider
 	if (*smc).Spec.CniType == nil {
 		(*smc).Spec.CniType = pointer.String(constant.CustomCNI)
 	}
+	if smc.Spec.DriftPolicy == nil {
+		smc.Spec.DriftPolicy = pointer.String(constant.DriftPolicyRestore)
+	}
 	switch *smc.Spec.CniType {
 	case constant.MacvlanCNI:
 		setMacvlanDefaultConfig(smc.Spec.MacvlanConfig)
@@ -44,6 +47,7 @@ func mutateSpiderMultusConfig(ctx context.Context, smc *spiderpoolv2beta1.Spider
 	// with custom CNI configuration, we don't need to add Coordinator configuration
 	if *smc.Spec.CniType == constant.CustomCNI {
 		smc.Spec.CoordinatorConfig = nil
+		smc.Spec.CoordinatorName = nil
 		smc.Spec.EnableCoordinator = pointer.Bool(false)
 	} else {
 		smc.Spec.CoordinatorConfig = setCoordinatorDefaultConfig(smc.Spec.CoordinatorConfig)
@@ -227,5 +231,9 @@ func setCoordinatorDefaultConfig(coordinator *spiderpoolv2beta1.CoordinatorSpec)
 		coordinator.TxQueueLen = pointer.Int(0)
 	}
 
+	if coordinator.VethMTU == nil {
+		coordinator.VethMTU = pointer.Int(0)
+	}
+
 	return coordinator
 }
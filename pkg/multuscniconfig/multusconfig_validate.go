@@ -4,9 +4,12 @@
 package multuscniconfig
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/strings/slices"
@@ -26,6 +29,7 @@ var (
 	ipoibConfigField     = field.NewPath("spec").Child("ipoibConfig")
 	ovsConfigField       = field.NewPath("spec").Child("ovsConfig")
 	customCniConfigField = field.NewPath("spec").Child("customCniTypeConfig")
+	coordinatorNameField = field.NewPath("spec").Child("coordinatorName")
 	annotationField      = field.NewPath("metadata").Child("annotations")
 )
 
@@ -90,6 +94,12 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 			}
 		}
 
+		if multusConfig.Spec.MacvlanConfig.MTU != nil {
+			if err := validateMTU(*multusConfig.Spec.MacvlanConfig.MTU); err != nil {
+				return field.Invalid(macvlanConfigField, *multusConfig.Spec.MacvlanConfig.MTU, err.Error())
+			}
+		}
+
 		if err := validateVlanCNIConfig(multusConfig.Spec.MacvlanConfig.Master, multusConfig.Spec.MacvlanConfig.Bond); err != nil {
 			return field.Invalid(macvlanConfigField, *multusConfig.Spec.MacvlanConfig, err.Error())
 		}
@@ -109,6 +119,12 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 			}
 		}
 
+		if multusConfig.Spec.IPVlanConfig.MTU != nil {
+			if err := validateMTU(*multusConfig.Spec.IPVlanConfig.MTU); err != nil {
+				return field.Invalid(ipvlanConfigField, *multusConfig.Spec.IPVlanConfig.MTU, err.Error())
+			}
+		}
+
 		if err := validateVlanCNIConfig(multusConfig.Spec.IPVlanConfig.Master, multusConfig.Spec.IPVlanConfig.Bond); err != nil {
 			return field.Invalid(ipvlanConfigField, *multusConfig.Spec.IPVlanConfig, err.Error())
 		}
@@ -128,6 +144,12 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 			}
 		}
 
+		if multusConfig.Spec.SriovConfig.MTU != nil {
+			if err := validateMTU(*multusConfig.Spec.SriovConfig.MTU); err != nil {
+				return field.Invalid(sriovConfigField, *multusConfig.Spec.SriovConfig.MTU, err.Error())
+			}
+		}
+
 		if multusConfig.Spec.SriovConfig.MinTxRateMbps != nil && multusConfig.Spec.SriovConfig.MaxTxRateMbps != nil {
 			if *multusConfig.Spec.SriovConfig.MinTxRateMbps > *multusConfig.Spec.SriovConfig.MaxTxRateMbps {
 				return field.Invalid(sriovConfigField, *multusConfig.Spec.SriovConfig.MinTxRateMbps, "minTxRateMbps must be less than maxTxRateMbps")
@@ -179,6 +201,12 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 			}
 		}
 
+		if multusConfig.Spec.OvsConfig.MTU != nil {
+			if err := validateMTU(*multusConfig.Spec.OvsConfig.MTU); err != nil {
+				return field.Invalid(ovsConfigField, *multusConfig.Spec.OvsConfig.MTU, err.Error())
+			}
+		}
+
 		for idx, trunk := range multusConfig.Spec.OvsConfig.Trunk {
 			if trunk.MinID != nil {
 				if *trunk.MinID > 4094 {
@@ -228,6 +256,29 @@ func validateCNIConfig(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *fiel
 	return nil
 }
 
+// validateCoordinatorName checks that the SpiderCoordinator referenced by
+// the SpiderMultusConfig exists.
+func (mcw *MultusConfigWebhook) validateCoordinatorName(ctx context.Context, multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *field.Error {
+	if multusConfig.Spec.CoordinatorName == nil {
+		return nil
+	}
+
+	name := *multusConfig.Spec.CoordinatorName
+	var coord spiderpoolv2beta1.SpiderCoordinator
+	if err := mcw.APIReader.Get(ctx, ktypes.NamespacedName{Name: name}, &coord); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(coordinatorNameField, name)
+		}
+		return field.InternalError(coordinatorNameField, fmt.Errorf("failed to get SpiderCoordinator %s: %v", name, err))
+	}
+
+	if coord.DeletionTimestamp != nil {
+		return field.Forbidden(coordinatorNameField, fmt.Sprintf("SpiderCoordinator %s is terminating", name))
+	}
+
+	return nil
+}
+
 func validateVlanCNIConfig(master []string, bond *spiderpoolv2beta1.BondConfig) error {
 	if len(master) == 0 {
 		return fmt.Errorf("master can't be empty")
@@ -250,6 +301,13 @@ func validateVlanId(vlanId int32) error {
 	return nil
 }
 
+func validateMTU(mtu int32) error {
+	if mtu < 68 || mtu > 65535 {
+		return fmt.Errorf("invalid mtu %v, please make sure mtu in range [68,65535]", mtu)
+	}
+	return nil
+}
+
 func validateAnnotation(multusConfig *spiderpoolv2beta1.SpiderMultusConfig) *field.Error {
 	// validate the custom net-attach-def resource name
 	customMultusName, ok := multusConfig.Annotations[constant.AnnoNetAttachConfName]
@@ -37,12 +37,14 @@ type MacvlanNetConf struct {
 	Type   string                    `json:"type"`
 	Master string                    `json:"master"`
 	Mode   string                    `json:"mode"`
+	MTU    *int32                    `json:"mtu,omitempty"`
 	IPAM   *spiderpoolcmd.IPAMConfig `json:"ipam,omitempty"`
 }
 
 type IPvlanNetConf struct {
 	Type   string                    `json:"type"`
 	Master string                    `json:"master"`
+	MTU    *int32                    `json:"mtu,omitempty"`
 	IPAM   *spiderpoolcmd.IPAMConfig `json:"ipam,omitempty"`
 }
 
@@ -52,6 +54,7 @@ type SRIOVNetConf struct {
 	MinTxRate *int `json:"minTxRate,omitempty"`
 	// Mbps, 0 = disable rate limiting
 	MaxTxRate *int                      `json:"maxTxRate,omitempty"`
+	MTU       *int32                    `json:"mtu,omitempty"`
 	Type      string                    `json:"type"`
 	DeviceID  string                    `json:"deviceID,omitempty"`
 	IPAM      *spiderpoolcmd.IPAMConfig `json:"ipam,omitempty"`
@@ -78,6 +81,7 @@ type RdmaNetConf struct {
 
 type OvsNetConf struct {
 	Vlan     *int32                     `json:"vlan,omitempty"`
+	MTU      *int32                     `json:"mtu,omitempty"`
 	Type     string                     `json:"type"`
 	BrName   string                     `json:"bridge"`
 	DeviceID string                     `json:"deviceID,omitempty"`
@@ -93,15 +97,18 @@ type IfacerNetConf struct {
 }
 
 type CoordinatorConfig struct {
-	IPConflict         *bool               `json:"detectIPConflict,omitempty"`
-	DetectGateway      *bool               `json:"detectGateway,omitempty"`
-	MacPrefix          string              `json:"podMACPrefix,omitempty"`
-	Mode               coordinatorcmd.Mode `json:"mode,omitempty"`
-	Type               string              `json:"type"`
-	PodDefaultRouteNIC string              `json:"podDefaultRouteNic,omitempty"`
-	OverlayPodCIDR     []string            `json:"overlayPodCIDR,omitempty"`
-	ServiceCIDR        []string            `json:"serviceCIDR,omitempty"`
-	HijackCIDR         []string            `json:"hijackCIDR,omitempty"`
+	IPConflict         *bool                         `json:"detectIPConflict,omitempty"`
+	DetectGateway      *bool                         `json:"detectGateway,omitempty"`
+	MacPrefix          string                        `json:"podMACPrefix,omitempty"`
+	Mode               coordinatorcmd.Mode           `json:"mode,omitempty"`
+	Type               string                        `json:"type"`
+	PodDefaultRouteNIC string                        `json:"podDefaultRouteNic,omitempty"`
+	OverlayPodCIDR     []string                      `json:"overlayPodCIDR,omitempty"`
+	ServiceCIDR        []string                      `json:"serviceCIDR,omitempty"`
+	HijackCIDR         []string                      `json:"hijackCIDR,omitempty"`
+	DetectOptions      *coordinatorcmd.DetectOptions `json:"detectOptions,omitempty"`
+	CoordinatorName    string                        `json:"coordinatorName,omitempty"`
+	VethMTU            *int64                        `json:"vethMTU,omitempty"`
 }
 
 func ParsePodNetworkAnnotation(podNetworks, defaultNamespace string) ([]*netv1.NetworkSelectionElement, error) {
@@ -5,6 +5,7 @@ package gwconnection
 
 import (
 	"fmt"
+	"net/netip"
 	"time"
 
 	"go.uber.org/zap"
@@ -12,12 +13,26 @@ import (
 	ping "github.com/prometheus-community/pro-bing"
 )
 
+const (
+	// ModeICMP detects the gateway with ICMP echo requests.
+	ModeICMP = "icmp"
+	// ModeL2 detects the gateway by resolving it with ARP for IPv4 and
+	// NDP neighbor solicitation for IPv6, for the gateways dropping ICMP.
+	ModeL2 = "l2"
+)
+
+// Detector detects whether a gateway is reachable.
+type Detector interface {
+	DetectGateway() error
+}
+
 type Pinger struct {
-	logger *zap.Logger
-	pinger *ping.Pinger
+	logger        *zap.Logger
+	pinger        *ping.Pinger
+	lossThreshold int
 }
 
-func NewPinger(count int, interval, timeout, gw string, logger *zap.Logger) (*Pinger, error) {
+func NewPinger(count int, interval, timeout, gw string, lossThreshold int, logger *zap.Logger) (*Pinger, error) {
 	pinger := ping.New(gw)
 	pinger.Count = count
 
@@ -34,19 +49,29 @@ func NewPinger(count int, interval, timeout, gw string, logger *zap.Logger) (*Pi
 	pinger.Timeout = timeoutDuration
 	pinger.SetPrivileged(true)
 
-	return &Pinger{logger, pinger}, nil
+	return &Pinger{logger, pinger, lossThreshold}, nil
 }
 
 func (p *Pinger) DetectGateway() error {
 	if err := p.pinger.Run(); err != nil {
-		return fmt.Errorf("failed to run DetectGateway: %v", err)
+		return fmt.Errorf("failed to run DetectGateway for %s gateway %s: %v", family(p.pinger.Addr()), p.pinger.Addr(), err)
 	}
 
 	stats := p.pinger.Statistics()
-	if stats.PacketLoss > 0 {
-		return fmt.Errorf("gateway %s is unreachable", p.pinger.Addr())
+	if stats.PacketLoss > float64(p.lossThreshold) {
+		return fmt.Errorf("%s gateway %s is unreachable: %d/%d ICMP packets lost, exceeding the loss threshold %d%%",
+			family(p.pinger.Addr()), p.pinger.Addr(), stats.PacketsSent-stats.PacketsRecv, stats.PacketsSent, p.lossThreshold)
 	}
 
-	p.logger.Sugar().Debugf("gateway %s is reachable", p.pinger.Addr())
+	p.logger.Sugar().Debugf("%s gateway %s is reachable, packet loss %v%%", family(p.pinger.Addr()), p.pinger.Addr(), stats.PacketLoss)
 	return nil
 }
+
+// family returns the IP family of gw for the messages.
+func family(gw string) string {
+	addr, err := netip.ParseAddr(gw)
+	if err == nil && addr.Is6() && !addr.Is4In6() {
+		return "IPv6"
+	}
+	return "IPv4"
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gwconnection
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/mdlayher/arp"
+	"github.com/mdlayher/ethernet"
+	"github.com/mdlayher/ndp"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/pkg/networking/ipchecking"
+)
+
+// Neighbor detects the gateway at L2, it resolves the IPv4 gateway with ARP
+// and the IPv6 gateway with NDP neighbor solicitation. It must be run in the
+// network namespace of the pod.
+type Neighbor struct {
+	logger        *zap.Logger
+	count         int
+	interval      time.Duration
+	timeout       time.Duration
+	lossThreshold int
+	iface         string
+	src, gw       netip.Addr
+}
+
+// NewNeighbor creates the L2 detector of gw, it sends count probes on
+// iface at interval, and waits at most timeout for the reply of each one.
+// src is the IPv4 address of the pod used as the sender of the ARP
+// requests, it is ignored for the IPv6 gateway.
+func NewNeighbor(count int, interval, timeout, iface string, src, gw netip.Addr, lossThreshold int, logger *zap.Logger) (*Neighbor, error) {
+	intervalDuration, err := time.ParseDuration(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutDuration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if gw.Is4() && !src.Is4() {
+		return nil, fmt.Errorf("no IPv4 address of interface %s to send the ARP requests to gateway %s", iface, gw)
+	}
+
+	return &Neighbor{
+		logger:        logger,
+		count:         count,
+		interval:      intervalDuration,
+		timeout:       timeoutDuration,
+		lossThreshold: lossThreshold,
+		iface:         iface,
+		src:           src,
+		gw:            gw,
+	}, nil
+}
+
+func (n *Neighbor) DetectGateway() error {
+	ifi, err := net.InterfaceByName(n.iface)
+	if err != nil {
+		return fmt.Errorf("failed to InterfaceByName %s: %w", n.iface, err)
+	}
+
+	var probe func() (bool, error)
+	var protocol string
+	if n.gw.Is4() {
+		protocol = "ARP"
+		client, err := arp.Dial(ifi)
+		if err != nil {
+			return fmt.Errorf("failed to init arp client: %w", err)
+		}
+		defer client.Close()
+		probe = func() (bool, error) { return n.probeByARP(ifi, client) }
+	} else {
+		protocol = "NDP"
+		conn, _, err := ndp.Listen(ifi, ndp.LinkLocal)
+		if err != nil {
+			return fmt.Errorf("failed to init ndp client: %w", err)
+		}
+		defer conn.Close()
+		probe = func() (bool, error) { return n.probeByNDP(ifi, conn) }
+	}
+
+	received := 0
+	for i := 0; i < n.count; i++ {
+		if i > 0 {
+			time.Sleep(n.interval)
+		}
+
+		ok, err := probe()
+		if err != nil {
+			return fmt.Errorf("failed to run DetectGateway for %s gateway %s: %v", family(n.gw.String()), n.gw, err)
+		}
+		if ok {
+			received++
+		}
+	}
+
+	lost := n.count - received
+	if lost*100 > n.lossThreshold*n.count {
+		return fmt.Errorf("%s gateway %s is unreachable: %d/%d %s probes lost, exceeding the loss threshold %d%%",
+			family(n.gw.String()), n.gw, lost, n.count, protocol, n.lossThreshold)
+	}
+
+	n.logger.Sugar().Debugf("%s gateway %s is reachable, %d/%d %s probes lost", family(n.gw.String()), n.gw, lost, n.count, protocol)
+	return nil
+}
+
+// probeByARP sends an ARP request to the gateway, and returns whether the
+// gateway replies in time.
+func (n *Neighbor) probeByARP(ifi *net.Interface, client *arp.Client) (bool, error) {
+	packet, err := arp.NewPacket(arp.OperationRequest, ifi.HardwareAddr, n.src, ethernet.Broadcast, n.gw)
+	if err != nil {
+		return false, err
+	}
+	if err := client.WriteTo(packet, ethernet.Broadcast); err != nil {
+		return false, fmt.Errorf("failed to send ARP request: %w", err)
+	}
+
+	if err := client.SetReadDeadline(time.Now().Add(n.timeout)); err != nil {
+		return false, fmt.Errorf("failed to set deadline: %w", err)
+	}
+	for {
+		reply, _, err := client.Read()
+		if err != nil {
+			if isTimeout(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to read ARP reply: %w", err)
+		}
+		if _, ok := ipchecking.ARPReplyFrom(reply, n.gw); ok {
+			return true, nil
+		}
+	}
+}
+
+// probeByNDP sends a neighbor solicitation to the solicited-node multicast
+// group of the gateway, and returns whether the gateway advertises in time.
+func (n *Neighbor) probeByNDP(ifi *net.Interface, conn *ndp.Conn) (bool, error) {
+	snm, err := ndp.SolicitedNodeMulticast(n.gw)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine solicited-node multicast address: %w", err)
+	}
+	if err := conn.WriteTo(ipchecking.NewNeighborSolicitation(ifi, n.gw), nil, snm); err != nil {
+		return false, fmt.Errorf("failed to send neighbor solicitation: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(n.timeout)); err != nil {
+		return false, fmt.Errorf("failed to set deadline: %w", err)
+	}
+	for {
+		msg, _, _, err := conn.ReadFrom()
+		if err != nil {
+			if isTimeout(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to read neighbor advertisement: %w", err)
+		}
+		if _, ok := ipchecking.NeighborAdvertisementFrom(msg, n.gw); ok {
+			return true, nil
+		}
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
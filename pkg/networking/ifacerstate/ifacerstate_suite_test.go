@@ -0,0 +1,16 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ifacerstate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIfacerState(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IfacerState Suite", Label("ifacerstate", "unittest"))
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ifacerstate records which container attachments use the bond and
+// VLAN interfaces set up by the ifacer plugin in a node-local state file, so
+// that an interface can be torn down once no attachment uses it.
+package ifacerstate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+)
+
+// StateFileName is the name of the state file shared by the ifacer plugin
+// and spiderpool-agent.
+const StateFileName = "ifacer.json"
+
+// StatePath returns the path of the state file, it lives in the directory
+// of the IPAM UNIX socket, which is the host directory mounted to the agent.
+func StatePath(ipamUnixSocketPath string) string {
+	return filepath.Join(filepath.Dir(ipamUnixSocketPath), StateFileName)
+}
+
+// Attachment is the NIC of a container using the interface.
+type Attachment struct {
+	ContainerID  string `json:"containerID"`
+	IfName       string `json:"ifName"`
+	PodNamespace string `json:"podNamespace,omitempty"`
+	PodName      string `json:"podName,omitempty"`
+	PodUID       string `json:"podUID,omitempty"`
+}
+
+// Interface is a bond or VLAN interface on the host.
+type Interface struct {
+	// Created is true if the interface is created by ifacer, the ones
+	// existing before are never torn down.
+	Created bool `json:"created"`
+	// Parent is the interface the VLAN interface is based on.
+	Parent      string       `json:"parent,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// State records the interfaces by name.
+type State struct {
+	Interfaces map[string]*Interface `json:"interfaces"`
+}
+
+// Attach records that the attachment uses the interface. Whether the
+// interface is created by ifacer is only recorded for the first attachment.
+func (s *State) Attach(name, parent string, created bool, attachment Attachment) {
+	iface, ok := s.Interfaces[name]
+	if !ok {
+		iface = &Interface{Created: created, Parent: parent}
+		s.Interfaces[name] = iface
+	}
+
+	for _, a := range iface.Attachments {
+		if a.ContainerID == attachment.ContainerID && a.IfName == attachment.IfName {
+			return
+		}
+	}
+	iface.Attachments = append(iface.Attachments, attachment)
+}
+
+// Detach removes the attachment from all the interfaces, and returns the
+// interfaces created by ifacer which are no longer used, in the order they
+// should be deleted.
+func (s *State) Detach(containerID, ifName string) []string {
+	return s.Prune(func(a Attachment) bool {
+		return a.ContainerID != containerID || a.IfName != ifName
+	})
+}
+
+// Prune removes the attachments which are not kept by keep from all the
+// interfaces, and returns the interfaces created by ifacer which are no
+// longer used, in the order they should be deleted. The unused interfaces
+// are forgotten.
+func (s *State) Prune(keep func(Attachment) bool) []string {
+	parents := map[string]struct{}{}
+	for _, iface := range s.Interfaces {
+		parents[iface.Parent] = struct{}{}
+	}
+
+	var unused []string
+	for name, iface := range s.Interfaces {
+		attachments := iface.Attachments[:0]
+		for _, a := range iface.Attachments {
+			if keep(a) {
+				attachments = append(attachments, a)
+			}
+		}
+		iface.Attachments = attachments
+
+		if len(iface.Attachments) != 0 {
+			continue
+		}
+		if iface.Created {
+			unused = append(unused, name)
+		}
+		delete(s.Interfaces, name)
+	}
+
+	// VLAN interfaces go before the bond they are based on.
+	sort.SliceStable(unused, func(i, j int) bool {
+		_, iIsParent := parents[unused[i]]
+		_, jIsParent := parents[unused[j]]
+		if iIsParent != jIsParent {
+			return !iIsParent
+		}
+		return unused[i] < unused[j]
+	})
+
+	return unused
+}
+
+// Update locks the state file exclusively, and saves the state after fn
+// succeeds. The state file is created if it does not exist.
+func Update(path string, fn func(state *State) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	lockFile, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the lock file of %s: %w", path, err)
+	}
+	defer lockFile.Close()
+
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	defer func() {
+		_ = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
+	}()
+
+	state, err := load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(state); err != nil {
+		return err
+	}
+
+	return save(path, state)
+}
+
+// Read reads the state file without locking it, which is fine since the
+// state file is replaced atomically.
+func Read(path string) (*State, error) {
+	return load(path)
+}
+
+func load(path string) (*State, error) {
+	state := &State{Interfaces: map[string]*Interface{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if len(data) == 0 {
+		return state, nil
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if state.Interfaces == nil {
+		state.Interfaces = map[string]*Interface{}
+	}
+
+	return state, nil
+}
+
+// save writes the state to a temporary file first, so that the state file
+// is never left half written.
+func save(path string, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// DeleteLinks deletes the interfaces in order, the ones no longer exist
+// are ignored.
+func DeleteLinks(names []string) error {
+	for _, name := range names {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			if _, ok := err.(netlink.LinkNotFoundError); ok {
+				continue
+			}
+			return fmt.Errorf("failed to LinkByName %s: %w", name, err)
+		}
+
+		if err := netlink.LinkDel(link); err != nil {
+			return fmt.Errorf("failed to delete interface %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ifacerstate_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/networking/ifacerstate"
+)
+
+var _ = Describe("IfacerState", Label("state_test"), func() {
+	var state *ifacerstate.State
+	var attachment1, attachment2 ifacerstate.Attachment
+
+	BeforeEach(func() {
+		state = &ifacerstate.State{Interfaces: map[string]*ifacerstate.Interface{}}
+		attachment1 = ifacerstate.Attachment{
+			ContainerID:  "container1",
+			IfName:       "net1",
+			PodNamespace: "default",
+			PodName:      "pod1",
+			PodUID:       "uid1",
+		}
+		attachment2 = ifacerstate.Attachment{
+			ContainerID:  "container2",
+			IfName:       "net1",
+			PodNamespace: "default",
+			PodName:      "pod2",
+			PodUID:       "uid2",
+		}
+	})
+
+	Describe("Attach", func() {
+		It("records the interface with the first attachment", func() {
+			state.Attach("bond0.100", "bond0", true, attachment1)
+
+			Expect(state.Interfaces).To(HaveKey("bond0.100"))
+			iface := state.Interfaces["bond0.100"]
+			Expect(iface.Created).To(BeTrue())
+			Expect(iface.Parent).To(Equal("bond0"))
+			Expect(iface.Attachments).To(ConsistOf(attachment1))
+		})
+
+		It("keeps whether the interface is created by the first attachment", func() {
+			state.Attach("bond0.100", "bond0", true, attachment1)
+			state.Attach("bond0.100", "bond0", false, attachment2)
+
+			iface := state.Interfaces["bond0.100"]
+			Expect(iface.Created).To(BeTrue())
+			Expect(iface.Attachments).To(ConsistOf(attachment1, attachment2))
+		})
+
+		It("does not record the same attachment twice", func() {
+			state.Attach("bond0.100", "bond0", true, attachment1)
+			state.Attach("bond0.100", "bond0", true, attachment1)
+
+			Expect(state.Interfaces["bond0.100"].Attachments).To(HaveLen(1))
+		})
+	})
+
+	Describe("Detach", func() {
+		It("returns nothing if the interface is still used", func() {
+			state.Attach("bond0.100", "bond0", true, attachment1)
+			state.Attach("bond0.100", "bond0", true, attachment2)
+
+			unused := state.Detach(attachment1.ContainerID, attachment1.IfName)
+			Expect(unused).To(BeEmpty())
+			Expect(state.Interfaces["bond0.100"].Attachments).To(ConsistOf(attachment2))
+		})
+
+		It("returns the unused VLAN interface before the bond it is based on", func() {
+			state.Attach("bond0", "", true, attachment1)
+			state.Attach("bond0.100", "bond0", true, attachment1)
+
+			unused := state.Detach(attachment1.ContainerID, attachment1.IfName)
+			Expect(unused).To(Equal([]string{"bond0.100", "bond0"}))
+			Expect(state.Interfaces).To(BeEmpty())
+		})
+
+		It("forgets the unused interface not created by ifacer without returning it", func() {
+			state.Attach("eth0.100", "eth0", false, attachment1)
+
+			unused := state.Detach(attachment1.ContainerID, attachment1.IfName)
+			Expect(unused).To(BeEmpty())
+			Expect(state.Interfaces).To(BeEmpty())
+		})
+	})
+
+	Describe("Prune", func() {
+		It("removes the attachments not kept from all the interfaces", func() {
+			state.Attach("bond0", "", true, attachment1)
+			state.Attach("bond0", "", true, attachment2)
+			state.Attach("bond0.100", "bond0", true, attachment1)
+			state.Attach("bond0.200", "bond0", true, attachment2)
+
+			unused := state.Prune(func(a ifacerstate.Attachment) bool {
+				return a.PodUID != attachment1.PodUID
+			})
+			Expect(unused).To(Equal([]string{"bond0.100"}))
+			Expect(state.Interfaces).To(HaveLen(2))
+			Expect(state.Interfaces["bond0"].Attachments).To(ConsistOf(attachment2))
+			Expect(state.Interfaces["bond0.200"].Attachments).To(ConsistOf(attachment2))
+		})
+
+		It("returns all the unused interfaces in the order to delete", func() {
+			state.Attach("bond1", "", true, attachment2)
+			state.Attach("bond0", "", true, attachment1)
+			state.Attach("bond0.200", "bond0", true, attachment2)
+			state.Attach("bond0.100", "bond0", true, attachment1)
+			state.Attach("eth0.100", "eth0", false, attachment1)
+
+			unused := state.Prune(func(ifacerstate.Attachment) bool { return false })
+			Expect(unused).To(Equal([]string{"bond0.100", "bond0.200", "bond1", "bond0"}))
+			Expect(state.Interfaces).To(BeEmpty())
+		})
+
+		It("keeps everything", func() {
+			state.Attach("bond0.100", "bond0", true, attachment1)
+
+			unused := state.Prune(func(ifacerstate.Attachment) bool { return true })
+			Expect(unused).To(BeEmpty())
+			Expect(state.Interfaces["bond0.100"].Attachments).To(ConsistOf(attachment1))
+		})
+	})
+
+	Describe("StatePath", func() {
+		It("lives in the directory of the IPAM UNIX socket", func() {
+			Expect(ifacerstate.StatePath("/var/run/spidernet/spiderpool.sock")).To(Equal("/var/run/spidernet/ifacer.json"))
+			Expect(ifacerstate.StatePath("/run/custom/agent.sock")).To(Equal("/run/custom/ifacer.json"))
+		})
+	})
+
+	Describe("Update and Read", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(GinkgoT().TempDir(), "spidernet", "ifacer.json")
+		})
+
+		It("reads an empty state if the state file does not exist", func() {
+			s, err := ifacerstate.Read(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Interfaces).To(BeEmpty())
+		})
+
+		It("saves the state after the update succeeds", func() {
+			err := ifacerstate.Update(path, func(s *ifacerstate.State) error {
+				s.Attach("bond0.100", "bond0", true, attachment1)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			s, err := ifacerstate.Read(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Interfaces).To(HaveKey("bond0.100"))
+			Expect(s.Interfaces["bond0.100"].Attachments).To(ConsistOf(attachment1))
+		})
+
+		It("does not save the state if the update fails", func() {
+			updateErr := errors.New("failed to update")
+			err := ifacerstate.Update(path, func(s *ifacerstate.State) error {
+				s.Attach("bond0.100", "bond0", true, attachment1)
+				return updateErr
+			})
+			Expect(err).To(MatchError(updateErr))
+
+			s, err := ifacerstate.Read(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.Interfaces).To(BeEmpty())
+		})
+
+		It("fails to read the invalid state file", func() {
+			Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+			Expect(os.WriteFile(path, []byte("invalid"), 0o600)).To(Succeed())
+
+			_, err := ifacerstate.Read(path)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -136,13 +136,11 @@ func (ipc *IPChecker) ipCheckingByARP() error {
 					return
 				}
 
-				if packet.Operation == arp.OperationReply {
-					// found reply and simple check if the reply packet is we want.
-					if packet.SenderIP.Compare(ipc.ip4) == 0 {
-						conflictingMac = packet.SenderHardwareAddr.String()
-						cancel()
-						return
-					}
+				// found reply and simple check if the reply packet is we want.
+				if mac, ok := ARPReplyFrom(packet, ipc.ip4); ok {
+					conflictingMac = mac.String()
+					cancel()
+					return
 				}
 			}
 		}
@@ -195,15 +193,7 @@ func (ipc *IPChecker) ipCheckingByNDP() error {
 	var err error
 	defer ipc.ndpClient.Close()
 
-	m := &ndp.NeighborSolicitation{
-		TargetAddress: ipc.ip6,
-		Options: []ndp.Option{
-			&ndp.LinkLayerAddress{
-				Direction: ndp.Source,
-				Addr:      ipc.ifi.HardwareAddr,
-			},
-		},
-	}
+	m := NewNeighborSolicitation(ipc.ifi, ipc.ip6)
 
 	var replyMac string
 	replyMac, err = ipc.sendReceiveLoop(m)
@@ -274,15 +264,49 @@ func (ipc *IPChecker) sendReceive(m ndp.Message) (string, error) {
 
 	msg, _, _, err := ipc.ndpClient.ReadFrom()
 	if err == nil {
-		na, ok := msg.(*ndp.NeighborAdvertisement)
-		if ok && na.TargetAddress.Compare(ipc.ip6) == 0 && len(na.Options) == 1 {
-			// found ndp reply what we want
-			option, ok := na.Options[0].(*ndp.LinkLayerAddress)
-			if ok {
-				return option.Addr.String(), nil
-			}
+		// found ndp reply what we want
+		if mac, ok := NeighborAdvertisementFrom(msg, ipc.ip6); ok {
+			return mac.String(), nil
 		}
 		return "", errRetry
 	}
 	return "", err
 }
+
+// NewNeighborSolicitation builds the neighbor solicitation for target,
+// which carries the hardware address of ifi as the source link-layer
+// address.
+func NewNeighborSolicitation(ifi *net.Interface, target netip.Addr) *ndp.NeighborSolicitation {
+	return &ndp.NeighborSolicitation{
+		TargetAddress: target,
+		Options: []ndp.Option{
+			&ndp.LinkLayerAddress{
+				Direction: ndp.Source,
+				Addr:      ifi.HardwareAddr,
+			},
+		},
+	}
+}
+
+// ARPReplyFrom returns the hardware address of target if packet is an ARP
+// reply sent by it.
+func ARPReplyFrom(packet *arp.Packet, target netip.Addr) (net.HardwareAddr, bool) {
+	if packet.Operation != arp.OperationReply || packet.SenderIP.Compare(target) != 0 {
+		return nil, false
+	}
+	return packet.SenderHardwareAddr, true
+}
+
+// NeighborAdvertisementFrom returns the hardware address of target if msg
+// is a neighbor advertisement of it.
+func NeighborAdvertisementFrom(msg ndp.Message, target netip.Addr) (net.HardwareAddr, bool) {
+	na, ok := msg.(*ndp.NeighborAdvertisement)
+	if !ok || na.TargetAddress.Compare(target) != 0 || len(na.Options) != 1 {
+		return nil, false
+	}
+	option, ok := na.Options[0].(*ndp.LinkLayerAddress)
+	if !ok {
+		return nil, false
+	}
+	return option.Addr, true
+}
@@ -165,3 +165,25 @@ func AddStaticNeighborTable(linkIndex int, dstIP net.IP, hwAddress net.HardwareA
 
 	return nil
 }
+
+// StaticNeighborTableExist returns true if the static neighborhood table of
+// dstIP with hardware address hwAddress exists on the link.
+func StaticNeighborTableExist(linkIndex int, dstIP net.IP, hwAddress net.HardwareAddr) (bool, error) {
+	family := netlink.FAMILY_V4
+	if dstIP.To4() == nil {
+		family = netlink.FAMILY_V6
+	}
+
+	neighs, err := netlink.NeighList(linkIndex, family)
+	if err != nil {
+		return false, fmt.Errorf("failed to list neigh table: %v", err)
+	}
+
+	for _, neigh := range neighs {
+		if neigh.IP.Equal(dstIP) && neigh.State&netlink.NUD_PERMANENT != 0 &&
+			neigh.HardwareAddr.String() == hwAddress.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
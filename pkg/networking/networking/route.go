@@ -149,6 +149,68 @@ func AddRoute(logger *zap.Logger, ruleTable, ipFamily int, scope netlink.Scope,
 	return nil
 }
 
+// RouteExist returns true if the route table ruleTable has a route to dst
+// via the interface iface. A nil dst means the default route.
+// Equivalent to: `ip route show <dst> dev <iface> table <ruleTable>`
+func RouteExist(ruleTable, ipFamily int, iface string, dst *net.IPNet) (bool, error) {
+	link, err := netlink.LinkByName(iface)
+	if err != nil {
+		return false, err
+	}
+
+	filterRoute := &netlink.Route{
+		LinkIndex: link.Attrs().Index,
+		Table:     ruleTable,
+	}
+	routes, err := netlink.RouteListFiltered(ipFamily, filterRoute, netlink.RT_FILTER_TABLE|netlink.RT_FILTER_OIF)
+	if err != nil {
+		return false, err
+	}
+
+	for idx := range routes {
+		if dst == nil {
+			if routes[idx].Dst == nil {
+				return true, nil
+			}
+			if ones, _ := routes[idx].Dst.Mask.Size(); ones == 0 {
+				return true, nil
+			}
+			continue
+		}
+		if IPNetEqual(routes[idx].Dst, dst) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RuleExist returns true if a rule matching all the non-zero fields of
+// rule (table, priority and mark) exists, and its src and dst equal to the
+// ones of rule.
+func RuleExist(rule *netlink.Rule, ipFamily int) (bool, error) {
+	rules, err := netlink.RuleList(ipFamily)
+	if err != nil {
+		return false, err
+	}
+
+	for idx := range rules {
+		if rules[idx].Table != rule.Table {
+			continue
+		}
+		if rule.Priority > 0 && rules[idx].Priority != rule.Priority {
+			continue
+		}
+		if rule.Mark > 0 && rules[idx].Mark != rule.Mark {
+			continue
+		}
+		if !IPNetEqual(rules[idx].Src, rule.Src) || !IPNetEqual(rules[idx].Dst, rule.Dst) {
+			continue
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 // MoveRouteTable move all routes of the specified interface to a new route table
 // Equivalent: `ip route del <route>` and `ip r route add <route> <table>`
 func MoveRouteTable(logger *zap.Logger, iface string, srcRuleTable, dstRuleTable, ipfamily int) error {
@@ -16,13 +16,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 var scheme *runtime.Scheme
 var fakeClient client.Client
 var tracker k8stesting.ObjectTracker
 var fakeAPIReader client.Reader
+var workloadKinds workloadkind.Registry
 var podManager podmanager.PodManager
 
 func TestPodManager(t *testing.T) {
@@ -53,9 +57,22 @@ var _ = BeforeSuite(func() {
 		}).
 		Build()
 
+	stsManager, err := statefulsetmanager.NewStatefulSetManager(fakeClient, fakeAPIReader)
+	Expect(err).NotTo(HaveOccurred())
+
+	workloadKinds, err = workloadkind.NewRegistry(
+		fakeClient,
+		fakeAPIReader,
+		stsManager,
+		kubevirtmanager.NewKubevirtManager(fakeClient, fakeAPIReader),
+		workloadkind.RegistryConfig{},
+	)
+	Expect(err).NotTo(HaveOccurred())
+
 	podManager, err = podmanager.NewPodManager(
 		fakeClient,
 		fakeAPIReader,
+		workloadKinds,
 	)
 	Expect(err).NotTo(HaveOccurred())
 })
@@ -31,13 +31,19 @@ import (
 var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 	Describe("New PodManager", func() {
 		It("inputs nil client", func() {
-			manager, err := podmanager.NewPodManager(nil, fakeAPIReader)
+			manager, err := podmanager.NewPodManager(nil, fakeAPIReader, workloadKinds)
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
 		})
 
 		It("inputs nil API reader", func() {
-			manager, err := podmanager.NewPodManager(fakeClient, nil)
+			manager, err := podmanager.NewPodManager(fakeClient, nil, workloadKinds)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs nil workload kind registry", func() {
+			manager, err := podmanager.NewPodManager(fakeClient, fakeAPIReader, nil)
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
 		})
@@ -240,13 +246,60 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 				err := kruiseapi.AddToScheme(scheme)
 				Expect(err).NotTo(HaveOccurred())
 
-				cloneSet := &kruisev1.CloneSet{}
-				err = controllerutil.SetControllerReference(cloneSet, podT, scheme)
+				unitedDeployment := &kruisev1.UnitedDeployment{}
+				err = controllerutil.SetControllerReference(unitedDeployment, podT, scheme)
 				Expect(err).NotTo(HaveOccurred())
 
 				podTopController, err := podManager.GetPodTopController(ctx, podT)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(slices.Contains(constant.K8sKinds, podTopController.Kind)).To(BeFalse())
+				Expect(podTopController.APP).To(BeNil())
+			})
+
+			It("Pod with OpenKruise CloneSet controller", func() {
+				err := kruiseapi.AddToScheme(scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				cloneSet := &kruisev1.CloneSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName,
+						Namespace: namespace,
+					},
+					Spec: kruisev1.CloneSetSpec{
+						Replicas: pointer.Int32(3),
+					},
+				}
+				err = fakeClient.Create(ctx, cloneSet)
+				Expect(err).NotTo(HaveOccurred())
+				defer func() {
+					Expect(fakeClient.Delete(ctx, cloneSet)).To(Succeed())
+				}()
+
+				err = controllerutil.SetControllerReference(cloneSet, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				podTopController, err := podManager.GetPodTopController(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.APIVersion).To(Equal(kruisev1.SchemeGroupVersion.String()))
+				Expect(podTopController.Kind).To(Equal(constant.KindCloneSet))
+				Expect(podTopController.APP).NotTo(BeNil())
+			})
+
+			It("failed to get the OpenKruise CloneSet controller", func() {
+				err := kruiseapi.AddToScheme(scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				cloneSet := &kruisev1.CloneSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName,
+						Namespace: namespace,
+					},
+				}
+				err = controllerutil.SetControllerReference(cloneSet, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = podManager.GetPodTopController(ctx, podT)
+				Expect(err).To(HaveOccurred())
 			})
 
 			It("Pod with ReplicaSet controller", func() {
@@ -0,0 +1,77 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+var WebhookLogger *zap.Logger
+
+// PodWebhook validates the IPAM annotations of the Pods when they are
+// created, so that an invalid request is rejected ahead of time instead of
+// failing the CNI ADD of the Pod.
+type PodWebhook struct{}
+
+func (pw *PodWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookLogger == nil {
+		WebhookLogger = logutils.Logger.Named("Pod-Webhook")
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.Pod{}).
+		WithValidator(pw).
+		Complete()
+}
+
+var _ webhook.CustomValidator = (*PodWebhook)(nil)
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pod := obj.(*corev1.Pod)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("PodNamespace", pod.Namespace),
+		zap.String("PodName", pod.Name),
+		zap.String("Operation", "CREATE"),
+	)
+
+	if _, err := ValidateAnnoPodIPs(pod); err != nil {
+		logger.Sugar().Errorf("Failed to create Pod: %v", err)
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: corev1.GroupName, Kind: constant.KindPod},
+			pod.Name,
+			field.ErrorList{field.Invalid(
+				field.NewPath("metadata").Child("annotations").Key(constant.AnnoPodIPs),
+				pod.Annotations[constant.AnnoPodIPs],
+				err.Error(),
+			)},
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
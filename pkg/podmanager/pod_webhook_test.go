@@ -0,0 +1,58 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+)
+
+var _ = Describe("PodWebhook", Label("pod_webhook_test"), func() {
+	var podWebhook *podmanager.PodWebhook
+	var podT *corev1.Pod
+
+	BeforeEach(func() {
+		podmanager.WebhookLogger = logutils.Logger.Named("Pod-Webhook")
+		podWebhook = &podmanager.PodWebhook{}
+		podT = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "default",
+			},
+		}
+	})
+
+	Describe("ValidateCreate", func() {
+		It("creates the Pod without IPAM annotations", func() {
+			warns, err := podWebhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeNil())
+		})
+
+		It("fails to create the Pod requesting the IP address of a NIC it does not have", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPs: `[{"interface": "net1", "ipv4": "172.18.41.40"}]`}
+
+			warns, err := podWebhook.ValidateCreate(context.TODO(), podT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(warns).To(BeNil())
+		})
+
+		It("creates the Pod requesting the IP address of its NIC", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPs: `[{"interface": "eth0", "ipv4": "172.18.40.40"}]`}
+
+			warns, err := podWebhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warns).To(BeNil())
+		})
+	})
+})
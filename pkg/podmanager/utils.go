@@ -4,12 +4,18 @@
 package podmanager
 
 import (
-	appsv1 "k8s.io/api/apps/v1"
+	"encoding/json"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	kubevirtv1 "kubevirt.io/api/core/v1"
+	"k8s.io/utils/strings/slices"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/multuscniconfig"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 func IsPodAlive(pod *corev1.Pod) bool {
@@ -36,20 +42,112 @@ func IsPodAlive(pod *corev1.Pod) bool {
 	return true
 }
 
-// IsStaticIPPod checks the given pod's controller ownerReference whether is StatefulSet or KubevirtVMI
-func IsStaticIPPod(enableStatefulSet, enableKubevirtStaticIP bool, pod *corev1.Pod) bool {
+// IsStaticIPPod checks the given pod's controller ownerReference whether is a workload with stable identity,
+// such as StatefulSet or KubevirtVMI
+func IsStaticIPPod(workloadKinds workloadkind.Registry, pod *corev1.Pod) bool {
 	ownerReference := metav1.GetControllerOf(pod)
 	if ownerReference == nil {
 		return false
 	}
 
-	if enableStatefulSet && ownerReference.APIVersion == appsv1.SchemeGroupVersion.String() && ownerReference.Kind == constant.KindStatefulSet {
-		return true
+	_, ok := workloadKinds.StableEndpointName(pod.Name, types.AppNamespacedName{
+		APIVersion: ownerReference.APIVersion,
+		Kind:       ownerReference.Kind,
+		Namespace:  pod.Namespace,
+		Name:       ownerReference.Name,
+	})
+
+	return ok
+}
+
+// ParseAnnoPodIPs parses and validates the value of the Pod annotation
+// ipam.spidernet.io/ips, so that the request of the specified IP addresses
+// could be checked before the Pod is created. The NIC of an item defaults
+// to the first NIC of the Pod if it is not set.
+func ParseAnnoPodIPs(value string) (types.AnnoPodIPsValue, error) {
+	var annoPodIPs types.AnnoPodIPsValue
+	if err := json.Unmarshal([]byte(value), &annoPodIPs); err != nil {
+		return nil, err
+	}
+
+	nics := map[string]struct{}{}
+	for index := range annoPodIPs {
+		item := &annoPodIPs[index]
+		if item.NIC == "" {
+			item.NIC = constant.ClusterDefaultInterfaceName
+		}
+		if _, ok := nics[item.NIC]; ok {
+			return nil, fmt.Errorf("duplicate interface %s", item.NIC)
+		}
+		nics[item.NIC] = struct{}{}
+
+		if item.IPv4 == "" && item.IPv6 == "" {
+			return nil, fmt.Errorf("interface %s requires at least one IP address", item.NIC)
+		}
+		if item.IPv4 != "" {
+			if err := spiderpoolip.IsIP(constant.IPv4, item.IPv4); err != nil {
+				return nil, fmt.Errorf("interface %s: %v", item.NIC, err)
+			}
+		}
+		if item.IPv6 != "" {
+			if err := spiderpoolip.IsIP(constant.IPv6, item.IPv6); err != nil {
+				return nil, fmt.Errorf("interface %s: %v", item.NIC, err)
+			}
+		}
+	}
+
+	return annoPodIPs, nil
+}
+
+// GetPodNICs returns the names of the NICs the Pod will have, the first NIC
+// and the additional ones attached through the Multus annotation
+// k8s.v1.cni.cncf.io/networks, which are named like multus does if the
+// interface is not specified.
+func GetPodNICs(pod *corev1.Pod) ([]string, error) {
+	nics := []string{constant.ClusterDefaultInterfaceName}
+
+	anno, ok := pod.Annotations[constant.MultusNetworkAttachmentAnnot]
+	if !ok || anno == "" {
+		return nics, nil
+	}
+
+	networks, err := multuscniconfig.ParsePodNetworkAnnotation(anno, pod.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Pod annotation '%s': %v", constant.MultusNetworkAttachmentAnnot, err)
 	}
+	for idx, network := range networks {
+		nic := network.InterfaceRequest
+		if nic == "" {
+			nic = fmt.Sprintf("net%d", idx+1)
+		}
+		nics = append(nics, nic)
+	}
+
+	return nics, nil
+}
 
-	if enableKubevirtStaticIP && ownerReference.APIVersion == kubevirtv1.SchemeGroupVersion.String() && ownerReference.Kind == constant.KindKubevirtVMI {
-		return true
+// ValidateAnnoPodIPs checks the Pod annotation ipam.spidernet.io/ips, every
+// NIC it requests IP addresses for must be a NIC of the Pod.
+func ValidateAnnoPodIPs(pod *corev1.Pod) (types.AnnoPodIPsValue, error) {
+	anno, ok := pod.Annotations[constant.AnnoPodIPs]
+	if !ok {
+		return nil, nil
+	}
+
+	annoPodIPs, err := ParseAnnoPodIPs(anno)
+	if err != nil {
+		return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodIPs, err)
+	}
+
+	nics, err := GetPodNICs(pod)
+	if err != nil {
+		return nil, fmt.Errorf("%w, %v", constant.ErrWrongInput, err)
+	}
+	for _, item := range annoPodIPs {
+		if !slices.Contains(nics, item.NIC) {
+			return nil, fmt.Errorf("%w, interface %s of Pod annotation '%s' is not a NIC of the Pod %v", constant.ErrWrongInput, item.NIC, constant.AnnoPodIPs, nics)
+		}
 	}
 
-	return false
+	return annoPodIPs, nil
 }
@@ -10,7 +10,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 var _ = Describe("PodManager utils", Label("pod_manager_utils_test"), func() {
@@ -75,4 +77,89 @@ var _ = Describe("PodManager utils", Label("pod_manager_utils_test"), func() {
 			Expect(isAlive).To(BeTrue())
 		})
 	})
+
+	Describe("Test ParseAnnoPodIPs", func() {
+		It("parses the NICs with default interface", func() {
+			annoPodIPs, err := podmanager.ParseAnnoPodIPs(`[{"ipv4": "172.18.40.40", "ipv6": "fc00::40"}, {"interface": "net1", "ipv4": "172.18.41.40"}]`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annoPodIPs).To(Equal(types.AnnoPodIPsValue{
+				{NIC: constant.ClusterDefaultInterfaceName, IPv4: "172.18.40.40", IPv6: "fc00::40"},
+				{NIC: "net1", IPv4: "172.18.41.40"},
+			}))
+		})
+
+		It("fails with invalid JSON", func() {
+			_, err := podmanager.ParseAnnoPodIPs(`{"ipv4": "172.18.40.40"}`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails with duplicate NICs", func() {
+			_, err := podmanager.ParseAnnoPodIPs(`[{"ipv4": "172.18.40.40"}, {"interface": "eth0", "ipv4": "172.18.40.41"}]`)
+			Expect(err).To(MatchError(ContainSubstring("duplicate interface eth0")))
+		})
+
+		It("fails with a NIC without IP address", func() {
+			_, err := podmanager.ParseAnnoPodIPs(`[{"interface": "net1"}]`)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails with an IP address of the wrong family", func() {
+			_, err := podmanager.ParseAnnoPodIPs(`[{"ipv4": "fc00::40"}]`)
+			Expect(err).To(HaveOccurred())
+
+			_, err = podmanager.ParseAnnoPodIPs(`[{"ipv6": "172.18.40.40"}]`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Test GetPodNICs", func() {
+		It("returns the first NIC only", func() {
+			nics, err := podmanager.GetPodNICs(podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nics).To(Equal([]string{constant.ClusterDefaultInterfaceName}))
+		})
+
+		It("returns the NICs attached through Multus", func() {
+			podT.Annotations = map[string]string{
+				constant.MultusNetworkAttachmentAnnot: "kube-system/macvlan@vlan100,kube-system/macvlan",
+			}
+
+			nics, err := podmanager.GetPodNICs(podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nics).To(Equal([]string{constant.ClusterDefaultInterfaceName, "vlan100", "net2"}))
+		})
+	})
+
+	Describe("Test ValidateAnnoPodIPs", func() {
+		It("does nothing without the annotation", func() {
+			annoPodIPs, err := podmanager.ValidateAnnoPodIPs(podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annoPodIPs).To(BeNil())
+		})
+
+		It("fails with the annotation in invalid format", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPs: `[{"ipv4": "172.18.40.400"}]`}
+
+			_, err := podmanager.ValidateAnnoPodIPs(podT)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("fails with a NIC the Pod does not have", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPs: `[{"interface": "net1", "ipv4": "172.18.41.40"}]`}
+
+			_, err := podmanager.ValidateAnnoPodIPs(podT)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("validates the annotation", func() {
+			podT.Annotations = map[string]string{
+				constant.MultusNetworkAttachmentAnnot: "kube-system/macvlan",
+				constant.AnnoPodIPs:                   `[{"interface": "net1", "ipv4": "172.18.41.40"}]`,
+			}
+
+			annoPodIPs, err := podmanager.ValidateAnnoPodIPs(podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annoPodIPs).To(HaveLen(1))
+		})
+	})
 })
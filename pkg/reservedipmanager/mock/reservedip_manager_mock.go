@@ -14,6 +14,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	reservedipmanager "github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	types "github.com/spidernet-io/spiderpool/pkg/types"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -42,18 +43,18 @@ func (m *MockReservedIPManager) EXPECT() *MockReservedIPManagerMockRecorder {
 }
 
 // AssembleReservedIPs mocks base method.
-func (m *MockReservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error) {
+func (m *MockReservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion, scope reservedipmanager.ReservedIPScope) ([]net.IP, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssembleReservedIPs", ctx, version)
+	ret := m.ctrl.Call(m, "AssembleReservedIPs", ctx, version, scope)
 	ret0, _ := ret[0].([]net.IP)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssembleReservedIPs indicates an expected call of AssembleReservedIPs.
-func (mr *MockReservedIPManagerMockRecorder) AssembleReservedIPs(ctx, version interface{}) *gomock.Call {
+func (mr *MockReservedIPManagerMockRecorder) AssembleReservedIPs(ctx, version, scope interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssembleReservedIPs", reflect.TypeOf((*MockReservedIPManager)(nil).AssembleReservedIPs), ctx, version)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssembleReservedIPs", reflect.TypeOf((*MockReservedIPManager)(nil).AssembleReservedIPs), ctx, version, scope)
 }
 
 // GetReservedIPByName mocks base method.
@@ -0,0 +1,445 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
+	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
+	informers "github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions/spiderpool.spidernet.io/v2beta1"
+	listers "github.com/spidernet-io/spiderpool/pkg/k8s/client/listers/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+var informerLogger *zap.Logger
+
+// ReservedIPController records the reserved IP addresses which are still
+// allocated in the SpiderReservedIP status, the webhook could not prevent
+// them since the IP addresses may be allocated before the reservation.
+type ReservedIPController struct {
+	ReservedIPControllerConfig
+	client       client.Client
+	informers    ctrlcache.Informers
+	poolLister   listers.SpiderIPPoolLister
+	poolSynced   cache.InformerSynced
+	rIPWorkqueue workqueue.RateLimitingInterface
+
+	rIPInformer     ctrlcache.Informer
+	rIPRegistration cache.ResourceEventHandlerRegistration
+}
+
+// reservedIPKey is the work item of a SpiderReservedIP, IPPool is set if
+// only the allocations from the IPPool need to be updated.
+type reservedIPKey struct {
+	ReservedIP string
+	IPPool     string
+}
+
+type ReservedIPControllerConfig struct {
+	ControllerWorkers   int
+	MaxWorkqueueLength  int
+	LeaderRetryElectGap time.Duration
+	ResyncPeriod        time.Duration
+}
+
+// NewReservedIPController creates the controller updating the
+// SpiderReservedIP status, the informers are used to watch the
+// SpiderReservedIPs.
+func NewReservedIPController(reservedIPControllerConfig ReservedIPControllerConfig, client client.Client, informers ctrlcache.Informers) *ReservedIPController {
+	informerLogger = logutils.Logger.Named("ReservedIP-Informer")
+
+	return &ReservedIPController{
+		ReservedIPControllerConfig: reservedIPControllerConfig,
+		client:                     client,
+		informers:                  informers,
+	}
+}
+
+func (rc *ReservedIPController) SetupInformer(ctx context.Context, client crdclientset.Interface, leader election.SpiderLeaseElector) error {
+	if client == nil {
+		return fmt.Errorf("spiderpoolv2beta1 clientset %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	informerLogger.Info("try to register ReservedIP informer")
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if !leader.IsElected() {
+				time.Sleep(rc.LeaderRetryElectGap)
+				continue
+			}
+
+			innerCtx, innerCancel := context.WithCancel(ctx)
+			go func() {
+				for {
+					select {
+					case <-innerCtx.Done():
+						return
+					default:
+					}
+
+					if !leader.IsElected() {
+						informerLogger.Warn("Leader lost, stop ReservedIP informer")
+						innerCancel()
+						return
+					}
+					time.Sleep(rc.LeaderRetryElectGap)
+				}
+			}()
+
+			informerLogger.Info("create ReservedIP informer")
+			factory := externalversions.NewSharedInformerFactory(client, rc.ResyncPeriod)
+			err := rc.addEventHandlers(innerCtx, factory.Spiderpool().V2beta1().SpiderIPPools())
+			if nil != err {
+				informerLogger.Error(err.Error())
+				continue
+			}
+			factory.Start(innerCtx.Done())
+
+			if err := rc.Run(innerCtx.Done()); nil != err {
+				informerLogger.Sugar().Errorf("failed to run ReservedIP controller, error: %v", err)
+			}
+			informerLogger.Error("SpiderReservedIP informer broken")
+		}
+	}()
+
+	return nil
+}
+
+// addEventHandlers enqueues the SpiderReservedIPs once they change, and the
+// ones applying to an IPPool once its IP allocations change. The handler of
+// the SpiderReservedIPs added by the last leader term is removed first.
+func (rc *ReservedIPController) addEventHandlers(ctx context.Context, poolInformer informers.SpiderIPPoolInformer) error {
+	rc.poolLister = poolInformer.Lister()
+	rc.poolSynced = poolInformer.Informer().HasSynced
+	rc.rIPWorkqueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SpiderReservedIPs")
+
+	_, err := poolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			rc.enqueueReservedIPsOfIPPool(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldPool := oldObj.(*spiderpoolv2beta1.SpiderIPPool)
+			newPool := newObj.(*spiderpoolv2beta1.SpiderIPPool)
+			if reflect.DeepEqual(oldPool.Status.AllocatedIPs, newPool.Status.AllocatedIPs) &&
+				reflect.DeepEqual(oldPool.Labels, newPool.Labels) {
+				return
+			}
+			rc.enqueueReservedIPsOfIPPool(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			rc.enqueueReservedIPsOfIPPool(obj)
+		},
+	})
+	if nil != err {
+		return err
+	}
+
+	if rc.rIPRegistration != nil {
+		if err := rc.rIPInformer.RemoveEventHandler(rc.rIPRegistration); err != nil {
+			return fmt.Errorf("failed to remove SpiderReservedIP event handler: %w", err)
+		}
+		rc.rIPRegistration = nil
+	}
+
+	rIPInformer, err := rc.informers.GetInformer(ctx, &spiderpoolv2beta1.SpiderReservedIP{})
+	if err != nil {
+		return fmt.Errorf("failed to get SpiderReservedIP informer: %w", err)
+	}
+
+	registration, err := rIPInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: rc.enqueueReservedIP,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			rc.enqueueReservedIP(newObj)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add SpiderReservedIP event handler: %w", err)
+	}
+	rc.rIPInformer = rIPInformer
+	rc.rIPRegistration = registration
+
+	return nil
+}
+
+func (rc *ReservedIPController) enqueueReservedIP(obj interface{}) {
+	rIP, ok := obj.(*spiderpoolv2beta1.SpiderReservedIP)
+	if !ok {
+		informerLogger.Sugar().Errorf("expected SpiderReservedIP but got %+v", obj)
+		return
+	}
+
+	rc.enqueue(reservedIPKey{ReservedIP: rIP.Name})
+}
+
+// enqueueReservedIPsOfIPPool enqueues the SpiderReservedIPs applying to the
+// IPPool before or after it changes, only the allocations from the IPPool are
+// updated.
+func (rc *ReservedIPController) enqueueReservedIPsOfIPPool(objs ...interface{}) {
+	var pools []*spiderpoolv2beta1.SpiderIPPool
+	for _, obj := range objs {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+
+		pool, ok := obj.(*spiderpoolv2beta1.SpiderIPPool)
+		if !ok {
+			informerLogger.Sugar().Errorf("expected SpiderIPPool but got %+v", obj)
+			return
+		}
+		pools = append(pools, pool)
+	}
+
+	ctx := context.TODO()
+	var rIPList spiderpoolv2beta1.SpiderReservedIPList
+	if err := rc.client.List(ctx, &rIPList); err != nil {
+		informerLogger.Sugar().Errorf("failed to list SpiderReservedIPs: %v", err)
+		return
+	}
+
+	for i := range rIPList.Items {
+		rIP := &rIPList.Items[i]
+		inScope, err := IPPoolsInScope(ctx, rc.client, rIP, pools)
+		if err != nil {
+			informerLogger.Sugar().Errorf("failed to get the IPPools in the scope of ReservedIP '%s': %v", rIP.Name, err)
+			continue
+		}
+		if len(inScope) != 0 {
+			rc.enqueue(reservedIPKey{ReservedIP: rIP.Name, IPPool: pools[0].Name})
+		}
+	}
+}
+
+func (rc *ReservedIPController) enqueue(key reservedIPKey) {
+	if rc.rIPWorkqueue.Len() >= rc.MaxWorkqueueLength {
+		informerLogger.Sugar().Errorf("The ReservedIP workqueue is out of capacity, discard enqueue ReservedIP '%s'", key.ReservedIP)
+		return
+	}
+	rc.rIPWorkqueue.Add(key)
+	informerLogger.Sugar().Debugf("added '%s' to ReservedIP workqueue", key.ReservedIP)
+}
+
+func (rc *ReservedIPController) Run(stopCh <-chan struct{}) error {
+	defer utilruntime.HandleCrash()
+	defer rc.rIPWorkqueue.ShutDown()
+
+	informerLogger.Debug("Waiting for informer caches to sync")
+	if ok := cache.WaitForCacheSync(stopCh, rc.poolSynced, rc.rIPInformer.HasSynced); !ok {
+		return fmt.Errorf("failed to wait for caches to sync")
+	}
+
+	for i := 0; i < rc.ControllerWorkers; i++ {
+		informerLogger.Sugar().Debugf("Starting ReservedIP processing worker %d", i)
+		go wait.Until(rc.runWorker, 1*time.Second, stopCh)
+	}
+
+	<-stopCh
+	informerLogger.Error("Shutting down ReservedIP controller workers")
+	return nil
+}
+
+func (rc *ReservedIPController) runWorker() {
+	for rc.processNextWorkItem() {
+	}
+}
+
+func (rc *ReservedIPController) processNextWorkItem() bool {
+	obj, shutdown := rc.rIPWorkqueue.Get()
+	if shutdown {
+		informerLogger.Error("ReservedIP workqueue is already shutdown!")
+		return false
+	}
+	defer rc.rIPWorkqueue.Done(obj)
+
+	key, ok := obj.(reservedIPKey)
+	if !ok {
+		rc.rIPWorkqueue.Forget(obj)
+		informerLogger.Sugar().Errorf("expected reservedIPKey in workQueue but got %+v", obj)
+		return true
+	}
+
+	if err := rc.syncHandler(context.TODO(), key); err != nil {
+		informerLogger.Sugar().Warnf("failed to sync ReservedIP '%s', requeuing: %v", key.ReservedIP, err)
+		rc.rIPWorkqueue.AddRateLimited(obj)
+		return true
+	}
+
+	rc.rIPWorkqueue.Forget(obj)
+	return true
+}
+
+// syncHandler updates the allocated reserved IP addresses in the
+// SpiderReservedIP status, and requeues it to clean them up once the
+// reservation expires. Only the allocations from the IPPool of the key are
+// updated if it is set.
+func (rc *ReservedIPController) syncHandler(ctx context.Context, key reservedIPKey) error {
+	var rIP spiderpoolv2beta1.SpiderReservedIP
+	if err := rc.client.Get(ctx, apitypes.NamespacedName{Name: key.ReservedIP}, &rIP); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if rIP.DeletionTimestamp != nil {
+		return nil
+	}
+
+	now := time.Now()
+	var allocations []spiderpoolv2beta1.ReservedIPAllocation
+	if key.IPPool == "" {
+		pools, err := rc.poolLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list SpiderIPPools: %w", err)
+		}
+
+		allocations, err = AllocatedReservedIPs(ctx, rc.client, &rIP, pools, now)
+		if err != nil {
+			return err
+		}
+	} else {
+		var pools []*spiderpoolv2beta1.SpiderIPPool
+		pool, err := rc.poolLister.Get(key.IPPool)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get SpiderIPPool '%s': %w", key.IPPool, err)
+			}
+		} else {
+			pools = append(pools, pool)
+		}
+
+		poolAllocations, err := AllocatedReservedIPs(ctx, rc.client, &rIP, pools, now)
+		if err != nil {
+			return err
+		}
+		if !IsReservedIPExpired(&rIP, now) {
+			for _, a := range rIP.Status.AllocatedIPs {
+				if a.IPPool != key.IPPool {
+					allocations = append(allocations, a)
+				}
+			}
+			allocations = append(allocations, poolAllocations...)
+			sortReservedIPAllocations(allocations)
+		}
+	}
+
+	if !reflect.DeepEqual(rIP.Status.AllocatedIPs, allocations) || rIP.Status.AllocatedIPCount == nil {
+		rIP.Status.AllocatedIPs = allocations
+		rIP.Status.AllocatedIPCount = pointer.Int64(int64(len(allocations)))
+		if err := rc.client.Status().Update(ctx, &rIP); err != nil {
+			return fmt.Errorf("failed to update ReservedIP status: %w", err)
+		}
+		informerLogger.Sugar().Debugf("update SpiderReservedIP '%s' status with %d allocated IPs successfully", rIP.Name, len(allocations))
+	}
+
+	if rIP.Spec.ExpireTime != nil && !IsReservedIPExpired(&rIP, now) {
+		rc.rIPWorkqueue.AddAfter(reservedIPKey{ReservedIP: rIP.Name}, rIP.Spec.ExpireTime.Sub(now))
+	}
+
+	return nil
+}
+
+// AllocatedReservedIPs returns the IP addresses of the SpiderReservedIP which
+// are allocated from the IPPools in its scope to the Pods in its scope,
+// sorted by IPPool and IP. It returns nothing once the reservation expires.
+func AllocatedReservedIPs(ctx context.Context, reader client.Reader, rIP *spiderpoolv2beta1.SpiderReservedIP, pools []*spiderpoolv2beta1.SpiderIPPool, now time.Time) ([]spiderpoolv2beta1.ReservedIPAllocation, error) {
+	if rIP.Spec.IPVersion == nil || IsReservedIPExpired(rIP, now) {
+		return nil, nil
+	}
+
+	reservedIPs, err := spiderpoolip.ParseIPRanges(*rIP.Spec.IPVersion, rIP.Spec.IPs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse ReservedIP '%s' IPs: %v", constant.ErrWrongInput, rIP.Name, err)
+	}
+	reserved := make(map[string]struct{}, len(reservedIPs))
+	for _, ip := range reservedIPs {
+		reserved[ip.String()] = struct{}{}
+	}
+
+	pools, err = IPPoolsInScope(ctx, reader, rIP, pools)
+	if err != nil {
+		return nil, err
+	}
+
+	// the namespace scope is checked once for each namespace
+	namespacesInScope := map[string]bool{}
+	var allocations []spiderpoolv2beta1.ReservedIPAllocation
+	for _, pool := range pools {
+		if pool.Status.AllocatedIPs == nil {
+			continue
+		}
+
+		records, err := convert.UnmarshalIPPoolAllocatedIPs(pool.Status.AllocatedIPs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to parse IPPool '%s' status allocatedIPs: %v", constant.ErrWrongInput, pool.Name, err)
+		}
+
+		for ip, record := range records {
+			if _, ok := reserved[ip]; !ok {
+				continue
+			}
+
+			namespace, _, err := cache.SplitMetaNamespaceKey(record.NamespacedName)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid Pod '%s' recorded in IPPool '%s'", constant.ErrWrongInput, record.NamespacedName, pool.Name)
+			}
+			inScope, ok := namespacesInScope[namespace]
+			if !ok {
+				inScope, err = isInNamespaceScope(ctx, reader, rIP, namespace)
+				if err != nil {
+					return nil, fmt.Errorf("failed to check whether namespace '%s' is in the scope of ReservedIP '%s': %w", namespace, rIP.Name, err)
+				}
+				namespacesInScope[namespace] = inScope
+			}
+			if !inScope {
+				continue
+			}
+
+			allocations = append(allocations, spiderpoolv2beta1.ReservedIPAllocation{
+				IP:     ip,
+				IPPool: pool.Name,
+				Pod:    record.NamespacedName,
+			})
+		}
+	}
+	sortReservedIPAllocations(allocations)
+
+	return allocations, nil
+}
+
+func sortReservedIPAllocations(allocations []spiderpoolv2beta1.ReservedIPAllocation) {
+	sort.Slice(allocations, func(i, j int) bool {
+		if allocations[i].IPPool != allocations[j].IPPool {
+			return allocations[i].IPPool < allocations[j].IPPool
+		}
+		return spiderpoolip.Cmp(net.ParseIP(allocations[i].IP), net.ParseIP(allocations[j].IP)) < 0
+	})
+}
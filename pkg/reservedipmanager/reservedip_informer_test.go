@@ -0,0 +1,170 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+var _ = Describe("ReservedIPController", Label("reservedip_informer_test"), func() {
+	Describe("AllocatedReservedIPs", func() {
+		var ctx context.Context
+		var now time.Time
+		var rIP *spiderpoolv2beta1.SpiderReservedIP
+		var pools []*spiderpoolv2beta1.SpiderIPPool
+
+		newPool := func(name string, version int64, records spiderpoolv2beta1.PoolIPAllocations) *spiderpoolv2beta1.SpiderIPPool {
+			data, err := convert.MarshalIPPoolAllocatedIPs(records)
+			Expect(err).NotTo(HaveOccurred())
+
+			return &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       spiderpoolv2beta1.IPPoolSpec{IPVersion: pointer.Int64(version)},
+				Status:     spiderpoolv2beta1.IPPoolStatus{AllocatedIPs: data},
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			now = time.Now()
+			rIP = &spiderpoolv2beta1.SpiderReservedIP{
+				ObjectMeta: metav1.ObjectMeta{Name: "reservedip"},
+				Spec: spiderpoolv2beta1.ReservedIPSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					IPs:       []string{"172.18.40.1-172.18.40.10"},
+				},
+			}
+			pools = []*spiderpoolv2beta1.SpiderIPPool{
+				newPool("pool-b", constant.IPv4, spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.10": {NamespacedName: "default/pod-1", PodUID: "uid-1"},
+					"172.18.40.2":  {NamespacedName: "kube-system/pod-2", PodUID: "uid-2"},
+					"172.18.40.20": {NamespacedName: "default/pod-3", PodUID: "uid-3"},
+				}),
+				newPool("pool-a", constant.IPv4, spiderpoolv2beta1.PoolIPAllocations{
+					"172.18.40.5": {NamespacedName: "default/pod-4", PodUID: "uid-4"},
+				}),
+				newPool("pool-v6", constant.IPv6, spiderpoolv2beta1.PoolIPAllocations{
+					"abcd:1234::1": {NamespacedName: "default/pod-5", PodUID: "uid-5"},
+				}),
+			}
+		})
+
+		It("lists the allocated reserved IP addresses in order", func() {
+			allocations, err := reservedipmanager.AllocatedReservedIPs(ctx, fakeClient, rIP, pools, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allocations).To(Equal([]spiderpoolv2beta1.ReservedIPAllocation{
+				{IP: "172.18.40.5", IPPool: "pool-a", Pod: "default/pod-4"},
+				{IP: "172.18.40.2", IPPool: "pool-b", Pod: "kube-system/pod-2"},
+				{IP: "172.18.40.10", IPPool: "pool-b", Pod: "default/pod-1"},
+			}))
+		})
+
+		It("only lists the ones in the scopes", func() {
+			rIP.Spec.IPPoolName = []string{"pool-b"}
+			rIP.Spec.NamespaceName = []string{"default"}
+
+			allocations, err := reservedipmanager.AllocatedReservedIPs(ctx, fakeClient, rIP, pools, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allocations).To(Equal([]spiderpoolv2beta1.ReservedIPAllocation{
+				{IP: "172.18.40.10", IPPool: "pool-b", Pod: "default/pod-1"},
+			}))
+		})
+
+		It("lists nothing once the reservation expires", func() {
+			rIP.Spec.ExpireTime = &metav1.Time{Time: now.Add(-time.Second)}
+
+			allocations, err := reservedipmanager.AllocatedReservedIPs(ctx, fakeClient, rIP, pools, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allocations).To(BeEmpty())
+		})
+
+		It("fails to parse the invalid IP ranges", func() {
+			rIP.Spec.IPs = []string{constant.InvalidIPRange}
+
+			allocations, err := reservedipmanager.AllocatedReservedIPs(ctx, fakeClient, rIP, pools, now)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(allocations).To(BeEmpty())
+		})
+	})
+
+	Describe("IPPoolsInScope", func() {
+		var ctx context.Context
+		var rIP *spiderpoolv2beta1.SpiderReservedIP
+		var pools []*spiderpoolv2beta1.SpiderIPPool
+
+		newPool := func(name, subnetName string, version int64) *spiderpoolv2beta1.SpiderIPPool {
+			pool := &spiderpoolv2beta1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+				Spec:       spiderpoolv2beta1.IPPoolSpec{IPVersion: pointer.Int64(version)},
+			}
+			if subnetName != "" {
+				pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet] = subnetName
+			}
+			return pool
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			rIP = &spiderpoolv2beta1.SpiderReservedIP{
+				ObjectMeta: metav1.ObjectMeta{Name: "reservedip"},
+				Spec: spiderpoolv2beta1.ReservedIPSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					IPs:       []string{"172.18.40.1-172.18.40.10"},
+				},
+			}
+			pools = []*spiderpoolv2beta1.SpiderIPPool{
+				newPool("pool-a", "subnet-a", constant.IPv4),
+				newPool("pool-b", "subnet-b", constant.IPv4),
+				newPool("pool-c", "", constant.IPv4),
+				newPool("pool-v6", "subnet-a", constant.IPv6),
+			}
+		})
+
+		It("returns all the IPPools of the same IP version without scopes", func() {
+			inScope, err := reservedipmanager.IPPoolsInScope(ctx, fakeClient, rIP, pools)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inScope).To(Equal(pools[:3]))
+		})
+
+		It("returns the IPPools matching the IPPool names or the subnet names", func() {
+			rIP.Spec.IPPoolName = []string{"pool-c"}
+			rIP.Spec.SubnetName = []string{"subnet-a"}
+
+			inScope, err := reservedipmanager.IPPoolsInScope(ctx, fakeClient, rIP, pools)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inScope).To(Equal([]*spiderpoolv2beta1.SpiderIPPool{pools[0], pools[2]}))
+		})
+
+		It("returns the IPPools of the subnets matching the subnet affinity", func() {
+			subnet := &spiderpoolv2beta1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "subnet-b",
+					Labels: map[string]string{"reserved": "true"},
+				},
+			}
+			err := fakeClient.Create(ctx, subnet)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(fakeClient.Delete, ctx, subnet)
+
+			rIP.Spec.SubnetAffinity = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"reserved": "true"},
+			}
+
+			inScope, err := reservedipmanager.IPPoolsInScope(ctx, fakeClient, rIP, pools)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inScope).To(Equal([]*spiderpoolv2beta1.SpiderIPPool{pools[1]}))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +22,7 @@ import (
 type ReservedIPManager interface {
 	GetReservedIPByName(ctx context.Context, rIPName string, cached bool) (*spiderpoolv2beta1.SpiderReservedIP, error)
 	ListReservedIPs(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderReservedIPList, error)
-	AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error)
+	AssembleReservedIPs(ctx context.Context, version types.IPVersion, scope ReservedIPScope) ([]net.IP, error)
 }
 
 type reservedIPManager struct {
@@ -71,7 +72,9 @@ func (rm *reservedIPManager) ListReservedIPs(ctx context.Context, cached bool, o
 	return &rIPList, nil
 }
 
-func (rm *reservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error) {
+// AssembleReservedIPs returns the IP addresses of the SpiderReservedIPs which
+// apply to the scope and have not expired.
+func (rm *reservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion, scope ReservedIPScope) ([]net.IP, error) {
 	if err := spiderpoolip.IsIPVersion(version); err != nil {
 		return nil, err
 	}
@@ -81,9 +84,19 @@ func (rm *reservedIPManager) AssembleReservedIPs(ctx context.Context, version ty
 		return nil, err
 	}
 
+	now := time.Now()
 	var ranges []string
-	for _, r := range rIPList.Items {
-		if r.DeletionTimestamp == nil {
+	for i := range rIPList.Items {
+		r := &rIPList.Items[i]
+		if r.DeletionTimestamp != nil || IsReservedIPExpired(r, now) {
+			continue
+		}
+
+		inScope, err := IsReservedIPInScope(ctx, rm.client, r, scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check the scope of ReservedIP %s: %w", r.Name, err)
+		}
+		if inScope {
 			ranges = append(ranges, r.Spec.IPs...)
 		}
 	}
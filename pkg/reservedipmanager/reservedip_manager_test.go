@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -238,7 +240,7 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 
 		Describe("AssembleReservedIPs", func() {
 			It("inputs invalid IP version", func() {
-				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.InvalidIPVersion)
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.InvalidIPVersion, reservedipmanager.ReservedIPScope{})
 				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPVersion))
 				Expect(ips).To(BeEmpty())
 			})
@@ -247,7 +249,7 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				patches := gomonkey.ApplyMethodReturn(fakeClient, "List", constant.ErrUnknown)
 				defer patches.Reset()
 
-				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4)
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{})
 				Expect(err).To(MatchError(constant.ErrUnknown))
 				Expect(ips).To(BeEmpty())
 			})
@@ -269,7 +271,7 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				err = fakeClient.Delete(ctx, terminatingV4RIPT)
 				Expect(err).NotTo(HaveOccurred())
 
-				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4)
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(ips).To(Equal(
 					[]net.IP{
@@ -280,6 +282,94 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				))
 			})
 
+			It("does not assemble expired reserved-IP addresses", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				rIPT.Spec.ExpireTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
+			It("assembles the reserved-IP addresses limited to the IPPool", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				rIPT.Spec.IPPoolName = []string{"pool"}
+				rIPT.Spec.ExpireTime = &metav1.Time{Time: time.Now().Add(time.Hour)}
+
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				pool := &spiderpoolv2beta1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{IPPool: pool})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(Equal([]net.IP{net.IPv4(172, 18, 40, 1)}))
+
+				pool.Name = "other-pool"
+				ips, err = rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{IPPool: pool})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
+			It("assembles the reserved-IP addresses limited to the Subnet by affinity", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				rIPT.Spec.SubnetAffinity = &metav1.LabelSelector{MatchLabels: labels}
+
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				subnet := &spiderpoolv2beta1.SpiderSubnet{ObjectMeta: metav1.ObjectMeta{Name: rIPName, Labels: labels}}
+				err = fakeClient.Create(ctx, subnet)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(fakeClient.Delete, ctx, subnet)
+
+				pool := &spiderpoolv2beta1.SpiderIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "pool",
+						Labels: map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: subnet.Name},
+					},
+				}
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{IPPool: pool})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(Equal([]net.IP{net.IPv4(172, 18, 40, 1)}))
+
+				pool.Labels = nil
+				ips, err = rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{IPPool: pool})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
+			It("assembles the reserved-IP addresses limited to the namespace by affinity", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				rIPT.Spec.NamespaceAffinity = &metav1.LabelSelector{MatchLabels: labels}
+
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: rIPName, Labels: labels}}
+				err = fakeClient.Create(ctx, namespace)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(fakeClient.Delete, ctx, namespace)
+
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{Namespace: namespace.Name})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(Equal([]net.IP{net.IPv4(172, 18, 40, 1)}))
+
+				ips, err = rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{Namespace: "default"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+
+				ips, err = rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
 			It("exists invalid ReservedIPs in the cluster", func() {
 				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs, constant.InvalidIPRange)
@@ -287,7 +377,7 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				err := fakeClient.Create(ctx, rIPT)
 				Expect(err).NotTo(HaveOccurred())
 
-				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4)
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4, reservedipmanager.ReservedIPScope{})
 				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
 				Expect(ips).To(BeEmpty())
 			})
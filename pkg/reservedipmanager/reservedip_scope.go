@@ -0,0 +1,191 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// ReservedIPScope describes where the IP addresses are allocated, only the
+// SpiderReservedIPs applying to it are assembled.
+type ReservedIPScope struct {
+	// IPPool is the SpiderIPPool the IP addresses are allocated from.
+	IPPool *spiderpoolv2beta1.SpiderIPPool
+
+	// Subnet is the SpiderSubnet the IP addresses are allocated from, it is
+	// got from the owner label of the IPPool if it is not set.
+	Subnet *spiderpoolv2beta1.SpiderSubnet
+
+	// Namespace is the namespace of the Pod the IP addresses are allocated
+	// to. If it is empty, the SpiderReservedIPs limited to some namespaces
+	// are left out, for the IP addresses are not allocated to any Pod yet.
+	Namespace string
+}
+
+// IsReservedIPExpired reports whether the reservation has lapsed.
+func IsReservedIPExpired(rIP *spiderpoolv2beta1.SpiderReservedIP, now time.Time) bool {
+	return rIP.Spec.ExpireTime != nil && !now.Before(rIP.Spec.ExpireTime.Time)
+}
+
+// IsReservedIPInScope reports whether the SpiderReservedIP applies to the
+// scope, the SpiderSubnets and namespaces are got through the reader if
+// their labels are needed.
+func IsReservedIPInScope(ctx context.Context, reader client.Reader, rIP *spiderpoolv2beta1.SpiderReservedIP, scope ReservedIPScope) (bool, error) {
+	inScope, err := isInIPPoolScope(ctx, reader, rIP, scope.IPPool, scope.Subnet)
+	if err != nil || !inScope {
+		return false, err
+	}
+
+	return isInNamespaceScope(ctx, reader, rIP, scope.Namespace)
+}
+
+func isInIPPoolScope(ctx context.Context, reader client.Reader, rIP *spiderpoolv2beta1.SpiderReservedIP, pool *spiderpoolv2beta1.SpiderIPPool, subnet *spiderpoolv2beta1.SpiderSubnet) (bool, error) {
+	if subnet == nil && pool != nil && needSubnetLabels(rIP) {
+		if subnetName := pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]; subnetName != "" {
+			s := &spiderpoolv2beta1.SpiderSubnet{}
+			err := reader.Get(ctx, apitypes.NamespacedName{Name: subnetName}, s)
+			if client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+			if err == nil {
+				subnet = s
+			}
+		}
+	}
+
+	return matchIPPoolScope(rIP, pool, subnet)
+}
+
+// IPPoolsInScope returns the IPPools of the same IP version the
+// SpiderReservedIP applies to by its IPPool and subnet scopes. The
+// SpiderSubnets are listed once if their labels are needed, instead of
+// getting the one of each IPPool.
+func IPPoolsInScope(ctx context.Context, reader client.Reader, rIP *spiderpoolv2beta1.SpiderReservedIP, pools []*spiderpoolv2beta1.SpiderIPPool) ([]*spiderpoolv2beta1.SpiderIPPool, error) {
+	if rIP.Spec.IPVersion == nil {
+		return nil, nil
+	}
+
+	var subnets map[string]*spiderpoolv2beta1.SpiderSubnet
+	if needSubnetLabels(rIP) {
+		var subnetList spiderpoolv2beta1.SpiderSubnetList
+		if err := reader.List(ctx, &subnetList); err != nil {
+			return nil, fmt.Errorf("failed to list SpiderSubnets: %w", err)
+		}
+		subnets = make(map[string]*spiderpoolv2beta1.SpiderSubnet, len(subnetList.Items))
+		for i := range subnetList.Items {
+			subnets[subnetList.Items[i].Name] = &subnetList.Items[i]
+		}
+	}
+
+	var inScope []*spiderpoolv2beta1.SpiderIPPool
+	for _, pool := range pools {
+		if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != *rIP.Spec.IPVersion {
+			continue
+		}
+
+		matched, err := matchIPPoolScope(rIP, pool, subnets[pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]])
+		if err != nil {
+			return nil, fmt.Errorf("failed to check whether IPPool '%s' is in the scope of ReservedIP '%s': %w", pool.Name, rIP.Name, err)
+		}
+		if matched {
+			inScope = append(inScope, pool)
+		}
+	}
+
+	return inScope, nil
+}
+
+// needSubnetLabels reports whether the SpiderSubnets are matched with the
+// subnet affinity of the SpiderReservedIP.
+func needSubnetLabels(rIP *spiderpoolv2beta1.SpiderReservedIP) bool {
+	return len(rIP.Spec.SubnetName) == 0 && rIP.Spec.SubnetAffinity != nil
+}
+
+// matchIPPoolScope matches the IPPool with the IPPool and subnet scopes of the
+// SpiderReservedIP. The SpiderSubnet is the one the IPPool belongs to, it is
+// needed only to match the subnet affinity, and nil if it does not exist.
+func matchIPPoolScope(rIP *spiderpoolv2beta1.SpiderReservedIP, pool *spiderpoolv2beta1.SpiderIPPool, subnet *spiderpoolv2beta1.SpiderSubnet) (bool, error) {
+	poolScoped := len(rIP.Spec.IPPoolName) != 0 || rIP.Spec.IPPoolAffinity != nil
+	subnetScoped := len(rIP.Spec.SubnetName) != 0 || rIP.Spec.SubnetAffinity != nil
+	if !poolScoped && !subnetScoped {
+		return true, nil
+	}
+
+	if poolScoped && pool != nil {
+		matched, err := matchNameOrAffinity(pool.Name, pool.Labels, rIP.Spec.IPPoolName, rIP.Spec.IPPoolAffinity)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	if !subnetScoped {
+		return false, nil
+	}
+
+	var subnetName string
+	if subnet != nil {
+		subnetName = subnet.Name
+	} else if pool != nil {
+		subnetName = pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]
+	}
+	if subnetName == "" {
+		return false, nil
+	}
+	if len(rIP.Spec.SubnetName) != 0 {
+		return slices.Contains(rIP.Spec.SubnetName, subnetName), nil
+	}
+	if subnet == nil {
+		return false, nil
+	}
+
+	return matchNameOrAffinity(subnet.Name, subnet.Labels, nil, rIP.Spec.SubnetAffinity)
+}
+
+func isInNamespaceScope(ctx context.Context, reader client.Reader, rIP *spiderpoolv2beta1.SpiderReservedIP, namespace string) (bool, error) {
+	if len(rIP.Spec.NamespaceName) == 0 && rIP.Spec.NamespaceAffinity == nil {
+		return true, nil
+	}
+	if namespace == "" {
+		return false, nil
+	}
+	if len(rIP.Spec.NamespaceName) != 0 {
+		return slices.Contains(rIP.Spec.NamespaceName, namespace), nil
+	}
+
+	var ns corev1.Namespace
+	if err := reader.Get(ctx, apitypes.NamespacedName{Name: namespace}, &ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return matchNameOrAffinity(ns.Name, ns.Labels, nil, rIP.Spec.NamespaceAffinity)
+}
+
+// matchNameOrAffinity matches the object with the names first, and with the
+// label selector only if no name is specified.
+func matchNameOrAffinity(name string, objLabels map[string]string, names []string, affinity *metav1.LabelSelector) (bool, error) {
+	if len(names) != 0 {
+		return slices.Contains(names, name), nil
+	}
+	if affinity == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(affinity)
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(objLabels)), nil
+}
@@ -6,7 +6,9 @@ package reservedipmanager
 import (
 	"context"
 	"strconv"
+	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
@@ -16,8 +18,12 @@ import (
 )
 
 var (
-	ipVersionField *field.Path = field.NewPath("spec").Child("ipVersion")
-	ipsField       *field.Path = field.NewPath("spec").Child("ips")
+	ipVersionField         *field.Path = field.NewPath("spec").Child("ipVersion")
+	ipsField               *field.Path = field.NewPath("spec").Child("ips")
+	ipPoolAffinityField    *field.Path = field.NewPath("spec").Child("ippoolAffinity")
+	subnetAffinityField    *field.Path = field.NewPath("spec").Child("subnetAffinity")
+	namespaceAffinityField *field.Path = field.NewPath("spec").Child("namespaceAffinity")
+	expireTimeField        *field.Path = field.NewPath("spec").Child("expireTime")
 )
 
 func (rw *ReservedIPWebhook) validateCreateReservedIP(ctx context.Context, rIP *spiderpoolv2beta1.SpiderReservedIP) field.ErrorList {
@@ -29,6 +35,10 @@ func (rw *ReservedIPWebhook) validateCreateReservedIP(ctx context.Context, rIP *
 	if err := rw.validateReservedIPSpec(ctx, rIP); err != nil {
 		errs = append(errs, err)
 	}
+	errs = append(errs, validateReservedIPScope(rIP)...)
+	if err := validateReservedIPExpireTime(nil, rIP); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -50,6 +60,10 @@ func (rw *ReservedIPWebhook) validateUpdateReservedIP(ctx context.Context, oldRI
 	if err := rw.validateReservedIPSpec(ctx, newRIP); err != nil {
 		errs = append(errs, err)
 	}
+	errs = append(errs, validateReservedIPScope(newRIP)...)
+	if err := validateReservedIPExpireTime(oldRIP, newRIP); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -124,3 +138,35 @@ func (rw *ReservedIPWebhook) validateReservedIPs(ctx context.Context, version ty
 
 	return nil
 }
+
+func validateReservedIPScope(rIP *spiderpoolv2beta1.SpiderReservedIP) field.ErrorList {
+	var errs field.ErrorList
+	options := validation.LabelSelectorValidationOptions{AllowInvalidLabelValueInSelector: false}
+	errs = append(errs, validation.ValidateLabelSelector(rIP.Spec.IPPoolAffinity, options, ipPoolAffinityField)...)
+	errs = append(errs, validation.ValidateLabelSelector(rIP.Spec.SubnetAffinity, options, subnetAffinityField)...)
+	errs = append(errs, validation.ValidateLabelSelector(rIP.Spec.NamespaceAffinity, options, namespaceAffinityField)...)
+
+	return errs
+}
+
+// validateReservedIPExpireTime forbids setting an expire time which has
+// already passed, the reservation would never take effect.
+func validateReservedIPExpireTime(oldRIP, newRIP *spiderpoolv2beta1.SpiderReservedIP) *field.Error {
+	expireTime := newRIP.Spec.ExpireTime
+	if expireTime == nil {
+		return nil
+	}
+	if oldRIP != nil && oldRIP.Spec.ExpireTime != nil && oldRIP.Spec.ExpireTime.Equal(expireTime) {
+		return nil
+	}
+
+	if IsReservedIPExpired(newRIP, time.Now()) {
+		return field.Invalid(
+			expireTimeField,
+			expireTime,
+			"must be in the future",
+		)
+	}
+
+	return nil
+}
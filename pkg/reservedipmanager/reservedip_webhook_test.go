@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -249,6 +250,47 @@ var _ = Describe("ReservedIPWebhook", Label("reservedip_webhook_test"), func() {
 				})
 			})
 
+			When("Validating the scopes", func() {
+				It("inputs invalid 'spec.namespaceAffinity'", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.10")
+					rIPT.Spec.NamespaceAffinity = &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      "foo",
+							Operator: metav1.LabelSelectorOpIn,
+						}},
+					}
+
+					warns, err := rIPWebhook.ValidateCreate(ctx, rIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs invalid 'spec.ippoolAffinity'", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.10")
+					rIPT.Spec.IPPoolAffinity = &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "invalid value"},
+					}
+
+					warns, err := rIPWebhook.ValidateCreate(ctx, rIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.expireTime'", func() {
+				It("inputs expire time in the past", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.10")
+					rIPT.Spec.ExpireTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+					warns, err := rIPWebhook.ValidateCreate(ctx, rIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			It("creates IPv4 ReservedIP with all fields valid", func() {
 				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs,
@@ -362,6 +404,33 @@ var _ = Describe("ReservedIPWebhook", Label("reservedip_webhook_test"), func() {
 				Expect(warns).To(BeNil())
 			})
 
+			When("Validating 'spec.expireTime'", func() {
+				It("keeps the expire time which has passed", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.10")
+					rIPT.Spec.ExpireTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+					newRIPT := rIPT.DeepCopy()
+					newRIPT.Spec.IPs = append(newRIPT.Spec.IPs, "172.18.40.11")
+
+					warns, err := rIPWebhook.ValidateUpdate(ctx, rIPT, newRIPT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+
+				It("changes the expire time to the past", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.10")
+
+					newRIPT := rIPT.DeepCopy()
+					newRIPT.Spec.ExpireTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+
+					warns, err := rIPWebhook.ValidateUpdate(ctx, rIPT, newRIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			It("updates IPv4 ReservedIP with all fields valid", func() {
 				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.1-172.18.40.2")
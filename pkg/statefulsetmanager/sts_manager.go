@@ -7,8 +7,14 @@ import (
 	"context"
 	"fmt"
 
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
@@ -17,7 +23,7 @@ import (
 type StatefulSetManager interface {
 	GetStatefulSetByName(ctx context.Context, namespace, name string, cached bool) (*appsv1.StatefulSet, error)
 	ListStatefulSets(ctx context.Context, cached bool, opts ...client.ListOption) (*appsv1.StatefulSetList, error)
-	IsValidStatefulSetPod(ctx context.Context, namespace, podName, podControllerType string) (bool, error)
+	IsValidStatefulSetPod(ctx context.Context, namespace, podName, podControllerAPIVersion, podControllerType string) (bool, error)
 }
 
 type statefulSetManager struct {
@@ -67,12 +73,25 @@ func (sm *statefulSetManager) ListStatefulSets(ctx context.Context, cached bool,
 	return &stsList, nil
 }
 
+// IsStatefulSet checks whether the controller is a StatefulSet, the OpenKruise Advanced StatefulSet
+// is included since its Pods are named and re-created in the same way.
+func IsStatefulSet(apiVersion, kind string) bool {
+	if kind != constant.KindStatefulSet {
+		return false
+	}
+
+	return apiVersion == appsv1.SchemeGroupVersion.String() ||
+		apiVersion == kruisev1alpha1.SchemeGroupVersion.String() ||
+		apiVersion == kruisev1beta1.SchemeGroupVersion.String()
+}
+
 // IsValidStatefulSetPod only serves for StatefulSet pod, it will check the pod whether need to be cleaned up with the given params podNS, podName.
 // Once the pod's controller StatefulSet was deleted, the pod's corresponding IPPool IP and Endpoint need to be cleaned up.
 // Or the pod's controller StatefulSet decreased its replicas and the pod's index is out of replicas, it needs to be cleaned up too.
-func (sm *statefulSetManager) IsValidStatefulSetPod(ctx context.Context, namespace, podName, podControllerType string) (bool, error) {
-	if podControllerType != constant.KindStatefulSet {
-		return false, fmt.Errorf("pod '%s/%s' is controlled by '%s' instead of StatefulSet", namespace, podName, podControllerType)
+// The API version of the controller tells apart the OpenKruise Advanced StatefulSet, which has the same kind.
+func (sm *statefulSetManager) IsValidStatefulSetPod(ctx context.Context, namespace, podName, podControllerAPIVersion, podControllerType string) (bool, error) {
+	if !IsStatefulSet(podControllerAPIVersion, podControllerType) {
+		return false, fmt.Errorf("pod '%s/%s' is controlled by '%s %s' instead of StatefulSet", namespace, podName, podControllerAPIVersion, podControllerType)
 	}
 
 	stsName, replicas, found := getStatefulSetNameAndOrdinal(podName)
@@ -80,9 +99,16 @@ func (sm *statefulSetManager) IsValidStatefulSetPod(ctx context.Context, namespa
 		return false, nil
 	}
 
+	if podControllerAPIVersion != appsv1.SchemeGroupVersion.String() {
+		return sm.isValidAdvancedStatefulSetPod(ctx, namespace, stsName, replicas)
+	}
+
 	sts, err := sm.GetStatefulSetByName(ctx, namespace, stsName, constant.IgnoreCache)
 	if err != nil {
-		return false, client.IgnoreNotFound(err)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
 	}
 
 	// Ref: https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#start-ordinal
@@ -103,3 +129,34 @@ func (sm *statefulSetManager) IsValidStatefulSetPod(ctx context.Context, namespa
 	// StatefulSet scaled down.
 	return false, nil
 }
+
+// isValidAdvancedStatefulSetPod checks the Pod of the OpenKruise Advanced StatefulSet, whose
+// ordinals skip the reserved ones.
+// Ref: https://openkruise.io/docs/user-manuals/advancedstatefulset/#reserved-ordinals
+func (sm *statefulSetManager) isValidAdvancedStatefulSetPod(ctx context.Context, namespace, name string, ordinal int) (bool, error) {
+	var sts kruisev1beta1.StatefulSet
+	if err := sm.apiReader.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, &sts); err != nil {
+		// The CRD of Advanced StatefulSet may not be installed.
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	reserved := make(map[int]struct{}, len(sts.Spec.ReserveOrdinals))
+	for _, o := range sts.Spec.ReserveOrdinals {
+		reserved[o] = struct{}{}
+	}
+	if _, ok := reserved[ordinal]; ok {
+		return false, nil
+	}
+
+	var index int
+	for i := 0; i < ordinal; i++ {
+		if _, ok := reserved[i]; !ok {
+			index++
+		}
+	}
+
+	return index < int(pointer.Int32Deref(sts.Spec.Replicas, 1)), nil
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -226,7 +229,7 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 
 		Describe("IsValidStatefulSetPod", func() {
 			It("is not a Pod of StatefulSet", func() {
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, "orphan-pod", constant.KindPod)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, "orphan-pod", corev1.SchemeGroupVersion.String(), constant.KindPod)
 				Expect(err).To(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
@@ -235,13 +238,13 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				patches := gomonkey.ApplyFuncReturn(strconv.ParseInt, int64(0), constant.ErrUnknown)
 				defer patches.Reset()
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
 
 			It("is a valid Pod controlled by StatefulSet, but the StatefulSet no longer exists", func() {
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
@@ -250,7 +253,7 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				patches := gomonkey.ApplyMethodReturn(fakeAPIReader, "Get", constant.ErrUnknown)
 				defer patches.Reset()
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).To(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
@@ -262,7 +265,7 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				err := tracker.Add(stsT)
 				Expect(err).NotTo(HaveOccurred())
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, replicas), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, replicas), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
@@ -274,7 +277,7 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				err := tracker.Add(stsT)
 				Expect(err).NotTo(HaveOccurred())
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, replicas-1), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, replicas-1), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeTrue())
 			})
@@ -286,7 +289,7 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				err := tracker.Add(stsT)
 				Expect(err).NotTo(HaveOccurred())
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 0), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeFalse())
 			})
@@ -298,10 +301,73 @@ var _ = Describe("StatefulSetManager", Label("sts_manager_test"), func() {
 				err := tracker.Add(stsT)
 				Expect(err).NotTo(HaveOccurred())
 
-				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 2), constant.KindStatefulSet)
+				valid, err := stsManager.IsValidStatefulSetPod(ctx, stsT.Namespace, fmt.Sprintf("%s-%d", stsName, 2), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(valid).To(BeTrue())
 			})
+
+			Context("OpenKruise Advanced StatefulSet", func() {
+				var advancedSts *kruisev1beta1.StatefulSet
+
+				BeforeEach(func() {
+					advancedSts = &kruisev1beta1.StatefulSet{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       constant.KindStatefulSet,
+							APIVersion: kruisev1beta1.SchemeGroupVersion.String(),
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      stsName,
+							Namespace: namespace,
+						},
+						Spec: kruisev1beta1.StatefulSetSpec{
+							Replicas:        pointer.Int32(2),
+							ReserveOrdinals: []int{1},
+						},
+					}
+
+					err := tracker.Add(advancedSts)
+					Expect(err).NotTo(HaveOccurred())
+
+					DeferCleanup(func() {
+						err := tracker.Delete(kruisev1beta1.SchemeGroupVersion.WithResource("statefulsets"), namespace, stsName)
+						Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+					})
+				})
+
+				It("is a valid Pod controlled by Advanced StatefulSet", func() {
+					valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, fmt.Sprintf("%s-%d", stsName, 2), kruisev1beta1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(valid).To(BeTrue())
+				})
+
+				It("the ordinal of the Pod is reserved", func() {
+					valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, fmt.Sprintf("%s-%d", stsName, 1), kruisev1beta1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(valid).To(BeFalse())
+				})
+
+				It("does not look up the Advanced StatefulSet for the Pod of StatefulSet", func() {
+					valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, fmt.Sprintf("%s-%d", stsName, 0), appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(valid).To(BeFalse())
+				})
+
+				It("used to be a Pod controlled by Advanced StatefulSet, but it scaled down", func() {
+					valid, err := stsManager.IsValidStatefulSetPod(ctx, namespace, fmt.Sprintf("%s-%d", stsName, 3), kruisev1beta1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(valid).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("IsStatefulSet", func() {
+		It("checks the controller", func() {
+			Expect(statefulsetmanager.IsStatefulSet(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeTrue())
+			Expect(statefulsetmanager.IsStatefulSet(kruisev1alpha1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeTrue())
+			Expect(statefulsetmanager.IsStatefulSet(kruisev1beta1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeTrue())
+			Expect(statefulsetmanager.IsStatefulSet(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)).To(BeFalse())
+			Expect(statefulsetmanager.IsStatefulSet("example.io/v1", constant.KindStatefulSet)).To(BeFalse())
 		})
 	})
 })
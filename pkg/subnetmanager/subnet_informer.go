@@ -14,6 +14,7 @@ import (
 	otelapi "go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -350,6 +351,9 @@ func (sc *SubnetController) processDynamicNextWorkItem(ctx context.Context) bool
 func (sc *SubnetController) syncHandler(ctx context.Context, subnetName string) (err error) {
 	subnet, err := sc.SubnetsLister.Get(subnetName)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metric.SubnetUsageLevel.Delete(attribute.String(constant.KindSpiderSubnet, subnetName))
+		}
 		return client.IgnoreNotFound(err)
 	}
 
@@ -367,6 +371,10 @@ func (sc *SubnetController) syncHandler(ctx context.Context, subnetName string)
 		return fmt.Errorf("failed to sync the IP ranges of controlled IPPools of Subnet: %v", err)
 	}
 
+	if err := sc.syncConditions(ctx, subnetCopy); err != nil {
+		return fmt.Errorf("failed to sync the conditions of Subnet: %v", err)
+	}
+
 	if subnet.DeletionTimestamp != nil {
 		if err := sc.removeFinalizer(ctx, subnetCopy); err != nil {
 			return fmt.Errorf("failed to remove finalizer: %v", err)
@@ -561,6 +569,65 @@ func (sc *SubnetController) syncControlledIPPoolIPs(ctx context.Context, subnet
 	return nil
 }
 
+// syncConditions updates the Ready and NearlyExhausted conditions of the
+// SpiderSubnet status, and emits an event once the usage level changes.
+func (sc *SubnetController) syncConditions(ctx context.Context, subnet *spiderpoolv2beta1.SpiderSubnet) error {
+	var allocatedIPCount, totalIPCount int64
+	if subnet.Status.AllocatedIPCount != nil {
+		allocatedIPCount = *subnet.Status.AllocatedIPCount
+	}
+	if subnet.Status.TotalIPCount != nil {
+		totalIPCount = *subnet.Status.TotalIPCount
+	}
+
+	readyCondition := metav1.Condition{
+		Type:               constant.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             constant.ConditionReasonAvailable,
+		Message:            "the Subnet is ready to provide IP addresses",
+		ObservedGeneration: subnet.Generation,
+	}
+	switch {
+	case subnet.DeletionTimestamp != nil:
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = constant.ConditionReasonTerminating
+		readyCondition.Message = "the Subnet is terminating"
+	case totalIPCount == 0:
+		readyCondition.Status = metav1.ConditionFalse
+		readyCondition.Reason = constant.ConditionReasonNoAvailableIPs
+		readyCondition.Message = "the Subnet has no IP addresses"
+	}
+
+	oldConditions := make([]metav1.Condition, len(subnet.Status.Conditions))
+	copy(oldConditions, subnet.Status.Conditions)
+	meta.SetStatusCondition(&subnet.Status.Conditions, readyCondition)
+	changed := !reflect.DeepEqual(oldConditions, subnet.Status.Conditions)
+
+	attr := attribute.String(constant.KindSpiderSubnet, subnet.Name)
+	oldLevel := ippoolmanager.UsageLevelOf(subnet.Status.Conditions)
+	if subnet.DeletionTimestamp == nil &&
+		ippoolmanager.SetUsageCondition(&subnet.Status.Conditions, subnet.Spec.UsageThresholds, allocatedIPCount, totalIPCount, subnet.Generation) {
+		changed = true
+	}
+
+	if changed {
+		if err := sc.Client.Status().Update(ctx, subnet); err != nil {
+			return err
+		}
+	}
+
+	if subnet.DeletionTimestamp != nil {
+		metric.SubnetUsageLevel.Delete(attr)
+		return nil
+	}
+
+	newLevel := ippoolmanager.UsageLevelOf(subnet.Status.Conditions)
+	ippoolmanager.RecordUsageEvent(subnet, subnet.Status.Conditions, oldLevel, newLevel)
+	metric.SubnetUsageLevel.Record(int64(newLevel), attr)
+
+	return nil
+}
+
 func (sc *SubnetController) removeFinalizer(ctx context.Context, subnet *spiderpoolv2beta1.SpiderSubnet) error {
 	logger := logutils.FromContext(ctx)
 
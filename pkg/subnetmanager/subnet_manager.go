@@ -147,8 +147,11 @@ func (sm *subnetManager) ReconcileAutoIPPool(ctx context.Context, pool *spiderpo
 				Subnet:    subnet.Spec.Subnet,
 				Gateway:   subnet.Spec.Gateway,
 				//Vlan:        subnet.Spec.Vlan,
-				Routes:      subnet.Spec.Routes,
-				PodAffinity: ippoolmanager.NewAutoPoolPodAffinity(podController),
+				Routes:             subnet.Spec.Routes,
+				PodAffinity:        ippoolmanager.NewAutoPoolPodAffinity(podController),
+				AllocationStrategy: subnet.Spec.AllocationStrategy,
+				QuarantineSeconds:  subnet.Spec.QuarantineSeconds,
+				DNS:                subnet.Spec.DNS,
 			},
 		}
 
@@ -362,7 +365,11 @@ func (sm *subnetManager) preAllocateIPsFromSubnet(ctx context.Context, subnet *s
 	}
 
 	// filter reserved IPs
-	reservedIPs, err := sm.rIPManager.AssembleReservedIPs(ctx, ipVersion)
+	reservedIPs, err := sm.rIPManager.AssembleReservedIPs(ctx, ipVersion, reservedipmanager.ReservedIPScope{
+		IPPool:    pool,
+		Subnet:    subnet,
+		Namespace: podController.Namespace,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to filter reservedIPs '%v' by IP version '%d', error: %v",
 			constant.ErrWrongInput, reservedIPs, ipVersion, err)
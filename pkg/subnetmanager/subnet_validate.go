@@ -26,6 +26,11 @@ var (
 	gatewayField           *field.Path = field.NewPath("spec").Child("gateway")
 	routesField            *field.Path = field.NewPath("spec").Child("routes")
 	controlledIPPoolsField *field.Path = field.NewPath("status").Child("controlledIPPools")
+
+	allocationStrategyField *field.Path = field.NewPath("spec").Child("allocationStrategy")
+	quarantineSecondsField  *field.Path = field.NewPath("spec").Child("quarantineSeconds")
+	dnsField                *field.Path = field.NewPath("spec").Child("dns")
+	usageThresholdsField    *field.Path = field.NewPath("spec").Child("usageThresholds")
 )
 
 func (sw *SubnetWebhook) validateCreateSubnet(ctx context.Context, subnet *spiderpoolv2beta1.SpiderSubnet) field.ErrorList {
@@ -103,6 +108,18 @@ func (sw *SubnetWebhook) validateSubnetSpec(ctx context.Context, subnet *spiderp
 	if err := validateSubnetGateway(subnet); err != nil {
 		return err
 	}
+	if err := ippoolmanager.ValidateAllocationStrategy(allocationStrategyField, subnet.Spec.AllocationStrategy); err != nil {
+		return err
+	}
+	if err := ippoolmanager.ValidateQuarantineSeconds(quarantineSecondsField, subnet.Spec.QuarantineSeconds); err != nil {
+		return err
+	}
+	if err := ippoolmanager.ValidateDNS(dnsField, subnet.Spec.DNS); err != nil {
+		return err
+	}
+	if err := ippoolmanager.ValidateUsageThresholds(usageThresholdsField, subnet.Spec.UsageThresholds); err != nil {
+		return err
+	}
 
 	return validateSubnetRoutes(*subnet.Spec.IPVersion, subnet.Spec.Subnet, subnet.Spec.Routes)
 }
@@ -738,6 +738,74 @@ var _ = Describe("SubnetWebhook", Label("subnet_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'spec.allocationStrategy'", func() {
+				It("inputs unsupported allocation strategy", func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					subnetT.Spec.AllocationStrategy = pointer.String("highest")
+
+					warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+
+				It("inputs supported allocation strategy", func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					subnetT.Spec.AllocationStrategy = pointer.String(constant.AllocationStrategyLeastRecentlyReleased)
+
+					warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.quarantineSeconds'", func() {
+				It("inputs negative quarantine seconds", func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					subnetT.Spec.QuarantineSeconds = pointer.Int64(-1)
+
+					warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.dns'", func() {
+				It("inputs invalid nameserver", func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					subnetT.Spec.DNS = &spiderpoolv2beta1.DNS{
+						Nameservers: []string{"172.18.40.256"},
+					}
+
+					warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
+			When("Validating 'spec.usageThresholds'", func() {
+				It("inputs warning threshold greater than critical threshold", func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.2-172.18.40.3")
+					subnetT.Spec.UsageThresholds = &spiderpoolv2beta1.UsageThresholds{
+						Warning:  pointer.Int64(90),
+						Critical: pointer.Int64(80),
+					}
+
+					warns, err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(warns).To(BeNil())
+				})
+			})
+
 			When("Validating 'spec.routes'", func() {
 				It("inputs default route", func() {
 					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
@@ -42,6 +42,14 @@ type AnnoIPPoolItem struct {
 	CleanGateway bool     `json:"cleangateway"`
 }
 
+type AnnoPodIPsValue []AnnoIPItem
+
+type AnnoIPItem struct {
+	NIC  string `json:"interface,omitempty"`
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
 type AnnoPodRoutesValue []AnnoRouteItem
 
 type AnnoRouteItem struct {
@@ -49,6 +57,13 @@ type AnnoRouteItem struct {
 	Gw  string `json:"gw"`
 }
 
+type AnnoPodDNSValue struct {
+	Nameservers []string `json:"nameservers,omitempty"`
+	Domain      string   `json:"domain,omitempty"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
 type AnnoNSDefautlV4PoolValue []string
 
 type AnnoNSDefautlV6PoolValue []string
@@ -59,6 +74,7 @@ type PodSubnetAnnoConfig struct {
 	FlexibleIPNum   *int
 	AssignIPNum     int
 	ReclaimIPPool   bool
+	SizingPolicy    string
 }
 
 func (in *PodSubnetAnnoConfig) String() string {
@@ -71,7 +87,8 @@ func (in *PodSubnetAnnoConfig) String() string {
 		`SingleSubnet:` + strings.Replace(strings.Replace(in.SingleSubnet.String(), "AnnoSubnetItem", "", 1), `&`, ``, 1) + `,`,
 		`FlexibleIPNum:` + stringutil.ValueToStringGenerated(in.FlexibleIPNum) + `,`,
 		`AssignIPNumber:` + fmt.Sprintf("%v", in.AssignIPNum) + `,`,
-		`ReclaimIPPool:` + fmt.Sprintf("%v", in.ReclaimIPPool),
+		`ReclaimIPPool:` + fmt.Sprintf("%v", in.ReclaimIPPool) + `,`,
+		`SizingPolicy:` + fmt.Sprintf("%v", in.SizingPolicy),
 		`}`,
 	}, "")
 	return s
@@ -0,0 +1,287 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package convert
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// CompactAllocatedIPsPrefix marks the IPPool.Status.AllocatedIPs recorded in
+// the compact format, the ones without it are JSON.
+const CompactAllocatedIPsPrefix = "compact/v1:"
+
+var errInvalidCompactAllocatedIPs = errors.New("invalid compact IP allocation records")
+
+// MarshalIPPoolAllocatedIPsCompact records the IP allocations in the compact
+// format. The records are sorted by IP address, each IP address only keeps
+// the bytes differing from the previous one, each Pod name only keeps the
+// suffix differing from the previous one, the namespaces are indexed and the
+// Pod UIDs are kept as 16 bytes. It falls back to JSON if any IP address is
+// invalid.
+func MarshalIPPoolAllocatedIPsCompact(records spiderpoolv2beta1.PoolIPAllocations) (*string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	type compactRecord struct {
+		ip        [net.IPv6len]byte
+		namespace string
+		name      string
+		uid       string
+	}
+
+	compactRecords := make([]*compactRecord, 0, len(records))
+	for ip, r := range records {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || addr.Is4In6() || addr.Zone() != "" || addr.String() != ip {
+			return MarshalIPPoolAllocatedIPs(records)
+		}
+
+		namespace, name, found := strings.Cut(r.NamespacedName, "/")
+		if !found || namespace == "" {
+			namespace, name = "", r.NamespacedName
+		}
+		compactRecords = append(compactRecords, &compactRecord{
+			ip:        addr.As16(),
+			namespace: namespace,
+			name:      name,
+			uid:       r.PodUID,
+		})
+	}
+	sort.Slice(compactRecords, func(i, j int) bool {
+		return bytes.Compare(compactRecords[i].ip[:], compactRecords[j].ip[:]) < 0
+	})
+
+	var namespaces []string
+	namespaceIndexes := map[string]int{}
+	for _, r := range compactRecords {
+		if _, ok := namespaceIndexes[r.namespace]; !ok {
+			namespaceIndexes[r.namespace] = len(namespaces)
+			namespaces = append(namespaces, r.namespace)
+		}
+	}
+
+	buf := make([]byte, 0, len(compactRecords)*32)
+	buf = binary.AppendUvarint(buf, uint64(len(namespaces)))
+	for _, ns := range namespaces {
+		buf = appendString(buf, ns)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(compactRecords)))
+	var prevIP [net.IPv6len]byte
+	prevName := ""
+	for _, r := range compactRecords {
+		n := commonPrefixLen(prevIP[:], r.ip[:])
+		buf = append(buf, byte(n))
+		buf = append(buf, r.ip[n:]...)
+		prevIP = r.ip
+
+		buf = binary.AppendUvarint(buf, uint64(namespaceIndexes[r.namespace]))
+
+		n = commonPrefixLen([]byte(prevName), []byte(r.name))
+		buf = binary.AppendUvarint(buf, uint64(n))
+		buf = appendString(buf, r.name[n:])
+		prevName = r.name
+
+		if b, ok := appendUID(append(buf, 0), r.uid); ok {
+			buf = b
+		} else {
+			buf = append(buf, 1)
+			buf = appendString(buf, r.uid)
+		}
+	}
+
+	data := CompactAllocatedIPsPrefix + base64.StdEncoding.EncodeToString(buf)
+
+	return &data, nil
+}
+
+func unmarshalIPPoolAllocatedIPsCompact(data string) (spiderpoolv2beta1.PoolIPAllocations, error) {
+	buf, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	r := &compactReader{buf: buf}
+
+	namespaceCount := r.uvarint()
+	if namespaceCount > uint64(len(buf)) {
+		return nil, errInvalidCompactAllocatedIPs
+	}
+	namespaces := make([]string, 0, namespaceCount)
+	for i := uint64(0); i < namespaceCount; i++ {
+		namespaces = append(namespaces, r.string())
+	}
+
+	recordCount := r.uvarint()
+	if recordCount > uint64(len(buf)) {
+		return nil, errInvalidCompactAllocatedIPs
+	}
+	records := make(spiderpoolv2beta1.PoolIPAllocations, recordCount)
+	ip := make(net.IP, net.IPv6len)
+	name := ""
+	for i := uint64(0); i < recordCount && r.err == nil; i++ {
+		n := int(r.byte())
+		if n > net.IPv6len {
+			return nil, errInvalidCompactAllocatedIPs
+		}
+		ip = append(ip[:n:n], r.bytes(net.IPv6len-n)...)
+
+		namespaceIndex := r.uvarint()
+		if namespaceIndex >= uint64(len(namespaces)) {
+			return nil, errInvalidCompactAllocatedIPs
+		}
+
+		n = int(r.uvarint())
+		if n > len(name) {
+			return nil, errInvalidCompactAllocatedIPs
+		}
+		name = name[:n] + r.string()
+
+		var uid string
+		if r.byte() == 0 {
+			uid = formatUID(r.bytes(16))
+		} else {
+			uid = r.string()
+		}
+
+		namespacedName := name
+		if namespaces[namespaceIndex] != "" {
+			namespacedName = namespaces[namespaceIndex] + "/" + name
+		}
+		records[ip.String()] = spiderpoolv2beta1.PoolIPAllocation{
+			NamespacedName: namespacedName,
+			PodUID:         uid,
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return records, nil
+}
+
+// compactReader reads the compact IP allocation records, the first error
+// sticks so that it only needs to be checked once.
+type compactReader struct {
+	buf []byte
+	err error
+}
+
+func (r *compactReader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.buf) {
+		r.err = errInvalidCompactAllocatedIPs
+		return make([]byte, n)
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+
+	return b
+}
+
+func (r *compactReader) byte() byte {
+	return r.bytes(1)[0]
+}
+
+func (r *compactReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = errInvalidCompactAllocatedIPs
+		return 0
+	}
+	r.buf = r.buf[n:]
+
+	return v
+}
+
+func (r *compactReader) string() string {
+	n := r.uvarint()
+	if n > uint64(len(r.buf)) {
+		r.err = errInvalidCompactAllocatedIPs
+		return ""
+	}
+
+	return string(r.bytes(int(n)))
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func commonPrefixLen(a, b []byte) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+
+	return n
+}
+
+// uidDashes are the positions of the dashes of a UUID.
+var uidDashes = [4]int{8, 13, 18, 23}
+
+// appendUID appends the 16 bytes of a UUID in lower case, the other UIDs are
+// not accepted since they could not be formatted back into the same string.
+func appendUID(buf []byte, uid string) ([]byte, bool) {
+	if len(uid) != 36 {
+		return nil, false
+	}
+
+	b := buf
+	start := 0
+	for _, dash := range append(uidDashes[:], len(uid)) {
+		if dash < len(uid) && uid[dash] != '-' {
+			return nil, false
+		}
+		for i := start; i < dash; i += 2 {
+			hi, ok1 := fromLowerHex(uid[i])
+			lo, ok2 := fromLowerHex(uid[i+1])
+			if !ok1 || !ok2 {
+				return nil, false
+			}
+			b = append(b, hi<<4|lo)
+		}
+		start = dash + 1
+	}
+
+	return b, true
+}
+
+func fromLowerHex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	}
+
+	return 0, false
+}
+
+func formatUID(b []byte) string {
+	var uid [36]byte
+	hex.Encode(uid[0:8], b[0:4])
+	hex.Encode(uid[9:13], b[4:6])
+	hex.Encode(uid[14:18], b[6:8])
+	hex.Encode(uid[19:23], b[8:10])
+	hex.Encode(uid[24:36], b[10:16])
+	for _, dash := range uidDashes {
+		uid[dash] = '-'
+	}
+
+	return string(uid[:])
+}
@@ -0,0 +1,120 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package convert_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/pointer"
+
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+)
+
+var _ = Describe("IPPool allocated IPs", Label("allocated_ips_test"), func() {
+	var records spiderpoolv2beta1.PoolIPAllocations
+
+	BeforeEach(func() {
+		records = spiderpoolv2beta1.PoolIPAllocations{
+			"172.18.40.2":  {NamespacedName: "default/nginx-7c5d8b6b9-abcde", PodUID: string(uuid.NewUUID())},
+			"172.18.40.3":  {NamespacedName: "default/nginx-7c5d8b6b9-fghij", PodUID: string(uuid.NewUUID())},
+			"172.18.41.10": {NamespacedName: "kube-system/coredns-0", PodUID: string(uuid.NewUUID())},
+			"172.18.40.1":  {NamespacedName: "vm", PodUID: "not-a-uuid"},
+		}
+	})
+
+	It("records nothing for no allocation", func() {
+		data, err := convert.MarshalIPPoolAllocatedIPsCompact(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(BeNil())
+	})
+
+	It("parses the IP allocations recorded in the compact format", func() {
+		data, err := convert.MarshalIPPoolAllocatedIPsCompact(records)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*data).To(HavePrefix(convert.CompactAllocatedIPsPrefix))
+
+		parsed, err := convert.UnmarshalIPPoolAllocatedIPs(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(records))
+	})
+
+	It("parses the IPv6 allocations recorded in the compact format", func() {
+		records = spiderpoolv2beta1.PoolIPAllocations{
+			"fd00:172:18::2":      {NamespacedName: "default/pod-1", PodUID: string(uuid.NewUUID())},
+			"fd00:172:18::1:2":    {NamespacedName: "default/pod-2", PodUID: string(uuid.NewUUID())},
+			"fd00:172:19::ffff:1": {NamespacedName: "test/pod-1", PodUID: string(uuid.NewUUID())},
+		}
+		data, err := convert.MarshalIPPoolAllocatedIPsCompact(records)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed, err := convert.UnmarshalIPPoolAllocatedIPs(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(records))
+	})
+
+	It("still parses the IP allocations recorded in JSON", func() {
+		data, err := convert.MarshalIPPoolAllocatedIPs(records)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*data).To(HavePrefix("{"))
+
+		parsed, err := convert.UnmarshalIPPoolAllocatedIPs(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(records))
+	})
+
+	DescribeTable("falls back to JSON for the IP addresses not formatted back",
+		func(ip string) {
+			records[ip] = spiderpoolv2beta1.PoolIPAllocation{NamespacedName: "default/pod", PodUID: string(uuid.NewUUID())}
+			data, err := convert.MarshalIPPoolAllocatedIPsCompact(records)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*data).To(HavePrefix("{"))
+
+			parsed, err := convert.UnmarshalIPPoolAllocatedIPs(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(records))
+		},
+		Entry("invalid IP address", "172.18.040.4"),
+		Entry("IPv4-mapped IPv6 address", "::ffff:172.18.40.4"),
+		Entry("IPv6 address not in canonical form", "fd00:172:18:0::4"),
+	)
+
+	It("fails to parse the broken records in the compact format", func() {
+		data, err := convert.MarshalIPPoolAllocatedIPsCompact(records)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, broken := range []string{
+			(*data)[:len(*data)-8],
+			convert.CompactAllocatedIPsPrefix + "AQ==",
+			convert.CompactAllocatedIPsPrefix + "%%%",
+		} {
+			_, err := convert.UnmarshalIPPoolAllocatedIPs(pointer.String(broken))
+			Expect(err).To(HaveOccurred(), broken)
+		}
+	})
+
+	It("takes much less space than JSON", func() {
+		records = spiderpoolv2beta1.PoolIPAllocations{}
+		for i := 1; i <= 50000; i++ {
+			records[fmt.Sprintf("10.6.%d.%d", i/256, i%256)] = spiderpoolv2beta1.PoolIPAllocation{
+				NamespacedName: fmt.Sprintf("default/nginx-7c5d8b6b9-%05d", i),
+				PodUID:         string(uuid.NewUUID()),
+			}
+		}
+
+		jsonData, err := convert.MarshalIPPoolAllocatedIPs(records)
+		Expect(err).NotTo(HaveOccurred())
+		compactData, err := convert.MarshalIPPoolAllocatedIPsCompact(records)
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoWriter.Printf("50000 IP allocations: JSON %d bytes, compact %d bytes\n", len(*jsonData), len(*compactData))
+		Expect(len(*compactData) * 2).To(BeNumerically("<", len(*jsonData)))
+
+		parsed, err := convert.UnmarshalIPPoolAllocatedIPs(compactData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(records))
+	})
+})
@@ -228,6 +228,28 @@ func ConvertSpecRoutesToOAIRoutes(nic string, specRoutes []spiderpoolv2beta1.Rou
 	return routes
 }
 
+func ConvertSpecDNSToOAIDNS(specDNS *spiderpoolv2beta1.DNS) *models.DNS {
+	if specDNS == nil {
+		return nil
+	}
+
+	return &models.DNS{
+		Nameservers: append([]string{}, specDNS.Nameservers...),
+		Domain:      specDNS.Domain,
+		Search:      append([]string{}, specDNS.Search...),
+		Options:     append([]string{}, specDNS.Options...),
+	}
+}
+
+func ConvertAnnoPodDNSToOAIDNS(annoPodDNS types.AnnoPodDNSValue) *models.DNS {
+	return &models.DNS{
+		Nameservers: append([]string{}, annoPodDNS.Nameservers...),
+		Domain:      annoPodDNS.Domain,
+		Search:      append([]string{}, annoPodDNS.Search...),
+		Options:     append([]string{}, annoPodDNS.Options...),
+	}
+}
+
 func ConvertOAIRoutesToSpecRoutes(oaiRoutes []*models.Route) []spiderpoolv2beta1.Route {
 	var routes []spiderpoolv2beta1.Route
 	for _, r := range oaiRoutes {
@@ -279,10 +301,15 @@ func GenIPConfigResult(allocateIP net.IP, nic string, ipPool *spiderpoolv2beta1.
 	}
 }
 
+// UnmarshalIPPoolAllocatedIPs parses the IP allocation records of the IPPool,
+// recorded either in JSON or in the compact format.
 func UnmarshalIPPoolAllocatedIPs(data *string) (spiderpoolv2beta1.PoolIPAllocations, error) {
 	if data == nil {
 		return nil, nil
 	}
+	if compact, ok := strings.CutPrefix(*data, CompactAllocatedIPsPrefix); ok {
+		return unmarshalIPPoolAllocatedIPsCompact(compact)
+	}
 
 	var records spiderpoolv2beta1.PoolIPAllocations
 	if err := json.Unmarshal([]byte(*data), &records); err != nil {
@@ -306,6 +333,87 @@ func MarshalIPPoolAllocatedIPs(records spiderpoolv2beta1.PoolIPAllocations) (*st
 	return &data, nil
 }
 
+func UnmarshalIPPoolReleasedIPs(data *string) (spiderpoolv2beta1.PoolIPReleases, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var records spiderpoolv2beta1.PoolIPReleases
+	if err := json.Unmarshal([]byte(*data), &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func MarshalIPPoolReleasedIPs(records spiderpoolv2beta1.PoolIPReleases) (*string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	v, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	data := string(v)
+
+	return &data, nil
+}
+
+func UnmarshalIPPoolCoolingIPs(data *string) (spiderpoolv2beta1.PoolIPCoolings, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var records spiderpoolv2beta1.PoolIPCoolings
+	if err := json.Unmarshal([]byte(*data), &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func MarshalIPPoolCoolingIPs(records spiderpoolv2beta1.PoolIPCoolings) (*string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	v, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	data := string(v)
+
+	return &data, nil
+}
+
+func UnmarshalIPPoolIPBlocks(data *string) (spiderpoolv2beta1.PoolIPBlocks, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var records spiderpoolv2beta1.PoolIPBlocks
+	if err := json.Unmarshal([]byte(*data), &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func MarshalIPPoolIPBlocks(records spiderpoolv2beta1.PoolIPBlocks) (*string, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	v, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	data := string(v)
+
+	return &data, nil
+}
+
 func UnmarshalSubnetAllocatedIPPools(data *string) (spiderpoolv2beta1.PoolIPPreAllocations, error) {
 	if data == nil {
 		return nil, nil
@@ -4,6 +4,13 @@
 package workloadendpointmanager
 
 import (
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 )
 
@@ -26,3 +33,130 @@ func RetrieveIPAllocation(uid, nic string, endpoint *spiderpoolv2beta1.SpiderEnd
 
 	return nil
 }
+
+// MergeIPAllocationDetail merges the detail into the details with the same
+// NIC, the IP address of the same IP version will be overwritten. If there is
+// no detail with the same NIC, the detail will be appended.
+func MergeIPAllocationDetail(details []spiderpoolv2beta1.IPAllocationDetail, detail spiderpoolv2beta1.IPAllocationDetail) []spiderpoolv2beta1.IPAllocationDetail {
+	for i := range details {
+		if details[i].NIC != detail.NIC {
+			continue
+		}
+
+		if detail.IPv4 != nil {
+			details[i].IPv4 = detail.IPv4
+			details[i].IPv4Pool = detail.IPv4Pool
+			details[i].IPv4Gateway = detail.IPv4Gateway
+		}
+		if detail.IPv6 != nil {
+			details[i].IPv6 = detail.IPv6
+			details[i].IPv6Pool = detail.IPv6Pool
+			details[i].IPv6Gateway = detail.IPv6Gateway
+		}
+		if detail.Vlan != nil {
+			details[i].Vlan = detail.Vlan
+		}
+		if detail.CleanGateway != nil {
+			details[i].CleanGateway = detail.CleanGateway
+		}
+
+		for _, r := range detail.Routes {
+			exist := false
+			for _, er := range details[i].Routes {
+				if er == r {
+					exist = true
+					break
+				}
+			}
+			if !exist {
+				details[i].Routes = append(details[i].Routes, r)
+			}
+		}
+
+		return details
+	}
+
+	return append(details, detail)
+}
+
+// RemoveIPAllocationDetail removes the IP address from the details, the
+// detail will be dropped once it has neither IPv4 nor IPv6 address.
+func RemoveIPAllocationDetail(details []spiderpoolv2beta1.IPAllocationDetail, ip string) ([]spiderpoolv2beta1.IPAllocationDetail, bool) {
+	removed := false
+	newDetails := make([]spiderpoolv2beta1.IPAllocationDetail, 0, len(details))
+	for _, d := range details {
+		if d.IPv4 != nil && strings.Split(*d.IPv4, "/")[0] == ip {
+			d.IPv4 = nil
+			d.IPv4Pool = nil
+			d.IPv4Gateway = nil
+			removed = true
+		}
+		if d.IPv6 != nil && strings.Split(*d.IPv6, "/")[0] == ip {
+			d.IPv6 = nil
+			d.IPv6Pool = nil
+			d.IPv6Gateway = nil
+			removed = true
+		}
+
+		if d.IPv4 == nil && d.IPv6 == nil {
+			continue
+		}
+		newDetails = append(newDetails, d)
+	}
+
+	return newDetails, removed
+}
+
+// StickyEndpointName returns the name of the Endpoint holding the sticky IP
+// addresses of the replica slot of the application. The Pods created by the
+// controllers are named <controller>-<suffix>, such as web-0 of StatefulSet,
+// so the name ends with ".sticky" to keep clear of them.
+func StickyEndpointName(appName string, slot int64) string {
+	return fmt.Sprintf("%s-%d.sticky", appName, slot)
+}
+
+// IsStickyIPEndpoint checks whether the Endpoint holds the sticky IP
+// addresses of a replica slot.
+func IsStickyIPEndpoint(endpoint *spiderpoolv2beta1.SpiderEndpoint) bool {
+	return endpoint != nil && endpoint.Status.StickySlot != nil
+}
+
+// IsEndpointOwnedBy checks whether the Endpoint records the IP addresses of
+// the Pods of the controller.
+func IsEndpointOwnedBy(endpoint *spiderpoolv2beta1.SpiderEndpoint, kind, name string) bool {
+	return endpoint != nil && endpoint.Status.OwnerControllerType == kind && endpoint.Status.OwnerControllerName == name
+}
+
+// stickySlotCapacity returns the number of the replica slots of the
+// Deployment or ReplicaSet whose IP addresses are kept, the surge Pods of
+// the rolling update of Deployment are included.
+func stickySlotCapacity(app client.Object) (int64, error) {
+	switch a := app.(type) {
+	case *appsv1.Deployment:
+		replicas := int64(1)
+		if a.Spec.Replicas != nil {
+			replicas = int64(*a.Spec.Replicas)
+		}
+		if a.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			return replicas, nil
+		}
+
+		maxSurge := intstr.FromString("25%")
+		if a.Spec.Strategy.RollingUpdate != nil && a.Spec.Strategy.RollingUpdate.MaxSurge != nil {
+			maxSurge = *a.Spec.Strategy.RollingUpdate.MaxSurge
+		}
+		surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(replicas), true)
+		if err != nil {
+			return 0, err
+		}
+
+		return replicas + int64(surge), nil
+	case *appsv1.ReplicaSet:
+		if a.Spec.Replicas != nil {
+			return int64(*a.Spec.Replicas), nil
+		}
+		return 1, nil
+	default:
+		return 0, fmt.Errorf("unsupported kind %T of sticky IP addresses", app)
+	}
+}
@@ -105,4 +105,112 @@ var _ = Describe("WorkloadEndpointManager utils", Label("workloadendpoint_manage
 			Expect(*allocation).To(Equal(allocationT))
 		})
 	})
+
+	Describe("Test IsEndpointOwnedBy", func() {
+		It("inputs nil Endpoint", func() {
+			Expect(workloadendpointmanager.IsEndpointOwnedBy(nil, constant.KindStatefulSet, "web")).To(BeFalse())
+		})
+
+		It("checks the kind and the name of the owner", func() {
+			endpointT.Status.OwnerControllerType = constant.KindStatefulSet
+			endpointT.Status.OwnerControllerName = "web"
+
+			Expect(workloadendpointmanager.IsEndpointOwnedBy(endpointT, constant.KindStatefulSet, "web")).To(BeTrue())
+			Expect(workloadendpointmanager.IsEndpointOwnedBy(endpointT, constant.KindDeployment, "web")).To(BeFalse())
+			Expect(workloadendpointmanager.IsEndpointOwnedBy(endpointT, constant.KindStatefulSet, "web-0")).To(BeFalse())
+		})
+	})
+
+	Describe("Test MergeIPAllocationDetail", func() {
+		var detailsT []spiderpoolv2beta1.IPAllocationDetail
+
+		BeforeEach(func() {
+			detailsT = []spiderpoolv2beta1.IPAllocationDetail{
+				{
+					NIC:      "eth0",
+					Vlan:     pointer.Int64(0),
+					IPv4:     pointer.String("172.18.40.10/24"),
+					IPv4Pool: pointer.String("ipv4-ippool-1"),
+					Routes:   []spiderpoolv2beta1.Route{{Dst: "10.0.0.0/8", Gw: "172.18.40.1"}},
+				},
+			}
+		})
+
+		It("appends the detail of a new NIC", func() {
+			details := workloadendpointmanager.MergeIPAllocationDetail(detailsT, spiderpoolv2beta1.IPAllocationDetail{
+				NIC:      "net1",
+				IPv4:     pointer.String("192.168.40.9/24"),
+				IPv4Pool: pointer.String("ipv4-ippool-2"),
+			})
+			Expect(details).To(HaveLen(2))
+			Expect(details[1].NIC).To(Equal("net1"))
+		})
+
+		It("overwrites the IP address of the same NIC and IP version", func() {
+			details := workloadendpointmanager.MergeIPAllocationDetail(detailsT, spiderpoolv2beta1.IPAllocationDetail{
+				NIC:      "eth0",
+				IPv4:     pointer.String("172.18.40.11/24"),
+				IPv4Pool: pointer.String("ipv4-ippool-3"),
+				Routes:   []spiderpoolv2beta1.Route{{Dst: "10.0.0.0/8", Gw: "172.18.40.1"}, {Dst: "10.1.0.0/16", Gw: "172.18.40.1"}},
+			})
+			Expect(details).To(HaveLen(1))
+			Expect(*details[0].IPv4).To(Equal("172.18.40.11/24"))
+			Expect(*details[0].IPv4Pool).To(Equal("ipv4-ippool-3"))
+			Expect(details[0].Routes).To(HaveLen(2))
+		})
+
+		It("adds the IP address of another IP version to the same NIC", func() {
+			details := workloadendpointmanager.MergeIPAllocationDetail(detailsT, spiderpoolv2beta1.IPAllocationDetail{
+				NIC:      "eth0",
+				IPv6:     pointer.String("abcd:1234::a/120"),
+				IPv6Pool: pointer.String("ipv6-ippool-1"),
+			})
+			Expect(details).To(HaveLen(1))
+			Expect(*details[0].IPv4).To(Equal("172.18.40.10/24"))
+			Expect(*details[0].IPv6).To(Equal("abcd:1234::a/120"))
+		})
+	})
+
+	Describe("Test RemoveIPAllocationDetail", func() {
+		var detailsT []spiderpoolv2beta1.IPAllocationDetail
+
+		BeforeEach(func() {
+			detailsT = []spiderpoolv2beta1.IPAllocationDetail{
+				{
+					NIC:      "eth0",
+					IPv4:     pointer.String("172.18.40.10/24"),
+					IPv4Pool: pointer.String("ipv4-ippool-1"),
+					IPv6:     pointer.String("abcd:1234::a/120"),
+					IPv6Pool: pointer.String("ipv6-ippool-1"),
+				},
+				{
+					NIC:      "net1",
+					IPv4:     pointer.String("192.168.40.9/24"),
+					IPv4Pool: pointer.String("ipv4-ippool-2"),
+				},
+			}
+		})
+
+		It("removes non-existent IP address", func() {
+			details, removed := workloadendpointmanager.RemoveIPAllocationDetail(detailsT, "172.18.40.11")
+			Expect(removed).To(BeFalse())
+			Expect(details).To(Equal(detailsT))
+		})
+
+		It("removes one IP address of the dual-stack NIC", func() {
+			details, removed := workloadendpointmanager.RemoveIPAllocationDetail(detailsT, "abcd:1234::a")
+			Expect(removed).To(BeTrue())
+			Expect(details).To(HaveLen(2))
+			Expect(details[0].IPv6).To(BeNil())
+			Expect(details[0].IPv6Pool).To(BeNil())
+			Expect(*details[0].IPv4).To(Equal("172.18.40.10/24"))
+		})
+
+		It("drops the detail with no IP addresses left", func() {
+			details, removed := workloadendpointmanager.RemoveIPAllocationDetail(detailsT, "192.168.40.9")
+			Expect(removed).To(BeTrue())
+			Expect(details).To(HaveLen(1))
+			Expect(details[0].NIC).To(Equal("eth0"))
+		})
+	})
 })
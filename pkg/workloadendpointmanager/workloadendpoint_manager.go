@@ -10,9 +10,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
-	kubevirtv1 "kubevirt.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/utils/convert"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 type WorkloadEndpointManager interface {
@@ -28,32 +30,40 @@ type WorkloadEndpointManager interface {
 	ListEndpoints(ctx context.Context, cached bool, opts ...client.ListOption) (*spiderpoolv2beta1.SpiderEndpointList, error)
 	DeleteEndpoint(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) error
 	RemoveFinalizer(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) error
-	PatchIPAllocationResults(ctx context.Context, results []*types.AllocationResult, endpoint *spiderpoolv2beta1.SpiderEndpoint, pod *corev1.Pod, podController types.PodTopController, isMultipleNicWithNoName bool) error
+	PatchIPAllocationResults(ctx context.Context, results []*types.AllocationResult, endpoint *spiderpoolv2beta1.SpiderEndpoint, pod *corev1.Pod, containerID string, podController types.PodTopController, isMultipleNicWithNoName bool) error
 	ReallocateCurrentIPAllocation(ctx context.Context, uid, nodeName, nic string, endpoint *spiderpoolv2beta1.SpiderEndpoint, isMultipleNicWithNoName bool) error
 	UpdateAllocationNICName(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint, nic string) (*spiderpoolv2beta1.PodIPAllocation, error)
+	UpdateIPAllocationResult(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint, result *types.AllocationResult) error
+	ReleaseIPAllocation(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint, ip string) error
+	IsStickyIPPod(ctx context.Context, pod *corev1.Pod, podController types.PodTopController) (bool, error)
+	IsValidStickyEndpoint(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) (bool, error)
+	IsStickyEndpointHeld(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) (bool, error)
+	GetStickyEndpoint(ctx context.Context, pod *corev1.Pod, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error)
+	GetPodEndpoint(ctx context.Context, namespace, endpointName, podUID string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error)
+	ClaimStickyEndpoint(ctx context.Context, pod *corev1.Pod, podController types.PodTopController) (*spiderpoolv2beta1.SpiderEndpoint, error)
 }
 
 type workloadEndpointManager struct {
-	client    client.Client
-	apiReader client.Reader
-
-	enableStatefulSet      bool
-	enableKubevirtStaticIP bool
+	client        client.Client
+	apiReader     client.Reader
+	workloadKinds workloadkind.Registry
 }
 
-func NewWorkloadEndpointManager(client client.Client, apiReader client.Reader, enableStatefulSet, enableKubevirtStaticIP bool) (WorkloadEndpointManager, error) {
+func NewWorkloadEndpointManager(client client.Client, apiReader client.Reader, workloadKinds workloadkind.Registry) (WorkloadEndpointManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
 	if apiReader == nil {
 		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
 	}
+	if workloadKinds == nil {
+		return nil, fmt.Errorf("workload kind registry %w", constant.ErrMissingRequiredParam)
+	}
 
 	return &workloadEndpointManager{
-		client:                 client,
-		apiReader:              apiReader,
-		enableStatefulSet:      enableStatefulSet,
-		enableKubevirtStaticIP: enableKubevirtStaticIP,
+		client:        client,
+		apiReader:     apiReader,
+		workloadKinds: workloadKinds,
 	}, nil
 }
 
@@ -112,7 +122,7 @@ func (em *workloadEndpointManager) RemoveFinalizer(ctx context.Context, endpoint
 	return nil
 }
 
-func (em *workloadEndpointManager) PatchIPAllocationResults(ctx context.Context, results []*types.AllocationResult, endpoint *spiderpoolv2beta1.SpiderEndpoint, pod *corev1.Pod, podController types.PodTopController, isMultipleNicWithNoName bool) error {
+func (em *workloadEndpointManager) PatchIPAllocationResults(ctx context.Context, results []*types.AllocationResult, endpoint *spiderpoolv2beta1.SpiderEndpoint, pod *corev1.Pod, containerID string, podController types.PodTopController, isMultipleNicWithNoName bool) error {
 	if pod == nil {
 		return fmt.Errorf("pod %w", constant.ErrMissingRequiredParam)
 	}
@@ -127,25 +137,39 @@ func (em *workloadEndpointManager) PatchIPAllocationResults(ctx context.Context,
 			},
 			Status: spiderpoolv2beta1.WorkloadEndpointStatus{
 				Current: spiderpoolv2beta1.PodIPAllocation{
-					UID:  string(pod.UID),
-					Node: pod.Spec.NodeName,
-					IPs:  convert.ConvertResultsToIPDetails(results, isMultipleNicWithNoName),
+					UID:         string(pod.UID),
+					Node:        pod.Spec.NodeName,
+					ContainerID: containerID,
+					IPs:         convert.ConvertResultsToIPDetails(results, isMultipleNicWithNoName),
 				},
-				OwnerControllerType: podController.Kind,
-				OwnerControllerName: podController.Name,
+				OwnerControllerType:       podController.Kind,
+				OwnerControllerName:       podController.Name,
+				OwnerControllerAPIVersion: podController.APIVersion,
 			},
 		}
 
-		// Do not set ownerReference for Endpoint when its corresponding Pod is
-		// controlled by StatefulSet/KubevirtVMI. Once the Pod of StatefulSet/KubevirtVMI is recreated,
+		// Do not set ownerReference for Endpoint when its corresponding Pod has
+		// stable identity, such as the Pod of StatefulSet/KubevirtVMI. Once the Pod is recreated,
 		// we can immediately retrieve the old IP allocation results from the
 		// Endpoint without worrying about the cascading deletion of the Endpoint.
+		stableEndpointName, isStaticIPPod := em.workloadKinds.StableEndpointName(pod.Name, podController.AppNamespacedName)
+		isStickyIPPod := false
+		if !isStaticIPPod {
+			var err error
+			isStickyIPPod, err = em.IsStickyIPPod(ctx, pod, podController)
+			if err != nil {
+				return err
+			}
+		}
+
 		switch {
-		case em.enableStatefulSet && podController.APIVersion == appsv1.SchemeGroupVersion.String() && podController.Kind == constant.KindStatefulSet:
-			logger.Sugar().Infof("do not set OwnerReference for SpiderEndpoint '%s' since the pod top controller is %s", endpoint, podController.Kind)
-		case em.enableKubevirtStaticIP && podController.APIVersion == kubevirtv1.SchemeGroupVersion.String() && podController.Kind == constant.KindKubevirtVMI:
-			endpoint.Name = podController.Name
+		case isStaticIPPod:
+			endpoint.Name = stableEndpointName
 			logger.Sugar().Infof("do not set OwnerReference for SpiderEndpoint '%s' since the pod top controller is %s", endpoint, podController.Kind)
+		case isStickyIPPod:
+			// The Endpoint is named after the replica slot rather than the Pod,
+			// it is handed over to the next Pod of the application.
+			logger.Sugar().Infof("do not set OwnerReference for SpiderEndpoint of Pod %s/%s since it holds the sticky IP addresses of %s", pod.Namespace, pod.Name, podController.Kind)
 		default:
 			if err := controllerutil.SetOwnerReference(pod, endpoint, em.client.Scheme()); err != nil {
 				return err
@@ -153,6 +177,9 @@ func (em *workloadEndpointManager) PatchIPAllocationResults(ctx context.Context,
 		}
 
 		controllerutil.AddFinalizer(endpoint, constant.SpiderFinalizer)
+		if isStickyIPPod {
+			return em.createStickyEndpoint(ctx, endpoint)
+		}
 		logger.Sugar().Infof("try to create SpiderEndpoint %s", endpoint)
 		return em.client.Create(ctx, endpoint)
 	}
@@ -161,6 +188,9 @@ func (em *workloadEndpointManager) PatchIPAllocationResults(ctx context.Context,
 		return nil
 	}
 
+	if len(containerID) != 0 {
+		endpoint.Status.Current.ContainerID = containerID
+	}
 	// TODO(iiiceoo): Only append records with different NIC.
 	endpoint.Status.Current.IPs = append(endpoint.Status.Current.IPs, convert.ConvertResultsToIPDetails(results, isMultipleNicWithNoName)...)
 	logger.Sugar().Infof("try to update SpiderEndpoint %s", endpoint)
@@ -224,3 +254,366 @@ func (em *workloadEndpointManager) UpdateAllocationNICName(ctx context.Context,
 
 	return &endpoint.Status.Current, nil
 }
+
+// UpdateIPAllocationResult sets the IP address of the allocation result to the
+// current IP allocation of the Endpoint, the IP address of the same NIC and IP
+// version will be replaced.
+func (em *workloadEndpointManager) UpdateIPAllocationResult(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint, result *types.AllocationResult) error {
+	if endpoint == nil {
+		return fmt.Errorf("endpoint %w", constant.ErrMissingRequiredParam)
+	}
+	if result == nil || result.IP == nil {
+		return fmt.Errorf("allocation result %w", constant.ErrMissingRequiredParam)
+	}
+
+	logger := logutils.FromContext(ctx)
+	deepCopy := endpoint.DeepCopy()
+	details := convert.ConvertResultsToIPDetails([]*types.AllocationResult{result}, false)
+	deepCopy.Status.Current.IPs = MergeIPAllocationDetail(deepCopy.Status.Current.IPs, details[0])
+	if reflect.DeepEqual(deepCopy, endpoint) {
+		return nil
+	}
+
+	deepCopy.DeepCopyInto(endpoint)
+	logger.Sugar().Infof("try to update SpiderEndpoint %s", endpoint)
+	return em.client.Update(ctx, endpoint)
+}
+
+// ReleaseIPAllocation removes the IP address from the current IP allocation
+// of the Endpoint. Once there are no IP addresses left, the Endpoint will be
+// deleted along with its finalizer.
+func (em *workloadEndpointManager) ReleaseIPAllocation(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint, ip string) error {
+	if endpoint == nil {
+		return fmt.Errorf("endpoint %w", constant.ErrMissingRequiredParam)
+	}
+
+	logger := logutils.FromContext(ctx)
+	details, removed := RemoveIPAllocationDetail(endpoint.Status.Current.IPs, ip)
+	if !removed {
+		return nil
+	}
+
+	if len(details) != 0 {
+		endpoint.Status.Current.IPs = details
+		logger.Sugar().Infof("try to update SpiderEndpoint %s", endpoint)
+		return em.client.Update(ctx, endpoint)
+	}
+
+	if endpoint.DeletionTimestamp == nil {
+		logger.Sugar().Infof("try to delete SpiderEndpoint %s with no IP addresses left", endpoint)
+		if err := em.DeleteEndpoint(ctx, endpoint); err != nil {
+			return err
+		}
+	}
+
+	return em.RemoveFinalizer(ctx, endpoint)
+}
+
+// IsStickyIPPod checks whether the Pod controlled by Deployment or
+// ReplicaSet requests sticky IP addresses, either with the annotation
+// ipam.spidernet.io/sticky-ip of the Pod or with an IPPool owned by the
+// application and annotated with it.
+func (em *workloadEndpointManager) IsStickyIPPod(ctx context.Context, pod *corev1.Pod, podController types.PodTopController) (bool, error) {
+	if pod == nil {
+		return false, fmt.Errorf("pod %w", constant.ErrMissingRequiredParam)
+	}
+
+	if podController.APIVersion != appsv1.SchemeGroupVersion.String() ||
+		(podController.Kind != constant.KindDeployment && podController.Kind != constant.KindReplicaSet) {
+		return false, nil
+	}
+	if pod.Annotations[constant.AnnoPodStickyIP] == constant.True {
+		return true, nil
+	}
+
+	return em.hasStickyIPPool(ctx, podController.Namespace, podController.Kind, podController.Name)
+}
+
+// IsValidStickyEndpoint checks whether the sticky IP addresses held by the
+// Endpoint should be kept, that is the Deployment or ReplicaSet still exists
+// and requests sticky IP addresses, and the replica slot of the Endpoint is
+// within its replicas (and the surge of rolling update).
+func (em *workloadEndpointManager) IsValidStickyEndpoint(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) (bool, error) {
+	if !IsStickyIPEndpoint(endpoint) {
+		return false, nil
+	}
+
+	app, err := em.getStickyIPApp(ctx, endpoint.Namespace, endpoint.Status.OwnerControllerType, endpoint.Status.OwnerControllerName)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if app == nil {
+		return false, nil
+	}
+
+	capacity, err := stickySlotCapacity(app)
+	if err != nil {
+		return false, err
+	}
+
+	return *endpoint.Status.StickySlot < capacity, nil
+}
+
+// IsStickyEndpointHeld checks whether the Pod which claimed the replica slot
+// of the Endpoint still exists and is not finished.
+func (em *workloadEndpointManager) IsStickyEndpointHeld(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) (bool, error) {
+	if endpoint == nil {
+		return false, fmt.Errorf("endpoint %w", constant.ErrMissingRequiredParam)
+	}
+
+	podUIDs, err := em.listPodUIDs(ctx, endpoint.Namespace)
+	if err != nil {
+		return false, err
+	}
+	_, ok := podUIDs[endpoint.Status.Current.UID]
+
+	return ok, nil
+}
+
+// GetStickyEndpoint gets the Endpoint of the replica slot claimed by the
+// Pod, it returns nil if there is none.
+func (em *workloadEndpointManager) GetStickyEndpoint(ctx context.Context, pod *corev1.Pod, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	if pod == nil {
+		return nil, fmt.Errorf("pod %w", constant.ErrMissingRequiredParam)
+	}
+
+	return em.getStickyEndpointByUID(ctx, pod.Namespace, string(pod.UID), cached)
+}
+
+// GetPodEndpoint gets the Endpoint recording the IP addresses of the Pod,
+// that is the one with the name, or the one of the replica slot claimed by
+// the Pod. The Endpoint of a replica slot with the name is skipped unless it
+// is claimed by the Pod, since a bare Pod may be named after the slot of an
+// application. It returns nil if there is none.
+func (em *workloadEndpointManager) GetPodEndpoint(ctx context.Context, namespace, endpointName, podUID string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	endpoint, err := em.GetEndpointByName(ctx, namespace, endpointName, cached)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if endpoint != nil && (!IsStickyIPEndpoint(endpoint) || endpoint.Status.Current.UID == podUID) {
+		return endpoint, nil
+	}
+	if len(podUID) == 0 {
+		return nil, nil
+	}
+
+	return em.getStickyEndpointByUID(ctx, namespace, podUID, cached)
+}
+
+func (em *workloadEndpointManager) getStickyEndpointByUID(ctx context.Context, namespace, podUID string, cached bool) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	endpointList, err := em.ListEndpoints(ctx, cached, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range endpointList.Items {
+		endpoint := &endpointList.Items[i]
+		if IsStickyIPEndpoint(endpoint) && endpoint.Status.Current.UID == podUID {
+			return endpoint, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ClaimStickyEndpoint claims the Endpoint holding the sticky IP addresses of
+// a vacant replica slot of the Pod's controller for the Pod, the one with the
+// lowest slot is preferred. A slot is vacant once the Pod which held it no
+// longer exists or is finished. The claim is recorded by setting the current IP allocation
+// of the Endpoint to the Pod, so that concurrent claims conflict with each
+// other. It returns nil if there is no vacant slot.
+func (em *workloadEndpointManager) ClaimStickyEndpoint(ctx context.Context, pod *corev1.Pod, podController types.PodTopController) (*spiderpoolv2beta1.SpiderEndpoint, error) {
+	if pod == nil {
+		return nil, fmt.Errorf("pod %w", constant.ErrMissingRequiredParam)
+	}
+
+	logger := logutils.FromContext(ctx)
+
+	endpoints, err := em.listStickyEndpoints(ctx, constant.IgnoreCache, pod.Namespace, podController.Kind, podController.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var podUIDs map[string]struct{}
+	var vacant *spiderpoolv2beta1.SpiderEndpoint
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if endpoint.Status.Current.UID == string(pod.UID) {
+			// Claimed by the Pod before.
+			return endpoint, nil
+		}
+		if endpoint.DeletionTimestamp != nil {
+			continue
+		}
+		if vacant != nil && *vacant.Status.StickySlot <= *endpoint.Status.StickySlot {
+			continue
+		}
+
+		if podUIDs == nil {
+			if podUIDs, err = em.listPodUIDs(ctx, pod.Namespace); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := podUIDs[endpoint.Status.Current.UID]; ok {
+			continue
+		}
+
+		isValid, err := em.IsValidStickyEndpoint(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if isValid {
+			vacant = endpoint
+		}
+	}
+
+	if vacant == nil {
+		return nil, nil
+	}
+
+	logger.Sugar().Infof("try to claim SpiderEndpoint %s of sticky slot %d", vacant, *vacant.Status.StickySlot)
+	vacant.Status.Current.UID = string(pod.UID)
+	vacant.Status.Current.Node = pod.Spec.NodeName
+	vacant.Status.Current.ContainerID = ""
+	if err := em.client.Update(ctx, vacant); err != nil {
+		return nil, err
+	}
+
+	return vacant, nil
+}
+
+// createStickyEndpoint creates the Endpoint of the lowest replica slot of
+// the Deployment or ReplicaSet not held by any Endpoint yet. The Endpoint is
+// named after the slot, so the concurrent creations of the same slot conflict
+// with each other, and the losers retry with the next slot.
+func (em *workloadEndpointManager) createStickyEndpoint(ctx context.Context, endpoint *spiderpoolv2beta1.SpiderEndpoint) error {
+	logger := logutils.FromContext(ctx)
+
+	return retry.OnError(retry.DefaultRetry, apierrors.IsAlreadyExists, func() error {
+		slot, err := em.nextStickySlot(ctx, endpoint.Namespace, endpoint.Status.OwnerControllerName)
+		if err != nil {
+			return err
+		}
+
+		endpoint.Name = StickyEndpointName(endpoint.Status.OwnerControllerName, slot)
+		endpoint.Status.StickySlot = &slot
+		logger.Sugar().Infof("try to create SpiderEndpoint %s of sticky slot %d", endpoint, slot)
+
+		return em.client.Create(ctx, endpoint)
+	})
+}
+
+// nextStickySlot returns the lowest replica slot of the application whose
+// Endpoint name is not taken yet.
+func (em *workloadEndpointManager) nextStickySlot(ctx context.Context, namespace, appName string) (int64, error) {
+	endpointList, err := em.ListEndpoints(ctx, constant.IgnoreCache, client.InNamespace(namespace))
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[string]struct{}, len(endpointList.Items))
+	for _, endpoint := range endpointList.Items {
+		names[endpoint.Name] = struct{}{}
+	}
+
+	var slot int64
+	for ; ; slot++ {
+		if _, ok := names[StickyEndpointName(appName, slot)]; !ok {
+			break
+		}
+	}
+
+	return slot, nil
+}
+
+func (em *workloadEndpointManager) listStickyEndpoints(ctx context.Context, cached bool, namespace, kind, name string) ([]spiderpoolv2beta1.SpiderEndpoint, error) {
+	endpointList, err := em.ListEndpoints(ctx, cached, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []spiderpoolv2beta1.SpiderEndpoint
+	for _, endpoint := range endpointList.Items {
+		if IsStickyIPEndpoint(&endpoint) && endpoint.Status.OwnerControllerType == kind && endpoint.Status.OwnerControllerName == name {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// listPodUIDs returns the UIDs of the Pods in the namespace which may still
+// take their IP addresses, that is the ones not finished yet.
+func (em *workloadEndpointManager) listPodUIDs(ctx context.Context, namespace string) (map[string]struct{}, error) {
+	var podList corev1.PodList
+	if err := em.client.List(ctx, &podList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	podUIDs := make(map[string]struct{}, len(podList.Items))
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podUIDs[string(pod.UID)] = struct{}{}
+	}
+
+	return podUIDs, nil
+}
+
+// hasStickyIPPool checks whether there is an IPPool owned by the application
+// and annotated with ipam.spidernet.io/sticky-ip, such as the one created
+// from SpiderSubnet for the application automatically.
+func (em *workloadEndpointManager) hasStickyIPPool(ctx context.Context, namespace, kind, name string) (bool, error) {
+	var ipPoolList spiderpoolv2beta1.SpiderIPPoolList
+	matchLabels := client.MatchingLabels{
+		constant.LabelIPPoolOwnerApplicationKind:      kind,
+		constant.LabelIPPoolOwnerApplicationNamespace: namespace,
+		constant.LabelIPPoolOwnerApplicationName:      name,
+	}
+	if err := em.client.List(ctx, &ipPoolList, matchLabels); err != nil {
+		return false, err
+	}
+
+	for _, ipPool := range ipPoolList.Items {
+		if ipPool.DeletionTimestamp == nil && ipPool.Annotations[constant.AnnoPodStickyIP] == constant.True {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getStickyIPApp gets the Deployment or ReplicaSet, it returns nil if the
+// application is being deleted or no longer requests sticky IP addresses.
+func (em *workloadEndpointManager) getStickyIPApp(ctx context.Context, namespace, kind, name string) (client.Object, error) {
+	var app client.Object
+	var template *corev1.PodTemplateSpec
+	switch kind {
+	case constant.KindDeployment:
+		deployment := &appsv1.Deployment{}
+		app, template = deployment, &deployment.Spec.Template
+	case constant.KindReplicaSet:
+		replicaSet := &appsv1.ReplicaSet{}
+		app, template = replicaSet, &replicaSet.Spec.Template
+	default:
+		return nil, nil
+	}
+
+	if err := em.apiReader.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, app); err != nil {
+		return nil, err
+	}
+	if app.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	if template.Annotations[constant.AnnoPodStickyIP] == constant.True {
+		return app, nil
+	}
+
+	hasStickyIPPool, err := em.hasStickyIPPool(ctx, namespace, kind, name)
+	if err != nil || !hasStickyIPPool {
+		return nil, err
+	}
+
+	return app, nil
+}
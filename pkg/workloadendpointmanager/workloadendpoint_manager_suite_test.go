@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -17,13 +18,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
 )
 
 var scheme *runtime.Scheme
 var fakeClient client.Client
 var tracker k8stesting.ObjectTracker
 var fakeAPIReader client.Reader
+var workloadKinds workloadkind.Registry
 var endpointManager workloadendpointmanager.WorkloadEndpointManager
 
 func TestWorkloadEndpointManager(t *testing.T) {
@@ -39,6 +44,9 @@ var _ = BeforeSuite(func() {
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = appsv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	fakeClient = fake.NewClientBuilder().
 		WithScheme(scheme).
 		WithIndex(&spiderpoolv2beta1.SpiderEndpoint{}, metav1.ObjectNameField, func(raw client.Object) []string {
@@ -57,11 +65,25 @@ var _ = BeforeSuite(func() {
 		}).
 		Build()
 
+	stsManager, err := statefulsetmanager.NewStatefulSetManager(fakeClient, fakeAPIReader)
+	Expect(err).NotTo(HaveOccurred())
+
+	workloadKinds, err = workloadkind.NewRegistry(
+		fakeClient,
+		fakeAPIReader,
+		stsManager,
+		kubevirtmanager.NewKubevirtManager(fakeClient, fakeAPIReader),
+		workloadkind.RegistryConfig{
+			EnableStatefulSet:      true,
+			EnableKubevirtStaticIP: true,
+		},
+	)
+	Expect(err).NotTo(HaveOccurred())
+
 	endpointManager, err = workloadendpointmanager.NewWorkloadEndpointManager(
 		fakeClient,
 		fakeAPIReader,
-		true,
-		true,
+		workloadKinds,
 	)
 	Expect(err).NotTo(HaveOccurred())
 })
@@ -17,12 +17,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/utils/pointer"
 	kubevirtv1 "kubevirt.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
 	spiderpooltypes "github.com/spidernet-io/spiderpool/pkg/types"
@@ -35,8 +37,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 			manager, err := workloadendpointmanager.NewWorkloadEndpointManager(
 				nil,
 				fakeAPIReader,
-				true,
-				true,
+				workloadKinds,
 			)
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
@@ -46,8 +47,17 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 			manager, err := workloadendpointmanager.NewWorkloadEndpointManager(
 				fakeClient,
 				nil,
-				true,
-				true,
+				workloadKinds,
+			)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs nil workload kind registry", func() {
+			manager, err := workloadendpointmanager.NewWorkloadEndpointManager(
+				fakeClient,
+				fakeAPIReader,
+				nil,
 			)
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
@@ -323,7 +333,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 			})
 
 			It("inputs nil Pod", func() {
-				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, nil, spiderpooltypes.PodTopController{}, false)
+				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, nil, "", spiderpooltypes.PodTopController{}, false)
 				Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			})
 
@@ -331,7 +341,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				patches := gomonkey.ApplyFuncReturn(controllerutil.SetOwnerReference, constant.ErrUnknown)
 				defer patches.Reset()
 
-				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, spiderpooltypes.PodTopController{}, false)
+				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, "", spiderpooltypes.PodTopController{}, false)
 				Expect(err).To(MatchError(constant.ErrUnknown))
 			})
 
@@ -339,16 +349,18 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				patches := gomonkey.ApplyMethodReturn(fakeClient, "Create", constant.ErrUnknown)
 				defer patches.Reset()
 
-				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, spiderpooltypes.PodTopController{}, false)
+				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, "", spiderpooltypes.PodTopController{}, false)
 				Expect(err).To(MatchError(constant.ErrUnknown))
 			})
 
 			It("creates Endpoint for orphan Pod", func() {
+				containerID := string(uuid.NewUUID())
 				err := endpointManager.PatchIPAllocationResults(
 					ctx,
 					[]*spiderpooltypes.AllocationResult{},
 					nil,
 					podT,
+					containerID,
 					spiderpooltypes.PodTopController{
 						AppNamespacedName: spiderpooltypes.AppNamespacedName{
 							APIVersion: corev1.SchemeGroupVersion.String(),
@@ -369,6 +381,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 
 				owner := endpoint.GetOwnerReferences()[0]
 				Expect(owner.UID).To(Equal(podT.GetUID()))
+				Expect(endpoint.Status.Current.ContainerID).To(Equal(containerID))
 				Expect(controllerutil.ContainsFinalizer(&endpoint, constant.SpiderFinalizer))
 			})
 
@@ -378,6 +391,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 					[]*spiderpooltypes.AllocationResult{},
 					nil,
 					podT,
+					"",
 					spiderpooltypes.PodTopController{
 						AppNamespacedName: spiderpooltypes.AppNamespacedName{
 							APIVersion: appsv1.SchemeGroupVersion.String(),
@@ -409,6 +423,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 					[]*spiderpooltypes.AllocationResult{},
 					nil,
 					podT,
+					"",
 					spiderpooltypes.PodTopController{
 						AppNamespacedName: spiderpooltypes.AppNamespacedName{
 							APIVersion: kubevirtv1.SchemeGroupVersion.String(),
@@ -436,7 +451,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				podT.SetUID(uuid.NewUUID())
 				endpointT.Status.Current.UID = string(uuid.NewUUID())
 
-				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, endpointT, podT, spiderpooltypes.PodTopController{}, false)
+				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, endpointT, podT, "", spiderpooltypes.PodTopController{}, false)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -448,7 +463,7 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				podT.SetUID(uid)
 				endpointT.Status.Current.UID = string(uid)
 
-				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, endpointT, podT, spiderpooltypes.PodTopController{}, false)
+				err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, endpointT, podT, "", spiderpooltypes.PodTopController{}, false)
 				Expect(err).To(MatchError(constant.ErrUnknown))
 			})
 
@@ -578,5 +593,547 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				Expect(podIPAllocation.IPs[0].NIC).To(Equal(nic))
 			})
 		})
+
+		Describe("UpdateIPAllocationResult", func() {
+			var resultT *spiderpooltypes.AllocationResult
+
+			BeforeEach(func() {
+				resultT = &spiderpooltypes.AllocationResult{
+					IP: &models.IPConfig{
+						Address: pointer.String("172.18.40.40/16"),
+						IPPool:  "ipv4-ippool",
+						Nic:     pointer.String("eth0"),
+						Version: pointer.Int64(constant.IPv4),
+					},
+				}
+			})
+
+			It("inputs nil Endpoint", func() {
+				err := endpointManager.UpdateIPAllocationResult(ctx, nil, resultT)
+				Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			})
+
+			It("inputs nil allocation result", func() {
+				err := endpointManager.UpdateIPAllocationResult(ctx, endpointT, nil)
+				Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			})
+
+			It("failed to update Endpoint due to some unknown errors", func() {
+				patches := gomonkey.ApplyMethodReturn(fakeClient, "Update", constant.ErrUnknown)
+				defer patches.Reset()
+
+				err := endpointManager.UpdateIPAllocationResult(ctx, endpointT, resultT)
+				Expect(err).To(MatchError(constant.ErrUnknown))
+			})
+
+			It("replaces the IP address of the same NIC and IP version", func() {
+				endpointT.Status.Current.UID = string(uuid.NewUUID())
+				endpointT.Status.Current.IPs = []spiderpoolv2beta1.IPAllocationDetail{
+					{
+						NIC:      "eth0",
+						IPv4:     pointer.String("172.18.40.10/16"),
+						IPv4Pool: pointer.String("default-ipv4-ippool"),
+						IPv6:     pointer.String("abcd:1234::a/120"),
+						IPv6Pool: pointer.String("default-ipv6-ippool"),
+					},
+				}
+
+				err := fakeClient.Create(ctx, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = endpointManager.UpdateIPAllocationResult(ctx, endpointT, resultT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpointT.Status.Current.IPs).To(HaveLen(1))
+				Expect(*endpointT.Status.Current.IPs[0].IPv4).To(Equal("172.18.40.40/16"))
+				Expect(*endpointT.Status.Current.IPs[0].IPv4Pool).To(Equal("ipv4-ippool"))
+				Expect(*endpointT.Status.Current.IPs[0].IPv6).To(Equal("abcd:1234::a/120"))
+			})
+		})
+
+		Describe("ReleaseIPAllocation", func() {
+			It("inputs nil Endpoint", func() {
+				err := endpointManager.ReleaseIPAllocation(ctx, nil, "172.18.40.10")
+				Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			})
+
+			It("releases the IP address not recorded in Endpoint", func() {
+				endpointT.Status.Current.IPs = []spiderpoolv2beta1.IPAllocationDetail{
+					{NIC: "eth0", IPv4: pointer.String("172.18.40.10/16")},
+				}
+
+				err := endpointManager.ReleaseIPAllocation(ctx, endpointT, "172.18.40.11")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpointT.Status.Current.IPs).To(HaveLen(1))
+			})
+
+			It("releases one of the IP addresses", func() {
+				endpointT.Status.Current.IPs = []spiderpoolv2beta1.IPAllocationDetail{
+					{NIC: "eth0", IPv4: pointer.String("172.18.40.10/16"), IPv6: pointer.String("abcd:1234::a/120")},
+				}
+
+				err := fakeClient.Create(ctx, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = endpointManager.ReleaseIPAllocation(ctx, endpointT, "172.18.40.10")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpointT.Status.Current.IPs).To(HaveLen(1))
+				Expect(endpointT.Status.Current.IPs[0].IPv4).To(BeNil())
+				Expect(*endpointT.Status.Current.IPs[0].IPv6).To(Equal("abcd:1234::a/120"))
+			})
+
+			It("deletes the Endpoint with no IP addresses left", func() {
+				controllerutil.AddFinalizer(endpointT, constant.SpiderFinalizer)
+				endpointT.Status.Current.IPs = []spiderpoolv2beta1.IPAllocationDetail{
+					{NIC: "eth0", IPv4: pointer.String("172.18.40.10/16")},
+				}
+
+				err := fakeClient.Create(ctx, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = endpointManager.ReleaseIPAllocation(ctx, endpointT, "172.18.40.10")
+				Expect(err).NotTo(HaveOccurred())
+
+				var endpoint spiderpoolv2beta1.SpiderEndpoint
+				err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: endpointName}, &endpoint)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		Describe("Sticky IP addresses", func() {
+			var deployName string
+			var deployT *appsv1.Deployment
+			var podController spiderpooltypes.PodTopController
+			var stickyEndpoints []*spiderpoolv2beta1.SpiderEndpoint
+
+			newStickyEndpoint := func(slot int64) *spiderpoolv2beta1.SpiderEndpoint {
+				endpoint := endpointT.DeepCopy()
+				endpoint.Name = workloadendpointmanager.StickyEndpointName(deployName, slot)
+				endpoint.Status.OwnerControllerType = constant.KindDeployment
+				endpoint.Status.OwnerControllerName = deployName
+				endpoint.Status.StickySlot = pointer.Int64(slot)
+				endpoint.Status.Current = spiderpoolv2beta1.PodIPAllocation{
+					UID:  string(uuid.NewUUID()),
+					Node: "node1",
+					IPs: []spiderpoolv2beta1.IPAllocationDetail{
+						{NIC: "eth0", IPv4: pointer.String(fmt.Sprintf("172.18.40.%d/16", slot+10))},
+					},
+				}
+				controllerutil.AddFinalizer(endpoint, constant.SpiderFinalizer)
+
+				return endpoint
+			}
+
+			addStickyEndpoint := func(endpoint *spiderpoolv2beta1.SpiderEndpoint) {
+				err := fakeClient.Create(ctx, endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				err = tracker.Add(endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				stickyEndpoints = append(stickyEndpoints, endpoint)
+			}
+
+			addPod := func(uid string, phase corev1.PodPhase) {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-%s", deployName, uid),
+						Namespace: namespace,
+						UID:       types.UID(uid),
+					},
+					Status: corev1.PodStatus{
+						Phase: phase,
+					},
+				}
+				err := fakeClient.Create(ctx, pod)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(func() {
+					err := fakeClient.Delete(ctx, pod)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				})
+			}
+
+			addStickyIPPool := func() {
+				ipPool := &spiderpoolv2beta1.SpiderIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fmt.Sprintf("auto4-%s", deployName),
+						Labels: map[string]string{
+							constant.LabelIPPoolOwnerApplicationKind:      constant.KindDeployment,
+							constant.LabelIPPoolOwnerApplicationNamespace: namespace,
+							constant.LabelIPPoolOwnerApplicationName:      deployName,
+						},
+						Annotations: map[string]string{constant.AnnoPodStickyIP: constant.True},
+					},
+				}
+				err := fakeClient.Create(ctx, ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(func() {
+					err := fakeClient.Delete(ctx, ipPool)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				})
+			}
+
+			BeforeEach(func() {
+				deployName = fmt.Sprintf("deploy-%v", count)
+				maxSurge := intstr.FromInt(1)
+				deployT = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      deployName,
+						Namespace: namespace,
+					},
+					Spec: appsv1.DeploymentSpec{
+						Replicas: pointer.Int32(2),
+						Strategy: appsv1.DeploymentStrategy{
+							Type: appsv1.RollingUpdateDeploymentStrategyType,
+							RollingUpdate: &appsv1.RollingUpdateDeployment{
+								MaxSurge: &maxSurge,
+							},
+						},
+						Template: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Annotations: map[string]string{constant.AnnoPodStickyIP: "true"},
+							},
+						},
+					},
+				}
+				podController = spiderpooltypes.PodTopController{
+					AppNamespacedName: spiderpooltypes.AppNamespacedName{
+						APIVersion: appsv1.SchemeGroupVersion.String(),
+						Kind:       constant.KindDeployment,
+						Namespace:  namespace,
+						Name:       deployName,
+					},
+				}
+				stickyEndpoints = nil
+			})
+
+			AfterEach(func() {
+				err := tracker.Delete(appsv1.SchemeGroupVersion.WithResource("deployments"), deployT.Namespace, deployT.Name)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+
+				for _, endpoint := range stickyEndpoints {
+					var e spiderpoolv2beta1.SpiderEndpoint
+					if err := fakeClient.Get(ctx, types.NamespacedName{Namespace: endpoint.Namespace, Name: endpoint.Name}, &e); err == nil {
+						controllerutil.RemoveFinalizer(&e, constant.SpiderFinalizer)
+						err = fakeClient.Update(ctx, &e)
+						Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+					}
+					err := fakeClient.Delete(ctx, endpoint, deleteOption)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+
+					err = tracker.Delete(
+						schema.GroupVersionResource{
+							Group:    constant.SpiderpoolAPIGroup,
+							Version:  constant.SpiderpoolAPIVersion,
+							Resource: "spiderendpoints",
+						},
+						endpoint.Namespace,
+						endpoint.Name,
+					)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				}
+			})
+
+			Describe("IsStickyIPPod", func() {
+				var podT *corev1.Pod
+
+				BeforeEach(func() {
+					podT = &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("%s-pod", deployName),
+							Namespace: namespace,
+						},
+					}
+				})
+
+				It("inputs nil Pod", func() {
+					isSticky, err := endpointManager.IsStickyIPPod(ctx, nil, podController)
+					Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+					Expect(isSticky).To(BeFalse())
+				})
+
+				It("checks the Pod of StatefulSet", func() {
+					podT.Annotations = map[string]string{constant.AnnoPodStickyIP: constant.True}
+					podController.Kind = constant.KindStatefulSet
+
+					isSticky, err := endpointManager.IsStickyIPPod(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isSticky).To(BeFalse())
+				})
+
+				It("checks the Pod with the annotation", func() {
+					podT.Annotations = map[string]string{constant.AnnoPodStickyIP: constant.True}
+
+					isSticky, err := endpointManager.IsStickyIPPod(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isSticky).To(BeTrue())
+				})
+
+				It("checks the Pod without any marker", func() {
+					isSticky, err := endpointManager.IsStickyIPPod(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isSticky).To(BeFalse())
+				})
+
+				It("checks the Pod whose application owns a sticky IPPool", func() {
+					addStickyIPPool()
+
+					isSticky, err := endpointManager.IsStickyIPPod(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isSticky).To(BeTrue())
+				})
+			})
+
+			Describe("IsValidStickyEndpoint", func() {
+				It("checks the Endpoint without sticky slot", func() {
+					isValid, err := endpointManager.IsValidStickyEndpoint(ctx, endpointT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeFalse())
+				})
+
+				It("checks the Endpoint of non-existent Deployment", func() {
+					isValid, err := endpointManager.IsValidStickyEndpoint(ctx, newStickyEndpoint(0))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeFalse())
+				})
+
+				It("checks the Endpoint of Deployment no longer requesting sticky IP addresses", func() {
+					deployT.Spec.Template.Annotations = nil
+					err := tracker.Add(deployT)
+					Expect(err).NotTo(HaveOccurred())
+
+					isValid, err := endpointManager.IsValidStickyEndpoint(ctx, newStickyEndpoint(0))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeFalse())
+				})
+
+				It("checks the Endpoint of Deployment owning a sticky IPPool", func() {
+					deployT.Spec.Template.Annotations = nil
+					err := tracker.Add(deployT)
+					Expect(err).NotTo(HaveOccurred())
+					addStickyIPPool()
+
+					isValid, err := endpointManager.IsValidStickyEndpoint(ctx, newStickyEndpoint(0))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeTrue())
+				})
+
+				It("checks the replica slots including the surge of rolling update", func() {
+					err := tracker.Add(deployT)
+					Expect(err).NotTo(HaveOccurred())
+
+					isValid, err := endpointManager.IsValidStickyEndpoint(ctx, newStickyEndpoint(2))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeTrue())
+
+					isValid, err = endpointManager.IsValidStickyEndpoint(ctx, newStickyEndpoint(3))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isValid).To(BeFalse())
+				})
+			})
+
+			Describe("IsStickyEndpointHeld", func() {
+				It("inputs nil Endpoint", func() {
+					isHeld, err := endpointManager.IsStickyEndpointHeld(ctx, nil)
+					Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+					Expect(isHeld).To(BeFalse())
+				})
+
+				It("checks the Endpoint whose Pod is running", func() {
+					endpoint := newStickyEndpoint(0)
+					addPod(endpoint.Status.Current.UID, corev1.PodRunning)
+
+					isHeld, err := endpointManager.IsStickyEndpointHeld(ctx, endpoint)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isHeld).To(BeTrue())
+				})
+
+				It("checks the Endpoint whose Pod is finished", func() {
+					endpoint := newStickyEndpoint(0)
+					addPod(endpoint.Status.Current.UID, corev1.PodFailed)
+
+					isHeld, err := endpointManager.IsStickyEndpointHeld(ctx, endpoint)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(isHeld).To(BeFalse())
+				})
+			})
+
+			Describe("GetStickyEndpoint", func() {
+				It("inputs nil Pod", func() {
+					endpoint, err := endpointManager.GetStickyEndpoint(ctx, nil, constant.IgnoreCache)
+					Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+					Expect(endpoint).To(BeNil())
+				})
+
+				It("gets the Endpoint of the slot claimed by the Pod", func() {
+					addStickyEndpoint(newStickyEndpoint(0))
+					endpointT := newStickyEndpoint(1)
+					addStickyEndpoint(endpointT)
+
+					pod := &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("%s-pod", deployName),
+							Namespace: namespace,
+							UID:       types.UID(endpointT.Status.Current.UID),
+						},
+					}
+					endpoint, err := endpointManager.GetStickyEndpoint(ctx, pod, constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Name).To(Equal(endpointT.Name))
+
+					pod.UID = uuid.NewUUID()
+					endpoint, err = endpointManager.GetStickyEndpoint(ctx, pod, constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).To(BeNil())
+				})
+			})
+
+			Describe("GetPodEndpoint", func() {
+				It("gets the Endpoint of the StatefulSet Pod with the same name as the Deployment", func() {
+					addStickyEndpoint(newStickyEndpoint(0))
+
+					stsEndpoint := endpointT.DeepCopy()
+					stsEndpoint.Name = fmt.Sprintf("%s-0", deployName)
+					stsEndpoint.Status.OwnerControllerType = constant.KindStatefulSet
+					stsEndpoint.Status.OwnerControllerName = deployName
+					addStickyEndpoint(stsEndpoint)
+
+					endpoint, err := endpointManager.GetPodEndpoint(ctx, namespace, stsEndpoint.Name, string(uuid.NewUUID()), constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Status.OwnerControllerType).To(Equal(constant.KindStatefulSet))
+					Expect(endpoint.Status.StickySlot).To(BeNil())
+				})
+
+				It("skips the Endpoint of the slot not claimed by the Pod with its name", func() {
+					endpointT := newStickyEndpoint(0)
+					addStickyEndpoint(endpointT)
+					claimed := newStickyEndpoint(1)
+					addStickyEndpoint(claimed)
+
+					endpoint, err := endpointManager.GetPodEndpoint(ctx, namespace, endpointT.Name, string(uuid.NewUUID()), constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).To(BeNil())
+
+					endpoint, err = endpointManager.GetPodEndpoint(ctx, namespace, endpointT.Name, claimed.Status.Current.UID, constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Name).To(Equal(claimed.Name))
+
+					endpoint, err = endpointManager.GetPodEndpoint(ctx, namespace, endpointT.Name, endpointT.Status.Current.UID, constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Name).To(Equal(endpointT.Name))
+				})
+
+				It("gets nothing without the UID of the Pod", func() {
+					endpoint, err := endpointManager.GetPodEndpoint(ctx, namespace, fmt.Sprintf("%s-pod", deployName), "", constant.IgnoreCache)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).To(BeNil())
+				})
+			})
+
+			Describe("ClaimStickyEndpoint", func() {
+				var podT *corev1.Pod
+
+				BeforeEach(func() {
+					podT = &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        fmt.Sprintf("%s-pod", deployName),
+							Namespace:   namespace,
+							UID:         uuid.NewUUID(),
+							Annotations: map[string]string{constant.AnnoPodStickyIP: "true"},
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "node2",
+						},
+					}
+
+					err := tracker.Add(deployT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("inputs nil Pod", func() {
+					endpoint, err := endpointManager.ClaimStickyEndpoint(ctx, nil, podController)
+					Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+					Expect(endpoint).To(BeNil())
+				})
+
+				It("claims the vacant slot with the lowest slot", func() {
+					addStickyEndpoint(newStickyEndpoint(1))
+					addStickyEndpoint(newStickyEndpoint(0))
+					addStickyEndpoint(newStickyEndpoint(3))
+
+					endpoint, err := endpointManager.ClaimStickyEndpoint(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Name).To(Equal(workloadendpointmanager.StickyEndpointName(deployName, 0)))
+					Expect(endpoint.Status.Current.UID).To(Equal(string(podT.UID)))
+					Expect(endpoint.Status.Current.Node).To(Equal(podT.Spec.NodeName))
+				})
+
+				It("returns the Endpoint claimed by the Pod before", func() {
+					endpointT := newStickyEndpoint(1)
+					endpointT.Status.Current.UID = string(podT.UID)
+					addStickyEndpoint(endpointT)
+					addStickyEndpoint(newStickyEndpoint(0))
+
+					endpoint, err := endpointManager.ClaimStickyEndpoint(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).NotTo(BeNil())
+					Expect(endpoint.Name).To(Equal(endpointT.Name))
+				})
+
+				It("does not claim the slot whose Pod still exists", func() {
+					endpointT := newStickyEndpoint(0)
+					addStickyEndpoint(endpointT)
+					addPod(endpointT.Status.Current.UID, corev1.PodRunning)
+
+					endpoint, err := endpointManager.ClaimStickyEndpoint(ctx, podT, podController)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(endpoint).To(BeNil())
+				})
+			})
+
+			Describe("PatchIPAllocationResults", func() {
+				var podT *corev1.Pod
+
+				BeforeEach(func() {
+					podT = &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        fmt.Sprintf("%s-pod", deployName),
+							Namespace:   namespace,
+							UID:         uuid.NewUUID(),
+							Annotations: map[string]string{constant.AnnoPodStickyIP: "true"},
+						},
+					}
+				})
+
+				It("creates the Endpoint of the next slot", func() {
+					addStickyEndpoint(newStickyEndpoint(0))
+					stickyEndpoints = append(stickyEndpoints, newStickyEndpoint(1))
+
+					err := endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, "", podController, false)
+					Expect(err).NotTo(HaveOccurred())
+
+					var endpoint spiderpoolv2beta1.SpiderEndpoint
+					err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: workloadendpointmanager.StickyEndpointName(deployName, 1)}, &endpoint)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(*endpoint.Status.StickySlot).To(Equal(int64(1)))
+					Expect(endpoint.Status.Current.UID).To(Equal(string(podT.UID)))
+					Expect(endpoint.GetOwnerReferences()).To(BeEmpty())
+					Expect(controllerutil.ContainsFinalizer(&endpoint, constant.SpiderFinalizer)).To(BeTrue())
+				})
+
+				It("fails to create the Endpoint of the slot taken concurrently", func() {
+					// The Endpoint is not seen by the API reader yet.
+					endpointT := newStickyEndpoint(0)
+					err := fakeClient.Create(ctx, endpointT)
+					Expect(err).NotTo(HaveOccurred())
+					stickyEndpoints = append(stickyEndpoints, endpointT)
+
+					err = endpointManager.PatchIPAllocationResults(ctx, []*spiderpooltypes.AllocationResult{}, nil, podT, "", podController, false)
+					Expect(apierrors.IsAlreadyExists(err)).To(BeTrue())
+				})
+			})
+		})
 	})
 })
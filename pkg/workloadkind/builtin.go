@@ -0,0 +1,293 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadkind
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	kruisev1beta1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// objectKind is a workload kind whose objects are got through the k8s client.
+type objectKind struct {
+	registry *registry
+	client   client.Client
+
+	// apiVersion is the API version recorded for the top controller, the
+	// objects are got with it.
+	apiVersion  string
+	apiVersions []string
+	kind        string
+	newObject   func() client.Object
+	replicas    func(obj client.Object) (int, bool)
+
+	// adopters are the workload kinds who take over the Pods of the
+	// objects they control, such as the Deployment for its ReplicaSets.
+	adopters []metav1.TypeMeta
+
+	// stableEndpointName and isValidPod are only set for the workload kinds
+	// whose Pods keep their IP addresses across re-creation.
+	stableEndpointName func(podName, controllerName string) string
+	isValidPod         func(ctx context.Context, namespace, podName, controllerName string) (bool, error)
+}
+
+func (k *objectKind) Kind() string {
+	return k.kind
+}
+
+func (k *objectKind) Match(apiVersion, kind string) bool {
+	if kind != k.kind {
+		return false
+	}
+	if apiVersion == k.apiVersion {
+		return true
+	}
+	for _, v := range k.apiVersions {
+		if apiVersion == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (k *objectKind) ResolveOwner(ctx context.Context, namespace string, owner *metav1.OwnerReference) (types.PodTopController, error) {
+	obj := k.newObject()
+	if err := k.client.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: owner.Name}, obj); err != nil {
+		return types.PodTopController{}, fmt.Errorf("failed to get %s %s/%s: %w", k.kind, namespace, owner.Name, err)
+	}
+
+	if parent := metav1.GetControllerOf(obj); parent != nil {
+		for _, adopter := range k.adopters {
+			if parent.APIVersion != adopter.APIVersion || parent.Kind != adopter.Kind {
+				continue
+			}
+			if parentKind := k.registry.Lookup(parent.APIVersion, parent.Kind); parentKind != nil {
+				return parentKind.ResolveOwner(ctx, namespace, parent)
+			}
+		}
+	}
+
+	return types.PodTopController{
+		AppNamespacedName: types.AppNamespacedName{
+			// the objects got from the k8s client have no TypeMeta
+			APIVersion: k.apiVersion,
+			Kind:       k.kind,
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		},
+		UID: obj.GetUID(),
+		APP: obj,
+	}, nil
+}
+
+func (k *objectKind) DesiredReplicas(app interface{}) (int, bool) {
+	if k.replicas == nil {
+		return 0, false
+	}
+	obj, ok := app.(client.Object)
+	if !ok || reflect.TypeOf(obj) != reflect.TypeOf(k.newObject()) {
+		return 0, false
+	}
+
+	return k.replicas(obj)
+}
+
+func (k *objectKind) StableEndpointName(podName, controllerName string) (string, bool) {
+	if k.stableEndpointName == nil {
+		return "", false
+	}
+
+	return k.stableEndpointName(podName, controllerName), true
+}
+
+func (k *objectKind) IsValidPod(ctx context.Context, namespace, podName, controllerName string) (bool, error) {
+	if k.isValidPod == nil {
+		return false, nil
+	}
+
+	return k.isValidPod(ctx, namespace, podName, controllerName)
+}
+
+// ownerKind is a workload kind whose objects are not got, the top controller
+// is recorded as the ownerReference of the Pod.
+type ownerKind struct {
+	objectKind
+}
+
+func (k *ownerKind) ResolveOwner(ctx context.Context, namespace string, owner *metav1.OwnerReference) (types.PodTopController, error) {
+	return types.PodTopController{
+		AppNamespacedName: types.AppNamespacedName{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  namespace,
+			Name:       owner.Name,
+		},
+		UID: owner.UID,
+	}, nil
+}
+
+func builtinKinds(r *registry, c client.Client, stsManager statefulsetmanager.StatefulSetManager, kubevirtManager kubevirtmanager.KubevirtManager, config RegistryConfig) []WorkloadKind {
+	deploymentType := metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: constant.KindDeployment}
+	rolloutType := metav1.TypeMeta{APIVersion: constant.ArgoRolloutsAPIVersion, Kind: constant.KindRollout}
+	cronJobType := metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: constant.KindCronJob}
+
+	var statefulSetEndpointName func(podName, controllerName string) string
+	var isValidStatefulSetPod, isValidAdvancedStatefulSetPod func(ctx context.Context, namespace, podName, controllerName string) (bool, error)
+	if config.EnableStatefulSet {
+		statefulSetEndpointName = func(podName, _ string) string {
+			return podName
+		}
+		isValidStatefulSetPod = func(ctx context.Context, namespace, podName, _ string) (bool, error) {
+			return stsManager.IsValidStatefulSetPod(ctx, namespace, podName, appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+		}
+		isValidAdvancedStatefulSetPod = func(ctx context.Context, namespace, podName, _ string) (bool, error) {
+			return stsManager.IsValidStatefulSetPod(ctx, namespace, podName, kruisev1beta1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+		}
+	}
+
+	var vmiEndpointName func(podName, controllerName string) string
+	var isValidVMPod func(ctx context.Context, namespace, podName, controllerName string) (bool, error)
+	if config.EnableKubevirtStaticIP {
+		// the Pods of the same VM share the SpiderEndpoint named after the VMI
+		vmiEndpointName = func(_, controllerName string) string {
+			return controllerName
+		}
+		isValidVMPod = func(ctx context.Context, namespace, _, controllerName string) (bool, error) {
+			return kubevirtManager.IsValidVMPod(ctx, namespace, constant.KindKubevirtVMI, controllerName)
+		}
+	}
+
+	objectKinds := []*objectKind{
+		{
+			apiVersion: corev1.SchemeGroupVersion.String(),
+			kind:       constant.KindPod,
+			newObject:  func() client.Object { return &corev1.Pod{} },
+			replicas: func(client.Object) (int, bool) {
+				return 1, true
+			},
+		},
+		{
+			apiVersion: appsv1.SchemeGroupVersion.String(),
+			kind:       constant.KindDeployment,
+			newObject:  func() client.Object { return &appsv1.Deployment{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetAppReplicas(obj.(*appsv1.Deployment).Spec.Replicas), true
+			},
+		},
+		{
+			apiVersion: appsv1.SchemeGroupVersion.String(),
+			kind:       constant.KindReplicaSet,
+			newObject:  func() client.Object { return &appsv1.ReplicaSet{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetAppReplicas(obj.(*appsv1.ReplicaSet).Spec.Replicas), true
+			},
+			adopters: []metav1.TypeMeta{deploymentType, rolloutType},
+		},
+		{
+			apiVersion: appsv1.SchemeGroupVersion.String(),
+			kind:       constant.KindStatefulSet,
+			newObject:  func() client.Object { return &appsv1.StatefulSet{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetAppReplicas(obj.(*appsv1.StatefulSet).Spec.Replicas), true
+			},
+			stableEndpointName: statefulSetEndpointName,
+			isValidPod:         isValidStatefulSetPod,
+		},
+		{
+			apiVersion: appsv1.SchemeGroupVersion.String(),
+			kind:       constant.KindDaemonSet,
+			newObject:  func() client.Object { return &appsv1.DaemonSet{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return int(obj.(*appsv1.DaemonSet).Status.DesiredNumberScheduled), true
+			},
+		},
+		{
+			apiVersion: batchv1.SchemeGroupVersion.String(),
+			kind:       constant.KindJob,
+			newObject:  func() client.Object { return &batchv1.Job{} },
+			replicas: func(obj client.Object) (int, bool) {
+				job := obj.(*batchv1.Job)
+				return applicationinformers.CalculateJobPodNum(job.Spec.Parallelism, job.Spec.Completions), true
+			},
+			adopters: []metav1.TypeMeta{cronJobType},
+		},
+		{
+			apiVersion: batchv1.SchemeGroupVersion.String(),
+			kind:       constant.KindCronJob,
+			newObject:  func() client.Object { return &batchv1.CronJob{} },
+			replicas: func(obj client.Object) (int, bool) {
+				jobSpec := obj.(*batchv1.CronJob).Spec.JobTemplate.Spec
+				return applicationinformers.CalculateJobPodNum(jobSpec.Parallelism, jobSpec.Completions), true
+			},
+		},
+		{
+			apiVersion: kruisev1alpha1.SchemeGroupVersion.String(),
+			kind:       constant.KindCloneSet,
+			newObject:  func() client.Object { return &kruisev1alpha1.CloneSet{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetAppReplicas(obj.(*kruisev1alpha1.CloneSet).Spec.Replicas), true
+			},
+		},
+		{
+			// the Advanced StatefulSet is always recorded with its storage version
+			apiVersion:  kruisev1beta1.SchemeGroupVersion.String(),
+			apiVersions: []string{kruisev1alpha1.SchemeGroupVersion.String()},
+			kind:        constant.KindStatefulSet,
+			newObject:   func() client.Object { return &kruisev1beta1.StatefulSet{} },
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetAppReplicas(obj.(*kruisev1beta1.StatefulSet).Spec.Replicas), true
+			},
+			stableEndpointName: statefulSetEndpointName,
+			isValidPod:         isValidAdvancedStatefulSetPod,
+		},
+		{
+			apiVersion: constant.ArgoRolloutsAPIVersion,
+			kind:       constant.KindRollout,
+			newObject: func() client.Object {
+				rollout := &unstructured.Unstructured{}
+				rollout.SetAPIVersion(constant.ArgoRolloutsAPIVersion)
+				rollout.SetKind(constant.KindRollout)
+				return rollout
+			},
+			replicas: func(obj client.Object) (int, bool) {
+				return applicationinformers.GetRolloutReplicas(obj.(*unstructured.Unstructured)), true
+			},
+		},
+	}
+
+	kinds := make([]WorkloadKind, 0, len(objectKinds)+1)
+	for _, k := range objectKinds {
+		k.registry = r
+		k.client = c
+		kinds = append(kinds, k)
+	}
+
+	// the kubevirt VMI is not resolved to the VM, and its replicas are unknown
+	kinds = append(kinds, &ownerKind{objectKind{
+		apiVersion:         kubevirtv1.SchemeGroupVersion.String(),
+		kind:               constant.KindKubevirtVMI,
+		stableEndpointName: vmiEndpointName,
+		isValidPod:         isValidVMPod,
+	}})
+
+	return kinds
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadkind
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/applicationcontroller/applicationinformers"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// CustomWorkload declares a CRD-based workload in the configmap, so that its
+// Pods are handled like the ones of the built-in workloads.
+type CustomWorkload struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+
+	// ReplicasPath is the dot-separated path of the desired replicas in the
+	// object, such as "spec.replicas". The replicas are unknown if it is empty.
+	ReplicasPath string `yaml:"replicasPath"`
+
+	// PodTemplatePath is the dot-separated path of the Pod template in the
+	// object, it defaults to "spec.template".
+	PodTemplatePath string `yaml:"podTemplatePath"`
+
+	// StaticIP makes the Pods keep their IP addresses across re-creation like
+	// the ones of StatefulSet, the Pods must be named "<workload>-<ordinal>"
+	// and the ordinals must be lower than the desired replicas.
+	StaticIP bool `yaml:"staticIP"`
+}
+
+// DefaultPodTemplatePath is the path of the Pod template of the custom
+// workload without PodTemplatePath.
+const DefaultPodTemplatePath = "spec.template"
+
+// PodTemplate returns the Pod template of the custom workload object, an
+// empty one is returned if the object has none.
+func (cw CustomWorkload) PodTemplate(obj *unstructured.Unstructured) (*corev1.PodTemplateSpec, error) {
+	return applicationinformers.GetPodTemplate(obj, strings.Split(cw.PodTemplatePath, ".")...)
+}
+
+func newCustomKind(c client.Client, apiReader client.Reader, cw CustomWorkload) (WorkloadKind, error) {
+	if _, err := schema.ParseGroupVersion(cw.APIVersion); err != nil || len(cw.APIVersion) == 0 {
+		return nil, fmt.Errorf("%w: invalid API version '%s' of custom workload", constant.ErrWrongInput, cw.APIVersion)
+	}
+	if len(cw.Kind) == 0 {
+		return nil, fmt.Errorf("%w: kind of custom workload %s must be specified", constant.ErrWrongInput, cw.APIVersion)
+	}
+	if cw.StaticIP && len(cw.ReplicasPath) == 0 {
+		return nil, fmt.Errorf("%w: custom workload %s/%s with static IP must specify the replicas path", constant.ErrWrongInput, cw.APIVersion, cw.Kind)
+	}
+
+	k := &objectKind{
+		client:     c,
+		apiVersion: cw.APIVersion,
+		kind:       cw.Kind,
+		newObject: func() client.Object {
+			obj := &unstructured.Unstructured{}
+			obj.SetAPIVersion(cw.APIVersion)
+			obj.SetKind(cw.Kind)
+			return obj
+		},
+	}
+
+	var fields []string
+	if len(cw.ReplicasPath) != 0 {
+		fields = strings.Split(cw.ReplicasPath, ".")
+		k.replicas = func(obj client.Object) (int, bool) {
+			u := obj.(*unstructured.Unstructured)
+			if u.GetAPIVersion() != cw.APIVersion || u.GetKind() != cw.Kind {
+				return 0, false
+			}
+			replicas, found, err := unstructured.NestedInt64(u.Object, fields...)
+			if err != nil || !found {
+				return 0, false
+			}
+			return int(replicas), true
+		}
+	}
+
+	if cw.StaticIP {
+		k.stableEndpointName = func(podName, _ string) string {
+			return podName
+		}
+		k.isValidPod = func(ctx context.Context, namespace, podName, controllerName string) (bool, error) {
+			prefix := controllerName + "-"
+			if !strings.HasPrefix(podName, prefix) {
+				return false, nil
+			}
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(podName, prefix))
+			if err != nil || ordinal < 0 {
+				return false, nil
+			}
+
+			obj := k.newObject()
+			if err := apiReader.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: controllerName}, obj); err != nil {
+				// The CRD of the workload may be uninstalled.
+				if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+					return false, nil
+				}
+				return false, err
+			}
+
+			replicas, ok := k.replicas(obj)
+			if !ok {
+				return false, fmt.Errorf("failed to get the replicas of %s %s/%s with path '%s'", cw.Kind, namespace, controllerName, cw.ReplicasPath)
+			}
+
+			return ordinal < replicas, nil
+		}
+	}
+
+	return k, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadkind
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// WorkloadKind describes how Spiderpool handles the Pods controlled by one kind of workload.
+type WorkloadKind interface {
+	// Kind returns the kind of the workload, which is recorded as the owner controller type of SpiderEndpoint.
+	Kind() string
+
+	// Match reports whether the controller with the given API version and kind is of the workload kind.
+	Match(apiVersion, kind string) bool
+
+	// ResolveOwner gets the controller referenced by the ownerReference of a Pod and resolves the top controller of the Pod.
+	ResolveOwner(ctx context.Context, namespace string, owner *metav1.OwnerReference) (types.PodTopController, error)
+
+	// DesiredReplicas returns the desired replicas of the top controller, ok is false if the replicas are unknown.
+	DesiredReplicas(app interface{}) (replicas int, ok bool)
+
+	// StableEndpointName returns the name of the SpiderEndpoint which keeps the IP addresses of the Pod across its
+	// re-creation, ok is false if the Pods have no stable identity.
+	StableEndpointName(podName, controllerName string) (name string, ok bool)
+
+	// IsValidPod reports whether the identity of the Pod with stable identity is still valid, the IP addresses of the
+	// invalid ones should be released.
+	IsValidPod(ctx context.Context, namespace, podName, controllerName string) (bool, error)
+}
+
+// Registry holds the workload kinds Spiderpool knows about, the built-in ones are always registered before the
+// custom ones declared in the configmap.
+type Registry interface {
+	// Lookup returns the workload kind of the controller, it returns nil if the controller is unknown.
+	Lookup(apiVersion, kind string) WorkloadKind
+
+	// LookupEndpointOwner returns the workload kind with stable identity by the owner controller API version and
+	// type recorded in SpiderEndpoint, it returns nil if there is none. The API version is empty for the SpiderEndpoints
+	// created by the former versions, the first workload kind of the type is returned then.
+	LookupEndpointOwner(ownerControllerAPIVersion, ownerControllerType string) WorkloadKind
+
+	// StableEndpointName returns the name of the SpiderEndpoint which keeps the IP addresses of the Pod controlled
+	// by the given controller, ok is false if the Pods have no stable identity.
+	StableEndpointName(podName string, controller types.AppNamespacedName) (name string, ok bool)
+
+	// CustomWorkloads returns the custom workloads declared in the configmap, the SpiderSubnet application
+	// controller watches them to create, scale and delete their auto-created IPPools.
+	CustomWorkloads() []CustomWorkload
+
+	// LookupCustom returns the declaration of the custom workload, it returns nil if the controller is not one.
+	LookupCustom(apiVersion, kind string) *CustomWorkload
+}
+
+type RegistryConfig struct {
+	EnableStatefulSet      bool
+	EnableKubevirtStaticIP bool
+	CustomWorkloads        []CustomWorkload
+}
+
+type registry struct {
+	kinds           []WorkloadKind
+	customWorkloads []CustomWorkload
+}
+
+func NewRegistry(client client.Client, apiReader client.Reader, stsManager statefulsetmanager.StatefulSetManager, kubevirtManager kubevirtmanager.KubevirtManager, config RegistryConfig) (Registry, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
+	if stsManager == nil {
+		return nil, fmt.Errorf("statefulset manager %w", constant.ErrMissingRequiredParam)
+	}
+	if kubevirtManager == nil {
+		return nil, fmt.Errorf("kubevirt manager %w", constant.ErrMissingRequiredParam)
+	}
+
+	r := &registry{}
+	r.kinds = builtinKinds(r, client, stsManager, kubevirtManager, config)
+
+	for _, cw := range config.CustomWorkloads {
+		if len(cw.PodTemplatePath) == 0 {
+			cw.PodTemplatePath = DefaultPodTemplatePath
+		}
+		kind, err := newCustomKind(client, apiReader, cw)
+		if err != nil {
+			return nil, err
+		}
+		// SpiderEndpoint and the work queue of the SpiderSubnet application
+		// controller only record the kind of the controller, so a kind served
+		// by several API groups could not be told apart.
+		for _, k := range r.kinds {
+			if k.Kind() == cw.Kind {
+				return nil, fmt.Errorf("%w: the kind of custom workload %s/%s is already registered", constant.ErrWrongInput, cw.APIVersion, cw.Kind)
+			}
+		}
+		r.kinds = append(r.kinds, kind)
+		r.customWorkloads = append(r.customWorkloads, cw)
+	}
+
+	return r, nil
+}
+
+func (r *registry) Lookup(apiVersion, kind string) WorkloadKind {
+	for _, k := range r.kinds {
+		if k.Match(apiVersion, kind) {
+			return k
+		}
+	}
+
+	return nil
+}
+
+func (r *registry) LookupEndpointOwner(ownerControllerAPIVersion, ownerControllerType string) WorkloadKind {
+	for _, k := range r.kinds {
+		if k.Kind() != ownerControllerType {
+			continue
+		}
+		if len(ownerControllerAPIVersion) != 0 && !k.Match(ownerControllerAPIVersion, ownerControllerType) {
+			continue
+		}
+		if _, ok := k.StableEndpointName("", ""); ok {
+			return k
+		}
+	}
+
+	return nil
+}
+
+func (r *registry) StableEndpointName(podName string, controller types.AppNamespacedName) (string, bool) {
+	k := r.Lookup(controller.APIVersion, controller.Kind)
+	if k == nil {
+		return "", false
+	}
+
+	return k.StableEndpointName(podName, controller.Name)
+}
+
+func (r *registry) CustomWorkloads() []CustomWorkload {
+	return r.customWorkloads
+}
+
+func (r *registry) LookupCustom(apiVersion, kind string) *CustomWorkload {
+	for i := range r.customWorkloads {
+		if r.customWorkloads[i].APIVersion == apiVersion && r.customWorkloads[i].Kind == kind {
+			return &r.customWorkloads[i]
+		}
+	}
+
+	return nil
+}
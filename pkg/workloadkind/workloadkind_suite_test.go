@@ -0,0 +1,52 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadkind_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/kubevirtmanager"
+	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
+)
+
+var scheme *runtime.Scheme
+var fakeClient client.Client
+var tracker k8stesting.ObjectTracker
+var fakeAPIReader client.Reader
+var stsManager statefulsetmanager.StatefulSetManager
+var kubevirtManager kubevirtmanager.KubevirtManager
+
+func TestWorkloadKind(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WorkloadKind Suite", Label("workloadkind", "unittest"))
+}
+
+var _ = BeforeSuite(func() {
+	scheme = runtime.NewScheme()
+	err := k8sscheme.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	tracker = k8stesting.NewObjectTracker(scheme, k8sscheme.Codecs.UniversalDecoder())
+	fakeAPIReader = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjectTracker(tracker).
+		Build()
+
+	stsManager, err = statefulsetmanager.NewStatefulSetManager(fakeClient, fakeAPIReader)
+	Expect(err).NotTo(HaveOccurred())
+
+	kubevirtManager = kubevirtmanager.NewKubevirtManager(fakeClient, fakeAPIReader)
+})
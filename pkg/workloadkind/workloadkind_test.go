@@ -0,0 +1,526 @@
+// Copyright 2023 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadkind_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/workloadkind"
+)
+
+var _ = Describe("WorkloadKind", Label("workloadkind_test"), func() {
+	var customWorkload workloadkind.CustomWorkload
+
+	BeforeEach(func() {
+		customWorkload = workloadkind.CustomWorkload{
+			APIVersion:   "apps.example.io/v1",
+			Kind:         "StaticSet",
+			ReplicasPath: "spec.replicas",
+			StaticIP:     true,
+		}
+	})
+
+	Describe("New Registry", func() {
+		It("inputs nil client", func() {
+			registry, err := workloadkind.NewRegistry(nil, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs nil API reader", func() {
+			registry, err := workloadkind.NewRegistry(fakeClient, nil, stsManager, kubevirtManager, workloadkind.RegistryConfig{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs nil StatefulSet manager", func() {
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, nil, kubevirtManager, workloadkind.RegistryConfig{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs nil kubevirt manager", func() {
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, nil, workloadkind.RegistryConfig{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workload with invalid API version", func() {
+			customWorkload.APIVersion = "apps.example.io/v1/v2"
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workload without kind", func() {
+			customWorkload.Kind = ""
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workload with static IP but without replicas path", func() {
+			customWorkload.ReplicasPath = ""
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs duplicate custom workloads", func() {
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload, customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workload overriding the built-in one", func() {
+			customWorkload.APIVersion = appsv1.SchemeGroupVersion.String()
+			customWorkload.Kind = constant.KindDeployment
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workload whose kind collides with the built-in one", func() {
+			customWorkload.Kind = constant.KindStatefulSet
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+
+		It("inputs custom workloads with the same kind", func() {
+			anotherWorkload := customWorkload
+			anotherWorkload.APIVersion = "apps.example.io/v2"
+			registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				CustomWorkloads: []workloadkind.CustomWorkload{customWorkload, anotherWorkload},
+			})
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(registry).To(BeNil())
+		})
+	})
+
+	Describe("Test Registry's method", func() {
+		var ctx context.Context
+		var count uint64
+		var namespace string
+		var registry workloadkind.Registry
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			atomic.AddUint64(&count, 1)
+			namespace = fmt.Sprintf("ns-%v", count)
+
+			var err error
+			registry, err = workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+				EnableStatefulSet:      true,
+				EnableKubevirtStaticIP: true,
+				CustomWorkloads:        []workloadkind.CustomWorkload{customWorkload},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Describe("Lookup", func() {
+			It("looks up the built-in workload kinds", func() {
+				kind := registry.Lookup(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Kind()).To(Equal(constant.KindDeployment))
+
+				kind = registry.Lookup("apps.kruise.io/v1alpha1", constant.KindStatefulSet)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Match("apps.kruise.io/v1beta1", constant.KindStatefulSet)).To(BeTrue())
+				Expect(kind.Match(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeFalse())
+			})
+
+			It("looks up the custom workload kind", func() {
+				kind := registry.Lookup(customWorkload.APIVersion, customWorkload.Kind)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Kind()).To(Equal(customWorkload.Kind))
+			})
+
+			It("looks up the unknown workload kind", func() {
+				Expect(registry.Lookup("apps.example.io/v1", "UnknownSet")).To(BeNil())
+			})
+		})
+
+		Describe("LookupCustom", func() {
+			It("looks up the custom workload", func() {
+				cw := registry.LookupCustom(customWorkload.APIVersion, customWorkload.Kind)
+				Expect(cw).NotTo(BeNil())
+				Expect(cw.PodTemplatePath).To(Equal(workloadkind.DefaultPodTemplatePath))
+				Expect(registry.CustomWorkloads()).To(Equal([]workloadkind.CustomWorkload{*cw}))
+			})
+
+			It("looks up the built-in workload kind", func() {
+				Expect(registry.LookupCustom(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)).To(BeNil())
+			})
+
+			It("gets the Pod template of custom workload", func() {
+				obj := newCustomObject(customWorkload, namespace, "static-set", 2).(*unstructured.Unstructured)
+				err := unstructured.SetNestedStringMap(obj.Object, map[string]string{"app": "static-set"}, "spec", "template", "metadata", "labels")
+				Expect(err).NotTo(HaveOccurred())
+
+				podTemplate, err := registry.LookupCustom(customWorkload.APIVersion, customWorkload.Kind).PodTemplate(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTemplate.Labels).To(HaveKeyWithValue("app", "static-set"))
+			})
+
+			It("gets the Pod template of custom workload with Pod template path", func() {
+				customWorkload.PodTemplatePath = "spec.podTemplate"
+				registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+					CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				obj := newCustomObject(customWorkload, namespace, "static-set", 2).(*unstructured.Unstructured)
+				err = unstructured.SetNestedStringMap(obj.Object, map[string]string{"app": "static-set"}, "spec", "podTemplate", "metadata", "labels")
+				Expect(err).NotTo(HaveOccurred())
+
+				podTemplate, err := registry.LookupCustom(customWorkload.APIVersion, customWorkload.Kind).PodTemplate(obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTemplate.Labels).To(HaveKeyWithValue("app", "static-set"))
+			})
+		})
+
+		Describe("LookupEndpointOwner", func() {
+			It("looks up the workload kinds with stable identity", func() {
+				kind := registry.LookupEndpointOwner(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Match(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeTrue())
+
+				kind = registry.LookupEndpointOwner("apps.kruise.io/v1beta1", constant.KindStatefulSet)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Match("apps.kruise.io/v1beta1", constant.KindStatefulSet)).To(BeTrue())
+
+				Expect(registry.LookupEndpointOwner(kubevirtv1.SchemeGroupVersion.String(), constant.KindKubevirtVMI)).NotTo(BeNil())
+				Expect(registry.LookupEndpointOwner(customWorkload.APIVersion, customWorkload.Kind)).NotTo(BeNil())
+			})
+
+			It("looks up the workload kinds recorded without API version", func() {
+				kind := registry.LookupEndpointOwner("", constant.KindStatefulSet)
+				Expect(kind).NotTo(BeNil())
+				Expect(kind.Match(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeTrue())
+			})
+
+			It("looks up the workload kind without stable identity", func() {
+				Expect(registry.LookupEndpointOwner(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)).To(BeNil())
+				Expect(registry.LookupEndpointOwner("apps.example.io/v2", customWorkload.Kind)).To(BeNil())
+			})
+
+			It("disables the static IP of StatefulSet and kubevirt VM", func() {
+				registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(registry.LookupEndpointOwner(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)).To(BeNil())
+				Expect(registry.LookupEndpointOwner(kubevirtv1.SchemeGroupVersion.String(), constant.KindKubevirtVMI)).To(BeNil())
+			})
+		})
+
+		Describe("StableEndpointName", func() {
+			It("gets the Endpoint name of StatefulSet Pod", func() {
+				name, ok := registry.StableEndpointName("sts-0", types.AppNamespacedName{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindStatefulSet,
+					Namespace:  namespace,
+					Name:       "sts",
+				})
+				Expect(ok).To(BeTrue())
+				Expect(name).To(Equal("sts-0"))
+			})
+
+			It("gets the Endpoint name of kubevirt VM Pod", func() {
+				name, ok := registry.StableEndpointName("virt-launcher-vm-x7k2m", types.AppNamespacedName{
+					APIVersion: kubevirtv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindKubevirtVMI,
+					Namespace:  namespace,
+					Name:       "vm",
+				})
+				Expect(ok).To(BeTrue())
+				Expect(name).To(Equal("vm"))
+			})
+
+			It("gets the Endpoint name of Deployment Pod", func() {
+				_, ok := registry.StableEndpointName("deploy-7d9f8-x7k2m", types.AppNamespacedName{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindDeployment,
+					Namespace:  namespace,
+					Name:       "deploy",
+				})
+				Expect(ok).To(BeFalse())
+			})
+
+			It("gets the Endpoint name of the Pod controlled by unknown controller", func() {
+				_, ok := registry.StableEndpointName("unknown-0", types.AppNamespacedName{
+					APIVersion: "apps.example.io/v1",
+					Kind:       "UnknownSet",
+					Namespace:  namespace,
+					Name:       "unknown",
+				})
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		Describe("ResolveOwner", func() {
+			It("resolves the Deployment of ReplicaSet", func() {
+				deployment := &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy",
+						Namespace: namespace,
+						UID:       "deploy-uid",
+					},
+				}
+				err := fakeClient.Create(ctx, deployment)
+				Expect(err).NotTo(HaveOccurred())
+
+				replicaSet := &appsv1.ReplicaSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-7d9f8",
+						Namespace: namespace,
+						OwnerReferences: []metav1.OwnerReference{{
+							APIVersion: appsv1.SchemeGroupVersion.String(),
+							Kind:       constant.KindDeployment,
+							Name:       deployment.Name,
+							UID:        deployment.UID,
+							Controller: pointer.Bool(true),
+						}},
+					},
+				}
+				err = fakeClient.Create(ctx, replicaSet)
+				Expect(err).NotTo(HaveOccurred())
+
+				kind := registry.Lookup(appsv1.SchemeGroupVersion.String(), constant.KindReplicaSet)
+				Expect(kind).NotTo(BeNil())
+
+				podTopController, err := kind.ResolveOwner(ctx, namespace, &metav1.OwnerReference{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindReplicaSet,
+					Name:       replicaSet.Name,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Kind).To(Equal(constant.KindDeployment))
+				Expect(podTopController.Name).To(Equal(deployment.Name))
+				Expect(podTopController.UID).To(Equal(deployment.UID))
+				Expect(podTopController.APP).To(BeAssignableToTypeOf(&appsv1.Deployment{}))
+			})
+
+			It("resolves the Job without CronJob", func() {
+				job := &batchv1.Job{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "job",
+						Namespace: namespace,
+					},
+				}
+				err := fakeClient.Create(ctx, job)
+				Expect(err).NotTo(HaveOccurred())
+
+				kind := registry.Lookup(batchv1.SchemeGroupVersion.String(), constant.KindJob)
+				Expect(kind).NotTo(BeNil())
+
+				podTopController, err := kind.ResolveOwner(ctx, namespace, &metav1.OwnerReference{
+					APIVersion: batchv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindJob,
+					Name:       job.Name,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.APIVersion).To(Equal(batchv1.SchemeGroupVersion.String()))
+				Expect(podTopController.Kind).To(Equal(constant.KindJob))
+				Expect(podTopController.Name).To(Equal(job.Name))
+			})
+
+			It("failed to get the owner", func() {
+				kind := registry.Lookup(appsv1.SchemeGroupVersion.String(), constant.KindDaemonSet)
+				Expect(kind).NotTo(BeNil())
+
+				_, err := kind.ResolveOwner(ctx, namespace, &metav1.OwnerReference{
+					APIVersion: appsv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindDaemonSet,
+					Name:       "non-existent",
+				})
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("resolves the kubevirt VMI without getting it", func() {
+				kind := registry.Lookup(kubevirtv1.SchemeGroupVersion.String(), constant.KindKubevirtVMI)
+				Expect(kind).NotTo(BeNil())
+
+				podTopController, err := kind.ResolveOwner(ctx, namespace, &metav1.OwnerReference{
+					APIVersion: kubevirtv1.SchemeGroupVersion.String(),
+					Kind:       constant.KindKubevirtVMI,
+					Name:       "vm",
+					UID:        "vmi-uid",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Name).To(Equal("vm"))
+				Expect(podTopController.UID).To(BeEquivalentTo("vmi-uid"))
+				Expect(podTopController.APP).To(BeNil())
+			})
+		})
+
+		Describe("DesiredReplicas", func() {
+			It("gets the replicas of Deployment", func() {
+				kind := registry.Lookup(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)
+				replicas, ok := kind.DesiredReplicas(&appsv1.Deployment{
+					Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32(3)},
+				})
+				Expect(ok).To(BeTrue())
+				Expect(replicas).To(Equal(3))
+			})
+
+			It("gets the replicas of CronJob", func() {
+				kind := registry.Lookup(batchv1.SchemeGroupVersion.String(), constant.KindCronJob)
+				replicas, ok := kind.DesiredReplicas(&batchv1.CronJob{
+					Spec: batchv1.CronJobSpec{
+						JobTemplate: batchv1.JobTemplateSpec{
+							Spec: batchv1.JobSpec{
+								Parallelism: pointer.Int32(2),
+								Completions: pointer.Int32(4),
+							},
+						},
+					},
+				})
+				Expect(ok).To(BeTrue())
+				Expect(replicas).To(Equal(4))
+			})
+
+			It("gets the replicas of mismatched application", func() {
+				kind := registry.Lookup(appsv1.SchemeGroupVersion.String(), constant.KindDeployment)
+				_, ok := kind.DesiredReplicas(&appsv1.StatefulSet{})
+				Expect(ok).To(BeFalse())
+
+				_, ok = kind.DesiredReplicas(nil)
+				Expect(ok).To(BeFalse())
+			})
+
+			It("gets the replicas of kubevirt VMI", func() {
+				kind := registry.Lookup(kubevirtv1.SchemeGroupVersion.String(), constant.KindKubevirtVMI)
+				_, ok := kind.DesiredReplicas(nil)
+				Expect(ok).To(BeFalse())
+			})
+
+			It("gets the replicas of custom workload", func() {
+				kind := registry.Lookup(customWorkload.APIVersion, customWorkload.Kind)
+				replicas, ok := kind.DesiredReplicas(newCustomObject(customWorkload, namespace, "static-set", 2))
+				Expect(ok).To(BeTrue())
+				Expect(replicas).To(Equal(2))
+			})
+
+			It("gets the replicas of custom workload without replicas path", func() {
+				customWorkload.ReplicasPath = ""
+				customWorkload.StaticIP = false
+				registry, err := workloadkind.NewRegistry(fakeClient, fakeAPIReader, stsManager, kubevirtManager, workloadkind.RegistryConfig{
+					CustomWorkloads: []workloadkind.CustomWorkload{customWorkload},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				kind := registry.Lookup(customWorkload.APIVersion, customWorkload.Kind)
+				_, ok := kind.DesiredReplicas(newCustomObject(customWorkload, namespace, "static-set", 2))
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		Describe("IsValidPod", func() {
+			It("checks the Pod of StatefulSet", func() {
+				sts := &appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "sts",
+						Namespace: namespace,
+					},
+					Spec: appsv1.StatefulSetSpec{Replicas: pointer.Int32(1)},
+				}
+				err := tracker.Add(sts)
+				Expect(err).NotTo(HaveOccurred())
+
+				kind := registry.LookupEndpointOwner(appsv1.SchemeGroupVersion.String(), constant.KindStatefulSet)
+				Expect(kind).NotTo(BeNil())
+
+				isValid, err := kind.IsValidPod(ctx, namespace, "sts-0", sts.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeTrue())
+
+				isValid, err = kind.IsValidPod(ctx, namespace, "sts-1", sts.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+			})
+
+			It("checks the Pod of custom workload", func() {
+				obj := newCustomObject(customWorkload, namespace, "static-set", 2).(*unstructured.Unstructured)
+				err := tracker.Add(obj)
+				Expect(err).NotTo(HaveOccurred())
+
+				kind := registry.LookupEndpointOwner(customWorkload.APIVersion, customWorkload.Kind)
+				Expect(kind).NotTo(BeNil())
+
+				isValid, err := kind.IsValidPod(ctx, namespace, "static-set-1", obj.GetName())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeTrue())
+
+				isValid, err = kind.IsValidPod(ctx, namespace, "static-set-2", obj.GetName())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+
+				isValid, err = kind.IsValidPod(ctx, namespace, "static-set-x7k2m", obj.GetName())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+
+				isValid, err = kind.IsValidPod(ctx, namespace, "other-0", obj.GetName())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+			})
+
+			It("checks the Pod of non-existent custom workload", func() {
+				kind := registry.LookupEndpointOwner(customWorkload.APIVersion, customWorkload.Kind)
+				Expect(kind).NotTo(BeNil())
+
+				isValid, err := kind.IsValidPod(ctx, namespace, "non-existent-0", "non-existent")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+			})
+
+			It("checks the Pod of workload without stable identity", func() {
+				kind := registry.Lookup(corev1.SchemeGroupVersion.String(), constant.KindPod)
+				Expect(kind).NotTo(BeNil())
+
+				isValid, err := kind.IsValidPod(ctx, namespace, "pod", "pod")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isValid).To(BeFalse())
+			})
+		})
+	})
+})
+
+func newCustomObject(cw workloadkind.CustomWorkload, namespace, name string, replicas int64) client.Object {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(cw.APIVersion)
+	obj.SetKind(cw.Kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	_ = unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+
+	return obj
+}